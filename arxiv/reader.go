@@ -0,0 +1,234 @@
+// Package arxiv provides functions to convert arXiv metadata to the commonmeta
+// metadata format, using the arXiv API.
+package arxiv
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/front-matter/commonmeta/authorutils"
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/textutils"
+	"github.com/front-matter/commonmeta/utils"
+)
+
+// Feed represents the Atom feed returned by the arXiv API.
+type Feed struct {
+	XMLName xml.Name  `xml:"http://www.w3.org/2005/Atom feed"`
+	Entries []Content `xml:"entry"`
+}
+
+// Content represents an entry of the Atom feed returned by the arXiv API.
+type Content struct {
+	ID              string     `xml:"id"`
+	Updated         string     `xml:"updated"`
+	Published       string     `xml:"published"`
+	Title           string     `xml:"title"`
+	Summary         string     `xml:"summary"`
+	Authors         []Author   `xml:"author"`
+	Links           []Link     `xml:"link"`
+	DOI             string     `xml:"http://arxiv.org/schemas/atom doi"`
+	JournalRef      string     `xml:"http://arxiv.org/schemas/atom journal_ref"`
+	Comment         string     `xml:"http://arxiv.org/schemas/atom comment"`
+	PrimaryCategory Category   `xml:"http://arxiv.org/schemas/atom primary_category"`
+	Categories      []Category `xml:"category"`
+}
+
+// Author represents an author of an arXiv entry.
+type Author struct {
+	Name         string   `xml:"name"`
+	Affiliations []string `xml:"http://arxiv.org/schemas/atom affiliation"`
+}
+
+// Link represents a link of an arXiv entry, e.g. to the abstract page or PDF.
+type Link struct {
+	Href  string `xml:"href,attr"`
+	Rel   string `xml:"rel,attr"`
+	Type  string `xml:"type,attr"`
+	Title string `xml:"title,attr"`
+}
+
+// Category represents an arXiv subject category, e.g. cs.DL.
+type Category struct {
+	Term string `xml:"term,attr"`
+}
+
+// BaseURL is the URL of the arXiv API.
+var BaseURL = "https://export.arxiv.org/api/query"
+
+// Option configures how entries are fetched from the arXiv API.
+type Option func(*options)
+
+type options struct {
+	baseURL string
+}
+
+// WithBaseURL sets the URL of the arXiv API, the default is BaseURL.
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
+// DOIPrefix is the prefix of the DOIs arXiv registers with DataCite.
+const DOIPrefix = "10.48550"
+
+// arxivRegexp matches new (2101.00001v2) and old style (hep-th/9901001)
+// arXiv identifiers, with optional version.
+var arxivRegexp = regexp.MustCompile(`(?i)^(?:arxiv:|https?://(?:export\.)?arxiv\.org/(?:abs|pdf)/)?((?:\d{4}\.\d{4,5})|(?:[a-z-]+(?:\.[A-Z]{2})?/\d{7}))(v\d+)?(?:\.pdf)?$`)
+
+// ValidateArxiv validates an arXiv identifier, given with or without arXiv:
+// prefix or as URL, and returns the identifier without and with version.
+func ValidateArxiv(str string) (string, string, bool) {
+	matched := arxivRegexp.FindStringSubmatch(strings.TrimSpace(str))
+	if len(matched) == 0 {
+		return "", "", false
+	}
+	return matched[1], matched[1] + matched[2], true
+}
+
+// Fetch fetches the metadata for an arXiv identifier, e.g. 2101.00001 or
+// 2101.00001v2, and converts it to the Commonmeta format. Without version,
+// the latest version is fetched.
+func Fetch(str string, opts ...Option) (commonmeta.Data, error) {
+	var data commonmeta.Data
+	_, id, ok := ValidateArxiv(str)
+	if !ok {
+		return data, errors.New("invalid arXiv identifier")
+	}
+	content, err := Get(id, opts...)
+	if err != nil {
+		return data, err
+	}
+	return Read(content)
+}
+
+// Get gets the Atom entry for an arXiv identifier from the arXiv API.
+func Get(id string, opts ...Option) (Content, error) {
+	var feed Feed
+	o := options{baseURL: BaseURL}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	u, _ := url.Parse(o.baseURL)
+	values := u.Query()
+	values.Add("id_list", id)
+	u.RawQuery = values.Encode()
+
+	client := &http.Client{
+		Timeout: 20 * time.Second,
+	}
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return Content{}, err
+	}
+	resp, err := utils.DoWithRetry(client, req, utils.DefaultRetry)
+	if err != nil {
+		return Content{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return Content{}, errors.New(resp.Status)
+	}
+	err = xml.NewDecoder(resp.Body).Decode(&feed)
+	if err != nil {
+		return Content{}, err
+	}
+	// the API returns an entry with an error message for invalid identifiers
+	if len(feed.Entries) == 0 || feed.Entries[0].Title == "Error" || feed.Entries[0].Published == "" {
+		return Content{}, fmt.Errorf("arXiv identifier %s not found", id)
+	}
+	return feed.Entries[0], nil
+}
+
+// Read reads an arXiv Atom entry and converts it to commonmeta. The arXiv DOI
+// is used as ID, the DOI of the published version is added as relation.
+func Read(content Content) (commonmeta.Data, error) {
+	var data commonmeta.Data
+
+	id, versionedID, ok := ValidateArxiv(content.ID)
+	if !ok {
+		return data, errors.New("invalid arXiv identifier")
+	}
+	data.ID = doiutils.NormalizeDOI(DOIPrefix + "/arXiv." + id)
+	data.Type = "Article"
+	data.Identifiers = []commonmeta.Identifier{
+		{Identifier: data.ID, IdentifierType: "DOI"},
+		{Identifier: versionedID, IdentifierType: "arXiv"},
+	}
+	if version := strings.TrimPrefix(versionedID, id); version != "" {
+		data.Version = version
+	}
+	data.URL = "https://arxiv.org/abs/" + versionedID
+
+	for _, v := range content.Authors {
+		givenName, familyName, orgName := authorutils.ParseName(v.Name)
+		contributor := commonmeta.Contributor{
+			Type:             "Person",
+			GivenName:        givenName,
+			FamilyName:       familyName,
+			ContributorRoles: []string{"Author"},
+		}
+		if orgName != "" {
+			contributor.Name = orgName
+			contributor.Type = commonmeta.GetContributorType(contributor)
+		}
+		for _, a := range v.Affiliations {
+			contributor.Affiliations = append(contributor.Affiliations, &commonmeta.Affiliation{Name: a})
+		}
+		data.Contributors = append(data.Contributors, contributor)
+	}
+
+	// titles and abstracts are wrapped over several lines
+	if title := strings.Join(strings.Fields(content.Title), " "); title != "" {
+		data.Titles = []commonmeta.Title{{Title: title}}
+	}
+	if abstract := textutils.StripMarkup(content.Summary); abstract != "" {
+		data.Descriptions = []commonmeta.Description{{Description: abstract, Type: "Abstract"}}
+	}
+	if comment := strings.Join(strings.Fields(content.Comment), " "); comment != "" {
+		data.Descriptions = append(data.Descriptions, commonmeta.Description{Description: comment, Type: "Other"})
+	}
+
+	data.Date.Published = content.Published
+	if content.Updated != content.Published {
+		data.Date.Updated = content.Updated
+	}
+
+	// the primary category is the first subject
+	categories := []string{content.PrimaryCategory.Term}
+	for _, v := range content.Categories {
+		categories = append(categories, v.Term)
+	}
+	for _, v := range utils.DedupeSlice(categories) {
+		if v != "" {
+			data.Subjects = append(data.Subjects, commonmeta.Subject{Subject: v})
+		}
+	}
+
+	for _, v := range content.Links {
+		if v.Title == "pdf" || v.Type == "application/pdf" {
+			data.Files = append(data.Files, commonmeta.File{URL: v.Href, MimeType: "application/pdf"})
+		}
+	}
+	if doi := doiutils.NormalizeDOI(content.DOI); doi != "" {
+		data.Relations = append(data.Relations, commonmeta.Relation{ID: doi, Type: "IsPreprintOf"})
+	}
+
+	data.Publisher = commonmeta.Publisher{Name: "arXiv"}
+	data.Container = commonmeta.Container{
+		Identifier:     "https://arxiv.org",
+		IdentifierType: "URL",
+		Type:           "Repository",
+		Title:          "arXiv",
+	}
+	data.Provider = "arXiv"
+	return data, nil
+}
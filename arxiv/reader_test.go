@@ -0,0 +1,139 @@
+package arxiv_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/front-matter/commonmeta/arxiv"
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/google/go-cmp/cmp"
+)
+
+const feed = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <title type="html">ArXiv Query: search_query=&amp;id_list=%s</title>
+  <entry>
+    <id>http://arxiv.org/abs/2101.00001v2</id>
+    <updated>2021-03-15T18:00:00Z</updated>
+    <published>2020-12-31T19:00:00Z</published>
+    <title>Open Citations and the
+  Scholarly Record</title>
+    <summary>  We study open citations
+in the scholarly record.
+</summary>
+    <author>
+      <name>Josiah Carberry</name>
+      <arxiv:affiliation xmlns:arxiv="http://arxiv.org/schemas/atom">Brown University</arxiv:affiliation>
+    </author>
+    <author>
+      <name>Jane Smith</name>
+    </author>
+    <arxiv:doi xmlns:arxiv="http://arxiv.org/schemas/atom">10.5555/12345678</arxiv:doi>
+    <link title="doi" href="http://dx.doi.org/10.5555/12345678" rel="related"/>
+    <arxiv:comment xmlns:arxiv="http://arxiv.org/schemas/atom">12 pages, 3 figures</arxiv:comment>
+    <link href="http://arxiv.org/abs/2101.00001v2" rel="alternate" type="text/html"/>
+    <link title="pdf" href="http://arxiv.org/pdf/2101.00001v2" rel="related" type="application/pdf"/>
+    <arxiv:primary_category xmlns:arxiv="http://arxiv.org/schemas/atom" term="cs.DL" scheme="http://arxiv.org/schemas/atom"/>
+    <category term="cs.DL" scheme="http://arxiv.org/schemas/atom"/>
+    <category term="cs.IR" scheme="http://arxiv.org/schemas/atom"/>
+  </entry>
+</feed>`
+
+const errorFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <entry>
+    <id>http://arxiv.org/api/errors#incorrect_id_format_for_9999.99999</id>
+    <title>Error</title>
+    <summary>incorrect id format for 9999.99999</summary>
+  </entry>
+</feed>`
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+	var ids []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Query().Get("id_list")
+		ids = append(ids, id)
+		if id != "2101.00001v2" && id != "2101.00001" {
+			fmt.Fprint(w, errorFeed)
+			return
+		}
+		fmt.Fprintf(w, feed, id)
+	}))
+	defer server.Close()
+
+	want := commonmeta.Data{
+		ID:   "https://doi.org/10.48550/arxiv.2101.00001",
+		Type: "Article",
+		Contributors: []commonmeta.Contributor{
+			{Type: "Person", GivenName: "Josiah", FamilyName: "Carberry", Affiliations: []*commonmeta.Affiliation{{Name: "Brown University"}}, ContributorRoles: []string{"Author"}},
+			{Type: "Person", GivenName: "Jane", FamilyName: "Smith", ContributorRoles: []string{"Author"}},
+		},
+		Container: commonmeta.Container{Identifier: "https://arxiv.org", IdentifierType: "URL", Type: "Repository", Title: "arXiv"},
+		Date:      commonmeta.Date{Published: "2020-12-31T19:00:00Z", Updated: "2021-03-15T18:00:00Z"},
+		Descriptions: []commonmeta.Description{
+			{Description: "We study open citations in the scholarly record.", Type: "Abstract"},
+			{Description: "12 pages, 3 figures", Type: "Other"},
+		},
+		Files: []commonmeta.File{{URL: "http://arxiv.org/pdf/2101.00001v2", MimeType: "application/pdf"}},
+		Identifiers: []commonmeta.Identifier{
+			{Identifier: "https://doi.org/10.48550/arxiv.2101.00001", IdentifierType: "DOI"},
+			{Identifier: "2101.00001v2", IdentifierType: "arXiv"},
+		},
+		Provider:  "arXiv",
+		Publisher: commonmeta.Publisher{Name: "arXiv"},
+		Relations: []commonmeta.Relation{{ID: "https://doi.org/10.5555/12345678", Type: "IsPreprintOf"}},
+		Subjects:  []commonmeta.Subject{{Subject: "cs.DL"}, {Subject: "cs.IR"}},
+		Titles:    []commonmeta.Title{{Title: "Open Citations and the Scholarly Record"}},
+		URL:       "https://arxiv.org/abs/2101.00001v2",
+		Version:   "v2",
+	}
+	for _, id := range []string{"2101.00001v2", "arXiv:2101.00001v2", "https://arxiv.org/abs/2101.00001v2"} {
+		got, err := arxiv.Fetch(id, arxiv.WithBaseURL(server.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Fetch(%s) mismatch (-want +got):\n%s", id, diff)
+		}
+	}
+	if ids[0] != "2101.00001v2" || ids[1] != "2101.00001v2" {
+		t.Errorf("Fetch: want versioned id_list, got %v", ids)
+	}
+
+	_, err := arxiv.Fetch("9999.99999", arxiv.WithBaseURL(server.URL))
+	if err == nil {
+		t.Error("Fetch(9999.99999): want error, got nil")
+	}
+	_, err = arxiv.Fetch("10.5555/12345678", arxiv.WithBaseURL(server.URL))
+	if err == nil {
+		t.Error("Fetch(10.5555/12345678): want error for DOI, got nil")
+	}
+}
+
+func TestValidateArxiv(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input       string
+		id          string
+		versionedID string
+		ok          bool
+	}
+	testCases := []testCase{
+		{input: "2101.00001", id: "2101.00001", versionedID: "2101.00001", ok: true},
+		{input: "2101.00001v2", id: "2101.00001", versionedID: "2101.00001v2", ok: true},
+		{input: "arXiv:1501.0001v1", id: "1501.0001", versionedID: "1501.0001v1", ok: true},
+		{input: "https://arxiv.org/pdf/2101.00001v3.pdf", id: "2101.00001", versionedID: "2101.00001v3", ok: true},
+		{input: "hep-th/9901001v1", id: "hep-th/9901001", versionedID: "hep-th/9901001v1", ok: true},
+		{input: "math.GT/0309136", id: "math.GT/0309136", versionedID: "math.GT/0309136", ok: true},
+		{input: "10.5555/12345678", ok: false},
+	}
+	for _, tc := range testCases {
+		id, versionedID, ok := arxiv.ValidateArxiv(tc.input)
+		if tc.id != id || tc.versionedID != versionedID || tc.ok != ok {
+			t.Errorf("ValidateArxiv(%v): want %v %v %v, got %v %v %v", tc.input, tc.id, tc.versionedID, tc.ok, id, versionedID, ok)
+		}
+	}
+}
@@ -2,6 +2,7 @@
 package authorutils
 
 import (
+	"slices"
 	"strings"
 )
 
@@ -43,6 +44,12 @@ func IsPersonalName(name string) bool {
 		"Team",
 		"Ministry",
 		"Government",
+		"Organization",
+		"Organisation",
+		"Agency",
+		"Council",
+		"Committee",
+		"Observatory",
 	}
 
 	for _, word := range organizationWords {
@@ -66,9 +73,16 @@ func IsPersonalName(name string) bool {
 	return true
 }
 
+// particles are lowercase words that start a family name, e.g. "van der Berg"
+var particles = []string{"van", "von", "der", "den", "de", "da", "del", "della", "di", "du", "la", "le", "ten", "ter", "dos", "das", "zu"}
+
+// ParseName parses a name into given name, family name and organization name.
+// Personal names are given as "Given Family" or "Family, Given", family names
+// can start with a lowercase particle, e.g. "Jan van der Berg".
 func ParseName(name string) (string, string, string) {
 	var givenName, familyName string
 
+	name = strings.Join(strings.Fields(name), " ")
 	if !IsPersonalName(name) {
 		return givenName, familyName, name
 	}
@@ -85,14 +99,29 @@ func ParseName(name string) (string, string, string) {
 		}
 	}
 
-	// default to the last word as family name
+	// "Family, Given" or "Family, Jr., Given"
+	parts := strings.Split(name, ",")
+	if len(parts) > 1 {
+		familyName = strings.TrimSpace(parts[0])
+		givenName = strings.TrimSpace(parts[len(parts)-1])
+		return givenName, familyName, ""
+	}
+
+	// default to the last word as family name, including lowercase particles
 	words := strings.Split(name, " ")
 	if len(words) == 1 {
 		familyName = name
 		return givenName, familyName, ""
 	} else if len(words) > 1 {
-		familyName = words[len(words)-1]
-		givenName = strings.Join(words[:len(words)-1], " ")
+		i := len(words) - 1
+		for j := 1; j < len(words)-1; j++ {
+			if slices.Contains(particles, words[j]) {
+				i = j
+				break
+			}
+		}
+		familyName = strings.Join(words[i:], " ")
+		givenName = strings.Join(words[:i], " ")
 		name = ""
 	}
 	return givenName, familyName, name
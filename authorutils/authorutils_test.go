@@ -18,6 +18,7 @@ func TestIsPersonalName(t *testing.T) {
 		{input: "LiberateScience", want: false},
 		{input: "Jane Smith, MD", want: true},
 		{input: "John", want: false},
+		{input: "World Health Organization", want: false},
 	}
 	for _, tc := range testCases {
 		got := authorutils.IsPersonalName(tc.input)
@@ -43,6 +44,12 @@ func TestParseName(t *testing.T) {
 		{input: "LiberateScience", givenName: "", familyName: "", name: "LiberateScience"},
 		{input: "Jane Smith, MD", givenName: "Jane", familyName: "Smith", name: ""},
 		{input: "John", givenName: "", familyName: "", name: "John"},
+		{input: "John A. Doe", givenName: "John A.", familyName: "Doe", name: ""},
+		{input: "van der Berg, Jan", givenName: "Jan", familyName: "van der Berg", name: ""},
+		{input: "Jan van der Berg", givenName: "Jan", familyName: "van der Berg", name: ""},
+		{input: "Ludwig van Beethoven", givenName: "Ludwig", familyName: "van Beethoven", name: ""},
+		{input: "NASA", givenName: "", familyName: "", name: "NASA"},
+		{input: "The Example Consortium", givenName: "", familyName: "", name: "The Example Consortium"},
 	}
 	for _, tc := range testCases {
 		givenName, familyName, name := authorutils.ParseName(tc.input)
@@ -1,14 +1,557 @@
+// Package bibtex provides functions to convert BibTeX metadata to/from the commonmeta metadata format.
 package bibtex
 
-var CMToBibMappings = map[string]string{
-	"Article":            "article",
-	"Book":               "book",
-	"BookChapter":        "inbook",
-	"Dissertation":       "phdthesis",
-	"JournalArticle":     "article",
-	"Manuscript":         "unpublished",
-	"Other":              "misc",
-	"Proceedings":        "proceedings",
-	"ProceedingsArticle": "inproceedings",
-	"Report":             "techreport",
+import (
+	"errors"
+	"log"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/front-matter/commonmeta/authorutils"
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/dateutils"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/isbnutils"
+	"github.com/front-matter/commonmeta/utils"
+)
+
+// Content represents a single BibTeX entry. Field names are lowercased,
+// field values are stored as found in the entry, without the outer delimiters.
+type Content struct {
+	Type   string
+	Key    string
+	Fields map[string]string
+}
+
+// BibToCMMappings maps BibTeX entry types to commonmeta types
+var BibToCMMappings = map[string]string{
+	"article":       "JournalArticle",
+	"book":          "Book",
+	"booklet":       "Book",
+	"conference":    "ProceedingsArticle",
+	"inbook":        "BookChapter",
+	"incollection":  "BookChapter",
+	"inproceedings": "ProceedingsArticle",
+	"manual":        "Document",
+	"mastersthesis": "Dissertation",
+	"misc":          "Other",
+	"phdthesis":     "Dissertation",
+	"proceedings":   "Proceedings",
+	"techreport":    "Report",
+	"unpublished":   "Manuscript",
+}
+
+// latexAccents maps LaTeX accent commands and base letters to unicode characters
+var latexAccents = map[string]map[rune]string{
+	"\"": {'a': "ä", 'e': "ë", 'i': "ï", 'o': "ö", 'u': "ü", 'y': "ÿ", 'A': "Ä", 'E': "Ë", 'I': "Ï", 'O': "Ö", 'U': "Ü"},
+	"'":  {'a': "á", 'e': "é", 'i': "í", 'o': "ó", 'u': "ú", 'y': "ý", 'c': "ć", 'n': "ń", 's': "ś", 'z': "ź", 'A': "Á", 'E': "É", 'I': "Í", 'O': "Ó", 'U': "Ú", 'C': "Ć", 'S': "Ś", 'Z': "Ź"},
+	"`":  {'a': "à", 'e': "è", 'i': "ì", 'o': "ò", 'u': "ù", 'A': "À", 'E': "È", 'I': "Ì", 'O': "Ò", 'U': "Ù"},
+	"^":  {'a': "â", 'e': "ê", 'i': "î", 'o': "ô", 'u': "û", 'A': "Â", 'E': "Ê", 'I': "Î", 'O': "Ô", 'U': "Û"},
+	"~":  {'a': "ã", 'n': "ñ", 'o': "õ", 'A': "Ã", 'N': "Ñ", 'O': "Õ"},
+	"c":  {'c': "ç", 's': "ş", 'C': "Ç", 'S': "Ş"},
+	"v":  {'c': "č", 's': "š", 'z': "ž", 'r': "ř", 'e': "ě", 'C': "Č", 'S': "Š", 'Z': "Ž", 'R': "Ř"},
+	"=":  {'a': "ā", 'e': "ē", 'i': "ī", 'o': "ō", 'u': "ū"},
+	"r":  {'a': "å", 'A': "Å"},
+	"H":  {'o': "ő", 'u': "ű", 'O': "Ő", 'U': "Ű"},
+}
+
+// latexSymbols maps LaTeX commands without arguments to unicode characters
+var latexSymbols = map[string]string{
+	"ss":    "ß",
+	"o":     "ø",
+	"O":     "Ø",
+	"ae":    "æ",
+	"AE":    "Æ",
+	"oe":    "œ",
+	"OE":    "Œ",
+	"aa":    "å",
+	"AA":    "Å",
+	"l":     "ł",
+	"L":     "Ł",
+	"i":     "ı",
+	"TeX":   "TeX",
+	"LaTeX": "LaTeX",
+}
+
+var months = map[string]int{
+	"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+	"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+}
+
+// Load loads the metadata for a single work from a BibTeX file
+func Load(filename string) (commonmeta.Data, error) {
+	var data commonmeta.Data
+
+	content, err := loadFile(filename)
+	if err != nil {
+		return data, err
+	}
+	if len(content) == 0 {
+		return data, errors.New("no BibTeX entries found")
+	}
+	data, err = Read(content[0])
+	if err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// LoadAll loads a list of works from a BibTeX file and converts it to the Commonmeta format
+func LoadAll(filename string) ([]commonmeta.Data, error) {
+	var data []commonmeta.Data
+
+	content, err := loadFile(filename)
+	if err != nil {
+		return data, err
+	}
+	data, err = ReadAll(content)
+	if err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+func loadFile(filename string) ([]Content, error) {
+	extension := path.Ext(filename)
+	if extension != ".bib" {
+		return nil, errors.New("invalid file extension")
+	}
+	bytes, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.New("error reading file")
+	}
+	return Parse(bytes)
+}
+
+// Read reads a BibTeX entry and converts it to commonmeta.
+func Read(content Content) (commonmeta.Data, error) {
+	var data commonmeta.Data
+	fields := content.Fields
+
+	data.ID = doiutils.NormalizeDOI(fields["doi"])
+	if data.ID == "" {
+		data.ID = utils.NormalizeID(fields["url"])
+	}
+	if data.ID == "" {
+		data.ID = content.Key
+	}
+
+	data.Type = BibToCMMappings[content.Type]
+	if data.Type == "" {
+		data.Type = "Other"
+	}
+
+	for _, v := range ParseAuthors(fields["author"]) {
+		v.ContributorRoles = []string{"Author"}
+		data.Contributors = append(data.Contributors, v)
+	}
+	for _, v := range ParseAuthors(fields["editor"]) {
+		v.ContributorRoles = []string{"Editor"}
+		data.Contributors = append(data.Contributors, v)
+	}
+
+	containerTitle := fields["journal"]
+	if containerTitle == "" {
+		containerTitle = fields["booktitle"]
+	}
+	var identifier, identifierType string
+	if fields["issn"] != "" {
+		identifier = fields["issn"]
+		identifierType = "ISSN"
+	} else if isbn := isbnutils.NormalizeISBN(fields["isbn"]); isbn != "" {
+		identifier = isbn
+		identifierType = "ISBN"
+	}
+	data.Container = commonmeta.Container{
+		Identifier:     identifier,
+		IdentifierType: identifierType,
+		Type:           commonmeta.ContainerTypes[data.Type],
+		Title:          Unescape(containerTitle),
+		Volume:         fields["volume"],
+		Issue:          fields["number"],
+	}
+	data.Container.SetPages(fields["pages"])
+
+	data.Date.Published = getDate(fields["year"], fields["month"], fields["day"])
+
+	if fields["abstract"] != "" {
+		data.Descriptions = append(data.Descriptions, commonmeta.Description{
+			Description: utils.Sanitize(Unescape(fields["abstract"])),
+			Type:        "Abstract",
+		})
+	}
+
+	if doiutils.NormalizeDOI(data.ID) != "" {
+		data.Identifiers = append(data.Identifiers, commonmeta.Identifier{
+			Identifier:     data.ID,
+			IdentifierType: "DOI",
+		})
+	}
+
+	publisher := fields["publisher"]
+	if publisher == "" {
+		publisher = fields["school"]
+	}
+	if publisher == "" {
+		publisher = fields["institution"]
+	}
+	if publisher != "" {
+		data.Publisher = commonmeta.Publisher{
+			Name: Unescape(publisher),
+		}
+	}
+
+	if fields["keywords"] != "" {
+		for _, v := range strings.Split(fields["keywords"], ",") {
+			v = strings.TrimSpace(Unescape(v))
+			if v != "" {
+				data.Subjects = append(data.Subjects, commonmeta.Subject{
+					Subject: v,
+				})
+			}
+		}
+	}
+
+	if fields["title"] != "" {
+		data.Titles = append(data.Titles, commonmeta.Title{
+			Title: Unescape(fields["title"]),
+		})
+	}
+
+	if fields["url"] != "" {
+		url, err := utils.NormalizeURL(fields["url"], true, false)
+		if err != nil {
+			url = fields["url"]
+		}
+		data.URL = url
+	}
+
+	return data, nil
+}
+
+// ReadAll reads a list of BibTeX entries and returns a list of works in Commonmeta format
+func ReadAll(content []Content) ([]commonmeta.Data, error) {
+	var data []commonmeta.Data
+	for _, v := range content {
+		d, err := Read(v)
+		if err != nil {
+			log.Println(err)
+		}
+		data = append(data, d)
+	}
+	return data, nil
+}
+
+// Parse parses BibTeX input into a list of entries. @comment, @preamble and
+// @string entries are skipped.
+func Parse(input []byte) ([]Content, error) {
+	var content []Content
+	s := string(input)
+	for {
+		start := strings.Index(s, "@")
+		if start == -1 {
+			break
+		}
+		s = s[start+1:]
+		open := strings.IndexAny(s, "{(")
+		if open == -1 {
+			return content, errors.New("invalid BibTeX entry")
+		}
+		entryType := strings.ToLower(strings.TrimSpace(s[:open]))
+		closeChar := byte('}')
+		if s[open] == '(' {
+			closeChar = ')'
+		}
+		end := matchingDelimiter(s, open, s[open], closeChar)
+		if end == -1 {
+			return content, errors.New("unbalanced delimiters in BibTeX entry")
+		}
+		body := s[open+1 : end]
+		s = s[end+1:]
+		if entryType == "comment" || entryType == "preamble" || entryType == "string" {
+			continue
+		}
+		entry, err := parseEntry(entryType, body)
+		if err != nil {
+			return content, err
+		}
+		content = append(content, entry)
+	}
+	return content, nil
+}
+
+// parseEntry parses the key and fields of a BibTeX entry body
+func parseEntry(entryType string, body string) (Content, error) {
+	entry := Content{
+		Type:   entryType,
+		Fields: make(map[string]string),
+	}
+	comma := strings.Index(body, ",")
+	if comma == -1 {
+		entry.Key = strings.TrimSpace(body)
+		return entry, nil
+	}
+	entry.Key = strings.TrimSpace(body[:comma])
+	s := body[comma+1:]
+	for {
+		s = strings.TrimLeft(s, ", \t\r\n")
+		if s == "" {
+			break
+		}
+		eq := strings.Index(s, "=")
+		if eq == -1 {
+			break
+		}
+		name := strings.ToLower(strings.TrimSpace(s[:eq]))
+		s = strings.TrimLeft(s[eq+1:], " \t\r\n")
+
+		// values can be concatenated with #
+		var value strings.Builder
+		for {
+			if s == "" {
+				break
+			}
+			var part string
+			switch s[0] {
+			case '{':
+				end := matchingDelimiter(s, 0, '{', '}')
+				if end == -1 {
+					return entry, errors.New("unbalanced braces in BibTeX field " + name)
+				}
+				part = s[1:end]
+				s = s[end+1:]
+			case '"':
+				end := matchingQuote(s)
+				if end == -1 {
+					return entry, errors.New("unbalanced quotes in BibTeX field " + name)
+				}
+				part = s[1:end]
+				s = s[end+1:]
+			default:
+				end := strings.IndexAny(s, ",#")
+				if end == -1 {
+					end = len(s)
+				}
+				part = strings.TrimSpace(s[:end])
+				s = s[end:]
+			}
+			value.WriteString(part)
+			s = strings.TrimLeft(s, " \t\r\n")
+			if !strings.HasPrefix(s, "#") {
+				break
+			}
+			s = strings.TrimLeft(s[1:], " \t\r\n")
+		}
+		entry.Fields[name] = normalizeWhitespace(value.String())
+	}
+	return entry, nil
+}
+
+// matchingDelimiter returns the index of the delimiter closing the one at position start
+func matchingDelimiter(s string, start int, open byte, close byte) int {
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case open:
+			depth++
+		case close:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// matchingQuote returns the index of the closing quote, ignoring quotes inside braces
+func matchingQuote(s string) int {
+	depth := 0
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case '"':
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func normalizeWhitespace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// ParseAuthors parses a BibTeX name list, e.g. "Family, Given and Family, Given",
+// into a list of contributors. Names protected by braces are treated as organizations.
+func ParseAuthors(str string) []commonmeta.Contributor {
+	var contributors []commonmeta.Contributor
+	for _, name := range splitNames(str) {
+		name = strings.TrimSpace(name)
+		if name == "" || name == "others" {
+			continue
+		}
+		if strings.HasPrefix(name, "{") && matchingDelimiter(name, 0, '{', '}') == len(name)-1 {
+			contributors = append(contributors, commonmeta.Contributor{
+				Type: "Organization",
+				Name: Unescape(name),
+			})
+			continue
+		}
+		name = Unescape(name)
+		var givenName, familyName string
+		parts := strings.Split(name, ",")
+		if len(parts) > 1 {
+			// "Family, Given" or "Family, Jr, Given"
+			familyName = strings.TrimSpace(parts[0])
+			givenName = strings.TrimSpace(parts[len(parts)-1])
+		} else {
+			var orgName string
+			givenName, familyName, orgName = authorutils.ParseName(name)
+			if orgName != "" {
+				contributors = append(contributors, commonmeta.Contributor{
+					Type: "Organization",
+					Name: orgName,
+				})
+				continue
+			}
+		}
+		contributors = append(contributors, commonmeta.Contributor{
+			Type:       "Person",
+			GivenName:  givenName,
+			FamilyName: familyName,
+		})
+	}
+	return contributors
+}
+
+// splitNames splits a BibTeX name list on " and " outside of braces
+func splitNames(str string) []string {
+	var names []string
+	depth := 0
+	last := 0
+	for i := 0; i < len(str); i++ {
+		switch str[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ' ', '\t', '\n':
+			if depth == 0 && i+5 <= len(str) && strings.EqualFold(str[i+1:i+4], "and") && unicode.IsSpace(rune(str[i+4])) {
+				names = append(names, str[last:i])
+				last = i + 5
+				i += 4
+			}
+		}
+	}
+	return append(names, str[last:])
+}
+
+// Unescape converts LaTeX accents and escaped characters to unicode and
+// removes the braces used to protect capitalization.
+func Unescape(str string) string {
+	var b strings.Builder
+	for i := 0; i < len(str); i++ {
+		c := str[i]
+		switch c {
+		case '{', '}':
+			continue
+		case '~':
+			b.WriteByte(' ')
+			continue
+		case '\\':
+			if i+1 >= len(str) {
+				continue
+			}
+			next := str[i+1]
+			// escaped special characters, e.g. \& or \%
+			if strings.IndexByte("&%$#_{}", next) != -1 {
+				b.WriteByte(next)
+				i++
+				continue
+			}
+			// command name: a single non-letter or a run of letters
+			j := i + 1
+			if unicode.IsLetter(rune(next)) {
+				for j < len(str) && unicode.IsLetter(rune(str[j])) {
+					j++
+				}
+			} else {
+				j++
+			}
+			command := str[i+1 : j]
+			if accents, ok := latexAccents[command]; ok {
+				// argument is either {x} or x, optionally preceded by a space
+				k := j
+				for k < len(str) && str[k] == ' ' {
+					k++
+				}
+				if k < len(str) && str[k] == '{' {
+					k++
+				}
+				if k < len(str) {
+					if s, ok := accents[rune(str[k])]; ok {
+						b.WriteString(s)
+						if k+1 < len(str) && str[k+1] == '}' {
+							k++
+						}
+						i = k
+						continue
+					}
+				}
+			}
+			if s, ok := latexSymbols[command]; ok {
+				b.WriteString(s)
+				// swallow the space terminating the command
+				if j < len(str) && str[j] == ' ' {
+					j++
+				}
+				i = j - 1
+				continue
+			}
+			// unknown command: drop the backslash and command name
+			if j < len(str) && str[j] == ' ' {
+				j++
+			}
+			i = j - 1
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return strings.ReplaceAll(strings.ReplaceAll(b.String(), "---", "—"), "--", "–")
+}
+
+// getDate returns an ISO 8601 date from BibTeX year, month and day fields
+func getDate(year string, month string, day string) string {
+	y, err := strconv.Atoi(strings.TrimSpace(year))
+	if err != nil {
+		return ""
+	}
+	month = strings.ToLower(strings.TrimSpace(month))
+	m, ok := months[month]
+	if !ok && len(month) > 3 {
+		m = months[month[:3]]
+	}
+	if m == 0 {
+		m, _ = strconv.Atoi(month)
+	}
+	if m < 1 || m > 12 {
+		return dateutils.GetDateFromParts(y)
+	}
+	d, _ := strconv.Atoi(strings.TrimSpace(day))
+	if d < 1 || d > 31 {
+		return dateutils.GetDateFromParts(y, m)
+	}
+	return dateutils.GetDateFromParts(y, m, d)
 }
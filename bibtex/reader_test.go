@@ -0,0 +1,165 @@
+package bibtex_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/front-matter/commonmeta/bibtex"
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name      string
+		filename  string
+		id        string
+		type_     string
+		published string
+		author    commonmeta.Contributor
+		title     string
+	}
+
+	testCases := []testCase{
+		{
+			name:      "journal article",
+			filename:  "crossref.bib",
+			id:        "https://doi.org/10.7554/elife.01567",
+			type_:     "JournalArticle",
+			published: "2014-02",
+			author:    commonmeta.Contributor{Type: "Person", GivenName: "Martial", FamilyName: "Sankar", ContributorRoles: []string{"Author"}},
+			title:     "Automated quantitative histology reveals vascular morphodynamics during Arabidopsis hypocotyl secondary growth",
+		},
+		{
+			name:      "dissertation",
+			filename:  "pure.bib",
+			id:        "dbbe66e459a446a0b6fddf42d3401ccb",
+			type_:     "Dissertation",
+			published: "2018-04-25",
+			author:    commonmeta.Contributor{Type: "Person", GivenName: "Y.", FamilyName: "Toparlar", ContributorRoles: []string{"Author"}},
+			title:     "A multiscale analysis of the urban heat island effect: from city averaged temperatures to the energy demand of individual buildings",
+		},
+	}
+	for _, tc := range testCases {
+		got, err := bibtex.Load(filepath.Join("testdata", tc.filename))
+		if err != nil {
+			t.Fatalf("BibTeX Load (%v): error %v", tc.filename, err)
+		}
+		if got.ID != tc.id {
+			t.Errorf("BibTeX Load ID (%v): want %v, got %v", tc.filename, tc.id, got.ID)
+		}
+		if got.Type != tc.type_ {
+			t.Errorf("BibTeX Load Type (%v): want %v, got %v", tc.filename, tc.type_, got.Type)
+		}
+		if got.Date.Published != tc.published {
+			t.Errorf("BibTeX Load Date (%v): want %v, got %v", tc.filename, tc.published, got.Date.Published)
+		}
+		if len(got.Contributors) == 0 {
+			t.Fatalf("BibTeX Load Contributors (%v): no contributors", tc.filename)
+		}
+		if diff := cmp.Diff(tc.author, got.Contributors[0]); diff != "" {
+			t.Errorf("BibTeX Load Contributors (%s) mismatch (-want +got):\n%s", tc.filename, diff)
+		}
+		if len(got.Titles) == 0 || got.Titles[0].Title != tc.title {
+			t.Errorf("BibTeX Load Titles (%v): want %v, got %v", tc.filename, tc.title, got.Titles)
+		}
+	}
+}
+
+func TestLoadAll(t *testing.T) {
+	t.Parallel()
+	got, err := bibtex.LoadAll(filepath.Join("testdata", "references.bib"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("BibTeX LoadAll: want 3 works, got %d", len(got))
+	}
+
+	article := got[0]
+	if article.ID != "https://doi.org/10.5555/12345678" {
+		t.Errorf("BibTeX LoadAll ID: got %v", article.ID)
+	}
+	wantContributors := []commonmeta.Contributor{
+		{Type: "Person", GivenName: "Jörg", FamilyName: "Müller", ContributorRoles: []string{"Author"}},
+		{Type: "Person", GivenName: "Ana", FamilyName: "García Márquez", ContributorRoles: []string{"Author"}},
+		{Type: "Organization", Name: "World Health Organization", ContributorRoles: []string{"Author"}},
+	}
+	if diff := cmp.Diff(wantContributors, article.Contributors); diff != "" {
+		t.Errorf("BibTeX LoadAll Contributors mismatch (-want +got):\n%s", diff)
+	}
+	if article.Titles[0].Title != "The DNA of Arabidopsis: a review" {
+		t.Errorf("BibTeX LoadAll Title: got %v", article.Titles[0].Title)
+	}
+	wantContainer := commonmeta.Container{
+		Type:      "Journal",
+		Title:     "Journal of Plant Science",
+		Volume:    "12",
+		Issue:     "3",
+		FirstPage: "101",
+		LastPage:  "115",
+	}
+	if diff := cmp.Diff(wantContainer, article.Container); diff != "" {
+		t.Errorf("BibTeX LoadAll Container mismatch (-want +got):\n%s", diff)
+	}
+	if article.Date.Published != "2019-03" {
+		t.Errorf("BibTeX LoadAll Date: got %v", article.Date.Published)
+	}
+
+	proceedings := got[1]
+	if proceedings.Type != "ProceedingsArticle" {
+		t.Errorf("BibTeX LoadAll Type: want ProceedingsArticle, got %v", proceedings.Type)
+	}
+	if proceedings.Titles[0].Title != "Faster parsing of reference lists" {
+		t.Errorf("BibTeX LoadAll Title: got %v", proceedings.Titles[0].Title)
+	}
+	if len(proceedings.Contributors) != 2 || proceedings.Contributors[1].FamilyName != "Doe" {
+		t.Errorf("BibTeX LoadAll Contributors: got %v", proceedings.Contributors)
+	}
+
+	book := got[2]
+	if book.Container.Identifier != "9780201134476" || book.Container.IdentifierType != "ISBN" {
+		t.Errorf("BibTeX LoadAll ISBN: got %v", book.Container)
+	}
+	if book.Titles[0].Title != "The TeXbook" {
+		t.Errorf("BibTeX LoadAll Title: got %v", book.Titles[0].Title)
+	}
+}
+
+func TestUnescape(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input string
+		want  string
+	}
+	testCases := []testCase{
+		{input: `{\"o}`, want: "ö"},
+		{input: `\"{u}ber`, want: "über"},
+		{input: `Schr\"odinger`, want: "Schrödinger"},
+		{input: `Fran\c{c}ois`, want: "François"},
+		{input: `Stra{\ss}e`, want: "Straße"},
+		{input: `{Arabidopsis} and {DNA}`, want: "Arabidopsis and DNA"},
+		{input: `R\&D 10\%`, want: "R&D 10%"},
+	}
+	for _, tc := range testCases {
+		got := bibtex.Unescape(tc.input)
+		if tc.want != got {
+			t.Errorf("Unescape(%v): want %v, got %v", tc.input, tc.want, got)
+		}
+	}
+}
+
+func TestParseAuthors(t *testing.T) {
+	t.Parallel()
+	got := bibtex.ParseAuthors("John A. Doe and van der Berg, Jan and Jan van der Berg and {NASA}")
+	want := []commonmeta.Contributor{
+		{Type: "Person", GivenName: "John A.", FamilyName: "Doe"},
+		{Type: "Person", GivenName: "Jan", FamilyName: "van der Berg"},
+		{Type: "Person", GivenName: "Jan", FamilyName: "van der Berg"},
+		{Type: "Organization", Name: "NASA"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("ParseAuthors mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,206 @@
+package bibtex
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/textutils"
+)
+
+var CMToBibMappings = map[string]string{
+	"Article":            "article",
+	"Book":               "book",
+	"BookChapter":        "inbook",
+	"Dissertation":       "phdthesis",
+	"JournalArticle":     "article",
+	"Manuscript":         "unpublished",
+	"Other":              "misc",
+	"Proceedings":        "proceedings",
+	"ProceedingsArticle": "inproceedings",
+	"Report":             "techreport",
+}
+
+// fieldOrder is the order in which fields are written
+var fieldOrder = []string{
+	"author",
+	"editor",
+	"title",
+	"journal",
+	"booktitle",
+	"year",
+	"volume",
+	"number",
+	"pages",
+	"publisher",
+	"issn",
+	"isbn",
+	"doi",
+	"url",
+	"keywords",
+	"abstract",
+}
+
+// Convert converts commonmeta metadata to a BibTeX entry.
+func Convert(data commonmeta.Data) (Content, error) {
+	content := Content{
+		Fields: make(map[string]string),
+	}
+
+	content.Type = CMToBibMappings[data.Type]
+	if content.Type == "" {
+		content.Type = "misc"
+	}
+
+	var authors, editors []string
+	for _, contributor := range data.Contributors {
+		var name string
+		if contributor.FamilyName != "" {
+			name = Escape(contributor.FamilyName)
+			if contributor.GivenName != "" {
+				name += ", " + Escape(contributor.GivenName)
+			}
+		} else if contributor.Name != "" {
+			// protect organization names from being parsed as personal names
+			name = "{" + Escape(contributor.Name) + "}"
+		} else {
+			continue
+		}
+		if slices.Contains(contributor.ContributorRoles, "Editor") {
+			editors = append(editors, name)
+		} else {
+			authors = append(authors, name)
+		}
+	}
+	content.Fields["author"] = strings.Join(authors, " and ")
+	content.Fields["editor"] = strings.Join(editors, " and ")
+
+	if len(data.Titles) > 0 {
+		content.Fields["title"] = Escape(data.Titles[0].Title)
+	}
+	if content.Type == "article" {
+		content.Fields["journal"] = Escape(data.Container.Title)
+	} else if data.Type == "BookChapter" || data.Type == "ProceedingsArticle" {
+		content.Fields["booktitle"] = Escape(data.Container.Title)
+	}
+	var year string
+	if len(data.Date.Published) >= 4 {
+		year = data.Date.Published[:4]
+		content.Fields["year"] = year
+	}
+	content.Fields["volume"] = data.Container.Volume
+	content.Fields["number"] = data.Container.Issue
+	content.Fields["pages"] = strings.Replace(data.Container.Pages(), "-", "--", 1)
+	content.Fields["publisher"] = Escape(data.Publisher.Name)
+	if data.Container.IdentifierType == "ISSN" {
+		content.Fields["issn"] = data.Container.Identifier
+	} else if data.Container.IdentifierType == "ISBN" {
+		content.Fields["isbn"] = data.Container.Identifier
+	}
+	doi, _ := doiutils.ValidateDOI(data.ID)
+	content.Fields["doi"] = doi
+	content.Fields["url"] = data.URL
+	var keywords []string
+	for _, subject := range data.Subjects {
+		if subject.Subject != "" {
+			keywords = append(keywords, Escape(subject.Subject))
+		}
+	}
+	content.Fields["keywords"] = strings.Join(keywords, ", ")
+	if len(data.Descriptions) > 0 {
+		content.Fields["abstract"] = Escape(textutils.StripMarkup(data.Descriptions[0].Description))
+	}
+
+	var familyName, titleWord string
+	if author, ok := data.FirstAuthor(); ok {
+		familyName = author.FamilyName
+		if familyName == "" {
+			familyName = author.Name
+		}
+	}
+	if len(data.Titles) > 0 {
+		for _, word := range strings.Fields(data.Titles[0].Title) {
+			if w := keyPart(word); w != "" {
+				titleWord = w
+				break
+			}
+		}
+	}
+	content.Key = keyPart(familyName) + year + titleWord
+	if content.Key == "" {
+		content.Key = doi
+	}
+
+	return content, nil
+}
+
+// Write writes BibTeX metadata.
+func Write(data commonmeta.Data) ([]byte, error) {
+	content, err := Convert(data)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(content.String()), nil
+}
+
+// WriteAll writes a list of BibTeX metadata as a concatenated .bib file.
+func WriteAll(list []commonmeta.Data) ([]byte, error) {
+	var entries []string
+	for _, data := range list {
+		content, err := Convert(data)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, content.String())
+	}
+	return []byte(strings.Join(entries, "\n")), nil
+}
+
+// String formats a BibTeX entry, omitting empty fields.
+func (c Content) String() string {
+	var b strings.Builder
+	b.WriteString("@" + c.Type + "{" + c.Key)
+	for _, name := range fieldOrder {
+		value := c.Fields[name]
+		if value == "" {
+			continue
+		}
+		b.WriteString(",\n  " + name + " = {" + value + "}")
+	}
+	b.WriteString("\n}\n")
+	return b.String()
+}
+
+// Escape escapes characters with a special meaning in BibTeX.
+func Escape(str string) string {
+	replacer := strings.NewReplacer(
+		"&", `\&`,
+		"%", `\%`,
+		"$", `\$`,
+		"#", `\#`,
+		"_", `\_`,
+	)
+	return replacer.Replace(str)
+}
+
+// keyPart returns the ASCII letters and digits of a string, used to build citation keys.
+// Accented letters are replaced by their base letter.
+func keyPart(str string) string {
+	var b strings.Builder
+	for _, r := range str {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			b.WriteRune(r)
+			continue
+		}
+		for _, accents := range latexAccents {
+			for base, accented := range accents {
+				if accented == string(r) {
+					b.WriteRune(base)
+				}
+			}
+		}
+	}
+	return b.String()
+}
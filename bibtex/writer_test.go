@@ -0,0 +1,101 @@
+package bibtex_test
+
+import (
+	"testing"
+
+	"github.com/front-matter/commonmeta/bibtex"
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+	data := commonmeta.Data{
+		ID:   "https://doi.org/10.5555/12345678",
+		Type: "JournalArticle",
+		URL:  "https://example.org/articles/12345678",
+		Contributors: []commonmeta.Contributor{
+			{Type: "Person", GivenName: "Jörg", FamilyName: "Müller", ContributorRoles: []string{"Author"}},
+			{Type: "Person", GivenName: "Jane", FamilyName: "Doe", ContributorRoles: []string{"Author"}},
+			{Type: "Organization", Name: "World Health Organization", ContributorRoles: []string{"Author"}},
+		},
+		Titles: []commonmeta.Title{{Title: "Plants & genomes: a review"}},
+		Container: commonmeta.Container{
+			Type:      "Journal",
+			Title:     "Journal of Plant Science",
+			Volume:    "12",
+			Issue:     "3",
+			FirstPage: "101",
+			LastPage:  "115",
+		},
+		Date:      commonmeta.Date{Published: "2019-03-01"},
+		Publisher: commonmeta.Publisher{Name: "Example Press"},
+	}
+	want := `@article{Muller2019Plants,
+  author = {Müller, Jörg and Doe, Jane and {World Health Organization}},
+  title = {Plants \& genomes: a review},
+  journal = {Journal of Plant Science},
+  year = {2019},
+  volume = {12},
+  number = {3},
+  pages = {101--115},
+  publisher = {Example Press},
+  doi = {10.5555/12345678},
+  url = {https://example.org/articles/12345678}
+}
+`
+	got, err := bibtex.Write(data)
+	if err != nil {
+		t.Errorf("BibTeX Write (%v): error %v", data.ID, err)
+	}
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("BibTeX Write (%v) mismatch (-want +got):\n%s", data.ID, diff)
+	}
+
+	// the output re-parses back to equivalent fields
+	entries, parseErr := bibtex.Parse(got)
+	if parseErr != nil {
+		t.Fatal(parseErr)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("BibTeX Parse: want 1 entry, got %d", len(entries))
+	}
+	roundtrip, readErr := bibtex.Read(entries[0])
+	if readErr != nil {
+		t.Fatal(readErr)
+	}
+	if diff := cmp.Diff(data.Contributors, roundtrip.Contributors); diff != "" {
+		t.Errorf("BibTeX Read Contributors mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(data.Container, roundtrip.Container); diff != "" {
+		t.Errorf("BibTeX Read Container mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(data.Titles, roundtrip.Titles); diff != "" {
+		t.Errorf("BibTeX Read Titles mismatch (-want +got):\n%s", diff)
+	}
+	if roundtrip.ID != data.ID || roundtrip.Type != data.Type || roundtrip.Date.Published != "2019" {
+		t.Errorf("BibTeX Read: got %v %v %v", roundtrip.ID, roundtrip.Type, roundtrip.Date.Published)
+	}
+}
+
+func TestWriteAll(t *testing.T) {
+	t.Parallel()
+	list := []commonmeta.Data{
+		{ID: "https://doi.org/10.5555/1", Type: "Book", Titles: []commonmeta.Title{{Title: "First"}}, Date: commonmeta.Date{Published: "2020"}},
+		{ID: "https://doi.org/10.5555/2", Type: "Dataset", Titles: []commonmeta.Title{{Title: "Second"}}},
+	}
+	got, err := bibtex.WriteAll(list)
+	if err != nil {
+		t.Errorf("BibTeX WriteAll: error %v", err)
+	}
+	entries, parseErr := bibtex.Parse(got)
+	if parseErr != nil {
+		t.Fatal(parseErr)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("BibTeX WriteAll: want 2 entries, got %d", len(entries))
+	}
+	if entries[0].Type != "book" || entries[0].Key != "2020First" || entries[1].Type != "misc" {
+		t.Errorf("BibTeX WriteAll: got %v", entries)
+	}
+}
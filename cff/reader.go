@@ -1,16 +1,265 @@
-// Package cff provides a function to read CFF and convert it to commonmeta.
+// Package cff provides functions to convert Citation File Format (CFF) metadata to/from the commonmeta metadata format.
 package cff
 
-import "github.com/front-matter/commonmeta/commonmeta"
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"strconv"
+	"strings"
 
-type content struct {
-	ID    string `json:"id"`
-	Title string `json:"title"`
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/spdxutils"
+	"github.com/front-matter/commonmeta/utils"
+	"sigs.k8s.io/yaml"
+)
+
+// Content represents the metadata in a CITATION.cff file (CFF version 1.2.0).
+type Content struct {
+	CFFVersion         string       `json:"cff-version"`
+	Message            string       `json:"message"`
+	Type               string       `json:"type,omitempty"`
+	Title              string       `json:"title"`
+	Authors            []Author     `json:"authors"`
+	Abstract           string       `json:"abstract,omitempty"`
+	Version            string       `json:"version,omitempty"`
+	DOI                string       `json:"doi,omitempty"`
+	Identifiers        []Identifier `json:"identifiers,omitempty"`
+	DateReleased       string       `json:"date-released,omitempty"`
+	License            License      `json:"license,omitempty"`
+	URL                string       `json:"url,omitempty"`
+	RepositoryCode     string       `json:"repository-code,omitempty"`
+	RepositoryArtifact string       `json:"repository-artifact,omitempty"`
+	Keywords           []string     `json:"keywords,omitempty"`
+	PreferredCitation  *Reference   `json:"preferred-citation,omitempty"`
+	References         []Reference  `json:"references,omitempty"`
+}
+
+// Author represents a person or an entity in CFF.
+type Author struct {
+	GivenNames   string `json:"given-names,omitempty"`
+	FamilyNames  string `json:"family-names,omitempty"`
+	NameParticle string `json:"name-particle,omitempty"`
+	NameSuffix   string `json:"name-suffix,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Affiliation  string `json:"affiliation,omitempty"`
+	ORCID        string `json:"orcid,omitempty"`
+	Email        string `json:"email,omitempty"`
+	Website      string `json:"website,omitempty"`
+}
+
+// Identifier represents an identifier in CFF.
+type Identifier struct {
+	Type        string `json:"type"`
+	Value       string `json:"value"`
+	Description string `json:"description,omitempty"`
+}
+
+// Reference represents a reference in CFF, used for preferred-citation and references.
+type Reference struct {
+	Type        string       `json:"type"`
+	Title       string       `json:"title"`
+	Authors     []Author     `json:"authors"`
+	DOI         string       `json:"doi,omitempty"`
+	Identifiers []Identifier `json:"identifiers,omitempty"`
+	URL         string       `json:"url,omitempty"`
+	Journal     string       `json:"journal,omitempty"`
+	Publisher   *Author      `json:"publisher,omitempty"`
+	Year        json.Number  `json:"year,omitempty"`
+	Volume      json.Number  `json:"volume,omitempty"`
+	Issue       string       `json:"issue,omitempty"`
+	Start       json.Number  `json:"start,omitempty"`
+	End         json.Number  `json:"end,omitempty"`
+}
+
+// License represents the license in CFF, which is either a SPDX license
+// identifier or a list of SPDX license identifiers.
+type License []string
+
+// UnmarshalJSON unmarshals a license given as string or list of strings.
+func (l *License) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*l = License{s}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(b, &list); err != nil {
+		return err
+	}
+	*l = License(list)
+	return nil
+}
+
+// MarshalJSON marshals a single license as string.
+func (l License) MarshalJSON() ([]byte, error) {
+	if len(l) == 1 {
+		return json.Marshal(l[0])
+	}
+	return json.Marshal([]string(l))
+}
+
+// Load loads the metadata for a single work from a CFF file
+func Load(filename string) (commonmeta.Data, error) {
+	var data commonmeta.Data
+
+	content, err := ReadCFF(filename)
+	if err != nil {
+		return data, err
+	}
+	data, err = Read(content)
+	if err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// ReadCFF reads CFF metadata from a YAML file.
+func ReadCFF(filename string) (Content, error) {
+	var content Content
+
+	extension := path.Ext(filename)
+	if extension != ".cff" && extension != ".yaml" && extension != ".yml" {
+		return content, errors.New("invalid file extension")
+	}
+	input, err := os.ReadFile(filename)
+	if err != nil {
+		return content, errors.New("error reading file")
+	}
+	return Parse(input)
 }
 
-// Read reads CFF and converts it to commonmeta.
-func Read(content content) (commonmeta.Data, error) {
+// Parse parses CFF metadata in YAML format.
+func Parse(input []byte) (Content, error) {
+	var content Content
+	err := yaml.Unmarshal(input, &content)
+	if err != nil {
+		return content, err
+	}
+	return content, nil
+}
+
+// Read reads CFF metadata and converts it to commonmeta. The DOI is taken
+// from the top-level doi, or from the identifiers list.
+func Read(content Content) (commonmeta.Data, error) {
 	var data commonmeta.Data
-	data.ID = content.ID
+
+	data.ID = doiutils.NormalizeDOI(content.DOI)
+	for _, v := range content.Identifiers {
+		identifier := getIdentifier(v)
+		if identifier.Identifier == "" {
+			continue
+		}
+		if data.ID == "" && identifier.IdentifierType == "DOI" {
+			data.ID = identifier.Identifier
+		}
+		if identifier.Identifier != data.ID {
+			data.Identifiers = append(data.Identifiers, identifier)
+		}
+	}
+	if data.ID != "" {
+		data.Identifiers = append([]commonmeta.Identifier{{Identifier: data.ID, IdentifierType: "DOI"}}, data.Identifiers...)
+	}
+	data.URL = content.RepositoryCode
+	if data.URL == "" {
+		data.URL = content.URL
+	}
+	if data.ID == "" {
+		data.ID = data.URL
+	}
+
+	data.Type = "Software"
+	for _, v := range content.Authors {
+		data.Contributors = append(data.Contributors, GetContributor(v))
+	}
+	if content.Title != "" {
+		data.Titles = []commonmeta.Title{{Title: content.Title}}
+	}
+	if content.Abstract != "" {
+		data.Descriptions = []commonmeta.Description{{
+			Description: utils.Sanitize(content.Abstract),
+			Type:        "Abstract",
+		}}
+	}
+	data.Version = content.Version
+	data.Date.Published = content.DateReleased
+	if len(content.License) > 0 {
+		data.License = commonmeta.License{
+			ID:  content.License[0],
+			URL: spdxutils.IDToURL(content.License[0]),
+		}
+	}
+	for _, v := range content.Keywords {
+		data.Subjects = append(data.Subjects, commonmeta.Subject{Subject: v})
+	}
+
+	// the preferred citation, e.g. a software paper, describes the software
+	if content.PreferredCitation != nil {
+		id := getReferenceID(*content.PreferredCitation)
+		if id != "" && id != data.ID {
+			data.Relations = append(data.Relations, commonmeta.Relation{
+				ID:   id,
+				Type: "IsSupplementTo",
+			})
+		}
+	}
+	for i, v := range content.References {
+		reference := commonmeta.Reference{
+			Key:             "ref" + strconv.Itoa(i+1),
+			ID:              getReferenceID(v),
+			Title:           v.Title,
+			PublicationYear: v.Year.String(),
+		}
+		data.References = append(data.References, reference)
+	}
+
 	return data, nil
 }
+
+// GetContributor converts a CFF author into the Commonmeta format
+func GetContributor(v Author) commonmeta.Contributor {
+	contributor := commonmeta.Contributor{
+		ID:               utils.NormalizeORCID(v.ORCID),
+		ContributorRoles: []string{"Author"},
+	}
+	if v.FamilyNames != "" || v.GivenNames != "" {
+		contributor.Type = "Person"
+		contributor.GivenName = v.GivenNames
+		contributor.FamilyName = strings.TrimSpace(v.NameParticle + " " + v.FamilyNames)
+	} else {
+		contributor.Type = "Organization"
+		contributor.Name = v.Name
+	}
+	if v.Affiliation != "" {
+		contributor.Affiliations = []*commonmeta.Affiliation{{Name: v.Affiliation}}
+	}
+	return contributor
+}
+
+// getIdentifier converts a CFF identifier into the Commonmeta format
+func getIdentifier(v Identifier) commonmeta.Identifier {
+	switch v.Type {
+	case "doi":
+		return commonmeta.Identifier{Identifier: doiutils.NormalizeDOI(v.Value), IdentifierType: "DOI"}
+	case "url":
+		return commonmeta.Identifier{Identifier: v.Value, IdentifierType: "URL"}
+	default:
+		return commonmeta.Identifier{Identifier: v.Value, IdentifierType: "Other"}
+	}
+}
+
+// getReferenceID returns the DOI or URL of a CFF reference
+func getReferenceID(v Reference) string {
+	doi := doiutils.NormalizeDOI(v.DOI)
+	if doi != "" {
+		return doi
+	}
+	for _, i := range v.Identifiers {
+		if i.Type == "doi" {
+			return doiutils.NormalizeDOI(i.Value)
+		}
+	}
+	return v.URL
+}
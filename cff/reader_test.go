@@ -0,0 +1,132 @@
+package cff_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/front-matter/commonmeta/cff"
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name string
+		want commonmeta.Data
+	}
+
+	testCases := []testCase{
+		{
+			name: "CITATION.cff",
+			want: commonmeta.Data{
+				ID:   "https://doi.org/10.5281/zenodo.1184077",
+				Type: "Software",
+				Contributors: []commonmeta.Contributor{
+					{
+						ID:               "https://orcid.org/0000-0002-9538-7919",
+						Type:             "Person",
+						GivenName:        "Robert",
+						FamilyName:       "Haines",
+						Affiliations:     []*commonmeta.Affiliation{{Name: "The University of Manchester, UK"}},
+						ContributorRoles: []string{"Author"},
+					},
+					{Type: "Organization", Name: "The Ruby Citation File Format Developers", ContributorRoles: []string{"Author"}},
+				},
+				Date: commonmeta.Date{Published: "2021-08-18"},
+				Descriptions: []commonmeta.Description{
+					{Description: "This library provides a Ruby interface to manipulate Citation File Format files", Type: "Abstract"},
+				},
+				Identifiers: []commonmeta.Identifier{
+					{Identifier: "https://doi.org/10.5281/zenodo.1184077", IdentifierType: "DOI"},
+				},
+				License: commonmeta.License{ID: "Apache-2.0", URL: "https://opensource.org/licenses/Apache-2.0"},
+				References: []commonmeta.Reference{
+					{Key: "ref1", ID: "https://doi.org/10.5281/zenodo.1003149", Title: "Citation File Format"},
+				},
+				Subjects: []commonmeta.Subject{
+					{Subject: "ruby"},
+					{Subject: "credit"},
+					{Subject: "software citation"},
+					{Subject: "research software"},
+					{Subject: "software sustainability"},
+					{Subject: "metadata"},
+					{Subject: "citation file format"},
+					{Subject: "CFF"},
+				},
+				Titles:  []commonmeta.Title{{Title: "Ruby CFF Library"}},
+				URL:     "https://github.com/citation-file-format/ruby-cff",
+				Version: "0.9.0",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		got, err := cff.Load(filepath.Join("testdata", tc.name))
+		if err != nil {
+			t.Fatalf("CFF Load (%v): error %v", tc.name, err)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("CFF Load (%v) mismatch (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	input := []byte(`cff-version: 1.2.0
+message: Please cite this software using the metadata from preferred-citation.
+title: Example Software
+authors:
+  - family-names: Carberry
+    given-names: Josiah
+    orcid: https://orcid.org/0000-0002-1825-0097
+version: 1.0.1
+identifiers:
+  - type: url
+    value: https://example.org/software
+  - type: doi
+    value: 10.5555/software.1
+license:
+  - MIT
+  - Apache-2.0
+preferred-citation:
+  type: article
+  title: "Example Software: a software paper"
+  authors:
+    - family-names: Carberry
+      given-names: Josiah
+  doi: 10.5555/12345678
+  journal: Journal of Psychoceramics
+  year: 2008
+`)
+	content, err := cff.Parse(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := cff.Read(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != "https://doi.org/10.5555/software.1" {
+		t.Errorf("CFF Read ID: want https://doi.org/10.5555/software.1, got %v", got.ID)
+	}
+	wantIdentifiers := []commonmeta.Identifier{
+		{Identifier: "https://doi.org/10.5555/software.1", IdentifierType: "DOI"},
+		{Identifier: "https://example.org/software", IdentifierType: "URL"},
+	}
+	if diff := cmp.Diff(wantIdentifiers, got.Identifiers); diff != "" {
+		t.Errorf("Identifiers mismatch (-want +got):\n%s", diff)
+	}
+	if got.Version != "1.0.1" {
+		t.Errorf("CFF Read version: want 1.0.1, got %v", got.Version)
+	}
+	wantLicense := commonmeta.License{ID: "MIT", URL: "https://opensource.org/licenses/MIT"}
+	if diff := cmp.Diff(wantLicense, got.License); diff != "" {
+		t.Errorf("License mismatch (-want +got):\n%s", diff)
+	}
+	wantRelations := []commonmeta.Relation{{ID: "https://doi.org/10.5555/12345678", Type: "IsSupplementTo"}}
+	if diff := cmp.Diff(wantRelations, got.Relations); diff != "" {
+		t.Errorf("Relations mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,123 @@
+package cff
+
+import (
+	"encoding/json"
+	"log/slog"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/schemautils"
+	"github.com/front-matter/commonmeta/utils"
+	"github.com/front-matter/commonmeta/verutils"
+	"github.com/xeipuuv/gojsonschema"
+	"sigs.k8s.io/yaml"
+)
+
+// Convert converts Commonmeta metadata to CFF metadata.
+func Convert(data commonmeta.Data) (Content, error) {
+	content := Content{
+		CFFVersion: "1.2.0",
+		Message:    "If you use this software, please cite it using the metadata from this file.",
+		Type:       "software",
+	}
+
+	doi, ok := doiutils.ValidateDOI(data.ID)
+	if ok {
+		content.DOI = doi
+	}
+	for _, v := range data.Identifiers {
+		if v.Identifier == data.ID {
+			continue
+		}
+		switch v.IdentifierType {
+		case "DOI":
+			doi, ok := doiutils.ValidateDOI(v.Identifier)
+			if ok {
+				content.Identifiers = append(content.Identifiers, Identifier{Type: "doi", Value: doi})
+			}
+		case "URL":
+			content.Identifiers = append(content.Identifiers, Identifier{Type: "url", Value: v.Identifier})
+		}
+	}
+	if data.Type != "Software" && data.Type != "" {
+		content.Type = "dataset"
+	}
+
+	for _, v := range data.Contributors {
+		if len(v.ContributorRoles) > 0 && v.ContributorRoles[0] != "Author" {
+			continue
+		}
+		content.Authors = append(content.Authors, getAuthor(v))
+	}
+	if len(data.Titles) > 0 {
+		content.Title = data.Titles[0].Title
+	}
+	for _, v := range data.Descriptions {
+		if v.Type == "Abstract" || v.Type == "" {
+			content.Abstract = v.Description
+			break
+		}
+	}
+	content.Version = verutils.NormalizeVersion(data.Version)
+	content.DateReleased = data.Date.Published
+	if len(content.DateReleased) > 10 {
+		content.DateReleased = content.DateReleased[:10]
+	}
+	if data.License.ID != "" {
+		content.License = License{data.License.ID}
+	}
+	if utils.ValidateURL(data.URL) == "URL" {
+		content.RepositoryCode = data.URL
+	}
+	for _, v := range data.Subjects {
+		content.Keywords = append(content.Keywords, v.Subject)
+	}
+
+	return content, nil
+}
+
+// Write writes commonmeta metadata in CFF format.
+func Write(data commonmeta.Data) ([]byte, []gojsonschema.ResultError) {
+	content, err := Convert(data)
+	if err != nil {
+		slog.Error("error converting metadata", "error", err)
+	}
+	output, err := json.Marshal(content)
+	if err != nil {
+		slog.Error("error marshalling JSON", "error", err)
+	}
+	validation := schemautils.JSONSchemaErrors(output, "cff_v1.2.0")
+	output, err = yaml.JSONToYAML(output)
+	if err != nil {
+		slog.Error("error converting JSON to YAML", "error", err)
+	}
+	if !validation.Valid() {
+		return output, validation.Errors()
+	}
+	return output, nil
+}
+
+// getAuthor converts a Commonmeta contributor to a CFF author.
+func getAuthor(v commonmeta.Contributor) Author {
+	var author Author
+	if v.Type == "Organization" {
+		author.Name = v.Name
+	} else {
+		author.GivenNames = v.GivenName
+		author.FamilyNames = v.FamilyName
+		if v.FamilyName == "" {
+			author.Name = v.Name
+		}
+		_, ok := utils.ValidateORCID(v.ID)
+		if ok {
+			author.ORCID = v.ID
+		}
+	}
+	for _, a := range v.Affiliations {
+		if a != nil && a.Name != "" {
+			author.Affiliation = a.Name
+			break
+		}
+	}
+	return author
+}
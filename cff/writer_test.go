@@ -0,0 +1,35 @@
+package cff_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/front-matter/commonmeta/cff"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	data, err := cff.Load(filepath.Join("testdata", "CITATION.cff"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, jsErr := cff.Write(data)
+	if jsErr != nil {
+		t.Fatalf("CFF Write: validation errors %v", jsErr)
+	}
+	content, err := cff.Parse(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := cff.Read(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// references are not written
+	data.References = nil
+	if diff := cmp.Diff(data, got); diff != "" {
+		t.Errorf("CFF Write mismatch (-want +got):\n%s", diff)
+	}
+}
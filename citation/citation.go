@@ -0,0 +1,240 @@
+// Package citation formats commonmeta metadata as human-readable citation in
+// Markdown, using the APA, MLA or Chicago (author-date) style.
+package citation
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/csl"
+)
+
+// Styles lists the supported citation styles.
+var Styles = []string{"apa", "mla", "chicago"}
+
+// Format formats the metadata as citation in the given style. Titles of
+// containers are set in italics using Markdown.
+func Format(data commonmeta.Data, style string) (string, error) {
+	item, err := csl.Convert(data)
+	if err != nil {
+		return "", err
+	}
+	switch strings.ToLower(style) {
+	case "apa":
+		return formatAPA(item), nil
+	case "mla":
+		return formatMLA(item), nil
+	case "chicago":
+		return formatChicago(item), nil
+	}
+	return "", fmt.Errorf("unsupported citation style: %s", style)
+}
+
+// formatAPA formats a citation in APA style (7th edition), e.g.
+// Author, A., & Author, B. (Year). Title. *Container*, *Volume*(Issue), Pages. DOI
+func formatAPA(item csl.CSL) string {
+	var names []string
+	for _, v := range item.Author {
+		if v.Family == "" {
+			names = append(names, v.Literal)
+			continue
+		}
+		name := v.Family
+		if initials := getInitials(v.Given); initials != "" {
+			name += ", " + initials
+		}
+		names = append(names, name)
+	}
+	var parts []string
+	if len(names) > 0 {
+		parts = append(parts, withPeriod(joinNames(names, ", ", ", & ")))
+	}
+	year := getYear(item)
+	if year == "" {
+		year = "n.d."
+	}
+	parts = append(parts, "("+year+").")
+	if item.Title != "" {
+		parts = append(parts, withPeriod(item.Title))
+	}
+	if item.ContainerTitle != "" {
+		source := "*" + item.ContainerTitle + "*"
+		if item.Volume != "" {
+			source += ", *" + item.Volume + "*"
+			if item.Issue != "" {
+				source += "(" + item.Issue + ")"
+			}
+		}
+		if item.Page != "" {
+			source += ", " + getPages(item.Page)
+		}
+		parts = append(parts, source+".")
+	} else if item.Publisher != "" {
+		parts = append(parts, withPeriod(item.Publisher))
+	}
+	if link := getLink(item); link != "" {
+		parts = append(parts, link)
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatMLA formats a citation in MLA style (9th edition), e.g.
+// Author, First, and First Author. “Title.” *Container*, vol. 1, no. 2, Year, pp. Pages. DOI.
+func formatMLA(item csl.CSL) string {
+	var names []string
+	for i, v := range item.Author {
+		switch {
+		case v.Family == "":
+			names = append(names, v.Literal)
+		case i == 0 && v.Given != "":
+			names = append(names, v.Family+", "+v.Given)
+		default:
+			names = append(names, strings.TrimSpace(v.Given+" "+v.Family))
+		}
+	}
+	var parts []string
+	if len(names) > 2 {
+		parts = append(parts, names[0]+", et al.")
+	} else if len(names) > 0 {
+		parts = append(parts, withPeriod(strings.Join(names, ", and ")))
+	}
+	if item.Title != "" {
+		parts = append(parts, "“"+withPeriod(item.Title)+"”")
+	}
+	var source []string
+	if item.ContainerTitle != "" {
+		source = append(source, "*"+item.ContainerTitle+"*")
+	} else if item.Publisher != "" {
+		source = append(source, item.Publisher)
+	}
+	if item.Volume != "" {
+		source = append(source, "vol. "+item.Volume)
+	}
+	if item.Issue != "" {
+		source = append(source, "no. "+item.Issue)
+	}
+	if year := getYear(item); year != "" {
+		source = append(source, year)
+	}
+	if item.Page != "" {
+		prefix := "p. "
+		if strings.Contains(item.Page, "-") {
+			prefix = "pp. "
+		}
+		source = append(source, prefix+getPages(item.Page))
+	}
+	if link := getLink(item); link != "" {
+		source = append(source, link)
+	}
+	if len(source) > 0 {
+		parts = append(parts, strings.Join(source, ", ")+".")
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatChicago formats a citation in Chicago author-date style (17th
+// edition), e.g. Author, First, and First Author. Year. “Title.” *Container* 1 (2): Pages. DOI.
+func formatChicago(item csl.CSL) string {
+	var names []string
+	for i, v := range item.Author {
+		switch {
+		case v.Family == "":
+			names = append(names, v.Literal)
+		case i == 0 && v.Given != "":
+			names = append(names, v.Family+", "+v.Given)
+		default:
+			names = append(names, strings.TrimSpace(v.Given+" "+v.Family))
+		}
+	}
+	var parts []string
+	if len(names) > 0 {
+		parts = append(parts, withPeriod(joinNames(names, ", ", ", and ")))
+	}
+	year := getYear(item)
+	if year == "" {
+		year = "n.d."
+	}
+	parts = append(parts, withPeriod(year))
+	if item.Title != "" {
+		parts = append(parts, "“"+withPeriod(item.Title)+"”")
+	}
+	if item.ContainerTitle != "" {
+		source := "*" + item.ContainerTitle + "*"
+		if item.Volume != "" {
+			source += " " + item.Volume
+		}
+		if item.Issue != "" {
+			source += " (" + item.Issue + ")"
+		}
+		if item.Page != "" {
+			source += ": " + getPages(item.Page)
+		}
+		parts = append(parts, source+".")
+	} else if item.Publisher != "" {
+		parts = append(parts, withPeriod(item.Publisher))
+	}
+	if link := getLink(item); link != "" {
+		parts = append(parts, link+".")
+	}
+	return strings.Join(parts, " ")
+}
+
+// getInitials returns the initials of given names, e.g. "J. S." for
+// "Josiah Stinkney", or "J.-P." for "Jean-Paul".
+func getInitials(given string) string {
+	var initials []string
+	for _, name := range strings.Fields(given) {
+		var hyphenated []string
+		for _, part := range strings.Split(name, "-") {
+			r, _ := utf8.DecodeRuneInString(part)
+			if r != utf8.RuneError {
+				hyphenated = append(hyphenated, string(r)+".")
+			}
+		}
+		if len(hyphenated) > 0 {
+			initials = append(initials, strings.Join(hyphenated, "-"))
+		}
+	}
+	return strings.Join(initials, " ")
+}
+
+// getYear returns the year the work was issued.
+func getYear(item csl.CSL) string {
+	if item.Issued == nil || len(item.Issued.DateParts) == 0 || len(item.Issued.DateParts[0]) == 0 {
+		return ""
+	}
+	return strconv.Itoa(item.Issued.DateParts[0][0])
+}
+
+// getLink returns the DOI as URL, or the URL of the work.
+func getLink(item csl.CSL) string {
+	if item.DOI != "" {
+		return "https://doi.org/" + item.DOI
+	}
+	return item.URL
+}
+
+// getPages returns a page range using an en dash.
+func getPages(pages string) string {
+	return strings.ReplaceAll(pages, "-", "–")
+}
+
+// joinNames joins names, using a separate separator before the last name.
+func joinNames(names []string, sep string, sepLast string) string {
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], sep) + sepLast + names[len(names)-1]
+}
+
+// withPeriod adds a period, unless the string ends with punctuation.
+func withPeriod(s string) string {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.HasSuffix(s, ".") || strings.HasSuffix(s, "?") || strings.HasSuffix(s, "!") {
+		return s
+	}
+	return s + "."
+}
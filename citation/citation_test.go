@@ -0,0 +1,99 @@
+package citation_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/front-matter/commonmeta/citation"
+	"github.com/front-matter/commonmeta/commonmeta"
+)
+
+var article = commonmeta.Data{
+	ID:   "https://doi.org/10.7554/elife.01567",
+	Type: "JournalArticle",
+	Contributors: []commonmeta.Contributor{
+		{Type: "Person", GivenName: "Martial", FamilyName: "Sankar", ContributorRoles: []string{"Author"}},
+		{Type: "Person", GivenName: "Kaisa", FamilyName: "Nieminen", ContributorRoles: []string{"Author"}},
+	},
+	Titles: []commonmeta.Title{{Title: "Automated quantitative histology reveals vascular morphodynamics during Arabidopsis hypocotyl secondary growth"}},
+	Container: commonmeta.Container{
+		Type:      "Journal",
+		Title:     "eLife",
+		Volume:    "3",
+		Issue:     "2",
+		FirstPage: "123",
+		LastPage:  "145",
+	},
+	Date: commonmeta.Date{Published: "2014-02-11"},
+}
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		style string
+		want  string
+	}
+
+	testCases := []testCase{
+		{style: "apa", want: "Sankar, M., & Nieminen, K. (2014). Automated quantitative histology reveals vascular morphodynamics during Arabidopsis hypocotyl secondary growth. *eLife*, *3*(2), 123–145. https://doi.org/10.7554/elife.01567"},
+		{style: "mla", want: "Sankar, Martial, and Kaisa Nieminen. “Automated quantitative histology reveals vascular morphodynamics during Arabidopsis hypocotyl secondary growth.” *eLife*, vol. 3, no. 2, 2014, pp. 123–145, https://doi.org/10.7554/elife.01567."},
+		{style: "chicago", want: "Sankar, Martial, and Kaisa Nieminen. 2014. “Automated quantitative histology reveals vascular morphodynamics during Arabidopsis hypocotyl secondary growth.” *eLife* 3 (2): 123–145. https://doi.org/10.7554/elife.01567."},
+	}
+	for _, tc := range testCases {
+		got, err := citation.Format(article, tc.style)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.want {
+			t.Errorf("Format %s:\nwant %s\ngot  %s", tc.style, tc.want, got)
+		}
+	}
+}
+
+func TestFormatAuthors(t *testing.T) {
+	t.Parallel()
+	data := commonmeta.Data{
+		ID:   "https://doi.org/10.5555/12345678",
+		Type: "JournalArticle",
+		Contributors: []commonmeta.Contributor{
+			{Type: "Person", GivenName: "Jean-Paul", FamilyName: "Sartre", ContributorRoles: []string{"Author"}},
+			{Type: "Person", GivenName: "Josiah Stinkney", FamilyName: "Carberry", ContributorRoles: []string{"Author"}},
+			{Type: "Organization", Name: "The GTEx Consortium", ContributorRoles: []string{"Author"}},
+		},
+		Titles: []commonmeta.Title{{Title: "Is this a title?"}},
+	}
+	type testCase struct {
+		style string
+		want  string
+	}
+
+	testCases := []testCase{
+		{style: "apa", want: "Sartre, J.-P., Carberry, J. S., & The GTEx Consortium. (n.d.). Is this a title? https://doi.org/10.5555/12345678"},
+		{style: "mla", want: "Sartre, Jean-Paul, et al. “Is this a title?” https://doi.org/10.5555/12345678."},
+		{style: "chicago", want: "Sartre, Jean-Paul, Josiah Stinkney Carberry, and The GTEx Consortium. n.d. “Is this a title?” https://doi.org/10.5555/12345678."},
+	}
+	for _, tc := range testCases {
+		got, err := citation.Format(data, tc.style)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tc.want {
+			t.Errorf("Format %s:\nwant %s\ngot  %s", tc.style, tc.want, got)
+		}
+	}
+}
+
+func TestFormatUnsupportedStyle(t *testing.T) {
+	t.Parallel()
+	_, err := citation.Format(article, "vancouver")
+	if err == nil {
+		t.Error("Format vancouver: want error, got nil")
+	}
+}
+
+func ExampleFormat() {
+	s, _ := citation.Format(article, "apa")
+	fmt.Println(s)
+	// Output:
+	// Sankar, M., & Nieminen, K. (2014). Automated quantitative histology reveals vascular morphodynamics during Arabidopsis hypocotyl secondary growth. *eLife*, *3*(2), 123–145. https://doi.org/10.7554/elife.01567
+}
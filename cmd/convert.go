@@ -4,21 +4,21 @@ Copyright © 2024 NAME HERE <EMAIL ADDRESS>
 package cmd
 
 import (
-	"bytes"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
+	"github.com/front-matter/commonmeta/arxiv"
 	"github.com/front-matter/commonmeta/commonmeta"
 	"github.com/front-matter/commonmeta/crossrefxml"
-	"github.com/front-matter/commonmeta/csl"
 	"github.com/front-matter/commonmeta/datacite"
 	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/formats"
 	"github.com/front-matter/commonmeta/jsonfeed"
-	"github.com/front-matter/commonmeta/schemaorg"
+	"github.com/front-matter/commonmeta/pubmed"
 	"github.com/front-matter/commonmeta/utils"
-	"github.com/xeipuuv/gojsonschema"
 
 	"github.com/front-matter/commonmeta/crossref"
 
@@ -28,121 +28,188 @@ import (
 var convertCmd = &cobra.Command{
 	Use:   "convert",
 	Short: "Convert scholarly metadata from one format to another",
-	Long: `Convert scholarly metadata between formats. Currently
-supported input formats are Crossref and DataCite DOIs, currently
-the only supported output format is Commonmeta. Example usage:
-
-commonmeta 10.5555/12345678`,
-
-	Run: func(cmd *cobra.Command, args []string) {
-		var id string  // an identifier, content fetched via API
-		var str string // a string, content loaded from a file
-		var err error
-		var data commonmeta.Data
-
-		// loginID, _ := cmd.Flags().GetString("login_id")
-		// loginPassword, _ := cmd.Flags().GetString("login_passwd")
-		depositor, _ := cmd.Flags().GetString("depositor")
-		email, _ := cmd.Flags().GetString("email")
-		registrant, _ := cmd.Flags().GetString("registrant")
-
-		if len(args) == 0 {
-			fmt.Println("Please provide an input")
-			return
+	Long: `Convert scholarly metadata between formats. The input and
+output formats are given with the --from and --to flags, defaulting
+to Commonmeta. Crossref and DataCite DOIs are fetched via API,
+the registration agency of the DOI is looked up unless given with
+the --from flag. Example usage:
+
+commonmeta 10.5555/12345678
+
+Without the --from flag, or with --from auto, the registration agency
+of a DOI is looked up and the DOI fetched from Crossref or DataCite:
+
+commonmeta convert 10.5061/dryad.8515 --from auto
+
+Metadata can also be read from a file or from standard input. The
+input format is detected from the file extension and content, unless
+given with the --from flag:
+
+commonmeta convert record.bib
+cat record.json | commonmeta convert --from datacite
+
+PubMed IDs (PMID or PMCID) are fetched via the NCBI APIs:
+
+commonmeta convert 24567894 --from pubmed
+
+arXiv identifiers, with or without version, are fetched via the arXiv API:
+
+commonmeta convert 2101.00001v2 --from arxiv
+
+Use the --output flag to write the result to a file instead of stdout:
+
+commonmeta convert record.bib --to schemaorg --output record.jsonld`,
+
+	SilenceUsage: true,
+	RunE:         convert,
+}
+
+func init() {
+	rootCmd.AddCommand(convertCmd)
+
+	convertCmd.Flags().StringP("input", "i", "", "the file to read from, use - for standard input")
+}
+
+// convert fetches, loads or reads the metadata for a single work and writes
+// it in the format given with the --to flag. It is used by both the root and
+// the convert command.
+func convert(cmd *cobra.Command, args []string) error {
+	var id string  // an identifier, content fetched via API
+	var str string // a string, content loaded from a file
+	var err error
+	var data commonmeta.Data
+
+	// loginID, _ := cmd.Flags().GetString("login_id")
+	// loginPassword, _ := cmd.Flags().GetString("login_passwd")
+	depositor, _ := cmd.Flags().GetString("depositor")
+	email, _ := cmd.Flags().GetString("email")
+	registrant, _ := cmd.Flags().GetString("registrant")
+
+	input, _ := cmd.Flags().GetString("input")
+	if len(args) > 0 {
+		input = args[0]
+	}
+	// detect the format if --from is not specified or auto
+	var from string
+	if cmd.Flags().Changed("from") {
+		from, _ = cmd.Flags().GetString("from")
+	}
+	if from == "auto" {
+		from = ""
+	}
+
+	// read from standard input if no input is given or input is "-"
+	if input == "" || input == "-" {
+		b, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil || len(b) == 0 {
+			return errors.New("please provide an input")
+		}
+		if from == "" {
+			from = utils.DetectFormat(input, b)
+		}
+		data, err = formats.Read(b, from)
+		if err != nil {
+			return err
 		}
-		input := args[0]
+	} else if from == "pubmed" {
+		// a PMID or PMCID, fetched via the NCBI APIs
+		data, err = pubmed.Fetch(input)
+	} else if from == "arxiv" {
+		// an arXiv identifier, fetched via the arXiv API
+		data, err = arxiv.Fetch(input)
+	} else {
 		id = utils.NormalizeID(input)
 		if id == "" {
 			_, err = os.Stat(input)
 			if err != nil {
-				fmt.Printf("File not found: %s", input)
-				return
+				return fmt.Errorf("file not found: %s", input)
 			}
 			str = input
 		}
 
-		from, _ := cmd.Flags().GetString("from")
-		if from == "" {
-			var ok bool
+		if from == "" && str != "" {
+			b, err := os.ReadFile(str)
+			if err == nil {
+				from = utils.DetectFormat(str, b)
+			}
+			if from == "" {
+				return errors.New("please provide the input format with --from")
+			}
+		} else if from == "" {
 			doi, ok := doiutils.ValidateDOI(input)
 			if !ok {
-				cmd.PrintErr("Please provide a valid DOI from Crossref or Datacite")
-				return
+				return errors.New("please provide a valid DOI from Crossref or Datacite")
 			}
-			from, ok = doiutils.GetDOIRA(doi)
-			if !ok {
-				cmd.PrintErr("Please provide a valid DOI from Crossref or Datacite")
-				return
+			ra, err := doiutils.RegistrationAgency(doi)
+			if err != nil {
+				return err
+			}
+			from = strings.ToLower(ra)
+			if from != "crossref" && from != "datacite" {
+				return fmt.Errorf("unsupported registration agency %s for DOI %s", ra, doi)
 			}
-			from = strings.ToLower(from)
 		}
 
 		if id != "" {
 			if from == "crossref" {
-				data, err = crossref.Fetch(id)
+				data, err = crossref.Fetch(id, crossrefOptions(cmd)...)
 			} else if from == "crossrefxml" {
 				data, err = crossrefxml.Fetch(id)
 			} else if from == "datacite" {
-				data, err = datacite.Fetch(id)
+				data, err = datacite.Fetch(id, dataciteOptions(cmd)...)
 			} else if from == "jsonfeed" {
 				data, err = jsonfeed.Fetch(id)
 			} else {
-				fmt.Println("Please provide a valid input")
-				return
+				return fmt.Errorf("please provide a valid input: unsupported format %s", from)
 			}
 		} else if str != "" {
-			if from == "commonmeta" {
-				data, err = commonmeta.Load(str)
-			} else if from == "crossref" {
-				data, err = crossref.Load(str)
-			} else if from == "crossrefxml" {
-				data, err = crossrefxml.Load(str)
-			} else if from == "datacite" {
-				data, err = datacite.Load(str)
-			} else {
-				cmd.PrintErr("Please provide a valid input")
-				return
-			}
-		}
-
-		var output []byte
-		var jsErr []gojsonschema.ResultError
-		to, _ := cmd.Flags().GetString("to")
-		if to == "commonmeta" {
-			output, jsErr = commonmeta.Write(data)
-		} else if to == "csl" {
-			output, jsErr = csl.Write(data)
-		} else if to == "datacite" {
-			output, jsErr = datacite.Write(data)
-		} else if to == "schemaorg" {
-			output, jsErr = schemaorg.Write(data)
-		} else if to == "crossrefxml" {
-			account := crossrefxml.Account{
-				Depositor:  depositor,
-				Email:      email,
-				Registrant: registrant,
+			data, err = formats.Load(str, from)
+			if errors.Is(err, formats.ErrUnsupportedFormat) {
+				return fmt.Errorf("please provide a valid input: %w", err)
 			}
-			output, jsErr = crossrefxml.Write(data, account)
 		}
-
-		if err != nil {
-			cmd.PrintErr(err)
-		}
-
-		if to == "crossrefxml" {
-			cmd.Printf("%s\n", output)
-		} else {
-			var out bytes.Buffer
-			json.Indent(&out, output, "", "  ")
-			cmd.Println(out.String())
-		}
-
-		if jsErr != nil {
-			cmd.PrintErr(jsErr)
-		}
-	},
-}
-
-func init() {
-	rootCmd.AddCommand(convertCmd)
+	}
+
+	if err != nil {
+		return err
+	}
+	fn, err := titleCase(cmd)
+	if err != nil {
+		return err
+	}
+	if fn != nil {
+		applyTitleCase(&data, fn)
+	}
+	enrich(cmd, &data)
+
+	to, _ := cmd.Flags().GetString("to")
+	strict, _ := cmd.Flags().GetBool("strict")
+	columns, _ := cmd.Flags().GetStringSlice("columns")
+	account := crossrefxml.Account{
+		Depositor:  depositor,
+		Email:      email,
+		Registrant: registrant,
+	}
+	output, err := formats.Write(data, to, formats.WithAccount(account), formats.WithStrict(strict), formats.WithColumns(columns))
+	var jsErr *formats.ValidationError
+	if err != nil && !errors.As(err, &jsErr) {
+		return err
+	}
+	// in strict mode there is no output for an invalid record
+	if jsErr != nil && strict {
+		return jsErr
+	}
+	if isJSON(to) {
+		output = formatJSON(cmd, output)
+	}
+	err = writeOutput(cmd, to, output)
+	if err != nil {
+		return err
+	}
+
+	// the output is written, even if it doesn't validate
+	if jsErr != nil {
+		return jsErr
+	}
+	return nil
 }
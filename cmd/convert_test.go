@@ -0,0 +1,423 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/crossref"
+	"github.com/front-matter/commonmeta/datacite"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/formats"
+	"github.com/google/go-cmp/cmp"
+)
+
+// runConvert runs the convert command with the given standard input and
+// arguments, and returns standard output and standard error.
+func runConvert(stdin []byte, args ...string) ([]byte, []byte, error) {
+	return runCommand(stdin, append([]string{"convert"}, args...)...)
+}
+
+// runCommand runs the root command with the given standard input and
+// arguments, and returns standard output and standard error.
+func runCommand(stdin []byte, args ...string) ([]byte, []byte, error) {
+	// reset flags set by previous runs of the command
+	for _, name := range []string{"from", "to", "input", "output", "strict", "title-case", "pretty", "compact", "verbose"} {
+		flag := convertCmd.Flags().Lookup(name)
+		if flag == nil {
+			flag = rootCmd.PersistentFlags().Lookup(name)
+		}
+		flag.Value.Set(flag.DefValue)
+		flag.Changed = false
+	}
+	var stdout, stderr bytes.Buffer
+	rootCmd.SetIn(bytes.NewReader(stdin))
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetErr(&stderr)
+	rootCmd.SetArgs(args)
+	err := rootCmd.Execute()
+	return stdout.Bytes(), stderr.Bytes(), err
+}
+
+// executeConvert runs the convert command with the given standard input and
+// arguments, and returns the commonmeta output.
+func executeConvert(t *testing.T, stdin []byte, args ...string) commonmeta.Data {
+	t.Helper()
+
+	stdout, stderr, err := runConvert(stdin, args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stderr) > 0 {
+		t.Fatalf("Convert %v: error %s", args, stderr)
+	}
+
+	var data commonmeta.Data
+	err = json.Unmarshal(stdout, &data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestConvertStdin(t *testing.T) {
+	input, err := os.ReadFile(filepath.Join("testdata", "10.5438_zhyx-n122.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := executeConvert(t, input, "--from", "datacite", "--to", "commonmeta", "-")
+	if got.ID != "https://doi.org/10.5438/zhyx-n122" {
+		t.Errorf("Convert stdin ID: want https://doi.org/10.5438/zhyx-n122, got %v", got.ID)
+	}
+	if got.Type != "Document" {
+		t.Errorf("Convert stdin type: want Document, got %v", got.Type)
+	}
+	wantContributors := []commonmeta.Contributor{
+		{
+			Type:             "Person",
+			ContributorRoles: []string{"Author"},
+			GivenName:        "Rorie",
+			FamilyName:       "Edmunds",
+			Affiliations:     []*commonmeta.Affiliation{{Name: "DataCite"}},
+		},
+		{
+			ID:               "https://orcid.org/0000-0003-4448-3844",
+			Type:             "Person",
+			ContributorRoles: []string{"Author"},
+			GivenName:        "Paul",
+			FamilyName:       "Vierkant",
+			Affiliations:     []*commonmeta.Affiliation{{Name: "DataCite"}},
+		},
+	}
+	if diff := cmp.Diff(wantContributors, got.Contributors); diff != "" {
+		t.Errorf("Convert stdin contributors mismatch (-want +got):\n%s", diff)
+	}
+	wantLicense := commonmeta.License{ID: "CC-BY-4.0", URL: "https://creativecommons.org/licenses/by/4.0/legalcode"}
+	if diff := cmp.Diff(wantLicense, got.License); diff != "" {
+		t.Errorf("Convert stdin license mismatch (-want +got):\n%s", diff)
+	}
+
+	// an empty or unreadable input is an error
+	for _, stdin := range [][]byte{nil, []byte("{")} {
+		_, _, err := runConvert(stdin, "--from", "datacite", "-")
+		if err == nil {
+			t.Errorf("Convert stdin %q: want error, got none", stdin)
+		}
+	}
+}
+
+func TestConvertFile(t *testing.T) {
+	type testCase struct {
+		args []string
+		want string
+	}
+
+	testCases := []testCase{
+		{args: []string{filepath.Join("..", "testdata", "bibtex", "crossref.bib")}, want: "https://doi.org/10.7554/elife.01567"},
+		{args: []string{"--input", filepath.Join("..", "testdata", "datacitexml", "datacite-example-full-v4.4.xml")}, want: "https://doi.org/10.5072/example-full"},
+		{args: []string{"--from", "datacite", filepath.Join("testdata", "10.5438_zhyx-n122.json")}, want: "https://doi.org/10.5438/zhyx-n122"},
+	}
+	for _, tc := range testCases {
+		got := executeConvert(t, nil, tc.args...)
+		if tc.want != got.ID {
+			t.Errorf("Convert file %v: want %v, got %v", tc.args, tc.want, got.ID)
+		}
+	}
+
+	// a missing or unreadable file is an error, and no record is written
+	invalid := filepath.Join(t.TempDir(), "invalid.json")
+	if err := os.WriteFile(invalid, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{filepath.Join("testdata", "missing.json")},
+		{"--from", "datacite", invalid},
+	} {
+		stdout, _, err := runConvert(nil, args...)
+		if err == nil {
+			t.Errorf("Convert file %v: want error", args)
+		}
+		if len(stdout) > 0 {
+			t.Errorf("Convert file %v: want no output, got %s", args, stdout)
+		}
+	}
+}
+
+func TestConvertOutput(t *testing.T) {
+	dir := t.TempDir()
+	input := filepath.Join("testdata", "10.5438_zhyx-n122.json")
+
+	type testCase struct {
+		output string
+		to     string
+		want   string
+	}
+
+	testCases := []testCase{
+		{output: filepath.Join(dir, "record.json"), to: "commonmeta", want: filepath.Join(dir, "record.json")},
+		{output: filepath.Join(dir, "nested", "record"), to: "commonmeta", want: filepath.Join(dir, "nested", "record.json")},
+		{output: filepath.Join(dir, "schemaorg", "record"), to: "schemaorg", want: filepath.Join(dir, "schemaorg", "record.jsonld")},
+	}
+	for _, tc := range testCases {
+		stdout, stderr, err := runConvert(nil, "--from", "datacite", "--to", tc.to, "--output", tc.output, input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(stdout) > 0 || len(stderr) > 0 {
+			t.Errorf("Convert output %v: want no output, got %s %s", tc.output, stdout, stderr)
+		}
+		b, err := os.ReadFile(tc.want)
+		if err != nil {
+			t.Fatalf("Convert output %v: %v", tc.output, err)
+		}
+		if !json.Valid(b) {
+			t.Errorf("Convert output %v: invalid JSON", tc.want)
+		}
+	}
+
+	// the parent directory can't be created, as it is a file
+	file := filepath.Join(dir, "record.json")
+	_, _, err := runConvert(nil, "--from", "datacite", "--output", filepath.Join(file, "record.json"), input)
+	if err == nil {
+		t.Errorf("Convert output: want error writing to %v, got none", filepath.Join(file, "record.json"))
+	}
+}
+
+func TestConvertTo(t *testing.T) {
+	type testCase struct {
+		from  string
+		to    string
+		input string
+		want  map[string]interface{}
+	}
+
+	testCases := []testCase{
+		{
+			from:  "crossref",
+			to:    "csl",
+			input: filepath.Join("..", "testdata", "crossref", "crossref.json"),
+			want:  map[string]interface{}{"id": "https://doi.org/10.7554/elife.01567", "type": "article-journal", "DOI": "10.7554/elife.01567", "container-title": "eLife"},
+		},
+		{
+			from:  "datacite",
+			to:    "schemaorg",
+			input: filepath.Join("testdata", "10.5438_zhyx-n122.json"),
+			want:  map[string]interface{}{"@context": "http://schema.org", "@id": "https://doi.org/10.5438/zhyx-n122", "@type": "CreativeWork", "name": "DataCite Member Survey 2022"},
+		},
+	}
+	for _, tc := range testCases {
+		stdout, stderr, err := runConvert(nil, "--from", tc.from, "--to", tc.to, tc.input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(stderr) > 0 {
+			t.Fatalf("Convert %v to %v: error %s", tc.from, tc.to, stderr)
+		}
+		var got map[string]interface{}
+		err = json.Unmarshal(stdout, &got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k, v := range tc.want {
+			if got[k] != v {
+				t.Errorf("Convert %v to %v (%v): want %v, got %v", tc.from, tc.to, k, v, got[k])
+			}
+		}
+	}
+
+	_, _, err := runConvert(nil, "--from", "datacite", "--to", "unknown", filepath.Join("testdata", "10.5438_zhyx-n122.json"))
+	if err == nil {
+		t.Errorf("Convert to unknown: want error, got none")
+	}
+}
+
+func TestConvertRegistrationAgency(t *testing.T) {
+	// not parallel, as the test changes the API base URLs
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/ra/") {
+			doi := strings.TrimPrefix(r.URL.Path, "/ra/")
+			ra := "Crossref"
+			if doiutils.Prefix(doi) == "10.3280" {
+				ra = "mEDRA"
+			} else if doiutils.Prefix(doi) == "10.5061" {
+				ra = "DataCite"
+			}
+			fmt.Fprintf(w, `[{"DOI":%q,"RA":%q}]`, doi, ra)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/dois/") {
+			doi := strings.TrimPrefix(r.URL.Path, "/dois/")
+			fmt.Fprintf(w, `{"data": {"id": %q, "attributes": {"doi": %q, "titles": [{"title": "Title of %s"}], "types": {"resourceTypeGeneral": "Dataset"}, "publisher": "Dryad", "publicationYear": 2024}}}`, doi, doi, doi)
+			return
+		}
+		doi := strings.TrimPrefix(r.URL.Path, "/works/")
+		fmt.Fprintf(w, `{"status": "ok", "message-type": "work", "message": {"DOI": %q, "type": "journal-article", "title": ["Title of %s"]}}`, doi, doi)
+	}))
+	defer server.Close()
+	dataciteURL := datacite.BaseURL
+	datacite.BaseURL = server.URL
+	defer func() { datacite.BaseURL = dataciteURL }()
+	raURL := doiutils.RAURL
+	doiutils.RAURL = server.URL + "/ra/"
+	defer func() { doiutils.RAURL = raURL }()
+	baseURL := crossref.BaseURL
+	crossref.BaseURL = server.URL
+	defer func() { crossref.BaseURL = baseURL }()
+
+	data := executeConvert(t, nil, "10.5555/12345678")
+	if data.ID != "https://doi.org/10.5555/12345678" || data.Type != "JournalArticle" {
+		t.Errorf("Convert registration agency: want Crossref journal article, got %v %v", data.ID, data.Type)
+	}
+
+	// a DataCite DOI, without --from or with --from auto
+	for _, args := range [][]string{{"10.5061/dryad.8515"}, {"--from", "auto", "10.5061/dryad.8515"}} {
+		data = executeConvert(t, nil, args...)
+		if data.ID != "https://doi.org/10.5061/dryad.8515" || data.Type != "Dataset" {
+			t.Errorf("Convert registration agency %v: want DataCite dataset, got %v %v", args, data.ID, data.Type)
+		}
+	}
+
+	_, _, err := runConvert(nil, "10.3280/ecag2018-002003")
+	if err == nil || !strings.Contains(err.Error(), "mEDRA") {
+		t.Errorf("Convert registration agency: want error for mEDRA DOI, got %v", err)
+	}
+}
+
+func TestConvertStrict(t *testing.T) {
+	// the type is not supported by the commonmeta schema
+	input := []byte(`{"id":"https://doi.org/10.5555/strict","type":"Umbrella"}`)
+
+	var jsErr *formats.ValidationError
+	stdout, _, err := runConvert(input, "--from", "commonmeta", "--to", "commonmeta", "-")
+	if !errors.As(err, &jsErr) {
+		t.Errorf("Convert lenient: want validation error, got %v", err)
+	}
+	if !strings.Contains(string(stdout), "https://doi.org/10.5555/strict") {
+		t.Errorf("Convert lenient: want invalid output, got %s", stdout)
+	}
+
+	stdout, _, err = runConvert(input, "--from", "commonmeta", "--to", "commonmeta", "--strict", "-")
+	if !errors.As(err, &jsErr) {
+		t.Errorf("Convert strict: want validation error, got %v", err)
+	}
+	if strings.Contains(string(stdout), "https://doi.org/10.5555/strict") {
+		t.Errorf("Convert strict: want no output, got %s", stdout)
+	}
+}
+
+func TestConvertTitleCase(t *testing.T) {
+	input := []byte(`{"id":"https://doi.org/10.5555/case","type":"JournalArticle","titles":[{"title":"The Role of DNA in Soil pH Regulation"}]}`)
+
+	data := executeConvert(t, input, "--from", "commonmeta", "--title-case", "sentence", "-")
+	if data.Titles[0].Title != "The role of DNA in soil pH regulation" {
+		t.Errorf("Convert sentence case: got %q", data.Titles[0].Title)
+	}
+	data = executeConvert(t, input, "--from", "commonmeta", "--title-case", "title", "-")
+	if data.Titles[0].Title != "The Role of DNA in Soil pH Regulation" {
+		t.Errorf("Convert title case: got %q", data.Titles[0].Title)
+	}
+
+	_, _, err := runConvert(input, "--from", "commonmeta", "--title-case", "upper", "-")
+	if err == nil {
+		t.Error("Convert upper case: want error, got nil")
+	}
+}
+
+func TestConvertCompact(t *testing.T) {
+	input := []byte(`{"id":"https://doi.org/10.5555/compact","type":"JournalArticle","titles":[{"title":"Compact"}]}`)
+
+	stdout, _, err := runConvert(input, "--from", "commonmeta", "--compact", "-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := strings.TrimSuffix(string(stdout), "\n")
+	if strings.Contains(got, "\n") {
+		t.Errorf("Convert compact: want no newlines, got %s", got)
+	}
+	if !strings.HasPrefix(got, `{"id":"https://doi.org/10.5555/compact"`) {
+		t.Errorf("Convert compact: want compact JSON, got %s", got)
+	}
+
+	stdout, _, err = runConvert(input, "--from", "commonmeta", "--pretty", "-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(stdout), "{\n  \"id\": \"https://doi.org/10.5555/compact\"") {
+		t.Errorf("Convert pretty: want two-space indentation, got %s", stdout)
+	}
+}
+
+func TestConvertValidJSON(t *testing.T) {
+	input, err := os.ReadFile(filepath.Join("testdata", "10.5438_zhyx-n122.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, to := range []string{"commonmeta", "csl", "datacite", "schemaorg"} {
+		stdout, _, err := runConvert(input, "--from", "datacite", "--to", to, "--pretty", "-")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !json.Valid(stdout) {
+			t.Errorf("Convert to %s: want valid JSON, got %s", to, stdout)
+		}
+	}
+}
+
+func TestConvertRoot(t *testing.T) {
+	input, err := os.ReadFile(filepath.Join("testdata", "10.5438_zhyx-n122.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _, err := runConvert(input, "--from", "datacite", "-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, stderr, err := runCommand(input, "--from", "datacite", "-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stderr) > 0 {
+		t.Fatalf("Convert root: error %s", stderr)
+	}
+	if diff := cmp.Diff(string(want), string(got)); diff != "" {
+		t.Errorf("Convert root mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertVerbose(t *testing.T) {
+	// not parallel, as the test changes the API base URL
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": "ok", "message-type": "work", "message": {"DOI": "10.5555/12345678", "type": "journal-article", "title": ["Title"]}}`)
+	}))
+	defer server.Close()
+	baseURL := crossref.BaseURL
+	crossref.BaseURL = server.URL
+	defer func() { crossref.BaseURL = baseURL }()
+
+	stdout, stderr, err := runConvert(nil, "10.5555/12345678", "--from", "crossref", "-v")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(stderr), "level=DEBUG") || !strings.Contains(string(stderr), server.URL+"/works/10.5555/12345678") {
+		t.Errorf("Convert verbose: want debug message for request, got %s", stderr)
+	}
+	if !json.Valid(stdout) {
+		t.Errorf("Convert verbose: want JSON output, got %s", stdout)
+	}
+
+	_, stderr, err = runConvert(nil, "10.5555/12345678", "--from", "crossref")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(stderr), "level=DEBUG") {
+		t.Errorf("Convert without verbose: want no debug messages, got %s", stderr)
+	}
+}
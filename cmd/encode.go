@@ -4,8 +4,6 @@ Copyright © 2024 Front Matter <info@front-matter.io>
 package cmd
 
 import (
-	"fmt"
-
 	"github.com/front-matter/commonmeta/doiutils"
 	"github.com/front-matter/commonmeta/utils"
 	"github.com/spf13/cobra"
@@ -18,7 +16,7 @@ var encodeCmd = &cobra.Command{
 	Long:  `Generate a random DOI string given a prefix. For example: commonmeta encode 10.5555`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if len(args) == 0 {
-			fmt.Println("Please provide an input")
+			cmd.PrintErr("Please provide an input")
 			return
 		}
 		input := args[0]
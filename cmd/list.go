@@ -4,23 +4,24 @@ Copyright © 2024 Front Matter <info@front-matter.io>
 package cmd
 
 import (
-	"bytes"
-	"encoding/json"
+	"bufio"
+	"errors"
 	"fmt"
 	"os"
+	"path"
+	"strings"
 
 	"github.com/front-matter/commonmeta/commonmeta"
-	"github.com/front-matter/commonmeta/csl"
-	"github.com/xeipuuv/gojsonschema"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/formats"
 
 	"github.com/front-matter/commonmeta/crossref"
 	"github.com/front-matter/commonmeta/crossrefxml"
 	"github.com/front-matter/commonmeta/jsonfeed"
+	"github.com/front-matter/commonmeta/orcid"
 
 	"github.com/front-matter/commonmeta/datacite"
 
-	"github.com/front-matter/commonmeta/schemaorg"
-
 	"github.com/spf13/cobra"
 )
 
@@ -29,12 +30,20 @@ var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "A list of works",
 	Long: `A list of works. Currently only available for
-	the Crossref and DataCite provider. Options include numnber of works, 
+	the Crossref and DataCite provider, and the works of an ORCID record. Options include numnber of works, 
 	work type, and Crossref member id or DataCite client id. For example:
 
 	commonmeta list --number 10 --member 78 --type journal-article,
-	commonmeta list --number 10 --member cern.zenodo --type dataset`,
-	Run: func(cmd *cobra.Command, args []string) {
+	commonmeta list --number 10 --member cern.zenodo --type dataset
+	commonmeta list dois.txt --from crossref --workers 10
+	commonmeta list works.json --dedupe
+	commonmeta list works.json --type JournalArticle --published-since 2020
+	commonmeta list 0000-0002-1825-0097 --from orcid
+
+	Without --from, the registration agency of the first DOI in
+	the list is used.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
 		var input string
 		var str string // a string, content loaded from a file
 		var err error
@@ -62,16 +71,37 @@ var listCmd = &cobra.Command{
 		email, _ := cmd.Flags().GetString("email")
 		registrant, _ := cmd.Flags().GetString("registrant")
 
-		if input != "" {
+		if input != "" && from != "orcid" {
 			_, err = os.Stat(input)
 			if err != nil {
-				cmd.PrintErrf("File not found: %s", input)
-				return
+				return fmt.Errorf("file not found: %s", input)
 			}
 			str = input
 		}
 
-		if str != "" && from == "commonmeta" {
+		if str != "" && path.Ext(str) == ".txt" {
+			// a list of DOIs, one per line
+			dois, err := readLines(str)
+			if err != nil {
+				return err
+			}
+			workers, _ := cmd.Flags().GetInt("workers")
+			if !cmd.Flags().Changed("from") && len(dois) > 0 {
+				// use the registration agency of the first DOI
+				ra, err := doiutils.RegistrationAgency(dois[0])
+				if err != nil {
+					return err
+				}
+				from = strings.ToLower(ra)
+			}
+			if from == "crossref" {
+				data, err = crossref.FetchCrossrefList(dois, workers, crossrefOptions(cmd)...)
+			} else if from == "datacite" {
+				data, err = datacite.FetchDataciteList(dois, workers, dataciteOptions(cmd)...)
+			} else {
+				return errors.New("please provide --from crossref or --from datacite for a list of DOIs")
+			}
+		} else if str != "" && from == "commonmeta" {
 			data, err = commonmeta.LoadAll(str)
 		} else if str != "" && from == "crossref" {
 			data, err = crossref.LoadAll(str)
@@ -81,49 +111,107 @@ var listCmd = &cobra.Command{
 			data, err = datacite.LoadAll(str)
 		} else if str != "" && from == "jsonfeed" {
 			data, err = jsonfeed.LoadAll(str)
+		} else if from == "orcid" {
+			// the works of an ORCID record, the input is an ORCID iD
+			data, err = orcid.FetchWorks(input)
 		} else if from == "crossref" {
 			data, err = crossref.FetchAll(number, member, type_, sample, hasORCID, hasROR, hasReferences, hasRelation, hasAbstract, hasAward, hasLicense, hasArchive)
 		} else if from == "datacite" {
 			data, err = datacite.FetchAll(number, sample)
 		}
 		if err != nil {
-			cmd.PrintErr(err)
+			return err
 		}
-
-		var output []byte
-		var jsErr []gojsonschema.ResultError
-		to, _ := cmd.Flags().GetString("to")
-		if to == "commonmeta" {
-			output, jsErr = commonmeta.WriteAll(data)
-		} else if to == "csl" {
-			output, jsErr = csl.WriteAll(data)
-		} else if to == "datacite" {
-			output, jsErr = datacite.WriteAll(data)
-		} else if to == "crossrefxml" {
-			account := crossrefxml.Account{
-				Depositor:  depositor,
-				Email:      email,
-				Registrant: registrant,
+		dedupe, _ := cmd.Flags().GetBool("dedupe")
+		if dedupe {
+			data = commonmeta.Dedupe(data)
+		}
+		var filters []commonmeta.FilterOption
+		if str != "" && type_ != "" {
+			// works fetched from an API are already filtered by type
+			filters = append(filters, commonmeta.WithType(strings.Split(type_, ",")...))
+		}
+		publishedSince, _ := cmd.Flags().GetString("published-since")
+		if publishedSince != "" {
+			filters = append(filters, commonmeta.WithPublishedSince(publishedSince))
+		}
+		publishedUntil, _ := cmd.Flags().GetString("published-until")
+		if publishedUntil != "" {
+			filters = append(filters, commonmeta.WithPublishedUntil(publishedUntil))
+		}
+		if len(filters) > 0 {
+			data = commonmeta.Filter(data, filters...)
+		}
+		fn, err := titleCase(cmd)
+		if err != nil {
+			return err
+		}
+		if fn != nil {
+			for i := range data {
+				applyTitleCase(&data[i], fn)
 			}
-			output, jsErr = crossrefxml.WriteAll(data, account)
-		} else if to == "schemaorg" {
-			output, jsErr = schemaorg.WriteAll(data)
+		}
+		for i := range data {
+			enrich(cmd, &data[i])
 		}
 
-		if to == "crossrefxml" {
-			fmt.Printf("%s\n", output)
-		} else {
-			var out bytes.Buffer
-			json.Indent(&out, output, "", "  ")
-			fmt.Println(out.String())
+		to, _ := cmd.Flags().GetString("to")
+		strict, _ := cmd.Flags().GetBool("strict")
+		columns, _ := cmd.Flags().GetStringSlice("columns")
+		account := crossrefxml.Account{
+			Depositor:  depositor,
+			Email:      email,
+			Registrant: registrant,
+		}
+		output, err := formats.WriteAll(data, to, formats.WithAccount(account), formats.WithStrict(strict), formats.WithColumns(columns))
+		var jsErr *formats.ValidationError
+		if err != nil && !errors.As(err, &jsErr) {
+			return err
+		}
+		// in strict mode there is no output for an invalid record
+		if jsErr != nil && strict {
+			return jsErr
+		}
+		if isJSON(to) {
+			output = formatJSON(cmd, output)
+		}
+		err = writeOutput(cmd, to, output)
+		if err != nil {
+			return err
 		}
 
+		// the output is written, even if it doesn't validate
 		if jsErr != nil {
-			cmd.PrintErr(jsErr)
+			return jsErr
 		}
+		return nil
 	},
 }
 
 func init() {
 	rootCmd.AddCommand(listCmd)
+
+	listCmd.Flags().IntP("workers", "w", 5, "number of concurrent requests when fetching a list of DOIs")
+	listCmd.Flags().Bool("dedupe", false, "merge works with the same DOI")
+	listCmd.Flags().String("published-since", "", "only works published on or after this date, e.g. 2020 or 2020-06-01")
+	listCmd.Flags().String("published-until", "", "only works published on or before this date, e.g. 2023 or 2023-12")
+}
+
+// readLines reads the non-empty lines of a file, e.g. a list of DOIs.
+func readLines(filename string) ([]string, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, scanner.Err()
 }
@@ -4,9 +4,19 @@ Copyright © 2024 Front Matter <info@front-matter.io>
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"time"
 
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/crossref"
+	"github.com/front-matter/commonmeta/datacite"
+	"github.com/front-matter/commonmeta/textutils"
+	"github.com/front-matter/commonmeta/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -14,17 +24,212 @@ import (
 var rootCmd = &cobra.Command{
 	Use:   "commonmeta",
 	Short: "Convert scholarly metadata from one format to another",
-	Long: `Convert scholarly metadata between formats. Currently
-supported input formats are Crossref and DataCite DOIs, currently
-the only supported output format is Commonmeta. Example usage:
+	Long: `Convert scholarly metadata between formats. The input and
+output formats are given with the --from and --to flags, defaulting
+to Commonmeta. Crossref and DataCite DOIs are fetched via API.
+Example usage:
 
-commonmeta 10.5555/12345678`,
+commonmeta 10.5555/12345678
 
-	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("root called")
+This is the same as the convert command, which also reads from files
+and standard input.`,
+
+	// the first argument is the work to convert, not a subcommand
+	Args:         cobra.ArbitraryArgs,
+	SilenceUsage: true,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		setLogger(cmd)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return cmd.Help()
+		}
+		return convert(cmd, args)
 	},
 }
 
+// extensions maps output formats to file extensions, used if the output
+// file has no extension.
+var extensions = map[string]string{
+	"atom":        ".xml",
+	"bibtex":      ".bib",
+	"cff":         ".cff",
+	"codemeta":    ".jsonld",
+	"commonmeta":  ".json",
+	"crossrefxml": ".xml",
+	"csl":         ".json",
+	"csv":         ".csv",
+	"datacite":    ".json",
+	"datacitexml": ".xml",
+	"dublincore":  ".xml",
+	"inveniordm":  ".json",
+	"ris":         ".ris",
+	"rss":         ".xml",
+	"schemaorg":   ".jsonld",
+}
+
+// isJSON returns true if the output format is JSON or JSON-LD.
+func isJSON(to string) bool {
+	extension := extensions[to]
+	return extension == ".json" || extension == ".jsonld"
+}
+
+// setLogger sends log messages to stderr, keeping stdout for the results.
+// Debug messages, e.g. the requests made to fetch metadata, are logged with
+// the --verbose flag.
+func setLogger(cmd *cobra.Command) {
+	level := slog.LevelInfo
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	if verbose {
+		level = slog.LevelDebug
+	}
+	handler := slog.NewTextHandler(cmd.ErrOrStderr(), &slog.HandlerOptions{Level: level})
+	slog.SetDefault(slog.New(handler))
+}
+
+// formatJSON indents JSON output with two spaces, or removes insignificant
+// whitespace with the --compact flag. Without --pretty or --compact, output
+// to a terminal or file is indented, output piped to another program compact.
+func formatJSON(cmd *cobra.Command, output []byte) []byte {
+	var out bytes.Buffer
+	var err error
+	if isCompact(cmd) {
+		err = json.Compact(&out, output)
+	} else {
+		err = json.Indent(&out, output, "", "  ")
+	}
+	if err != nil {
+		return output
+	}
+	return out.Bytes()
+}
+
+// isCompact returns true if JSON output should be compact, using the
+// --compact and --pretty flags, or whether stdout is piped.
+func isCompact(cmd *cobra.Command) bool {
+	if cmd.Flags().Changed("compact") {
+		compact, _ := cmd.Flags().GetBool("compact")
+		return compact
+	}
+	if cmd.Flags().Changed("pretty") {
+		pretty, _ := cmd.Flags().GetBool("pretty")
+		return !pretty
+	}
+	filename, _ := cmd.Flags().GetString("output")
+	if filename != "" {
+		return false
+	}
+	f, ok := cmd.OutOrStdout().(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice == 0
+}
+
+// writeOutput writes the output to the file given with the --output flag,
+// creating parent directories if needed, or to stdout.
+func writeOutput(cmd *cobra.Command, to string, output []byte) error {
+	filename, _ := cmd.Flags().GetString("output")
+	if filename == "" {
+		fmt.Fprintf(cmd.OutOrStdout(), "%s\n", output)
+		return nil
+	}
+	if filepath.Ext(filename) == "" {
+		filename += extensions[to]
+	}
+	err := os.MkdirAll(filepath.Dir(filename), 0o755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filename, append(output, '\n'), 0o644)
+}
+
+// titleCase returns the function to change the case of titles given with
+// the --title-case flag, either "sentence" or "title", or nil.
+func titleCase(cmd *cobra.Command) (func(string) string, error) {
+	style, _ := cmd.Flags().GetString("title-case")
+	switch style {
+	case "":
+		return nil, nil
+	case "sentence":
+		return textutils.SentenceCase, nil
+	case "title":
+		return textutils.TitleCase, nil
+	}
+	return nil, fmt.Errorf("unsupported title case: %s", style)
+}
+
+// applyTitleCase changes the case of the titles of a work, except for
+// translated titles.
+func applyTitleCase(data *commonmeta.Data, fn func(string) string) {
+	for i, v := range data.Titles {
+		if v.Type != "TranslatedTitle" {
+			data.Titles[i].Title = fn(v.Title)
+		}
+	}
+}
+
+// enrich adds ROR IDs to the affiliations of a work if the
+// --enrich-affiliations flag is set, ORCID iDs to its contributors if the
+// --enrich-orcid flag is set, and ROR IDs to its funders if the
+// --enrich-funders flag is set. API responses are cached using the
+// --cache-dir and --cache-ttl flags.
+func enrich(cmd *cobra.Command, data *commonmeta.Data) {
+	enrichAffiliations, _ := cmd.Flags().GetBool("enrich-affiliations")
+	enrichORCID, _ := cmd.Flags().GetBool("enrich-orcid")
+	enrichFunders, _ := cmd.Flags().GetBool("enrich-funders")
+	if !enrichAffiliations && !enrichORCID && !enrichFunders {
+		return
+	}
+	var cache *utils.Cache
+	dir, _ := cmd.Flags().GetString("cache-dir")
+	ttl, _ := cmd.Flags().GetDuration("cache-ttl")
+	if dir != "" {
+		cache = utils.NewCache(dir, ttl)
+	}
+	if enrichAffiliations {
+		err := commonmeta.EnrichAffiliations(data, cache)
+		if err != nil {
+			cmd.PrintErr(err)
+		}
+	}
+	if enrichORCID {
+		err := commonmeta.EnrichORCID(data, commonmeta.WithORCIDCache(cache))
+		if err != nil {
+			cmd.PrintErr(err)
+		}
+	}
+	if enrichFunders {
+		err := commonmeta.EnrichFunders(data, cache)
+		if err != nil {
+			cmd.PrintErr(err)
+		}
+	}
+}
+
+// crossrefOptions returns the options for fetching from the Crossref API,
+// using the --cache-dir and --cache-ttl flags.
+func crossrefOptions(cmd *cobra.Command) []crossref.Option {
+	dir, _ := cmd.Flags().GetString("cache-dir")
+	ttl, _ := cmd.Flags().GetDuration("cache-ttl")
+	if dir == "" {
+		return nil
+	}
+	return []crossref.Option{crossref.WithCache(dir, ttl)}
+}
+
+// dataciteOptions returns the options for fetching from the DataCite API,
+// using the --cache-dir and --cache-ttl flags.
+func dataciteOptions(cmd *cobra.Command) []datacite.Option {
+	dir, _ := cmd.Flags().GetString("cache-dir")
+	ttl, _ := cmd.Flags().GetDuration("cache-ttl")
+	if dir == "" {
+		return nil
+	}
+	return []datacite.Option{datacite.WithCache(dir, ttl)}
+}
+
 func Execute() {
 	err := rootCmd.Execute()
 	if err != nil {
@@ -33,8 +238,20 @@ func Execute() {
 }
 
 func init() {
-	rootCmd.PersistentFlags().StringP("from", "f", "commonmeta", "the format to convert from")
+	rootCmd.PersistentFlags().StringP("from", "f", "commonmeta", "the format to convert from, or auto to detect it")
 	rootCmd.PersistentFlags().StringP("to", "t", "commonmeta", "the format to convert to")
+	rootCmd.PersistentFlags().StringP("output", "o", "", "the file to write to, default is stdout")
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "log debug messages to stderr, e.g. API requests")
+	rootCmd.PersistentFlags().Bool("pretty", false, "indent JSON output, the default for output to a terminal or file")
+	rootCmd.PersistentFlags().Bool("compact", false, "don't indent JSON output, the default if output is piped")
+	rootCmd.PersistentFlags().Bool("strict", false, "don't write output that fails schema validation")
+	rootCmd.PersistentFlags().String("title-case", "", "change the case of titles, either sentence or title")
+	rootCmd.PersistentFlags().Bool("enrich-affiliations", false, "add ROR IDs to affiliations using the ROR affiliation matching API")
+	rootCmd.PersistentFlags().Bool("enrich-orcid", false, "add ORCID iDs to persons matched by name and affiliation using the ORCID API")
+	rootCmd.PersistentFlags().Bool("enrich-funders", false, "replace Crossref Funder IDs with ROR IDs using the ROR API")
+	rootCmd.PersistentFlags().StringSlice("columns", nil, "the columns to write in csv format, e.g. DOI,Title,Year")
+	rootCmd.PersistentFlags().String("cache-dir", "", "directory to cache API responses in, default is no caching")
+	rootCmd.PersistentFlags().Duration("cache-ttl", 24*time.Hour, "how long to use cached API responses")
 
 	rootCmd.PersistentFlags().IntP("number", "n", 10, "number of results")
 	rootCmd.PersistentFlags().StringP("member", "m", "", "Crossref member ID")
@@ -4,14 +4,10 @@ Copyright © 2024 Front Matter <info@front-matter.io>
 package cmd
 
 import (
-	"bytes"
-	"encoding/json"
-	"fmt"
+	"errors"
 
 	"github.com/front-matter/commonmeta/commonmeta"
-	"github.com/front-matter/commonmeta/csl"
-	"github.com/front-matter/commonmeta/schemaorg"
-	"github.com/xeipuuv/gojsonschema"
+	"github.com/front-matter/commonmeta/formats"
 
 	"github.com/front-matter/commonmeta/crossref"
 
@@ -55,31 +51,24 @@ var sampleCmd = &cobra.Command{
 			data, err = datacite.FetchAll(number, sample)
 		}
 		if err != nil {
-			fmt.Println(err)
+			cmd.PrintErr(err)
 		}
 
-		var output []byte
-		var jsErr []gojsonschema.ResultError
 		to, _ := cmd.Flags().GetString("to")
-		if to == "commonmeta" {
-			output, jsErr = commonmeta.WriteAll(data)
-		} else if to == "csl" {
-			output, jsErr = csl.WriteAll(data)
-		} else if to == "datacite" {
-			output, jsErr = datacite.WriteAll(data)
-		} else if to == "schemaorg" {
-			output, jsErr = schemaorg.WriteAll(data)
+		strict, _ := cmd.Flags().GetBool("strict")
+		output, writeErr := formats.WriteAll(data, to, formats.WithStrict(strict))
+		var jsErr *formats.ValidationError
+		if writeErr != nil && !errors.As(writeErr, &jsErr) {
+			err = writeErr
 		}
 
 		if err != nil {
 			cmd.PrintErr(err)
 		}
-		var out bytes.Buffer
-		json.Indent(&out, output, "", "  ")
-		cmd.Println(out.String())
+		cmd.Println(string(formatJSON(cmd, output)))
 
 		if jsErr != nil {
-			cmd.PrintErr(jsErr)
+			cmd.PrintErr(jsErr.Errors)
 		}
 	},
 }
@@ -0,0 +1,116 @@
+/*
+Copyright © 2024 Front Matter <info@front-matter.io>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/front-matter/commonmeta/formats"
+	"github.com/spf13/cobra"
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Start an HTTP server converting metadata",
+	Long: `Start an HTTP server converting metadata between formats, e.g. for
+use in a pipeline. The metadata are posted in the request body, the input
+and output formats are given with the from and to query parameters,
+defaulting to Commonmeta. For example:
+
+commonmeta serve --addr :8080
+curl -X POST --data-binary @record.json "http://localhost:8080/convert?from=crossref&to=csl"
+
+With strict=true, output that fails schema validation is rejected.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		addr, _ := cmd.Flags().GetString("addr")
+		server := &http.Server{
+			Addr:              addr,
+			Handler:           newServeMux(),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+		cmd.Printf("Listening on %s\n", addr)
+		return server.ListenAndServe()
+	},
+}
+
+// contentTypes maps output formats to the content type of the response.
+var contentTypes = map[string]string{
+	"bibtex":      "application/x-bibtex",
+	"cff":         "application/vnd.cff+yaml",
+	"codemeta":    "application/vnd.codemeta.ld+json",
+	"commonmeta":  "application/vnd.commonmeta+json",
+	"crossrefxml": "application/vnd.crossref.unixref+xml",
+	"csl":         "application/vnd.citationstyles.csl+json",
+	"csv":         "text/csv",
+	"datacite":    "application/vnd.datacite.datacite+json",
+	"datacitexml": "application/vnd.datacite.datacite+xml",
+	"dublincore":  "application/vnd.dublincore+xml",
+	"inveniordm":  "application/vnd.inveniordm.v1+json",
+	"schemaorg":   "application/vnd.schemaorg.ld+json",
+}
+
+// maxBodySize is the maximum size of a request body, in bytes.
+const maxBodySize = 10 << 20
+
+// newServeMux returns the handler for the HTTP server.
+func newServeMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /convert", convertHandler)
+	return mux
+}
+
+// convertHandler converts the metadata in the request body from the format
+// given with the from query parameter to the format given with to.
+func convertHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	from := query.Get("from")
+	if from == "" {
+		from = "commonmeta"
+	}
+	to := query.Get("to")
+	if to == "" {
+		to = "commonmeta"
+	}
+	strict, _ := strconv.ParseBool(query.Get("strict"))
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	data, err := formats.Read(body, from)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("error reading %s: %v", from, err), http.StatusBadRequest)
+		return
+	}
+	output, err := formats.Write(data, to, formats.WithStrict(strict))
+	var jsErr *formats.ValidationError
+	if errors.Is(err, formats.ErrUnsupportedFormat) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil && !errors.As(err, &jsErr) {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if jsErr != nil && strict {
+		http.Error(w, err.Error(), http.StatusUnprocessableEntity)
+		return
+	}
+	w.Header().Set("Content-Type", contentTypes[to])
+	w.Write(output)
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+
+	serveCmd.Flags().String("addr", ":8080", "the address to listen on")
+}
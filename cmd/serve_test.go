@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/front-matter/commonmeta/csl"
+)
+
+func TestServeConvert(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(newServeMux())
+	defer server.Close()
+
+	input := `{"DOI": "10.5555/12345678", "type": "journal-article", "title": ["Toward a Unified Theory of High-Energy Metaphysics"], "author": [{"given": "Josiah", "family": "Carberry"}]}`
+	resp, err := http.Post(server.URL+"/convert?from=crossref&to=csl", "application/vnd.crossref+json", strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		t.Fatalf("Serve convert: want status 200, got %d: %s", resp.StatusCode, body)
+	}
+	if got := resp.Header.Get("Content-Type"); got != "application/vnd.citationstyles.csl+json" {
+		t.Errorf("Serve convert content type: want application/vnd.citationstyles.csl+json, got %v", got)
+	}
+	var got csl.CSL
+	err = json.NewDecoder(resp.Body).Decode(&got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != "https://doi.org/10.5555/12345678" || got.Type != "article-journal" {
+		t.Errorf("Serve convert: want article-journal https://doi.org/10.5555/12345678, got %v %v", got.Type, got.ID)
+	}
+	if len(got.Author) != 1 || got.Author[0].Family != "Carberry" {
+		t.Errorf("Serve convert author: want Carberry, got %v", got.Author)
+	}
+}
+
+func TestServeConvertErrors(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(newServeMux())
+	defer server.Close()
+
+	type testCase struct {
+		method string
+		query  string
+		body   string
+		want   int
+	}
+	testCases := []testCase{
+		{method: http.MethodPost, query: "from=unknown", body: "{}", want: http.StatusBadRequest},
+		{method: http.MethodPost, query: "from=crossref&to=unknown", body: `{"DOI": "10.5555/12345678"}`, want: http.StatusBadRequest},
+		{method: http.MethodPost, query: "from=crossref", body: "not json", want: http.StatusBadRequest},
+		{method: http.MethodGet, query: "from=crossref", want: http.StatusMethodNotAllowed},
+	}
+	for _, tc := range testCases {
+		req, err := http.NewRequest(tc.method, server.URL+"/convert?"+tc.query, strings.NewReader(tc.body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tc.want {
+			t.Errorf("Serve %s %s: want status %d, got %d", tc.method, tc.query, tc.want, resp.StatusCode)
+		}
+	}
+}
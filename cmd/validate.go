@@ -0,0 +1,85 @@
+/*
+Copyright © 2024 Front Matter <info@front-matter.io>
+*/
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/front-matter/commonmeta/formats"
+	"github.com/front-matter/commonmeta/utils"
+	"github.com/spf13/cobra"
+)
+
+// validateCmd represents the validate command
+var validateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate metadata against the JSON Schema of a format",
+	Long: `Validate metadata read from a file or standard input against the
+JSON Schema of the format given with the --to flag, defaulting to
+Commonmeta, without writing the converted metadata. Schema errors are
+listed with the field they apply to, and the command exits with an
+error if there are any. For example:
+
+commonmeta validate record.json --from csl --to datacite
+cat record.bib | commonmeta validate --from bibtex --to crossrefxml`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		input := "-"
+		if len(args) > 0 {
+			input = args[0]
+		}
+		var b []byte
+		var err error
+		if input == "-" {
+			b, err = io.ReadAll(cmd.InOrStdin())
+		} else {
+			b, err = os.ReadFile(input)
+		}
+		if err != nil {
+			return err
+		}
+
+		// detect the format if --from is not specified or auto
+		from, _ := cmd.Flags().GetString("from")
+		if !cmd.Flags().Changed("from") || from == "auto" {
+			from = utils.DetectFormat(input, b)
+		}
+		if from == "" {
+			return errors.New("please provide the input format with --from")
+		}
+		data, err := formats.Read(b, from)
+		if err != nil {
+			return err
+		}
+
+		// the work is named by its ID, or by the file it was read from
+		name := data.ID
+		if name == "" && input == "-" {
+			name = "standard input"
+		} else if name == "" {
+			name = input
+		}
+
+		to, _ := cmd.Flags().GetString("to")
+		_, err = formats.Write(data, to)
+		var jsErr *formats.ValidationError
+		if errors.As(err, &jsErr) {
+			schemaErrors := jsErr.SchemaErrors()
+			cmd.PrintErr(schemaErrors.Table())
+			return fmt.Errorf("%s is not valid %s: %d schema errors", name, to, len(schemaErrors))
+		}
+		if err != nil {
+			return err
+		}
+		cmd.Printf("%s is valid %s\n", name, to)
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(validateCmd)
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	input := []byte(`{"id":"https://doi.org/10.5555/12345678","type":"article-journal","title":"Valid","author":[{"given":"Josiah","family":"Carberry"}]}`)
+	stdout, stderr, err := runCommand(input, "validate", "--from", "csl", "-")
+	if err != nil {
+		t.Fatalf("Validate: want no error, got %v: %s", err, stderr)
+	}
+	if !strings.Contains(string(stdout), "is valid commonmeta") {
+		t.Errorf("Validate: want valid, got %s", stdout)
+	}
+
+	// the CSL record has no id, which commonmeta requires as URI
+	input = []byte(`{"type":"article-journal","title":"Invalid","author":[{"family":"Carberry"}]}`)
+	stdout, stderr, err = runCommand(input, "validate", "--from", "csl", "-")
+	if err == nil {
+		t.Fatal("Validate invalid: want error, got nil")
+	}
+	if !strings.Contains(err.Error(), "schema errors") {
+		t.Errorf("Validate invalid: want schema errors, got %v", err)
+	}
+	if !strings.Contains(string(stderr), `/id   ""     "" is not a valid uri`) {
+		t.Errorf("Validate invalid: want error for field id, got %s", stderr)
+	}
+	if strings.Contains(string(stdout), "Invalid") {
+		t.Errorf("Validate invalid: want no output, got %s", stdout)
+	}
+}
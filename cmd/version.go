@@ -4,19 +4,89 @@ Copyright © 2024 Front Matter <info@front-matter.io>
 package cmd
 
 import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+
 	"github.com/spf13/cobra"
 )
 
+// buildVersion, buildCommit and buildDate are set with SetVersionInfo from
+// the ldflags of a release build. Otherwise they are read from the build
+// info of the binary.
+var (
+	buildVersion string
+	buildCommit  string
+	buildDate    string
+)
+
 // versionCmd represents the version command
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print the version number of commonmeta",
-	Long:  `All software has versions. This is commonmeta's`,
+	Long: `Print the version, git commit and build date of commonmeta.
+Please include them when reporting a bug.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		cmd.Println("Commonmeta v0.3.24 -- HEAD")
+		cmd.Println(versionString())
 	},
 }
 
+// SetVersionInfo sets the version, git commit and build date, e.g. from the
+// ldflags set by GoReleaser.
+func SetVersionInfo(version, commit, date string) {
+	buildVersion, buildCommit, buildDate = version, commit, date
+	rootCmd.Version = versionString()
+}
+
+// versionString returns the version of commonmeta with git commit and build
+// date, if known, e.g. "commonmeta v0.3.24 (commit 1a2b3c4, built
+// 2024-05-01T10:00:00Z)".
+func versionString() string {
+	version, commit, date := buildVersion, buildCommit, buildDate
+	dirty := false
+	if info, ok := debug.ReadBuildInfo(); ok {
+		if version == "" {
+			version = info.Main.Version
+		}
+		for _, s := range info.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				if commit == "" {
+					commit = s.Value
+				}
+			case "vcs.time":
+				if date == "" {
+					date = s.Value
+				}
+			case "vcs.modified":
+				dirty = s.Value == "true" && buildCommit == ""
+			}
+		}
+	}
+	if version == "" {
+		version = "(devel)"
+	}
+
+	var details []string
+	if commit != "" {
+		commit = commit[:min(len(commit), 7)]
+		if dirty {
+			commit += "-dirty"
+		}
+		details = append(details, "commit "+commit)
+	}
+	if date != "" {
+		details = append(details, "built "+date)
+	}
+	if len(details) == 0 {
+		return "commonmeta " + version
+	}
+	return fmt.Sprintf("commonmeta %s (%s)", version, strings.Join(details, ", "))
+}
+
 func init() {
 	rootCmd.AddCommand(versionCmd)
+
+	rootCmd.Version = versionString()
+	rootCmd.SetVersionTemplate("{{.Version}}\n")
 }
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVersion(t *testing.T) {
+	// reset the --version flag of the root command, used by other tests
+	defer func() {
+		flag := rootCmd.Flags().Lookup("version")
+		flag.Value.Set(flag.DefValue)
+		flag.Changed = false
+	}()
+
+	for _, args := range [][]string{{"version"}, {"--version"}} {
+		stdout, _, err := runCommand(nil, args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := strings.TrimSpace(string(stdout))
+		if !strings.HasPrefix(got, "commonmeta ") || len(got) <= len("commonmeta ") {
+			t.Errorf("Version %v: want version string, got %q", args, got)
+		}
+	}
+
+	SetVersionInfo("v1.2.3", "1a2b3c4d5e6f", "2024-05-01T10:00:00Z")
+	defer SetVersionInfo("", "", "")
+	want := "commonmeta v1.2.3 (commit 1a2b3c4, built 2024-05-01T10:00:00Z)"
+	if got := versionString(); got != want {
+		t.Errorf("Version with ldflags: want %q, got %q", want, got)
+	}
+}
@@ -1,19 +1,300 @@
-// Package codemeta provides a function to read Codemeta metadata and convert it to commonmeta.
+// Package codemeta provides functions to convert Codemeta metadata to/from the commonmeta metadata format.
 package codemeta
 
 import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/front-matter/commonmeta/authorutils"
 	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/dateutils"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/spdxutils"
+	"github.com/front-matter/commonmeta/utils"
 )
 
-type content struct {
-	ID    string `json:"id"`
-	Title string `json:"title"`
+// Content represents the metadata in a codemeta.json file. Author, contributor,
+// maintainer, keywords, license and programmingLanguage can be a single value
+// or a list.
+type Content struct {
+	Context             json.RawMessage `json:"@context"`
+	ID                  string          `json:"@id"`
+	Type                string          `json:"@type"`
+	Identifier          json.RawMessage `json:"identifier"`
+	Name                string          `json:"name"`
+	Description         string          `json:"description"`
+	Author              json.RawMessage `json:"author"`
+	Contributor         json.RawMessage `json:"contributor"`
+	Maintainer          json.RawMessage `json:"maintainer"`
+	CodeRepository      string          `json:"codeRepository"`
+	URL                 string          `json:"url"`
+	ProgrammingLanguage json.RawMessage `json:"programmingLanguage"`
+	Version             string          `json:"version"`
+	SoftwareVersion     string          `json:"softwareVersion"`
+	License             json.RawMessage `json:"license"`
+	DateCreated         string          `json:"dateCreated"`
+	DatePublished       string          `json:"datePublished"`
+	DateModified        string          `json:"dateModified"`
+	Keywords            json.RawMessage `json:"keywords"`
+	Publisher           json.RawMessage `json:"publisher"`
+}
+
+// Person represents a schema:Person or schema:Organization in Codemeta.
+type Person struct {
+	ID          string          `json:"@id,omitempty"`
+	Type        string          `json:"@type,omitempty"`
+	GivenName   string          `json:"givenName,omitempty"`
+	FamilyName  string          `json:"familyName,omitempty"`
+	Name        string          `json:"name,omitempty"`
+	Email       string          `json:"email,omitempty"`
+	Affiliation json.RawMessage `json:"affiliation,omitempty"`
+}
+
+// CodemetaToCMMappings maps Codemeta types to Commonmeta types
+var CodemetaToCMMappings = map[string]string{
+	"SoftwareSourceCode":  "Software",
+	"SoftwareApplication": "Software",
+	"Dataset":             "Dataset",
+	"ScholarlyArticle":    "JournalArticle",
+	"CreativeWork":        "Other",
 }
 
-// Read reads codemeta and converts it to commonmeta.
-func Read(content content) (commonmeta.Data, error) {
+// Load loads the metadata for a single work from a codemeta.json file
+func Load(filename string) (commonmeta.Data, error) {
 	var data commonmeta.Data
 
-	data.ID = content.ID
+	content, err := ReadJSON(filename)
+	if err != nil {
+		return data, err
+	}
+	data, err = Read(content)
+	if err != nil {
+		return data, err
+	}
 	return data, nil
 }
+
+// ReadJSON reads Codemeta metadata from a JSON file.
+func ReadJSON(filename string) (Content, error) {
+	var content Content
+
+	extension := path.Ext(filename)
+	if extension != ".json" {
+		return content, errors.New("invalid file extension")
+	}
+	file, err := os.Open(filename)
+	if err != nil {
+		return content, errors.New("error reading file")
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	err = decoder.Decode(&content)
+	if err != nil {
+		return content, err
+	}
+	return content, nil
+}
+
+// Read reads Codemeta metadata and converts it to commonmeta.
+func Read(content Content) (commonmeta.Data, error) {
+	var data commonmeta.Data
+
+	// use @id, then identifier, then codeRepository as the ID
+	data.ID = utils.NormalizeID(content.ID)
+	identifiers := getStrings(content.Identifier)
+	if data.ID == "" {
+		for _, v := range identifiers {
+			data.ID = utils.NormalizeID(v)
+			if data.ID != "" {
+				break
+			}
+		}
+	}
+	data.URL = content.CodeRepository
+	if data.URL == "" {
+		data.URL = content.URL
+	}
+	if data.ID == "" {
+		data.ID = utils.NormalizeID(data.URL)
+	}
+	if doiutils.NormalizeDOI(data.ID) != "" {
+		data.Identifiers = append(data.Identifiers, commonmeta.Identifier{
+			Identifier:     data.ID,
+			IdentifierType: "DOI",
+		})
+	}
+	for _, v := range identifiers {
+		if utils.NormalizeID(v) == data.ID {
+			continue
+		}
+		data.Identifiers = append(data.Identifiers, commonmeta.Identifier{
+			Identifier:     v,
+			IdentifierType: "Other",
+		})
+	}
+
+	data.Type = CodemetaToCMMappings[strings.TrimPrefix(content.Type, "schema:")]
+	if data.Type == "" {
+		data.Type = "Software"
+	}
+
+	roles := []struct {
+		raw  json.RawMessage
+		role string
+	}{
+		{content.Author, "Author"},
+		{content.Contributor, "Other"},
+		{content.Maintainer, "Maintainer"},
+	}
+	for _, r := range roles {
+		for _, v := range getPersons(r.raw) {
+			contributor, ok := GetContributor(v)
+			if ok {
+				contributor.ContributorRoles = []string{r.role}
+				data.Contributors = append(data.Contributors, contributor)
+			}
+		}
+	}
+
+	if content.Name != "" {
+		data.Titles = []commonmeta.Title{{Title: content.Name}}
+	}
+	if content.Description != "" {
+		data.Descriptions = []commonmeta.Description{{
+			Description: utils.Sanitize(content.Description),
+			Type:        "Abstract",
+		}}
+	}
+
+	data.Date.Created = dateutils.ParseDate(content.DateCreated)
+	data.Date.Published = dateutils.ParseDate(content.DatePublished)
+	data.Date.Updated = dateutils.ParseDate(content.DateModified)
+
+	licenses := getStrings(content.License)
+	if len(licenses) > 0 {
+		data.License = getLicense(licenses[0])
+	}
+
+	var keywords []string
+	for _, v := range getStrings(content.Keywords) {
+		keywords = append(keywords, strings.Split(v, ",")...)
+	}
+	// commonmeta has no field for the programming language, use it as a subject
+	keywords = append(keywords, getStrings(content.ProgrammingLanguage)...)
+	for _, v := range keywords {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			data.Subjects = append(data.Subjects, commonmeta.Subject{Subject: v})
+		}
+	}
+
+	publishers := getStrings(content.Publisher)
+	if len(publishers) > 0 {
+		data.Publisher = commonmeta.Publisher{Name: publishers[0]}
+	} else if persons := getPersons(content.Publisher); len(persons) > 0 {
+		data.Publisher = commonmeta.Publisher{Name: persons[0].Name}
+	}
+
+	data.Version = content.SoftwareVersion
+	if data.Version == "" {
+		data.Version = content.Version
+	}
+
+	return data, nil
+}
+
+// GetContributor converts a Codemeta person or organization into the Commonmeta format
+func GetContributor(v Person) (commonmeta.Contributor, bool) {
+	var contributor commonmeta.Contributor
+
+	t := strings.TrimPrefix(v.Type, "schema:")
+	if t != "Person" && t != "Organization" {
+		if v.GivenName != "" || v.FamilyName != "" || authorutils.IsPersonalName(v.Name) {
+			t = "Person"
+		} else {
+			t = "Organization"
+		}
+	}
+	var affiliations []*commonmeta.Affiliation
+	for _, name := range getStrings(v.Affiliation) {
+		affiliations = append(affiliations, &commonmeta.Affiliation{Name: name})
+	}
+	for _, a := range getPersons(v.Affiliation) {
+		if a.Name != "" {
+			affiliations = append(affiliations, &commonmeta.Affiliation{
+				ID:   utils.NormalizeROR(a.ID),
+				Name: a.Name,
+			})
+		}
+	}
+
+	if t == "Person" {
+		givenName, familyName := v.GivenName, v.FamilyName
+		if familyName == "" && v.Name != "" {
+			givenName, familyName, _ = authorutils.ParseName(v.Name)
+		}
+		if familyName == "" {
+			return contributor, false
+		}
+		contributor = commonmeta.Contributor{
+			ID:           utils.NormalizeORCID(v.ID),
+			Type:         "Person",
+			GivenName:    givenName,
+			FamilyName:   familyName,
+			Affiliations: affiliations,
+		}
+	} else {
+		if v.Name == "" {
+			return contributor, false
+		}
+		contributor = commonmeta.Contributor{
+			ID:           utils.NormalizeROR(v.ID),
+			Type:         "Organization",
+			Name:         v.Name,
+			Affiliations: affiliations,
+		}
+	}
+	return contributor, true
+}
+
+// getLicense returns the license from a SPDX URL or SPDX identifier.
+func getLicense(license string) commonmeta.License {
+	id, url := spdxutils.Normalize(license)
+	return commonmeta.License{ID: id, URL: url}
+}
+
+// getStrings returns the values of a JSON string or list of strings.
+func getStrings(raw json.RawMessage) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return []string{s}
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return list
+	}
+	return nil
+}
+
+// getPersons returns the values of a JSON object or list of objects.
+func getPersons(raw json.RawMessage) []Person {
+	if len(raw) == 0 {
+		return nil
+	}
+	var person Person
+	if err := json.Unmarshal(raw, &person); err == nil {
+		return []Person{person}
+	}
+	var list []Person
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return list
+	}
+	return nil
+}
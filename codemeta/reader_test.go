@@ -0,0 +1,65 @@
+package codemeta_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/front-matter/commonmeta/codemeta"
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name string
+		want commonmeta.Data
+	}
+
+	testCases := []testCase{
+		{
+			name: "codemeta.json",
+			want: commonmeta.Data{
+				ID:   "https://doi.org/10.5281/zenodo.8340374",
+				Type: "Software",
+				Contributors: []commonmeta.Contributor{
+					{
+						ID:               "https://orcid.org/0000-0003-1419-2405",
+						Type:             "Person",
+						GivenName:        "Martin",
+						FamilyName:       "Fenner",
+						Affiliations:     []*commonmeta.Affiliation{{Name: "Front Matter"}},
+						ContributorRoles: []string{"Author"},
+					},
+					{Type: "Organization", Name: "Front Matter", ContributorRoles: []string{"Other"}},
+				},
+				Date: commonmeta.Date{Published: "2023-09-12"},
+				Descriptions: []commonmeta.Description{
+					{Description: "Library for conversions to/from the Commonmeta scholarly metadata format", Type: "Abstract"},
+				},
+				Identifiers: []commonmeta.Identifier{
+					{Identifier: "https://doi.org/10.5281/zenodo.8340374", IdentifierType: "DOI"},
+				},
+				License: commonmeta.License{ID: "MIT", URL: "https://opensource.org/licenses/MIT"},
+				Subjects: []commonmeta.Subject{
+					{Subject: "scholarly metadata"},
+					{Subject: "citation"},
+					{Subject: "Python"},
+				},
+				Titles:  []commonmeta.Title{{Title: "commonmeta-py"}},
+				URL:     "https://github.com/front-matter/commonmeta-py",
+				Version: "0.8.1",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		got, err := codemeta.Load(filepath.Join("testdata", tc.name))
+		if err != nil {
+			t.Fatalf("Codemeta Load (%v): error %v", tc.name, err)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("Codemeta Load (%v) mismatch (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package codemeta
+
+import (
+	"encoding/json"
+	"log/slog"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/verutils"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// Context is the JSON-LD context of Codemeta version 3.
+const Context = "https://w3id.org/codemeta/3.0"
+
+// Codemeta represents the Codemeta metadata written to a codemeta.json file.
+type Codemeta struct {
+	Context        string        `json:"@context"`
+	ID             string        `json:"@id,omitempty"`
+	Type           string        `json:"@type"`
+	Identifier     string        `json:"identifier,omitempty"`
+	Name           string        `json:"name,omitempty"`
+	Description    string        `json:"description,omitempty"`
+	Author         []Person      `json:"author,omitempty"`
+	Contributor    []Person      `json:"contributor,omitempty"`
+	Maintainer     []Person      `json:"maintainer,omitempty"`
+	CodeRepository string        `json:"codeRepository,omitempty"`
+	Version        string        `json:"version,omitempty"`
+	License        string        `json:"license,omitempty"`
+	DateCreated    string        `json:"dateCreated,omitempty"`
+	DatePublished  string        `json:"datePublished,omitempty"`
+	DateModified   string        `json:"dateModified,omitempty"`
+	Keywords       []string      `json:"keywords,omitempty"`
+	Publisher      *Organization `json:"publisher,omitempty"`
+}
+
+// Organization represents a schema:Organization, e.g. the publisher.
+type Organization struct {
+	ID   string `json:"@id,omitempty"`
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+// CMToCodemetaMappings maps Commonmeta types to Codemeta types
+var CMToCodemetaMappings = map[string]string{
+	"Software":       "SoftwareSourceCode",
+	"Dataset":        "Dataset",
+	"JournalArticle": "ScholarlyArticle",
+}
+
+// Convert converts Commonmeta metadata to Codemeta metadata.
+func Convert(data commonmeta.Data) (Codemeta, error) {
+	codemeta := Codemeta{
+		Context:        Context,
+		ID:             data.ID,
+		CodeRepository: data.URL,
+		Version:        verutils.NormalizeVersion(data.Version),
+		DateCreated:    data.Date.Created,
+		DatePublished:  data.Date.Published,
+		DateModified:   data.Date.Updated,
+	}
+	doi, ok := doiutils.ValidateDOI(data.ID)
+	if ok {
+		codemeta.Identifier = doiutils.NormalizeDOI(doi)
+	}
+	codemeta.Type = CMToCodemetaMappings[data.Type]
+	if codemeta.Type == "" {
+		codemeta.Type = "CreativeWork"
+	}
+
+	for _, v := range data.Contributors {
+		person := getPerson(v)
+		role := "Author"
+		if len(v.ContributorRoles) > 0 {
+			role = v.ContributorRoles[0]
+		}
+		switch role {
+		case "Author":
+			codemeta.Author = append(codemeta.Author, person)
+		case "Maintainer":
+			codemeta.Maintainer = append(codemeta.Maintainer, person)
+		default:
+			codemeta.Contributor = append(codemeta.Contributor, person)
+		}
+	}
+
+	if len(data.Titles) > 0 {
+		codemeta.Name = data.Titles[0].Title
+	}
+	if len(data.Descriptions) > 0 {
+		codemeta.Description = data.Descriptions[0].Description
+	}
+	if data.License.URL != "" {
+		codemeta.License = data.License.URL
+	}
+	if data.License.ID != "" {
+		codemeta.License = "https://spdx.org/licenses/" + data.License.ID
+	}
+	for _, v := range data.Subjects {
+		codemeta.Keywords = append(codemeta.Keywords, v.Subject)
+	}
+	if data.Publisher.Name != "" {
+		codemeta.Publisher = &Organization{Type: "Organization", Name: data.Publisher.Name}
+	}
+	return codemeta, nil
+}
+
+// Write writes commonmeta metadata in Codemeta format.
+func Write(data commonmeta.Data) ([]byte, []gojsonschema.ResultError) {
+	codemeta, err := Convert(data)
+	if err != nil {
+		slog.Error("error converting metadata", "error", err)
+	}
+	output, err := json.Marshal(codemeta)
+	if err != nil {
+		slog.Error("error marshalling JSON", "error", err)
+	}
+	return output, nil
+}
+
+// getPerson converts a Commonmeta contributor to a Codemeta person or organization.
+func getPerson(v commonmeta.Contributor) Person {
+	person := Person{
+		ID:         v.ID,
+		Type:       v.Type,
+		GivenName:  v.GivenName,
+		FamilyName: v.FamilyName,
+		Name:       v.Name,
+	}
+	if person.Type == "" {
+		person.Type = "Person"
+	}
+	var affiliations []Organization
+	for _, a := range v.Affiliations {
+		if a != nil && a.Name != "" {
+			affiliations = append(affiliations, Organization{ID: a.ID, Type: "Organization", Name: a.Name})
+		}
+	}
+	if len(affiliations) > 0 {
+		person.Affiliation, _ = json.Marshal(affiliations)
+	}
+	return person
+}
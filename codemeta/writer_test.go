@@ -0,0 +1,44 @@
+package codemeta_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/front-matter/commonmeta/codemeta"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	data, err := codemeta.Load(filepath.Join("testdata", "codemeta.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, jsErr := codemeta.Write(data)
+	if jsErr != nil {
+		t.Fatalf("Codemeta Write: validation errors %v", jsErr)
+	}
+	var content codemeta.Content
+	err = json.Unmarshal(output, &content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(content.Context) != `"https://w3id.org/codemeta/3.0"` {
+		t.Errorf("Codemeta Write @context: want https://w3id.org/codemeta/3.0, got %s", content.Context)
+	}
+	got, err := codemeta.Read(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Version != "0.8.1" {
+		t.Errorf("Codemeta Write version: want 0.8.1, got %v", got.Version)
+	}
+	if got.URL != "https://github.com/front-matter/commonmeta-py" {
+		t.Errorf("Codemeta Write codeRepository: want https://github.com/front-matter/commonmeta-py, got %v", got.URL)
+	}
+	if diff := cmp.Diff(data, got); diff != "" {
+		t.Errorf("Codemeta Write mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,71 @@
+package commonmeta
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/front-matter/commonmeta/doiutils"
+)
+
+// Dedupe collapses works sharing the same DOI into a single work. The most
+// complete work is used as base, empty fields are filled in from the other
+// works, and contributors and subjects are merged. Works without DOI are kept
+// as is, and the order of the first occurrence of a DOI is preserved.
+func Dedupe(list []Data) []Data {
+	var dois []string
+	groups := make(map[string][]Data)
+	var data []Data
+	for _, v := range list {
+		doi, ok := doiutils.ValidateDOI(v.ID)
+		if !ok {
+			data = append(data, v)
+			dois = append(dois, "")
+			continue
+		}
+		doi = strings.ToLower(doi)
+		if _, ok := groups[doi]; !ok {
+			dois = append(dois, doi)
+			data = append(data, Data{})
+		}
+		groups[doi] = append(groups[doi], v)
+	}
+	for i, doi := range dois {
+		if doi != "" {
+			data[i] = merge(groups[doi])
+		}
+	}
+	return data
+}
+
+// merge merges a list of works describing the same work, using the most
+// complete work as base.
+func merge(list []Data) Data {
+	base := 0
+	for i := range list {
+		if completeness(list[i]) > completeness(list[base]) {
+			base = i
+		}
+	}
+	data := list[base]
+	for i, v := range list {
+		if i == base {
+			continue
+		}
+		data = Merge(data, v,
+			WithMergeStrategy("contributors", MergeUnion),
+			WithMergeStrategy("subjects", MergeUnion))
+	}
+	return data
+}
+
+// completeness returns the number of non-empty fields of a work.
+func completeness(data Data) int {
+	var n int
+	v := reflect.ValueOf(data)
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Field(i).IsZero() {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,62 @@
+package commonmeta_test
+
+import (
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDedupe(t *testing.T) {
+	t.Parallel()
+	list := []commonmeta.Data{
+		{
+			ID:     "https://doi.org/10.7554/elife.01567",
+			Type:   "JournalArticle",
+			Titles: []commonmeta.Title{{Title: "Automated quantitative histology reveals vascular morphodynamics during Arabidopsis hypocotyl secondary growth"}},
+			Contributors: []commonmeta.Contributor{
+				{ID: "https://orcid.org/0000-0002-1825-0097", Type: "Person", GivenName: "Martial", FamilyName: "Sankar", ContributorRoles: []string{"Author"}},
+			},
+			Subjects: []commonmeta.Subject{{Subject: "Plant Biology"}},
+		},
+		{
+			ID:   "https://example.org/post",
+			Type: "Article",
+		},
+		{
+			ID:   "10.7554/ELIFE.01567",
+			Type: "JournalArticle",
+			Contributors: []commonmeta.Contributor{
+				{ID: "https://orcid.org/0000-0002-1825-0097", Type: "Person", GivenName: "M.", FamilyName: "Sankar", ContributorRoles: []string{"Author"}},
+				{Type: "Person", GivenName: "Kaisa", FamilyName: "Nieminen", ContributorRoles: []string{"Author"}},
+			},
+			Date:      commonmeta.Date{Published: "2014-02-11"},
+			Container: commonmeta.Container{Type: "Journal", Title: "eLife", Volume: "3"},
+			Publisher: commonmeta.Publisher{Name: "eLife Sciences Publications, Ltd"},
+			Subjects:  []commonmeta.Subject{{Subject: "plant biology"}, {Subject: "Developmental Biology"}},
+		},
+	}
+	want := []commonmeta.Data{
+		{
+			ID:     "10.7554/ELIFE.01567",
+			Type:   "JournalArticle",
+			Titles: []commonmeta.Title{{Title: "Automated quantitative histology reveals vascular morphodynamics during Arabidopsis hypocotyl secondary growth"}},
+			Contributors: []commonmeta.Contributor{
+				{ID: "https://orcid.org/0000-0002-1825-0097", Type: "Person", GivenName: "M.", FamilyName: "Sankar", ContributorRoles: []string{"Author"}},
+				{Type: "Person", GivenName: "Kaisa", FamilyName: "Nieminen", ContributorRoles: []string{"Author"}},
+			},
+			Date:      commonmeta.Date{Published: "2014-02-11"},
+			Container: commonmeta.Container{Type: "Journal", Title: "eLife", Volume: "3"},
+			Publisher: commonmeta.Publisher{Name: "eLife Sciences Publications, Ltd"},
+			Subjects:  []commonmeta.Subject{{Subject: "plant biology"}, {Subject: "Developmental Biology"}},
+		},
+		{
+			ID:   "https://example.org/post",
+			Type: "Article",
+		},
+	}
+	got := commonmeta.Dedupe(list)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Dedupe mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,234 @@
+package commonmeta
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/front-matter/commonmeta/orcidutils"
+	"github.com/front-matter/commonmeta/rorutils"
+	"github.com/front-matter/commonmeta/utils"
+)
+
+// EnrichAffiliations deduplicates the affiliations of each contributor and
+// adds ROR IDs to affiliations without ID, using the ROR affiliation matching
+// API. Each affiliation string is looked up once, and responses are cached if
+// cache is not nil. Affiliations that could not be looked up are kept without
+// ID and their errors returned joined. opts configure the requests to the
+// ROR API.
+func EnrichAffiliations(data *Data, cache *utils.Cache, opts ...rorutils.Option) error {
+	ids := make(map[string]string)
+	var errs []error
+	for i, contributor := range data.Contributors {
+		var affiliations []*Affiliation
+		for _, v := range contributor.Affiliations {
+			if v == nil {
+				continue
+			}
+			affiliation := &Affiliation{
+				ID:   v.ID,
+				Name: strings.Join(strings.Fields(v.Name), " "),
+			}
+			if affiliation.ID == "" && affiliation.Name != "" {
+				id, ok := ids[affiliation.Name]
+				if !ok {
+					var err error
+					id, err = rorutils.MatchAffiliation(affiliation.Name, cache, opts...)
+					if err != nil {
+						errs = append(errs, fmt.Errorf("%s: %w", affiliation.Name, err))
+					}
+					ids[affiliation.Name] = id
+				}
+				affiliation.ID = id
+			}
+			if affiliation.ID == "" && affiliation.Name == "" {
+				continue
+			}
+			if !slices.ContainsFunc(affiliations, func(e *Affiliation) bool { return *e == *affiliation }) {
+				affiliations = append(affiliations, affiliation)
+			}
+		}
+		data.Contributors[i].Affiliations = affiliations
+	}
+	return errors.Join(errs...)
+}
+
+// EnrichFunders replaces the Crossref Funder IDs of funding references with
+// the ROR IDs of the funders, using the Funder Registry IDs stored by ROR.
+// Each Funder ID is looked up once, and responses are cached if cache is not
+// nil. Funder IDs without ROR ID are kept. opts configure the requests to the
+// ROR API.
+func EnrichFunders(data *Data, cache *utils.Cache, opts ...rorutils.Option) error {
+	rors := make(map[string]string)
+	var errs []error
+	for i, v := range data.FundingReferences {
+		if v.FunderIdentifierType != "Crossref Funder ID" || v.FunderIdentifier == "" {
+			continue
+		}
+		ror, ok := rors[v.FunderIdentifier]
+		if !ok {
+			var err error
+			ror, err = rorutils.FunderToROR(v.FunderIdentifier, cache, opts...)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", v.FunderIdentifier, err))
+			}
+			rors[v.FunderIdentifier] = ror
+		}
+		if ror != "" {
+			data.FundingReferences[i].FunderIdentifier = ror
+			data.FundingReferences[i].FunderIdentifierType = "ROR"
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ORCIDOption configures how EnrichORCID matches contributors to ORCID
+// records.
+type ORCIDOption func(*orcidOptions)
+
+type orcidOptions struct {
+	baseURL  string
+	cache    *utils.Cache
+	minScore float64
+}
+
+// DefaultORCIDMinScore is the default minimum score of an ORCID match,
+// requiring the full given and family name and an affiliation to match.
+const DefaultORCIDMinScore = 0.9
+
+// WithORCIDBaseURL sets the base URL of the ORCID public API, the default is
+// orcidutils.BaseURL.
+func WithORCIDBaseURL(baseURL string) ORCIDOption {
+	return func(o *orcidOptions) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithORCIDCache caches ORCID API responses in cache.
+func WithORCIDCache(cache *utils.Cache) ORCIDOption {
+	return func(o *orcidOptions) {
+		o.cache = cache
+	}
+}
+
+// WithORCIDMinScore sets the minimum score between 0 and 1 of an ORCID match.
+// Matching the full given and family name scores 0.6, matching only the
+// initial of the given name 0.3, and matching an affiliation adds 0.4.
+func WithORCIDMinScore(score float64) ORCIDOption {
+	return func(o *orcidOptions) {
+		o.minScore = score
+	}
+}
+
+// EnrichORCID adds ORCID iDs to persons without ID, searching the ORCID
+// public API by name and affiliation. As name matching is fuzzy, an ORCID iD
+// is only added if exactly one record scores at least the minimum score,
+// ambiguous matches are skipped. Existing IDs are never overwritten. Persons
+// that could not be looked up are kept without ID and their errors returned
+// joined.
+func EnrichORCID(data *Data, opts ...ORCIDOption) error {
+	o := orcidOptions{baseURL: orcidutils.BaseURL, minScore: DefaultORCIDMinScore}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	var errs []error
+	for i, contributor := range data.Contributors {
+		if contributor.ID != "" || contributor.Type == "Organization" || contributor.GivenName == "" || contributor.FamilyName == "" {
+			continue
+		}
+		var affiliations []string
+		for _, v := range contributor.Affiliations {
+			if v != nil && v.Name != "" {
+				affiliations = append(affiliations, v.Name)
+			}
+		}
+		// without affiliation, the minimum score can't be reached
+		if len(affiliations) == 0 && o.minScore > 0.6 {
+			continue
+		}
+		var affiliation string
+		if len(affiliations) > 0 {
+			affiliation = affiliations[0]
+		}
+		results, err := orcidutils.Search(contributor.GivenName, contributor.FamilyName, affiliation, o.cache, orcidutils.WithBaseURL(o.baseURL))
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", contributor.GivenName, contributor.FamilyName, err))
+			continue
+		}
+		var matches []string
+		for _, v := range results {
+			id, ok := orcidutils.ValidateORCID(v.ORCID)
+			if ok && orcidScore(contributor, affiliations, v) >= o.minScore && !slices.Contains(matches, id) {
+				matches = append(matches, id)
+			}
+		}
+		if len(matches) == 1 {
+			data.Contributors[i].ID = matches[0]
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// orcidScore scores how well an ORCID record matches a person, between 0 and
+// 1. The family name must match, the given name in full or by initial.
+func orcidScore(contributor Contributor, affiliations []string, result orcidutils.SearchResult) float64 {
+	if !strings.EqualFold(strings.TrimSpace(result.FamilyNames), strings.TrimSpace(contributor.FamilyName)) {
+		return 0
+	}
+	var score float64
+	given := strings.TrimSpace(result.GivenNames)
+	switch {
+	case strings.EqualFold(given, strings.TrimSpace(contributor.GivenName)):
+		score = 0.6
+	case given != "" && strings.EqualFold(initial(given), initial(contributor.GivenName)):
+		score = 0.3
+	default:
+		return 0
+	}
+	for _, a := range affiliations {
+		if slices.ContainsFunc(result.InstitutionName, func(name string) bool {
+			return affiliationMatches(name, a)
+		}) {
+			score += 0.4
+			break
+		}
+	}
+	return score
+}
+
+// affiliationMatches reports whether one affiliation name contains the other
+// as a whole sequence of words, ignoring case and punctuation, e.g. "Harvard
+// University" and "Harvard University Medical School", but not "MIT" and
+// "Smithsonian Institution".
+func affiliationMatches(a, b string) bool {
+	wordsA, wordsB := words(a), words(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return false
+	}
+	if len(wordsA) > len(wordsB) {
+		wordsA, wordsB = wordsB, wordsA
+	}
+	for i := 0; i+len(wordsA) <= len(wordsB); i++ {
+		if slices.Equal(wordsA, wordsB[i:i+len(wordsA)]) {
+			return true
+		}
+	}
+	return false
+}
+
+// words splits a name into lowercase words of letters and digits.
+func words(name string) []string {
+	return strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// initial returns the first letter of a name.
+func initial(name string) string {
+	for _, r := range strings.TrimSpace(name) {
+		return string(r)
+	}
+	return ""
+}
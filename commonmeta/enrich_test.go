@@ -0,0 +1,214 @@
+package commonmeta_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/rorutils"
+	"github.com/front-matter/commonmeta/utils"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEnrichAffiliations(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		switch r.URL.Query().Get("affiliation") {
+		case "MIT":
+			fmt.Fprint(w, `{"number_of_results": 1, "items": [{"substring": "MIT", "score": 1.0, "matching_type": "ACRONYM", "chosen": true, "organization": {"id": "https://ror.org/042nb2s44"}}]}`)
+		case "Department of Physics":
+			fmt.Fprint(w, `{"number_of_results": 1, "items": [{"substring": "Department of Physics", "score": 0.5, "matching_type": "PHRASE", "chosen": false, "organization": {"id": "https://ror.org/02jx3x895"}}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	newData := func() commonmeta.Data {
+		return commonmeta.Data{
+			Contributors: []commonmeta.Contributor{
+				{FamilyName: "Fenner", Affiliations: []*commonmeta.Affiliation{
+					{Name: "MIT"},
+					{Name: " MIT "},
+					{Name: "Department of Physics"},
+				}},
+				{FamilyName: "Crosas", Affiliations: []*commonmeta.Affiliation{
+					{Name: "MIT"},
+					{ID: "https://ror.org/03vek6s52", Name: "Harvard University"},
+				}},
+			},
+		}
+	}
+	want := [][]*commonmeta.Affiliation{
+		{
+			{ID: "https://ror.org/042nb2s44", Name: "MIT"},
+			{Name: "Department of Physics"},
+		},
+		{
+			{ID: "https://ror.org/042nb2s44", Name: "MIT"},
+			{ID: "https://ror.org/03vek6s52", Name: "Harvard University"},
+		},
+	}
+
+	cache := utils.NewCache(t.TempDir(), 0)
+	for _, run := range []string{"lookup", "cached"} {
+		data := newData()
+		err := commonmeta.EnrichAffiliations(&data, cache, rorutils.WithBaseURL(server.URL))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got [][]*commonmeta.Affiliation
+		for _, v := range data.Contributors {
+			got = append(got, v.Affiliations)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("EnrichAffiliations(%s) mismatch (-want +got):\n%s", run, diff)
+		}
+		// each affiliation string is looked up once, then cached
+		if requests != 2 {
+			t.Errorf("EnrichAffiliations(%s): want 2 requests, got %d", run, requests)
+		}
+	}
+
+	data := commonmeta.Data{
+		Contributors: []commonmeta.Contributor{
+			{Affiliations: []*commonmeta.Affiliation{{Name: "Unknown Institute"}}},
+		},
+	}
+	err := commonmeta.EnrichAffiliations(&data, nil, rorutils.WithBaseURL(server.URL))
+	if err == nil {
+		t.Error("EnrichAffiliations: want error for failed lookup, got nil")
+	}
+	if len(data.Contributors[0].Affiliations) != 1 || data.Contributors[0].Affiliations[0].ID != "" {
+		t.Errorf("EnrichAffiliations: want affiliation kept without ID, got %v", data.Contributors[0].Affiliations)
+	}
+}
+
+func TestEnrichORCID(t *testing.T) {
+	t.Parallel()
+
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		queries = append(queries, q)
+		switch {
+		case strings.Contains(q, `family-name:"Fenner`):
+			fmt.Fprint(w, `{"expanded-result": [
+				{"orcid-id": "0000-0003-1419-2405", "given-names": "Martin", "family-names": "Fenner", "institution-name": ["Front Matter", "DataCite"]}
+			], "num-found": 1}`)
+		case strings.Contains(q, `family-name:"Smith"`):
+			fmt.Fprint(w, `{"expanded-result": [
+				{"orcid-id": "0000-0002-1825-0097", "given-names": "John", "family-names": "Smith", "institution-name": ["Harvard University"]},
+				{"orcid-id": "0000-0001-5109-3700", "given-names": "John", "family-names": "Smith", "institution-name": ["Harvard University", "MIT"]}
+			], "num-found": 2}`)
+		case strings.Contains(q, `family-name:"Doe"`):
+			fmt.Fprint(w, `{"expanded-result": [
+				{"orcid-id": "0000-0001-2345-6789", "given-names": "Jane", "family-names": "Doe", "institution-name": ["Smithsonian Institution", "Smith College"]}
+			], "num-found": 1}`)
+		default:
+			fmt.Fprint(w, `{"expanded-result": null, "num-found": 0}`)
+		}
+	}))
+	defer server.Close()
+
+	data := commonmeta.Data{
+		Contributors: []commonmeta.Contributor{
+			{Type: "Person", GivenName: "Martin", FamilyName: "Fenner", Affiliations: []*commonmeta.Affiliation{{Name: "Front Matter"}}},
+			{Type: "Person", GivenName: "John", FamilyName: "Smith", Affiliations: []*commonmeta.Affiliation{{Name: "Harvard University"}}},
+			{Type: "Person", ID: "https://orcid.org/0000-0002-9876-1234", GivenName: "Josiah", FamilyName: "Carberry", Affiliations: []*commonmeta.Affiliation{{Name: "Brown University"}}},
+			{Type: "Person", GivenName: "Mercè", FamilyName: "Crosas"},
+			{Type: "Organization", Name: "Front Matter"},
+			{Type: "Person", GivenName: "Jane", FamilyName: "Doe", Affiliations: []*commonmeta.Affiliation{{Name: "MIT"}}},
+		},
+	}
+	err := commonmeta.EnrichORCID(&data, commonmeta.WithORCIDBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, v := range data.Contributors {
+		got = append(got, v.ID)
+	}
+	want := []string{
+		"https://orcid.org/0000-0003-1419-2405",
+		"",
+		"https://orcid.org/0000-0002-9876-1234",
+		"",
+		"",
+		"",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("EnrichORCID mismatch (-want +got):\n%s", diff)
+	}
+	// persons with ID or without affiliation are not looked up, and a short
+	// affiliation only matches whole words
+	if len(queries) != 3 {
+		t.Errorf("EnrichORCID: want 3 queries, got %v", queries)
+	}
+
+	// with a lower minimum score, the given name and initials are enough
+	data = commonmeta.Data{
+		Contributors: []commonmeta.Contributor{
+			{Type: "Person", GivenName: "M.", FamilyName: "Fenner", Affiliations: []*commonmeta.Affiliation{{Name: "DataCite"}}},
+			{Type: "Person", GivenName: "Martin", FamilyName: "Fennerson", Affiliations: []*commonmeta.Affiliation{{Name: "DataCite"}}},
+		},
+	}
+	err = commonmeta.EnrichORCID(&data, commonmeta.WithORCIDBaseURL(server.URL), commonmeta.WithORCIDMinScore(0.7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Contributors[0].ID != "https://orcid.org/0000-0003-1419-2405" || data.Contributors[1].ID != "" {
+		t.Errorf("EnrichORCID with minimum score 0.7: got %v", data.Contributors)
+	}
+}
+
+func TestEnrichFunders(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if r.URL.Query().Get("query.advanced") == "external_ids.all:100000001" {
+			fmt.Fprint(w, `{"items": [{"id": "https://ror.org/021nxhr62", "external_ids": [{"type": "fundref", "all": ["100000001"]}]}]}`)
+			return
+		}
+		fmt.Fprint(w, `{"items": []}`)
+	}))
+	defer server.Close()
+
+	data := commonmeta.Data{
+		FundingReferences: []commonmeta.FundingReference{
+			{FunderIdentifier: "https://doi.org/10.13039/100000001", FunderIdentifierType: "Crossref Funder ID", FunderName: "National Science Foundation", AwardNumber: "1234567"},
+			{FunderIdentifier: "https://doi.org/10.13039/100000001", FunderIdentifierType: "Crossref Funder ID", FunderName: "National Science Foundation", AwardNumber: "7654321"},
+			{FunderIdentifier: "https://doi.org/10.13039/501100000780", FunderIdentifierType: "Crossref Funder ID", FunderName: "European Commission"},
+			{FunderIdentifier: "https://ror.org/01cwqze88", FunderIdentifierType: "ROR", FunderName: "National Institutes of Health"},
+			{FunderName: "Wellcome Trust"},
+		},
+	}
+	err := commonmeta.EnrichFunders(&data, nil, rorutils.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []commonmeta.FundingReference{
+		{FunderIdentifier: "https://ror.org/021nxhr62", FunderIdentifierType: "ROR", FunderName: "National Science Foundation", AwardNumber: "1234567"},
+		{FunderIdentifier: "https://ror.org/021nxhr62", FunderIdentifierType: "ROR", FunderName: "National Science Foundation", AwardNumber: "7654321"},
+		{FunderIdentifier: "https://doi.org/10.13039/501100000780", FunderIdentifierType: "Crossref Funder ID", FunderName: "European Commission"},
+		{FunderIdentifier: "https://ror.org/01cwqze88", FunderIdentifierType: "ROR", FunderName: "National Institutes of Health"},
+		{FunderName: "Wellcome Trust"},
+	}
+	if diff := cmp.Diff(want, data.FundingReferences); diff != "" {
+		t.Errorf("EnrichFunders mismatch (-want +got):\n%s", diff)
+	}
+	// each Funder ID is looked up once
+	if requests != 2 {
+		t.Errorf("EnrichFunders: want 2 requests, got %d", requests)
+	}
+}
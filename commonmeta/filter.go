@@ -0,0 +1,81 @@
+package commonmeta
+
+import (
+	"slices"
+	"strings"
+)
+
+// FilterOption configures which works are kept by Filter.
+type FilterOption func(*filterOptions)
+
+type filterOptions struct {
+	types          []string
+	publishedSince string
+	publishedUntil string
+}
+
+// WithType keeps works of one of the given types. Types are matched ignoring
+// case, hyphens and underscores, e.g. "journal-article" matches JournalArticle.
+func WithType(types ...string) FilterOption {
+	return func(o *filterOptions) {
+		for _, v := range types {
+			if t := normalizeType(v); t != "" {
+				o.types = append(o.types, t)
+			}
+		}
+	}
+}
+
+// WithPublishedSince keeps works published on or after an ISO 8601 date, which
+// can be a partial date such as 2020 or 2020-06.
+func WithPublishedSince(date string) FilterOption {
+	return func(o *filterOptions) {
+		o.publishedSince = date
+	}
+}
+
+// WithPublishedUntil keeps works published on or before an ISO 8601 date,
+// which can be a partial date such as 2020 or 2020-06.
+func WithPublishedUntil(date string) FilterOption {
+	return func(o *filterOptions) {
+		o.publishedUntil = date
+	}
+}
+
+// Filter returns the works in list matching all options. Publication dates
+// are compared with the precision of the less precise date, so a work
+// published in 2020 matches a filter for works published since 2020-06. Works
+// without publication date don't match a date filter.
+func Filter(list []Data, opts ...FilterOption) []Data {
+	var o filterOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	var data []Data
+	for _, v := range list {
+		if len(o.types) > 0 && !slices.Contains(o.types, normalizeType(v.Type)) {
+			continue
+		}
+		if o.publishedSince != "" && (v.Date.Published == "" || compareDate(v.Date.Published, o.publishedSince) < 0) {
+			continue
+		}
+		if o.publishedUntil != "" && (v.Date.Published == "" || compareDate(v.Date.Published, o.publishedUntil) > 0) {
+			continue
+		}
+		data = append(data, v)
+	}
+	return data
+}
+
+// normalizeType returns a type in lowercase without hyphens, underscores and
+// spaces.
+func normalizeType(t string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", "_", "", " ", "").Replace(t))
+}
+
+// compareDate compares two ISO 8601 dates with the precision of the shorter
+// date.
+func compareDate(a, b string) int {
+	n := min(len(a), len(b))
+	return strings.Compare(a[:n], b[:n])
+}
@@ -0,0 +1,44 @@
+package commonmeta_test
+
+import (
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+	list := []commonmeta.Data{
+		{ID: "https://doi.org/10.5555/1", Type: "JournalArticle", Date: commonmeta.Date{Published: "2019-12-31"}},
+		{ID: "https://doi.org/10.5555/2", Type: "Dataset", Date: commonmeta.Date{Published: "2020"}},
+		{ID: "https://doi.org/10.5555/3", Type: "JournalArticle", Date: commonmeta.Date{Published: "2020-06"}},
+		{ID: "https://doi.org/10.5555/4", Type: "BookChapter", Date: commonmeta.Date{Published: "2021-03-15T10:00:00Z"}},
+		{ID: "https://doi.org/10.5555/5", Type: "JournalArticle"},
+	}
+
+	type testCase struct {
+		name string
+		opts []commonmeta.FilterOption
+		want []string
+	}
+	testCases := []testCase{
+		{name: "no filter", want: []string{"1", "2", "3", "4", "5"}},
+		{name: "type", opts: []commonmeta.FilterOption{commonmeta.WithType("JournalArticle")}, want: []string{"1", "3", "5"}},
+		{name: "types", opts: []commonmeta.FilterOption{commonmeta.WithType("dataset", "book-chapter")}, want: []string{"2", "4"}},
+		{name: "since year", opts: []commonmeta.FilterOption{commonmeta.WithPublishedSince("2020")}, want: []string{"2", "3", "4"}},
+		{name: "since month", opts: []commonmeta.FilterOption{commonmeta.WithPublishedSince("2020-07")}, want: []string{"2", "4"}},
+		{name: "until date", opts: []commonmeta.FilterOption{commonmeta.WithPublishedUntil("2020-01-01")}, want: []string{"1", "2"}},
+		{name: "range", opts: []commonmeta.FilterOption{commonmeta.WithPublishedSince("2020-01"), commonmeta.WithPublishedUntil("2020-12")}, want: []string{"2", "3"}},
+		{name: "type and range", opts: []commonmeta.FilterOption{commonmeta.WithType("journal_article"), commonmeta.WithPublishedUntil("2020")}, want: []string{"1", "3"}},
+	}
+	for _, tc := range testCases {
+		var got []string
+		for _, v := range commonmeta.Filter(list, tc.opts...) {
+			got = append(got, v.ID[len("https://doi.org/10.5555/"):])
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("Filter(%s) mismatch (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
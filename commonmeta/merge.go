@@ -0,0 +1,128 @@
+package commonmeta
+
+import (
+	"reflect"
+	"strings"
+)
+
+// MergeStrategy determines how a field is merged by Merge.
+type MergeStrategy int
+
+const (
+	// MergeBase keeps the value of base, unless it is empty. This is the
+	// default strategy.
+	MergeBase MergeStrategy = iota
+	// MergeOverlay uses the value of overlay, unless it is empty.
+	MergeOverlay
+	// MergeUnion appends the items of overlay not found in base, for list
+	// fields such as contributors or subjects. Other fields are merged using
+	// MergeBase.
+	MergeUnion
+)
+
+// MergeOption configures how Merge merges a field.
+type MergeOption func(map[string]MergeStrategy)
+
+// WithMergeStrategy sets the strategy for a field, using the field name of
+// the commonmeta JSON Schema, e.g. "references" or "fundingReferences".
+func WithMergeStrategy(field string, strategy MergeStrategy) MergeOption {
+	return func(strategies map[string]MergeStrategy) {
+		strategies[field] = strategy
+	}
+}
+
+// Merge merges metadata for the same work from two sources, e.g. Crossref and
+// DataCite. Values in base take precedence, empty fields in base are filled in
+// from overlay. Nested fields such as container or date are merged field by
+// field, list fields are used as a whole. Use WithMergeStrategy to change
+// how a field is merged.
+func Merge(base, overlay Data, opts ...MergeOption) Data {
+	strategies := make(map[string]MergeStrategy)
+	for _, opt := range opts {
+		opt(strategies)
+	}
+
+	data := base
+	dst := reflect.ValueOf(&data).Elem()
+	src := reflect.ValueOf(overlay)
+	for i := 0; i < dst.NumField(); i++ {
+		name, _, _ := strings.Cut(dst.Type().Field(i).Tag.Get("json"), ",")
+		switch strategies[name] {
+		case MergeOverlay:
+			mergeValue(dst.Field(i), src.Field(i), true)
+		case MergeUnion:
+			if dst.Field(i).Kind() == reflect.Slice {
+				mergeSlice(dst.Field(i), src.Field(i))
+			} else {
+				mergeValue(dst.Field(i), src.Field(i), false)
+			}
+		default:
+			mergeValue(dst.Field(i), src.Field(i), false)
+		}
+	}
+	return data
+}
+
+// mergeValue sets dst to src if dst is empty, or if overlay is true and src
+// is not empty. Nested structs are merged field by field.
+func mergeValue(dst, src reflect.Value, overlay bool) {
+	if dst.Kind() == reflect.Struct {
+		for i := 0; i < dst.NumField(); i++ {
+			mergeValue(dst.Field(i), src.Field(i), overlay)
+		}
+		return
+	}
+	if dst.IsZero() || (overlay && !src.IsZero()) {
+		dst.Set(src)
+	}
+}
+
+// mergeSlice appends the items of src not found in dst. Contributors are
+// matched by ID or name, subjects ignoring case.
+func mergeSlice(dst, src reflect.Value) {
+	merged := reflect.AppendSlice(reflect.MakeSlice(dst.Type(), 0, dst.Len()+src.Len()), dst)
+	for i := 0; i < src.Len(); i++ {
+		item := src.Index(i)
+		found := false
+		for j := 0; j < merged.Len(); j++ {
+			if equalItem(merged.Index(j).Interface(), item.Interface()) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = reflect.Append(merged, item)
+		}
+	}
+	if merged.Len() > 0 {
+		dst.Set(merged)
+	}
+}
+
+// equalItem returns true if two items of a list field describe the same thing.
+func equalItem(a, b any) bool {
+	switch a := a.(type) {
+	case Contributor:
+		return equalContributor(a, b.(Contributor))
+	case Subject:
+		return strings.EqualFold(a.Subject, b.(Subject).Subject)
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// equalContributor returns true if two contributors are the same, matching
+// by ID, or by name if either contributor has no ID.
+func equalContributor(a, b Contributor) bool {
+	if a.ID != "" && b.ID != "" {
+		return strings.EqualFold(a.ID, b.ID)
+	}
+	return strings.EqualFold(contributorName(a), contributorName(b))
+}
+
+// contributorName returns the name of a contributor, used for comparison.
+func contributorName(c Contributor) string {
+	if c.Name != "" {
+		return strings.TrimSpace(c.Name)
+	}
+	return strings.TrimSpace(c.GivenName + " " + c.FamilyName)
+}
@@ -0,0 +1,87 @@
+package commonmeta_test
+
+import (
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMerge(t *testing.T) {
+	t.Parallel()
+	crossref := commonmeta.Data{
+		ID:       "https://doi.org/10.5281/zenodo.5244404",
+		Type:     "JournalArticle",
+		Provider: "Crossref",
+		Titles:   []commonmeta.Title{{Title: "Climate-driven shifts in phenology"}},
+		Contributors: []commonmeta.Contributor{
+			{Type: "Person", GivenName: "Josiah", FamilyName: "Carberry", ContributorRoles: []string{"Author"}},
+		},
+		Container: commonmeta.Container{Type: "Journal", Title: "Journal of Psychoceramics", Volume: "5"},
+		References: []commonmeta.Reference{
+			{Key: "ref1", ID: "https://doi.org/10.1038/nature01286"},
+			{Key: "ref2", ID: "https://doi.org/10.1111/gcb.12345"},
+		},
+	}
+	datacite := commonmeta.Data{
+		ID:       "https://doi.org/10.5281/zenodo.5244404",
+		Type:     "Dataset",
+		Provider: "DataCite",
+		Titles:   []commonmeta.Title{{Title: "Climate-driven shifts in phenology (data)"}},
+		Contributors: []commonmeta.Contributor{
+			{ID: "https://orcid.org/0000-0002-1825-0097", Type: "Person", GivenName: "Josiah", FamilyName: "Carberry", ContributorRoles: []string{"Author"}},
+			{Type: "Person", GivenName: "Jane", FamilyName: "Smith", ContributorRoles: []string{"Author"}},
+		},
+		Container: commonmeta.Container{Type: "DataRepository", Title: "Zenodo", FirstPage: "1"},
+		FundingReferences: []commonmeta.FundingReference{
+			{FunderIdentifier: "https://doi.org/10.13039/501100000780", FunderName: "European Commission", AwardNumber: "654039"},
+		},
+		License: commonmeta.License{ID: "CC-BY-4.0", URL: "https://creativecommons.org/licenses/by/4.0/legalcode"},
+	}
+
+	type testCase struct {
+		name string
+		opts []commonmeta.MergeOption
+		want commonmeta.Data
+	}
+	testCases := []testCase{
+		{
+			name: "base",
+			want: commonmeta.Data{
+				ID:                "https://doi.org/10.5281/zenodo.5244404",
+				Type:              "JournalArticle",
+				Provider:          "Crossref",
+				Titles:            crossref.Titles,
+				Contributors:      crossref.Contributors,
+				Container:         commonmeta.Container{Type: "Journal", Title: "Journal of Psychoceramics", FirstPage: "1", Volume: "5"},
+				References:        crossref.References,
+				FundingReferences: datacite.FundingReferences,
+				License:           datacite.License,
+			},
+		},
+		{
+			name: "strategies",
+			opts: []commonmeta.MergeOption{
+				commonmeta.WithMergeStrategy("contributors", commonmeta.MergeOverlay),
+				commonmeta.WithMergeStrategy("titles", commonmeta.MergeUnion),
+			},
+			want: commonmeta.Data{
+				ID:                "https://doi.org/10.5281/zenodo.5244404",
+				Type:              "JournalArticle",
+				Provider:          "Crossref",
+				Titles:            append(crossref.Titles, datacite.Titles...),
+				Contributors:      datacite.Contributors,
+				Container:         commonmeta.Container{Type: "Journal", Title: "Journal of Psychoceramics", FirstPage: "1", Volume: "5"},
+				References:        crossref.References,
+				FundingReferences: datacite.FundingReferences,
+				License:           datacite.License,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		got := commonmeta.Merge(crossref, datacite, tc.opts...)
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("Merge(%s) mismatch (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
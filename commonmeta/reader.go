@@ -5,9 +5,16 @@ import (
 	"bufio"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"path"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/front-matter/commonmeta/authorutils"
 )
 
 type Reader struct {
@@ -72,7 +79,7 @@ var ContributorRoles = []string{
 // ContainerTypes maps types to associated container types
 var ContainerTypes = map[string]string{
 	"BookChapter":        "Book",
-	"Dataset":            "Database",
+	"Dataset":            "DataRepository",
 	"JournalArticle":     "Journal",
 	"JournalIssue":       "Journal",
 	"Book":               "BookSeries",
@@ -141,6 +148,7 @@ type Container struct {
 	Title          string `json:"title,omitempty"`
 	FirstPage      string `json:"firstPage,omitempty"`
 	LastPage       string `json:"lastPage,omitempty"`
+	ArticleNumber  string `json:"articleNumber,omitempty"`
 	Volume         string `json:"volume,omitempty"`
 	Issue          string `json:"issue,omitempty"`
 }
@@ -154,9 +162,12 @@ type Contributor struct {
 	FamilyName       string         `json:"familyName,omitempty"`
 	Affiliations     []*Affiliation `json:"affiliations,omitempty"`
 	ContributorRoles []string       `json:"contributorRoles,omitempty"`
+	Identifiers      []Identifier   `json:"identifiers,omitempty"`
 }
 
 // Date represents the date of a publication, defined in the commonmeta JSON Schema.
+// Dates are ISO 8601 dates, date ranges such as a collection period are
+// ISO 8601 intervals, e.g. 2020-01-01/2020-12-31.
 type Date struct {
 	Created     string `json:"created,omitempty"`
 	Submitted   string `json:"submitted,omitempty"`
@@ -195,14 +206,16 @@ type FundingReference struct {
 	FunderIdentifierType string `json:"funderIdentifierType,omitempty"`
 	FunderName           string `json:"funderName,omitempty"`
 	AwardNumber          string `json:"awardNumber,omitempty"`
-	AwardURI             string `json:"award_uri,omitempty"`
+	AwardTitle           string `json:"awardTitle,omitempty"`
+	AwardURI             string `json:"awardUri,omitempty"`
 }
 
 // GeoLocation represents the geographical location of a publication, defined in the commonmeta JSON Schema.
 type GeoLocation struct {
-	GeoLocationPlace string           `json:"geoLocationPlace,omitempty"`
-	GeoLocationPoint GeoLocationPoint `json:"geoLocationPoint,omitempty"`
-	GeoLocationBox   GeoLocationBox   `json:"geoLocationBox,omitempty"`
+	GeoLocationPlace    string               `json:"geoLocationPlace,omitempty"`
+	GeoLocationPoint    GeoLocationPoint     `json:"geoLocationPoint,omitempty"`
+	GeoLocationBox      GeoLocationBox       `json:"geoLocationBox,omitempty"`
+	GeoLocationPolygons []GeoLocationPolygon `json:"geoLocationPolygons,omitempty"`
 }
 
 // GeoLocationPoint represents a point in a geographical location, defined in the commonmeta JSON Schema.
@@ -221,8 +234,8 @@ type GeoLocationBox struct {
 
 // GeoLocationPolygon represents a polygon in a geographical location, defined in the commonmeta JSON Schema.
 type GeoLocationPolygon struct {
-	PolygonPoints  []GeoLocationPoint `json:"polygon_points,omitempty"`
-	InPolygonPoint GeoLocationPoint   `json:"in_polygon_point,omitempty"`
+	PolygonPoints  []GeoLocationPoint `json:"polygonPoints,omitempty"`
+	InPolygonPoint GeoLocationPoint   `json:"inPolygonPoint,omitempty"`
 }
 
 // Identifier represents the identifier of a publication, defined in the commonmeta JSON Schema.
@@ -248,8 +261,16 @@ type Reference struct {
 	Key             string `json:"key"`
 	ID              string `json:"id,omitempty"`
 	Type            string `json:"type,omitempty"`
+	Contributor     string `json:"contributor,omitempty"`
 	Title           string `json:"title,omitempty"`
+	Publisher       string `json:"publisher,omitempty"`
 	PublicationYear string `json:"publicationYear,omitempty"`
+	Volume          string `json:"volume,omitempty"`
+	Issue           string `json:"issue,omitempty"`
+	FirstPage       string `json:"firstPage,omitempty"`
+	LastPage        string `json:"lastPage,omitempty"`
+	ContainerTitle  string `json:"containerTitle,omitempty"`
+	Edition         string `json:"edition,omitempty"`
 	Unstructured    string `json:"unstructured,omitempty"`
 }
 
@@ -327,6 +348,55 @@ func ReadAll(content []Data) ([]Data, error) {
 	return data, nil
 }
 
+// FetchList fetches a list of works concurrently, using fetch with a bounded
+// number of workers. The works are returned in the order of ids, works that
+// could not be fetched are skipped and their errors returned joined.
+func FetchList(ids []string, workers int, fetch func(string) (Data, error)) ([]Data, error) {
+	if workers < 1 {
+		workers = 1
+	}
+	results := make([]Data, len(ids))
+	errs := make([]error, len(ids))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], errs[i] = fetch(ids[i])
+			}
+		}()
+	}
+	for i := range ids {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	var data []Data
+	for i, result := range results {
+		if errs[i] != nil {
+			errs[i] = fmt.Errorf("%s: %w", ids[i], errs[i])
+			continue
+		}
+		data = append(data, result)
+	}
+	return data, errors.Join(errs...)
+}
+
+// FirstAuthor returns the first contributor with the Author role. The order
+// of the contributors is the author order of the source.
+func (d *Data) FirstAuthor() (Contributor, bool) {
+	for _, v := range d.Contributors {
+		if slices.Contains(v.ContributorRoles, "Author") {
+			return v, true
+		}
+	}
+	return Contributor{}, false
+}
+
 // Pages returns the first and last page of a work as a string.
 func (c *Container) Pages() string {
 	if c.FirstPage == "" {
@@ -337,3 +407,122 @@ func (c *Container) Pages() string {
 	}
 	return c.FirstPage + "-" + c.LastPage
 }
+
+// SetPages sets the first and last page of a work from a page string, e.g.
+// "123-145", "123–145", "e12345" or "123, 125, 127". Abbreviated page ranges
+// such as "123-45" are expanded.
+func (c *Container) SetPages(pages string) {
+	c.FirstPage, c.LastPage = "", ""
+	pages = strings.TrimSpace(pages)
+	pages = strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(pages, "pp."), "p."))
+	pages = strings.NewReplacer("--", "-", "\u2013", "-", "\u2014", "-", "\u2212", "-").Replace(pages)
+	if pages == "" {
+		return
+	}
+
+	// use the first and last page of a list of pages or page ranges
+	ranges := strings.Split(pages, ",")
+	first, _, _ := strings.Cut(ranges[0], "-")
+	c.FirstPage = strings.TrimSpace(first)
+	last := ranges[len(ranges)-1]
+	if i := strings.LastIndex(last, "-"); i >= 0 {
+		last = last[i+1:]
+	}
+	last = strings.TrimSpace(last)
+	if last == c.FirstPage {
+		return
+	}
+	if isDigits(c.FirstPage) && isDigits(last) && len(last) < len(c.FirstPage) {
+		last = c.FirstPage[:len(c.FirstPage)-len(last)] + last
+	}
+	c.LastPage = last
+}
+
+// isDigits returns true if the string is not empty and consists only of digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// identifierTypeAliases maps alternative names of identifier types, in
+// lowercase, to the identifier types of the commonmeta schema.
+var identifierTypeAliases = map[string]string{
+	"arxiv id":  "arXiv",
+	"hdl":       "Handle",
+	"pmc":       "PMCID",
+	"pmc id":    "PMCID",
+	"pubmed":    "PMID",
+	"pubmed id": "PMID",
+	"uri":       "URL",
+}
+
+var (
+	pmcidRegexp = regexp.MustCompile(`^PMC\d+$`)
+	arxivRegexp = regexp.MustCompile(`(?i)^(arxiv:)?\d{4}\.\d{4,5}(v\d+)?$`)
+	uuidRegexp  = regexp.MustCompile(`(?i)^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+)
+
+// GetIdentifierType returns the identifier type of the commonmeta schema for
+// an identifier. The given identifier type is matched ignoring case and
+// common alternative names such as PubMed. Without a known identifier type,
+// the type is guessed from the identifier, falling back to Other.
+func GetIdentifierType(identifier string, identifierType string) string {
+	for _, t := range IdentifierTypes {
+		if strings.EqualFold(t, identifierType) {
+			return t
+		}
+	}
+	if t, ok := identifierTypeAliases[strings.ToLower(identifierType)]; ok {
+		return t
+	}
+	identifier = strings.TrimSpace(identifier)
+	switch {
+	case pmcidRegexp.MatchString(identifier):
+		return "PMCID"
+	case arxivRegexp.MatchString(identifier):
+		return "arXiv"
+	case uuidRegexp.MatchString(identifier):
+		return "UUID"
+	case strings.HasPrefix(identifier, "ark:/"):
+		return "ARK"
+	case strings.HasPrefix(strings.ToLower(identifier), "urn:"):
+		return "URN"
+	case strings.HasPrefix(identifier, "https://") || strings.HasPrefix(identifier, "http://"):
+		return "URL"
+	}
+	return "Other"
+}
+
+// GetContributorType returns the type of a contributor, either Person or
+// Organization. If the type is not known, it is guessed from given and family
+// name, ORCID or ROR ID, affiliations, and words known to be used in
+// organization names.
+func GetContributorType(c Contributor) string {
+	if c.Type == "Person" || c.Type == "Organization" {
+		return c.Type
+	}
+	if c.GivenName != "" || c.FamilyName != "" {
+		return "Person"
+	}
+	if strings.HasPrefix(c.ID, "https://orcid.org/") {
+		return "Person"
+	}
+	if strings.HasPrefix(c.ID, "https://ror.org/") {
+		return "Organization"
+	}
+	// organizations don't have affiliations
+	if len(c.Affiliations) > 0 {
+		return "Person"
+	}
+	if c.Name != "" && authorutils.IsPersonalName(c.Name) {
+		return "Person"
+	}
+	return "Organization"
+}
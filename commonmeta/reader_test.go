@@ -15,7 +15,87 @@ func TestData(t *testing.T) {
 	}
 }
 
-func ExamplePages() {
+func TestGetIdentifierType(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		identifier     string
+		identifierType string
+		want           string
+	}
+	testCases := []testCase{
+		{identifier: "24567894", identifierType: "PMID", want: "PMID"},
+		{identifier: "24567894", identifierType: "pubmed", want: "PMID"},
+		{identifier: "24567894", identifierType: "", want: "Other"},
+		{identifier: "PMC3926531", identifierType: "", want: "PMCID"},
+		{identifier: "arXiv:2101.00001v2", identifierType: "", want: "arXiv"},
+		{identifier: "10.5555/1", identifierType: "doi", want: "DOI"},
+		{identifier: "2027/uc1.b4394747", identifierType: "hdl", want: "Handle"},
+		{identifier: "ark:/13030/tf5p30086k", identifierType: "", want: "ARK"},
+		{identifier: "urn:nbn:de:101:1-2018", identifierType: "", want: "URN"},
+		{identifier: "https://example.org/1", identifierType: "", want: "URL"},
+		{identifier: "Z-123", identifierType: "Local accession number", want: "Other"},
+	}
+	for _, tc := range testCases {
+		got := commonmeta.GetIdentifierType(tc.identifier, tc.identifierType)
+		if tc.want != got {
+			t.Errorf("GetIdentifierType(%v, %v): want %v, got %v", tc.identifier, tc.identifierType, tc.want, got)
+		}
+	}
+}
+
+func TestGetContributorType(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name        string
+		contributor commonmeta.Contributor
+		want        string
+	}
+	testCases := []testCase{
+		{name: "type", contributor: commonmeta.Contributor{Type: "Organization", Name: "Josiah Carberry"}, want: "Organization"},
+		{name: "family name", contributor: commonmeta.Contributor{GivenName: "Josiah", FamilyName: "Carberry"}, want: "Person"},
+		{name: "orcid", contributor: commonmeta.Contributor{ID: "https://orcid.org/0000-0002-1825-0097", Name: "Carberry"}, want: "Person"},
+		{name: "ror", contributor: commonmeta.Contributor{ID: "https://ror.org/05gq02987", Name: "Brown"}, want: "Organization"},
+		{name: "personal name", contributor: commonmeta.Contributor{Name: "Josiah Carberry"}, want: "Person"},
+		{name: "organization name", contributor: commonmeta.Contributor{Name: "World Health Organization"}, want: "Organization"},
+	}
+	for _, tc := range testCases {
+		got := commonmeta.GetContributorType(tc.contributor)
+		if tc.want != got {
+			t.Errorf("GetContributorType(%s): want %v, got %v", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestFirstAuthor(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name         string
+		contributors []commonmeta.Contributor
+		want         string
+	}
+	testCases := []testCase{
+		{name: "authors", contributors: []commonmeta.Contributor{
+			{FamilyName: "Fenner", ContributorRoles: []string{"Author"}},
+			{FamilyName: "Crosas", ContributorRoles: []string{"Author"}},
+		}, want: "Fenner"},
+		{name: "editor listed first", contributors: []commonmeta.Contributor{
+			{FamilyName: "Carberry", ContributorRoles: []string{"Editor"}},
+			{FamilyName: "Crosas", ContributorRoles: []string{"Author"}},
+		}, want: "Crosas"},
+		{name: "no authors", contributors: []commonmeta.Contributor{
+			{FamilyName: "Carberry", ContributorRoles: []string{"Editor"}},
+		}, want: ""},
+	}
+	for _, tc := range testCases {
+		data := commonmeta.Data{Contributors: tc.contributors}
+		got, ok := data.FirstAuthor()
+		if tc.want != got.FamilyName || ok != (tc.want != "") {
+			t.Errorf("FirstAuthor(%s): want %q, got %q, ok %v", tc.name, tc.want, got.FamilyName, ok)
+		}
+	}
+}
+
+func ExampleContainer_Pages() {
 	book := commonmeta.Container{
 		Type:           "Book",
 		Identifier:     "9783662463703",
@@ -29,3 +109,64 @@ func ExamplePages() {
 	// Output:
 	// 155-158
 }
+
+func TestPages(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name      string
+		firstPage string
+		lastPage  string
+		want      string
+	}
+	testCases := []testCase{
+		{name: "page range", firstPage: "1095", lastPage: "1101", want: "1095-1101"},
+		{name: "article number", firstPage: "e1004123", want: "e1004123"},
+		{name: "last page only", lastPage: "42", want: "42"},
+		{name: "no pages", want: ""},
+	}
+	for _, tc := range testCases {
+		c := commonmeta.Container{FirstPage: tc.firstPage, LastPage: tc.lastPage}
+		got := c.Pages()
+		if tc.want != got {
+			t.Errorf("Pages(%s): want %q, got %q", tc.name, tc.want, got)
+		}
+	}
+}
+
+func TestSetPages(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input     string
+		firstPage string
+		lastPage  string
+	}
+	testCases := []testCase{
+		{input: "123-145", firstPage: "123", lastPage: "145"},
+		{input: "123–145", firstPage: "123", lastPage: "145"},
+		{input: "123--145", firstPage: "123", lastPage: "145"},
+		{input: "pp. 123 - 145", firstPage: "123", lastPage: "145"},
+		{input: "1234-56", firstPage: "1234", lastPage: "1256"},
+		{input: "123", firstPage: "123", lastPage: ""},
+		{input: "123-123", firstPage: "123", lastPage: ""},
+		{input: "e12345", firstPage: "e12345", lastPage: ""},
+		{input: "S12-S18", firstPage: "S12", lastPage: "S18"},
+		{input: "123, 125, 127", firstPage: "123", lastPage: "127"},
+		{input: "123-125, 130-135", firstPage: "123", lastPage: "135"},
+		{input: "", firstPage: "", lastPage: ""},
+	}
+	for _, tc := range testCases {
+		var c commonmeta.Container
+		c.SetPages(tc.input)
+		if c.FirstPage != tc.firstPage || c.LastPage != tc.lastPage {
+			t.Errorf("SetPages(%q): want %q, %q, got %q, %q", tc.input, tc.firstPage, tc.lastPage, c.FirstPage, c.LastPage)
+		}
+	}
+}
+
+func ExampleContainer_SetPages() {
+	var journal commonmeta.Container
+	journal.SetPages("1095–1101")
+	fmt.Println(journal.FirstPage, journal.LastPage)
+	// Output:
+	// 1095 1101
+}
@@ -3,7 +3,6 @@ package commonmeta
 import (
 	"bufio"
 	"encoding/json"
-	"fmt"
 	"io"
 
 	"github.com/front-matter/commonmeta/schemautils"
@@ -21,28 +20,30 @@ func NewWriter(w io.Writer) *Writer {
 	}
 }
 
-// Write writes commonmeta metadata.
-func Write(data Data) ([]byte, []gojsonschema.ResultError) {
+// Write writes commonmeta metadata. Errors marshalling the metadata are
+// returned as error, schema validation errors as list together with the
+// output.
+func Write(data Data) ([]byte, []gojsonschema.ResultError, error) {
 	output, err := json.Marshal(data)
 	if err != nil {
-		fmt.Println(err)
+		return nil, nil, err
 	}
 	validation := schemautils.JSONSchemaErrors(output)
 	if !validation.Valid() {
-		return nil, validation.Errors()
+		return output, validation.Errors(), nil
 	}
-	return output, nil
+	return output, nil, nil
 }
 
 // WriteAll writes commonmeta metadata in slice format.
-func WriteAll(list []Data) ([]byte, []gojsonschema.ResultError) {
+func WriteAll(list []Data) ([]byte, []gojsonschema.ResultError, error) {
 	output, err := json.Marshal(list)
 	if err != nil {
-		fmt.Println(err)
+		return nil, nil, err
 	}
 	validation := schemautils.JSONSchemaErrors(output)
 	if !validation.Valid() {
-		return nil, validation.Errors()
+		return output, validation.Errors(), nil
 	}
-	return output, nil
+	return output, nil, nil
 }
@@ -0,0 +1,82 @@
+package commonmeta_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+)
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+	data := commonmeta.Data{
+		ID:     "https://doi.org/10.5555/commonmeta",
+		Type:   "Dataset",
+		Titles: []commonmeta.Title{{Title: "Commonmeta"}},
+	}
+	output, jsErr, err := commonmeta.Write(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jsErr != nil {
+		t.Errorf("Write: schema errors %v", jsErr)
+	}
+	if len(output) == 0 {
+		t.Error("Write: empty output")
+	}
+}
+
+func TestWriteMarshalError(t *testing.T) {
+	t.Parallel()
+	// NaN can't be represented in JSON
+	data := commonmeta.Data{
+		ID:   "https://doi.org/10.5555/commonmeta",
+		Type: "Dataset",
+		GeoLocations: []commonmeta.GeoLocation{
+			{GeoLocationPoint: commonmeta.GeoLocationPoint{PointLongitude: math.NaN(), PointLatitude: 52.5}},
+		},
+	}
+	output, _, err := commonmeta.Write(data)
+	if err == nil {
+		t.Error("Write: want error for NaN coordinate, got nil")
+	}
+	if output != nil {
+		t.Errorf("Write: want no output, got %s", output)
+	}
+
+	output, _, err = commonmeta.WriteAll([]commonmeta.Data{data})
+	if err == nil {
+		t.Error("WriteAll: want error for NaN coordinate, got nil")
+	}
+	if output != nil {
+		t.Errorf("WriteAll: want no output, got %s", output)
+	}
+}
+
+func TestWriteValidation(t *testing.T) {
+	t.Parallel()
+	// the required id is missing
+	data := commonmeta.Data{
+		Type:   "Dataset",
+		Titles: []commonmeta.Title{{Title: "Commonmeta"}},
+	}
+	output, jsErr, err := commonmeta.Write(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jsErr) == 0 {
+		t.Error("Write: want schema errors for missing id, got none")
+	}
+	// invalid output is returned together with the errors
+	if len(output) == 0 {
+		t.Error("Write: want output, got none")
+	}
+
+	_, jsErr, err = commonmeta.WriteAll([]commonmeta.Data{data})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jsErr) == 0 {
+		t.Error("WriteAll: want schema errors for missing id, got none")
+	}
+}
@@ -3,11 +3,13 @@ package crossref
 
 import (
 	"bufio"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
@@ -20,8 +22,15 @@ import (
 	"time"
 
 	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/csl"
 	"github.com/front-matter/commonmeta/dateutils"
 	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/isbnutils"
+	"github.com/front-matter/commonmeta/isniutils"
+	"github.com/front-matter/commonmeta/langutils"
+	"github.com/front-matter/commonmeta/orcidutils"
+	"github.com/front-matter/commonmeta/spdxutils"
+	"github.com/front-matter/commonmeta/textutils"
 	"github.com/front-matter/commonmeta/utils"
 )
 
@@ -38,11 +47,14 @@ func NewReader(r io.Reader) *Reader {
 
 // Content is the struct for the message in tge JSON response from the Crossref API
 type Content struct {
-	ID       string   `json:"id"`
-	Type     string   `json:"type"`
-	Abstract string   `json:"abstract"`
-	Archive  []string `json:"archive"`
-	Author   []struct {
+	ID            string   `json:"id"`
+	Type          string   `json:"type"`
+	Subtype       string   `json:"subtype"`
+	Abstract      string   `json:"abstract"`
+	AlternativeID []string `json:"alternative-id"`
+	Archive       []string `json:"archive"`
+	ArticleNumber string   `json:"article-number"`
+	Author        []struct {
 		Given       string `json:"given"`
 		Family      string `json:"family"`
 		Name        string `json:"name"`
@@ -102,7 +114,15 @@ type Content struct {
 	Reference     []struct {
 		Key          string `json:"key"`
 		DOI          string `json:"DOI"`
+		Author       string `json:"author"`
 		ArticleTitle string `json:"article-title"`
+		VolumeTitle  string `json:"volume-title"`
+		JournalTitle string `json:"journal-title"`
+		SeriesTitle  string `json:"series-title"`
+		Volume       string `json:"volume"`
+		Issue        string `json:"issue"`
+		FirstPage    string `json:"first-page"`
+		Edition      string `json:"edition"`
 		Year         string `json:"year"`
 		Unstructured string `json:"unstructured"`
 	} `json:"reference"`
@@ -147,6 +167,10 @@ type Content struct {
 			ID     string `json:"id"`
 			IDType string `json:"id-type"`
 		} `json:"is-translation-of"`
+		HasTranslation []struct {
+			ID     string `json:"id"`
+			IDType string `json:"id-type"`
+		} `json:"has-translation"`
 		IsReviewedBy []struct {
 			ID     string `json:"id"`
 			IDType string `json:"id-type"`
@@ -228,12 +252,15 @@ var CRToCMMappings = map[string]string{
 // CrossrefContainerTypes maps Crossref types to Crossref container types
 var CrossrefContainerTypes = map[string]string{
 	"book-chapter":        "book",
+	"book-part":           "book",
+	"book-section":        "book",
 	"dataset":             "database",
 	"journal-article":     "journal",
 	"journal-issue":       "journal",
 	"monograph":           "book-series",
 	"proceedings-article": "proceedings",
 	"posted-content":      "periodical",
+	"reference-entry":     "book",
 }
 
 // CRToCMContainerTranslations maps Crossref container types to Commonmeta container types
@@ -247,17 +274,132 @@ var CRToCMContainerTranslations = map[string]string{
 }
 
 // relation types to include
-var relationTypes = []string{"IsPartOf", "HasPart", "IsVariantFormOf", "IsOriginalFormOf", "IsIdenticalTo", "IsTranslationOf", "IsReviewedBy", "Reviews", "HasReview", "IsPreprintOf", "HasPreprint", "IsSupplementTo", "IsSupplementedBy"}
+var relationTypes = []string{"IsNewVersionOf", "IsPreviousVersionOf", "IsVersionOf", "HasVersion", "IsPartOf", "HasPart", "IsVariantFormOf", "IsOriginalFormOf", "IsIdenticalTo", "IsTranslationOf", "HasTranslation", "IsReviewedBy", "Reviews", "HasReview", "IsPreprintOf", "HasPreprint", "IsSupplementTo", "IsSupplementedBy"}
+
+// BaseURL is the base URL of the Crossref REST API.
+var BaseURL = "https://api.crossref.org"
+
+// ResolverURL is the base URL of the DOI resolver, used for content negotiation.
+var ResolverURL = "https://doi.org"
+
+// Option configures how works are fetched from the Crossref API.
+type Option func(*options)
+
+type options struct {
+	baseURL            string
+	resolverURL        string
+	cache              *utils.Cache
+	retry              utils.Retry
+	contentNegotiation bool
+	mailto             string
+	userAgent          string
+	timeout            time.Duration
+}
+
+// getOptions returns the options with defaults applied. The mailto defaults
+// to the CROSSREF_MAILTO environment variable.
+func getOptions(opts []Option) options {
+	o := options{baseURL: BaseURL, resolverURL: ResolverURL, retry: utils.DefaultRetry, mailto: os.Getenv("CROSSREF_MAILTO"), timeout: 10 * time.Second}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithBaseURL sets the base URL of the Crossref REST API, the default is
+// BaseURL.
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithResolverURL sets the base URL of the DOI resolver used for content
+// negotiation, the default is ResolverURL.
+func WithResolverURL(resolverURL string) Option {
+	return func(o *options) {
+		o.resolverURL = resolverURL
+	}
+}
+
+// WithCache caches Crossref API responses in dir, and uses them for ttl.
+// A ttl of zero means cached responses never expire.
+func WithCache(dir string, ttl time.Duration) Option {
+	return func(o *options) {
+		o.cache = utils.NewCache(dir, ttl)
+	}
+}
+
+// WithContentNegotiation falls back to DOI content negotiation if the request
+// to the Crossref REST API fails, e.g. because of rate limiting.
+func WithContentNegotiation() Option {
+	return func(o *options) {
+		o.contentNegotiation = true
+	}
+}
+
+// WithRetry retries failed requests to the Crossref API up to maxAttempts
+// times in total, with exponential backoff starting at baseDelay.
+func WithRetry(maxAttempts int, baseDelay time.Duration) Option {
+	return func(o *options) {
+		o.retry = utils.Retry{MaxAttempts: maxAttempts, BaseDelay: baseDelay}
+	}
+}
+
+// WithMailto sends an email address with requests to the Crossref API, both
+// as mailto query parameter and in the User-Agent, so that requests use the
+// Crossref polite pool with better rate limits.
+func WithMailto(email string) Option {
+	return func(o *options) {
+		o.mailto = email
+	}
+}
+
+// WithTimeout sets the timeout of a single request to the Crossref API, the
+// default is 10 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+// WithUserAgent sets the User-Agent of requests to the Crossref API.
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.userAgent = userAgent
+	}
+}
+
+// getUserAgent returns the User-Agent for requests to the Crossref API.
+func (o options) getUserAgent() string {
+	if o.userAgent != "" {
+		return o.userAgent
+	}
+	mailto := o.mailto
+	if mailto == "" {
+		mailto = "info@front-matter.io"
+	}
+	return fmt.Sprintf("commonmeta/%s (https://commonmeta.org; mailto: %s)", "0.1", mailto)
+}
 
 // Fetch gets the metadata for a single work from the Crossref API and converts it to the Commonmeta format
-func Fetch(str string) (commonmeta.Data, error) {
+func Fetch(str string, opts ...Option) (commonmeta.Data, error) {
+	return FetchContext(context.Background(), str, opts...)
+}
+
+// FetchContext is like Fetch, but stops fetching when ctx is done, e.g.
+// when a batch job is canceled.
+func FetchContext(ctx context.Context, str string, opts ...Option) (commonmeta.Data, error) {
 	var data commonmeta.Data
 	id, ok := doiutils.ValidateDOI(str)
 	if !ok {
 		return data, errors.New("invalid DOI")
 	}
-	content, err := Get(id)
+	content, err := GetContext(ctx, id, opts...)
 	if err != nil {
+		if getOptions(opts).contentNegotiation && ctx.Err() == nil {
+			return fetchContentNegotiation(ctx, id, opts...)
+		}
 		return data, err
 	}
 	data, err = Read(content)
@@ -267,6 +409,67 @@ func Fetch(str string) (commonmeta.Data, error) {
 	return data, nil
 }
 
+// FetchContentNegotiation gets the metadata for a single work via DOI content
+// negotiation, requesting citeproc JSON from the DOI resolver, and converts
+// it to the Commonmeta format using the CSL reader.
+func FetchContentNegotiation(str string, opts ...Option) (commonmeta.Data, error) {
+	return fetchContentNegotiation(context.Background(), str, opts...)
+}
+
+func fetchContentNegotiation(ctx context.Context, str string, opts ...Option) (commonmeta.Data, error) {
+	var data commonmeta.Data
+	var content csl.Content
+
+	doi, ok := doiutils.ValidateDOI(str)
+	if !ok {
+		return data, errors.New("invalid DOI")
+	}
+	o := getOptions(opts)
+	key := "doi.org/" + doi
+	body, ok := o.cache.Get(key)
+	if !ok {
+		client := &http.Client{
+			Timeout: o.timeout,
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.resolverURL+"/"+doi, nil)
+		if err != nil {
+			return data, err
+		}
+		req.Header.Set("Accept", "application/vnd.citationstyles.csl+json")
+		resp, err := utils.DoWithRetry(client, req, o.retry)
+		if err != nil {
+			return data, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= 400 {
+			return data, errors.New(resp.Status)
+		}
+		body, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return data, err
+		}
+		err = o.cache.Set(key, body)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	err := json.Unmarshal(body, &content)
+	if err != nil {
+		return data, err
+	}
+	return csl.Read(content)
+}
+
+// FetchCrossrefList fetches a list of works by DOI from the Crossref REST
+// API, using the given number of concurrent workers. Works are returned in
+// the order of the DOIs.
+func FetchCrossrefList(dois []string, workers int, opts ...Option) ([]commonmeta.Data, error) {
+	fetch := func(doi string) (commonmeta.Data, error) {
+		return Fetch(doi, opts...)
+	}
+	return commonmeta.FetchList(dois, workers, fetch)
+}
+
 // FetchAll gets the metadata for a list of works from the Crossref API and converts it to the Commonmeta format
 func FetchAll(number int, member string, _type string, sample bool, hasORCID bool, hasROR bool, hasReferences bool, hasRelation bool, hasAbstract bool, hasAward bool, hasLicense bool, hasArchive bool) ([]commonmeta.Data, error) {
 
@@ -284,7 +487,12 @@ func FetchAll(number int, member string, _type string, sample bool, hasORCID boo
 }
 
 // Get gets the metadata for a single work from the Crossref API
-func Get(pid string) (Content, error) {
+func Get(pid string, opts ...Option) (Content, error) {
+	return GetContext(context.Background(), pid, opts...)
+}
+
+// GetContext is like Get, but stops the request when ctx is done.
+func GetContext(ctx context.Context, pid string, opts ...Option) (Content, error) {
 	// the envelope for the JSON response from the Crossref API
 	type Response struct {
 		Status         string  `json:"status"`
@@ -298,33 +506,42 @@ func Get(pid string) (Content, error) {
 	if !ok {
 		return response.Message, errors.New("invalid DOI")
 	}
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-	url := "https://api.crossref.org/works/" + doi
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	v := "0.1"
-	u := "info@front-matter.io"
-	userAgent := fmt.Sprintf("commonmeta/%s (https://commonmeta.org/; mailto: %s)", v, u)
-	req.Header.Set("User-Agent", userAgent)
-	if err != nil {
-		log.Fatalln(err)
-	}
-	resp, err := client.Do(req)
-	if err != nil {
-		return response.Message, err
-	}
-	if resp.StatusCode >= 400 {
-		return response.Message, errors.New(resp.Status)
-	}
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return response.Message, err
+	o := getOptions(opts)
+	key := "crossref/" + doi
+	body, ok := o.cache.Get(key)
+	if !ok {
+		client := &http.Client{
+			Timeout: o.timeout,
+		}
+		u := o.baseURL + "/works/" + doi
+		if o.mailto != "" {
+			u += "?mailto=" + url.QueryEscape(o.mailto)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+		if err != nil {
+			return response.Message, err
+		}
+		req.Header.Set("User-Agent", o.getUserAgent())
+		resp, err := utils.DoWithRetry(client, req, o.retry)
+		if err != nil {
+			return response.Message, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= 400 {
+			return response.Message, errors.New(resp.Status)
+		}
+		body, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return response.Message, err
+		}
+		err = o.cache.Set(key, body)
+		if err != nil {
+			log.Println(err)
+		}
 	}
-	err = json.Unmarshal(body, &response)
+	err := json.Unmarshal(body, &response)
 	if err != nil {
-		fmt.Println("error:", err)
+		slog.Error("error unmarshalling JSON", "error", err)
 	}
 	return response.Message, err
 }
@@ -339,7 +556,7 @@ func GetAll(number int, member string, _type string, sample bool, hasORCID bool,
 		Message        struct {
 			TotalResults int       `json:"total-results"`
 			Items        []Content `json:"items"`
-		} `json:"message`
+		} `json:"message"`
 	}
 	var response Response
 	if number > 100 {
@@ -372,11 +589,108 @@ func GetAll(number int, member string, _type string, sample bool, hasORCID bool,
 	}
 	err = json.Unmarshal(body, &response)
 	if err != nil {
-		fmt.Println("error:", err)
+		slog.Error("error unmarshalling JSON", "error", err)
 	}
 	return response.Message.Items, nil
 }
 
+// QueryParams are the parameters for a query of the Crossref works API.
+type QueryParams struct {
+	Member       string // Crossref member ID
+	Type         string // Crossref work type, e.g. journal-article
+	FromPubDate  string // earliest publication date, e.g. 2020 or 2020-06-01
+	UntilPubDate string // latest publication date
+	Rows         int    // number of works per page, default and maximum 1000
+	Limit        int    // maximum number of works, default is all works
+}
+
+// QueryWorks queries the Crossref works API, following the cursor until all
+// works matching the query (or Limit works) are fetched, and converts them
+// to the Commonmeta format. Use it to harvest all works of a member.
+func QueryWorks(params QueryParams, opts ...Option) ([]commonmeta.Data, error) {
+	// the envelope for the JSON response from the Crossref API
+	type Response struct {
+		Status  string `json:"status"`
+		Message struct {
+			NextCursor   string    `json:"next-cursor"`
+			TotalResults int       `json:"total-results"`
+			Items        []Content `json:"items"`
+		} `json:"message"`
+	}
+
+	o := getOptions(opts)
+	rows := params.Rows
+	if rows <= 0 || rows > 1000 {
+		rows = 1000
+	}
+	if params.Limit > 0 && params.Limit < rows {
+		rows = params.Limit
+	}
+	var filters []string
+	if params.Member != "" {
+		filters = append(filters, "member:"+params.Member)
+	}
+	if params.Type != "" {
+		filters = append(filters, "type:"+params.Type)
+	}
+	if params.FromPubDate != "" {
+		filters = append(filters, "from-pub-date:"+params.FromPubDate)
+	}
+	if params.UntilPubDate != "" {
+		filters = append(filters, "until-pub-date:"+params.UntilPubDate)
+	}
+
+	client := &http.Client{
+		Timeout: 60 * time.Second,
+	}
+	var content []Content
+	cursor := "*"
+	for {
+		u, _ := url.Parse(o.baseURL + "/works")
+		values := u.Query()
+		values.Add("rows", strconv.Itoa(rows))
+		values.Add("cursor", cursor)
+		if o.mailto != "" {
+			values.Add("mailto", o.mailto)
+		}
+		if len(filters) > 0 {
+			values.Add("filter", strings.Join(filters, ","))
+		}
+		u.RawQuery = values.Encode()
+		req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("User-Agent", o.getUserAgent())
+		resp, err := utils.DoWithRetry(client, req, o.retry)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode >= 400 {
+			resp.Body.Close()
+			return nil, errors.New(resp.Status)
+		}
+		var response Response
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		content = append(content, response.Message.Items...)
+		if params.Limit > 0 && len(content) >= params.Limit {
+			content = content[:params.Limit]
+			break
+		}
+		// the last page has fewer items than requested, or no next cursor
+		if len(response.Message.Items) < rows || response.Message.NextCursor == "" {
+			break
+		}
+		cursor = response.Message.NextCursor
+	}
+	return ReadAll(content)
+}
+
 // Load loads the metadata for a single work from a JSON file
 func Load(filename string) (commonmeta.Data, error) {
 	var data commonmeta.Data
@@ -472,6 +786,11 @@ func Read(content Content) (commonmeta.Data, error) {
 	if data.Type == "" {
 		data.Type = "Other"
 	}
+	// posted content is mapped to Article, preprints are kept apart from
+	// other posted content such as working papers via the additional type
+	if content.Type == "posted-content" && content.Subtype == "preprint" {
+		data.AdditionalType = "Preprint"
+	}
 	containerType := CrossrefContainerTypes[content.Type]
 	containerType = CRToCMContainerTranslations[containerType]
 
@@ -481,42 +800,41 @@ func Read(content Content) (commonmeta.Data, error) {
 		}
 	}
 
-	var identifier, identifierType string
-	if len(content.ISSNType) > 0 {
-		i := make(map[string]string)
-		for _, issn := range content.ISSNType {
-			i[issn.Type] = issn.Value
-		}
-		if i["electronic"] != "" {
-			identifier = i["electronic"]
-			identifierType = "ISSN"
-		} else if i["print"] != "" {
-			identifier = i["print"]
-			identifierType = "ISSN"
-		}
-	} else if len(content.ISBNType) > 0 {
-		i := make(map[string]string)
-		for _, isbn := range content.ISBNType {
-			i[isbn.Type] = isbn.Value
-		}
-		if i["electronic"] != "" {
-			identifier = i["electronic"]
-			identifierType = "ISBN"
-		} else if i["print"] != "" {
-			identifier = i["print"]
-			identifierType = "ISBN"
+	issns := make(map[string]string)
+	for _, v := range content.ISSNType {
+		issns[v.Type] = v.Value
+	}
+	isbns := make(map[string]string)
+	for _, v := range content.ISBNType {
+		// normalize to ISBN-13, drop invalid ISBNs
+		if isbn := isbnutils.NormalizeISBN(v.Value); isbn != "" {
+			isbns[v.Type] = isbn
+		}
+	}
+	issn := preferredIdentifier(issns)
+	// the linking ISSN (ISSN-L) groups the print and electronic ISSN of a
+	// journal, the ISSN is kept if the lookup fails
+	if issn != "" {
+		if issnl, err := utils.GetISSNL(issn); err == nil {
+			issn = issnl
 		}
 	}
+	isbn := preferredIdentifier(isbns)
+
+	// a book chapter is part of a book, and the book may be part of a book
+	// series with an ISSN, so the ISBN of the book is preferred
+	var identifier, identifierType string
+	if isbn != "" && (containerType == "Book" || issn == "") {
+		identifier = isbn
+		identifierType = "ISBN"
+	} else if issn != "" {
+		identifier = issn
+		identifierType = "ISSN"
+	}
 	var containerTitle string
 	if len(content.ContainerTitle) > 0 {
 		containerTitle = content.ContainerTitle[0]
 	}
-	var lastPage string
-	pages := strings.Split(content.Page, "-")
-	firstPage := pages[0]
-	if len(pages) > 1 {
-		lastPage = pages[1]
-	}
 	data.Container = commonmeta.Container{
 		Identifier:     identifier,
 		IdentifierType: identifierType,
@@ -524,16 +842,21 @@ func Read(content Content) (commonmeta.Data, error) {
 		Title:          containerTitle,
 		Volume:         content.Volume,
 		Issue:          content.Issue,
-		FirstPage:      firstPage,
-		LastPage:       lastPage,
+		ArticleNumber:  content.ArticleNumber,
 	}
+	data.Container.SetPages(content.Page)
 
-	for _, v := range content.Author {
+	// authors are kept in source order, with the first author first
+	authors := slices.Clone(content.Author)
+	sort.SliceStable(authors, func(i, j int) bool {
+		return authors[i].Sequence == "first" && authors[j].Sequence != "first"
+	})
+	for _, v := range authors {
 		if v.Name != "" || v.Given != "" || v.Family != "" {
 			var ID, Type string
 			if v.ORCID != "" {
-				// enforce HTTPS
-				ID, _ = utils.NormalizeURL(v.ORCID, true, false)
+				// normalize to HTTPS URL, drop invalid ORCID iDs
+				ID, _ = orcidutils.ValidateORCID(v.ORCID)
 			}
 			if v.Name != "" {
 				Type = "Organization"
@@ -544,8 +867,13 @@ func Read(content Content) (commonmeta.Data, error) {
 			if len(v.Affiliation) > 0 {
 				for _, a := range v.Affiliation {
 					var ID string
-					if len(a.ID) > 0 && a.ID[0].IDType == "ROR" {
-						ID = utils.NormalizeROR(a.ID[0].ID)
+					for _, i := range a.ID {
+						if i.IDType == "ROR" {
+							ID = utils.NormalizeROR(i.ID)
+							break
+						} else if i.IDType == "ISNI" && ID == "" {
+							ID = isniutils.NormalizeISNI(i.ID)
+						}
 					}
 					if a.Name != "" {
 						affiliations = append(affiliations, &commonmeta.Affiliation{
@@ -592,7 +920,7 @@ func Read(content Content) (commonmeta.Data, error) {
 	}
 
 	if content.Abstract != "" {
-		abstract := utils.Sanitize(content.Abstract)
+		abstract := textutils.StripMarkup(content.Abstract, textutils.WithFormatting())
 		data.Descriptions = append(data.Descriptions, commonmeta.Description{
 			Description: abstract,
 			Type:        "Abstract",
@@ -611,7 +939,7 @@ func Read(content Content) (commonmeta.Data, error) {
 		data.Files = utils.DedupeSlice(data.Files)
 	}
 
-	if len(content.Funder) > 1 {
+	if len(content.Funder) > 0 {
 		for _, v := range content.Funder {
 			funderIdentifier := doiutils.NormalizeDOI(v.DOI)
 			var funderIdentifierType string
@@ -635,20 +963,26 @@ func Read(content Content) (commonmeta.Data, error) {
 				})
 			}
 		}
-		// if len(content.Funder) > 1 {
 		data.FundingReferences = utils.DedupeSlice(data.FundingReferences)
-		// }
 	}
 
 	data.Identifiers = append(data.Identifiers, commonmeta.Identifier{
 		Identifier:     data.ID,
 		IdentifierType: "DOI",
 	})
+	// alternative IDs are mostly publisher IDs without type
+	for _, v := range content.AlternativeID {
+		if v != "" {
+			data.Identifiers = append(data.Identifiers, commonmeta.Identifier{
+				Identifier:     v,
+				IdentifierType: commonmeta.GetIdentifierType(v, ""),
+			})
+		}
+	}
 
-	data.Language = content.Language
+	data.Language = langutils.GetLanguage(content.Language, langutils.ISO6391)
 	if content.License != nil && len(content.License) > 0 {
-		url, _ := utils.NormalizeCCUrl(content.License[0].URL)
-		id := utils.URLToSPDX(url)
+		id, url := spdxutils.Normalize(content.License[0].URL)
 		data.License = commonmeta.License{
 			ID:  id,
 			URL: url,
@@ -664,11 +998,29 @@ func Read(content Content) (commonmeta.Data, error) {
 	}
 
 	for _, v := range content.Reference {
+		// books are cited with their volume title, articles with the
+		// journal title as container
+		title := v.ArticleTitle
+		containerTitle := v.JournalTitle
+		if title == "" {
+			title = v.VolumeTitle
+		} else if containerTitle == "" {
+			containerTitle = v.VolumeTitle
+		}
+		if containerTitle == "" {
+			containerTitle = v.SeriesTitle
+		}
 		reference := commonmeta.Reference{
 			Key:             v.Key,
 			ID:              doiutils.NormalizeDOI(v.DOI),
-			Title:           v.ArticleTitle,
+			Contributor:     v.Author,
+			Title:           title,
 			PublicationYear: v.Year,
+			Volume:          v.Volume,
+			Issue:           v.Issue,
+			FirstPage:       v.FirstPage,
+			ContainerTitle:  containerTitle,
+			Edition:         v.Edition,
 			Unstructured:    v.Unstructured,
 		}
 		containsKey := slices.ContainsFunc(data.References, func(e commonmeta.Reference) bool {
@@ -708,9 +1060,9 @@ func Read(content Content) (commonmeta.Data, error) {
 			})
 		}
 	}
-	if data.Container.IdentifierType == "ISSN" {
+	if issn != "" {
 		data.Relations = append(data.Relations, commonmeta.Relation{
-			ID:   utils.ISSNAsURL(data.Container.Identifier),
+			ID:   utils.ISSNAsURL(issn),
 			Type: "IsPartOf",
 		})
 	}
@@ -732,18 +1084,18 @@ func Read(content Content) (commonmeta.Data, error) {
 
 	if len(content.Title) > 0 && content.Title[0] != "" {
 		data.Titles = append(data.Titles, commonmeta.Title{
-			Title: content.Title[0],
+			Title: textutils.DecodeEntities(content.Title[0]),
 		})
 	}
 	if len(content.Subtitle) > 0 && content.Subtitle[0] != "" {
 		data.Titles = append(data.Titles, commonmeta.Title{
-			Title: content.Subtitle[0],
+			Title: textutils.DecodeEntities(content.Subtitle[0]),
 			Type:  "Subtitle",
 		})
 	}
 	if len(content.OriginalTitle) > 0 && content.OriginalTitle[0] != "" {
 		data.Titles = append(data.Titles, commonmeta.Title{
-			Title: content.OriginalTitle[0],
+			Title: textutils.DecodeEntities(content.OriginalTitle[0]),
 			Type:  "TranslatedTitle",
 		})
 	}
@@ -801,7 +1153,7 @@ func QueryURL(number int, member string, _type string, sample bool, hasORCID boo
 		"standard",
 	}
 
-	u, _ := url.Parse("https://api.crossref.org/works")
+	u, _ := url.Parse(BaseURL + "/works")
 	values := u.Query()
 	if sample {
 		values.Add("sample", strconv.Itoa(number))
@@ -879,3 +1231,12 @@ func GetMember(memberId string) (string, bool) {
 	}
 	return result.Message.PrimaryName, true
 }
+
+// preferredIdentifier returns the electronic ISSN or ISBN if there is one,
+// otherwise the print ISSN or ISBN.
+func preferredIdentifier(identifiers map[string]string) string {
+	if identifiers["electronic"] != "" {
+		return identifiers["electronic"]
+	}
+	return identifiers["print"]
+}
@@ -1,14 +1,24 @@
 package crossref_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/front-matter/commonmeta/commonmeta"
 	"github.com/front-matter/commonmeta/crossref"
+	"github.com/front-matter/commonmeta/csl"
+	"github.com/front-matter/commonmeta/datacite"
 	"github.com/front-matter/commonmeta/doiutils"
 
 	"github.com/google/go-cmp/cmp"
@@ -178,3 +188,667 @@ func ExampleGetMember() {
 	// Output:
 	// Public Library of Science (PLoS)
 }
+
+func TestFetchCrossrefList(t *testing.T) {
+	t.Parallel()
+
+	var active, maxActive int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		for {
+			m := atomic.LoadInt32(&maxActive)
+			if n <= m || atomic.CompareAndSwapInt32(&maxActive, m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		doi := strings.TrimPrefix(r.URL.Path, "/works/")
+		if doi == "10.5555/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"status": "ok", "message-type": "work", "message": {"DOI": %q, "type": "journal-article", "title": ["Title of %s"]}}`, doi, doi)
+	}))
+	defer server.Close()
+
+	dois := []string{"10.5555/1", "10.5555/2", "10.5555/missing", "10.5555/3", "10.5555/4", "10.5555/5", "10.5555/6"}
+	workers := 2
+	data, err := crossref.FetchCrossrefList(dois, workers, crossref.WithBaseURL(server.URL))
+	if err == nil || !strings.Contains(err.Error(), "10.5555/missing") {
+		t.Errorf("FetchCrossrefList: want error for 10.5555/missing, got %v", err)
+	}
+	var got []string
+	for _, d := range data {
+		got = append(got, d.ID)
+	}
+	want := []string{
+		"https://doi.org/10.5555/1",
+		"https://doi.org/10.5555/2",
+		"https://doi.org/10.5555/3",
+		"https://doi.org/10.5555/4",
+		"https://doi.org/10.5555/5",
+		"https://doi.org/10.5555/6",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("FetchCrossrefList mismatch (-want +got):\n%s", diff)
+	}
+	if maxActive > int32(workers) {
+		t.Errorf("FetchCrossrefList: want at most %d concurrent requests, got %d", workers, maxActive)
+	}
+	if maxActive < 2 {
+		t.Errorf("FetchCrossrefList: want concurrent requests, got %d", maxActive)
+	}
+}
+
+func TestFetchWithCache(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		doi := strings.TrimPrefix(r.URL.Path, "/works/")
+		fmt.Fprintf(w, `{"status": "ok", "message-type": "work", "message": {"DOI": %q, "type": "journal-article", "title": ["Title"]}}`, doi)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	first, err := crossref.Fetch("10.5555/12345678", crossref.WithCache(dir, time.Hour), crossref.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := crossref.Fetch("10.5555/12345678", crossref.WithCache(dir, time.Hour), crossref.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 1 {
+		t.Errorf("Fetch with cache: want 1 request, got %d", requests)
+	}
+	if diff := cmp.Diff(first, second); diff != "" {
+		t.Errorf("Fetch with cache mismatch (-want +got):\n%s", diff)
+	}
+
+	// without cache the API is called again
+	_, err = crossref.Fetch("10.5555/12345678", crossref.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 2 {
+		t.Errorf("Fetch without cache: want 2 requests, got %d", requests)
+	}
+}
+
+func TestFetchWithRetry(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) <= 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		doi := strings.TrimPrefix(r.URL.Path, "/works/")
+		fmt.Fprintf(w, `{"status": "ok", "message-type": "work", "message": {"DOI": %q, "type": "journal-article", "title": ["Title"]}}`, doi)
+	}))
+	defer server.Close()
+
+	// fails with a single attempt
+	_, err := crossref.Fetch("10.5555/12345678", crossref.WithRetry(1, time.Millisecond), crossref.WithBaseURL(server.URL))
+	if err == nil {
+		t.Errorf("Fetch without retry: want error, got none")
+	}
+
+	atomic.StoreInt32(&requests, 0)
+	data, err := crossref.Fetch("10.5555/12345678", crossref.WithRetry(3, time.Millisecond), crossref.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("Fetch with retry: error %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("Fetch with retry: want 3 requests, got %d", requests)
+	}
+	if data.ID != "https://doi.org/10.5555/12345678" {
+		t.Errorf("Fetch with retry ID: want https://doi.org/10.5555/12345678, got %v", data.ID)
+	}
+}
+
+func TestFetchContentNegotiation(t *testing.T) {
+	t.Parallel()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer api.Close()
+	resolver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "application/vnd.citationstyles.csl+json" {
+			w.WriteHeader(http.StatusNotAcceptable)
+			return
+		}
+		doi := strings.TrimPrefix(r.URL.Path, "/")
+		fmt.Fprintf(w, `{"type": "article-journal", "id": "https://doi.org/%[1]s", "DOI": %[1]q, "title": "Toward a Unified Theory of High-Energy Metaphysics: Silly String Theory", "author": [{"given": "Josiah", "family": "Carberry"}], "container-title": "Journal of Psychoceramics", "issued": {"date-parts": [[2008, 8, 13]]}}`, doi)
+	}))
+	defer resolver.Close()
+
+	// the REST API is rate-limited
+	_, err := crossref.Fetch("10.5555/12345678", crossref.WithRetry(1, time.Millisecond), crossref.WithBaseURL(api.URL))
+	if err == nil {
+		t.Errorf("Fetch without content negotiation: want error, got none")
+	}
+
+	got, err := crossref.Fetch("10.5555/12345678", crossref.WithRetry(1, time.Millisecond), crossref.WithContentNegotiation(), crossref.WithBaseURL(api.URL), crossref.WithResolverURL(resolver.URL))
+	if err != nil {
+		t.Fatalf("Fetch with content negotiation: error %v", err)
+	}
+	if got.ID != "https://doi.org/10.5555/12345678" {
+		t.Errorf("Fetch with content negotiation ID: want https://doi.org/10.5555/12345678, got %v", got.ID)
+	}
+	if got.Type != "JournalArticle" {
+		t.Errorf("Fetch with content negotiation type: want JournalArticle, got %v", got.Type)
+	}
+	wantContributors := []commonmeta.Contributor{
+		{Type: "Person", GivenName: "Josiah", FamilyName: "Carberry", ContributorRoles: []string{"Author"}},
+	}
+	if diff := cmp.Diff(wantContributors, got.Contributors); diff != "" {
+		t.Errorf("Fetch with content negotiation contributors mismatch (-want +got):\n%s", diff)
+	}
+	if got.Container.Title != "Journal of Psychoceramics" || got.Date.Published != "2008-08-13" {
+		t.Errorf("Fetch with content negotiation: want Journal of Psychoceramics 2008-08-13, got %v %v", got.Container.Title, got.Date.Published)
+	}
+}
+
+func TestReadFunders(t *testing.T) {
+	t.Parallel()
+	input := `{"DOI":"10.5555/funded","type":"journal-article","title":["A funded article"],"funder":[{"DOI":"10.13039/100000001","name":"National Science Foundation","award":["1234567"]},{"name":"Wellcome Trust","award":["WT-089"]}]}`
+	var content crossref.Content
+	if err := json.Unmarshal([]byte(input), &content); err != nil {
+		t.Fatal(err)
+	}
+	got, err := crossref.Read(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []commonmeta.FundingReference{
+		{FunderIdentifier: "https://doi.org/10.13039/100000001", FunderIdentifierType: "Crossref Funder ID", FunderName: "National Science Foundation", AwardNumber: "1234567"},
+		{FunderName: "Wellcome Trust", AwardNumber: "WT-089"},
+	}
+	if diff := cmp.Diff(want, got.FundingReferences); diff != "" {
+		t.Errorf("Read funders mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadISSNL(t *testing.T) {
+	t.Parallel()
+	// the electronic ISSN of Nature is mapped to its linking ISSN
+	input := `{"DOI":"10.1038/nature12373","type":"journal-article","title":["An article"],"container-title":["Nature"],"issn-type":[{"value":"0028-0836","type":"print"},{"value":"1476-4687","type":"electronic"}]}`
+	var content crossref.Content
+	if err := json.Unmarshal([]byte(input), &content); err != nil {
+		t.Fatal(err)
+	}
+	got, err := crossref.Read(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := commonmeta.Container{Identifier: "0028-0836", IdentifierType: "ISSN", Type: "Journal", Title: "Nature"}
+	if diff := cmp.Diff(want, got.Container); diff != "" {
+		t.Errorf("Read ISSN-L container mismatch (-want +got):\n%s", diff)
+	}
+	wantRelations := []commonmeta.Relation{{ID: "https://portal.issn.org/resource/ISSN/0028-0836", Type: "IsPartOf"}}
+	if diff := cmp.Diff(wantRelations, got.Relations); diff != "" {
+		t.Errorf("Read ISSN-L relations mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadAbstract(t *testing.T) {
+	t.Parallel()
+	input := `{"DOI":"10.5555/abstract","type":"journal-article","title":["An article"],"abstract":"<jats:p>First paragraph with <jats:italic>emphasis</jats:italic>.</jats:p>\n<jats:p>Second paragraph.</jats:p>"}`
+	var content crossref.Content
+	if err := json.Unmarshal([]byte(input), &content); err != nil {
+		t.Fatal(err)
+	}
+	got, err := crossref.Read(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []commonmeta.Description{
+		{Description: "First paragraph with <i>emphasis</i>.\n\nSecond paragraph.", Type: "Abstract"},
+	}
+	if diff := cmp.Diff(want, got.Descriptions); diff != "" {
+		t.Errorf("Read abstract mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadTitleEntities(t *testing.T) {
+	t.Parallel()
+	input := `{"DOI":"10.5555/entities","type":"journal-article","title":["Fish &amp; Chips, 1850&#x2013;1900"],"subtitle":["Caf&eacute; culture &amp;amp; more"]}`
+	var content crossref.Content
+	if err := json.Unmarshal([]byte(input), &content); err != nil {
+		t.Fatal(err)
+	}
+	got, err := crossref.Read(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []commonmeta.Title{
+		{Title: "Fish & Chips, 1850–1900"},
+		{Title: "Café culture &amp; more", Type: "Subtitle"},
+	}
+	if diff := cmp.Diff(want, got.Titles); diff != "" {
+		t.Errorf("Read title entities mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadIdentifiers(t *testing.T) {
+	t.Parallel()
+	input := `{"DOI":"10.7554/elife.01567","type":"journal-article","title":["An article"],"alternative-id":["e01567","PMC3926531"]}`
+	var content crossref.Content
+	if err := json.Unmarshal([]byte(input), &content); err != nil {
+		t.Fatal(err)
+	}
+	got, err := crossref.Read(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []commonmeta.Identifier{
+		{Identifier: "https://doi.org/10.7554/elife.01567", IdentifierType: "DOI"},
+		{Identifier: "e01567", IdentifierType: "Other"},
+		{Identifier: "PMC3926531", IdentifierType: "PMCID"},
+	}
+	if diff := cmp.Diff(want, got.Identifiers); diff != "" {
+		t.Errorf("Read identifiers mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadRelations(t *testing.T) {
+	t.Parallel()
+	input := `{"DOI":"10.5555/dataset","type":"dataset","title":["A dataset"],"relation":{"is-supplement-to":[{"id":"10.1371/journal.ppat.1000446","id-type":"doi"}],"is-new-version-of":[{"id":"10.5555/dataset.v1","id-type":"doi"}]}}`
+	var content crossref.Content
+	if err := json.Unmarshal([]byte(input), &content); err != nil {
+		t.Fatal(err)
+	}
+	got, err := crossref.Read(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []commonmeta.Relation{
+		{ID: "https://doi.org/10.5555/dataset.v1", Type: "IsNewVersionOf"},
+		{ID: "https://doi.org/10.1371/journal.ppat.1000446", Type: "IsSupplementTo"},
+	}
+	if diff := cmp.Diff(want, got.Relations); diff != "" {
+		t.Errorf("Read relations mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoadReferences(t *testing.T) {
+	t.Parallel()
+	got, err := crossref.Load(filepath.Join("..", "testdata", "crossref", "crossref.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the expected references as returned for the same article by Fetch
+	bytes, err := os.ReadFile(filepath.Join("testdata", "10.7554_elife.01567.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var want commonmeta.Data
+	err = json.Unmarshal(bytes, &want)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.References) != 27 {
+		t.Errorf("Load references: want 27 references, got %d", len(got.References))
+	}
+	if diff := cmp.Diff(want.References, got.References); diff != "" {
+		t.Errorf("Load references mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestQueryWorks(t *testing.T) {
+	t.Parallel()
+
+	// five works on three pages of two, the cursor is the offset of the page
+	var cursors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/works" || query.Get("filter") != "member:78,type:journal-article,from-pub-date:2020" {
+			http.Error(w, "unexpected query", http.StatusBadRequest)
+			return
+		}
+		cursor := query.Get("cursor")
+		cursors = append(cursors, cursor)
+		offset := 0
+		if cursor != "*" {
+			offset, _ = strconv.Atoi(strings.TrimPrefix(cursor, "page-"))
+		}
+		var items []string
+		for i := offset; i < min(offset+2, 5); i++ {
+			items = append(items, fmt.Sprintf(`{"DOI": "10.5555/%d", "type": "journal-article", "title": ["Title %d"]}`, i+1, i+1))
+		}
+		fmt.Fprintf(w, `{"status": "ok", "message-type": "work-list", "message": {"next-cursor": "page-%d", "total-results": 5, "items": [%s]}}`, offset+2, strings.Join(items, ","))
+	}))
+	defer server.Close()
+
+	params := crossref.QueryParams{Member: "78", Type: "journal-article", FromPubDate: "2020", Rows: 2}
+	data, err := crossref.QueryWorks(params, crossref.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, d := range data {
+		got = append(got, d.ID)
+	}
+	want := []string{
+		"https://doi.org/10.5555/1",
+		"https://doi.org/10.5555/2",
+		"https://doi.org/10.5555/3",
+		"https://doi.org/10.5555/4",
+		"https://doi.org/10.5555/5",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("QueryWorks mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"*", "page-2", "page-4"}, cursors); diff != "" {
+		t.Errorf("QueryWorks cursors mismatch (-want +got):\n%s", diff)
+	}
+
+	// stop following the cursor when the limit is reached
+	cursors = nil
+	params.Limit = 3
+	data, err = crossref.QueryWorks(params, crossref.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data) != 3 || len(cursors) != 2 {
+		t.Errorf("QueryWorks with limit: want 3 works in 2 requests, got %d works in %d requests", len(data), len(cursors))
+	}
+}
+
+func TestFetchWithMailto(t *testing.T) {
+	// not parallel, as the test changes the environment
+
+	var mailto, userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mailto = r.URL.Query().Get("mailto")
+		userAgent = r.Header.Get("User-Agent")
+		doi := strings.TrimPrefix(r.URL.Path, "/works/")
+		fmt.Fprintf(w, `{"status": "ok", "message-type": "work", "message": {"DOI": %q, "type": "journal-article", "title": ["Title"]}}`, doi)
+	}))
+	defer server.Close()
+
+	_, err := crossref.Fetch("10.5555/12345678", crossref.WithMailto("josiah@example.org"), crossref.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mailto != "josiah@example.org" {
+		t.Errorf("Fetch with mailto: want mailto josiah@example.org, got %q", mailto)
+	}
+	if !strings.Contains(userAgent, "mailto: josiah@example.org") {
+		t.Errorf("Fetch with mailto: want mailto in User-Agent, got %q", userAgent)
+	}
+
+	t.Setenv("CROSSREF_MAILTO", "jane@example.org")
+	_, err = crossref.Fetch("10.5555/12345678", crossref.WithUserAgent("harvester/1.0"), crossref.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mailto != "jane@example.org" {
+		t.Errorf("Fetch with CROSSREF_MAILTO: want mailto jane@example.org, got %q", mailto)
+	}
+	if userAgent != "harvester/1.0" {
+		t.Errorf("Fetch with user agent: want harvester/1.0, got %q", userAgent)
+	}
+}
+
+func TestFetchContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+	_, err := crossref.FetchContext(ctx, "10.5555/12345678", crossref.WithTimeout(5*time.Second), crossref.WithBaseURL(server.URL))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("FetchContext: want context.Canceled, got %v", err)
+	}
+}
+
+func TestFetchPreprint(t *testing.T) {
+	t.Parallel()
+
+	content, err := os.ReadFile(filepath.Join("testdata", "works", "10.1101_097196.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(content)
+	}))
+	defer server.Close()
+
+	data, err := crossref.Fetch("https://doi.org/10.1101/097196", crossref.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data.Type != "Article" || data.AdditionalType != "Preprint" {
+		t.Errorf("Fetch preprint: want type Article and additional type Preprint, got %s and %s", data.Type, data.AdditionalType)
+	}
+
+	cslWork, err := csl.Convert(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cslWork.Type != "article" || cslWork.Genre != "preprint" {
+		t.Errorf("Convert preprint to CSL: want type article and genre preprint, got %s and %s", cslWork.Type, cslWork.Genre)
+	}
+
+	dataciteWork, err := datacite.Convert(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := datacite.Types{
+		ResourceTypeGeneral: "Preprint",
+		ResourceType:        "Preprint",
+		SchemaOrg:           "Article",
+		Citeproc:            "article",
+		Bibtex:              "article",
+		Ris:                 "JOUR",
+	}
+	if diff := cmp.Diff(want, dataciteWork.Types); diff != "" {
+		t.Errorf("Convert preprint to DataCite mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFetchBookChapter(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		filename      string
+		wantContainer commonmeta.Container
+		wantRelations []commonmeta.Relation
+	}
+
+	testCases := []testCase{
+		{
+			name:     "book chapter",
+			filename: "10.1007_978-3-662-46370-3_13.json",
+			wantContainer: commonmeta.Container{
+				Identifier:     "9783662463703",
+				IdentifierType: "ISBN",
+				Type:           "Book",
+				Title:          "Shoulder Stiffness",
+				FirstPage:      "155",
+				LastPage:       "158",
+			},
+		},
+		{
+			name:     "book chapter in book series",
+			filename: "10.5555_12345679.json",
+			wantContainer: commonmeta.Container{
+				Identifier:     "9783319242774",
+				IdentifierType: "ISBN",
+				Type:           "Book",
+				Title:          "Theory and Practice of Digital Libraries",
+				FirstPage:      "16",
+				LastPage:       "27",
+			},
+			wantRelations: []commonmeta.Relation{
+				{ID: "https://portal.issn.org/resource/ISSN/1611-3349", Type: "IsPartOf"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		content, err := os.ReadFile(filepath.Join("testdata", "works", tc.filename))
+		if err != nil {
+			t.Fatal(err)
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write(content)
+		}))
+
+		doi := strings.Replace(strings.TrimSuffix(tc.filename, ".json"), "_", "/", 1)
+		got, err := crossref.Fetch(doi, crossref.WithBaseURL(server.URL))
+		server.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Type != "BookChapter" {
+			t.Errorf("Fetch(%s): want type BookChapter, got %s", tc.name, got.Type)
+		}
+		if diff := cmp.Diff(tc.wantContainer, got.Container); diff != "" {
+			t.Errorf("Fetch(%s) container mismatch (-want +got):\n%s", tc.name, diff)
+		}
+		if diff := cmp.Diff(tc.wantRelations, got.Relations); diff != "" {
+			t.Errorf("Fetch(%s) relations mismatch (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestFetchArticleNumber(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name          string
+		message       string
+		wantContainer commonmeta.Container
+		wantPage      string
+		wantNumber    string
+	}
+
+	testCases := []testCase{
+		{
+			name:    "paged article",
+			message: `{"DOI": "10.5555/12345678", "type": "journal-article", "container-title": ["Journal of Psychoceramics"], "volume": "5", "issue": "11", "page": "1-3"}`,
+			wantContainer: commonmeta.Container{
+				Type:      "Journal",
+				Title:     "Journal of Psychoceramics",
+				Volume:    "5",
+				Issue:     "11",
+				FirstPage: "1",
+				LastPage:  "3",
+			},
+			wantPage: "1-3",
+		},
+		{
+			name:    "article with article number",
+			message: `{"DOI": "10.5555/12345678", "type": "journal-article", "container-title": ["Journal of Psychoceramics"], "volume": "5", "article-number": "e1004123"}`,
+			wantContainer: commonmeta.Container{
+				Type:          "Journal",
+				Title:         "Journal of Psychoceramics",
+				Volume:        "5",
+				ArticleNumber: "e1004123",
+			},
+			wantNumber: "e1004123",
+		},
+	}
+	for _, tc := range testCases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"status": "ok", "message-type": "work", "message": %s}`, tc.message)
+		}))
+
+		got, err := crossref.Fetch("10.5555/12345678", crossref.WithBaseURL(server.URL))
+		server.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(tc.wantContainer, got.Container); diff != "" {
+			t.Errorf("Fetch(%s) container mismatch (-want +got):\n%s", tc.name, diff)
+		}
+		cslWork, err := csl.Convert(got)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cslWork.Page != tc.wantPage || cslWork.Number != tc.wantNumber {
+			t.Errorf("Convert(%s) to CSL: want page %q and number %q, got %q and %q", tc.name, tc.wantPage, tc.wantNumber, cslWork.Page, cslWork.Number)
+		}
+	}
+}
+
+func TestFetchAuthorOrder(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name    string
+		authors string
+		want    []string
+	}
+
+	testCases := []testCase{
+		{
+			name: "source order",
+			authors: `[{"given": "Martin", "family": "Fenner", "sequence": "first"},
+				{"given": "Mercè", "family": "Crosas", "sequence": "additional"},
+				{"given": "Jeffrey", "family": "Grethe", "sequence": "additional"},
+				{"given": "David", "family": "Kennedy", "sequence": "additional"},
+				{"given": "Henning", "family": "Hermjakob", "sequence": "additional"}]`,
+			want: []string{"Fenner", "Crosas", "Grethe", "Kennedy", "Hermjakob"},
+		},
+		{
+			name: "first author not listed first",
+			authors: `[{"given": "Mercè", "family": "Crosas", "sequence": "additional"},
+				{"given": "Jeffrey", "family": "Grethe", "sequence": "additional"},
+				{"given": "Martin", "family": "Fenner", "sequence": "first"},
+				{"given": "David", "family": "Kennedy", "sequence": "additional"},
+				{"given": "Henning", "family": "Hermjakob", "sequence": "additional"}]`,
+			want: []string{"Fenner", "Crosas", "Grethe", "Kennedy", "Hermjakob"},
+		},
+	}
+	for _, tc := range testCases {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"status": "ok", "message-type": "work", "message": {"DOI": "10.1101/097196", "type": "posted-content", "author": %s}}`, tc.authors)
+		}))
+
+		data, err := crossref.Fetch("10.1101/097196", crossref.WithBaseURL(server.URL))
+		server.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, v := range data.Contributors {
+			got = append(got, v.FamilyName)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("Fetch(%s) author order mismatch (-want +got):\n%s", tc.name, diff)
+		}
+		cslWork, err := csl.Convert(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = nil
+		for _, v := range cslWork.Author {
+			got = append(got, v.Family)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("Convert(%s) to CSL author order mismatch (-want +got):\n%s", tc.name, diff)
+		}
+		if author, ok := data.FirstAuthor(); !ok || author.FamilyName != tc.want[0] {
+			t.Errorf("FirstAuthor(%s): want %s, got %s", tc.name, tc.want[0], author.FamilyName)
+		}
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"log/slog"
 	"net/http"
 	"os"
 	"path"
@@ -17,6 +18,10 @@ import (
 	"github.com/front-matter/commonmeta/commonmeta"
 	"github.com/front-matter/commonmeta/dateutils"
 	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/isbnutils"
+	"github.com/front-matter/commonmeta/isniutils"
+	"github.com/front-matter/commonmeta/langutils"
+	"github.com/front-matter/commonmeta/spdxutils"
 	"github.com/front-matter/commonmeta/utils"
 )
 
@@ -93,8 +98,8 @@ type Abstract struct {
 type AcceptanceDate struct {
 	XMLName   xml.Name `xml:"acceptance_date"`
 	MediaType string   `xml:"media_type,attr"`
-	Month     string   `xml:"month"`
-	Day       string   `xml:"day"`
+	Month     string   `xml:"month,omitempty"`
+	Day       string   `xml:"day,omitempty"`
 	Year      string   `xml:"year"`
 }
 
@@ -111,8 +116,8 @@ type Affiliations struct {
 
 type ApprovalDate struct {
 	XMLName xml.Name `xml:"approval_date"`
-	Month   string   `xml:"month"`
-	Day     string   `xml:"day"`
+	Month   string   `xml:"month,omitempty"`
+	Day     string   `xml:"day,omitempty"`
 	Year    string   `xml:"year"`
 }
 
@@ -150,13 +155,14 @@ type Book struct {
 
 type BookMetadata struct {
 	XMLName         xml.Name          `xml:"book_metadata"`
-	Language        string            `xml:"language,attr"`
+	Language        string            `xml:"language,attr,omitempty"`
 	Contributors    Contributors      `xml:"contributors"`
 	Titles          Titles            `xml:"titles"`
 	Abstract        []Abstract        `xml:"abstract"`
-	EditionNumber   int               `xml:"edition_number"`
+	EditionNumber   int               `xml:"edition_number,omitempty"`
 	PublicationDate []PublicationDate `xml:"publication_date"`
 	ISBN            []ISBN            `xml:"isbn"`
+	NoISBN          *NoISBN           `xml:"noisbn,omitempty"`
 	Publisher       Publisher         `xml:"publisher"`
 	DOIData         DOIData           `xml:"doi_data"`
 }
@@ -236,24 +242,21 @@ type ConferencePaper struct {
 type ContentItem struct {
 	XMLName             xml.Name          `xml:"content_item"`
 	ComponentType       string            `xml:"component_type,attr"`
-	LevelSequenceNumber string            `xml:"level_sequence_number,attr"`
-	PublicationType     string            `xml:"publication_type,attr"`
+	LevelSequenceNumber string            `xml:"level_sequence_number,attr,omitempty"`
+	PublicationType     string            `xml:"publication_type,attr,omitempty"`
 	Contributors        Contributors      `xml:"contributors"`
 	Titles              Titles            `xml:"titles"`
 	PublicationDate     []PublicationDate `xml:"publication_date"`
-	Pages               struct {
-		FirstPage string `xml:"first_page"`
-		LastPage  string `xml:"last_page"`
-	} `xml:"pages"`
-	DOIData      DOIData      `xml:"doi_data"`
-	CitationList CitationList `xml:"citation_list,omitempty"`
+	Pages               Pages             `xml:"pages"`
+	DOIData             DOIData           `xml:"doi_data"`
+	CitationList        CitationList      `xml:"citation_list,omitempty"`
 }
 
 type CreationDate struct {
 	XMLName   xml.Name `xml:"creation_date"`
 	MediaType string   `xml:"media_type,attr"`
-	Month     string   `xml:"month"`
-	Day       string   `xml:"day"`
+	Month     string   `xml:"month,omitempty"`
+	Day       string   `xml:"day,omitempty"`
 	Year      string   `xml:"year"`
 }
 
@@ -375,6 +378,12 @@ type ISBN struct {
 	Text      string   `xml:",chardata"`
 }
 
+// NoISBN indicates that a book has no ISBN in Crossref XML metadata.
+type NoISBN struct {
+	XMLName xml.Name `xml:"noisbn"`
+	Reason  string   `xml:"reason,attr"`
+}
+
 // ISSN represents a ISSN in Crossref XML metadata.
 type ISSN struct {
 	XMLName   xml.Name `xml:"issn"`
@@ -411,10 +420,10 @@ type JournalArticle struct {
 	ReferenceDistributionOpts string            `xml:"reference_distribution_opts,attr,omitempty"`
 	Titles                    Titles            `xml:"titles,omitempty"`
 	Contributors              Contributors      `xml:"contributors,omitempty"`
-	PublicationDate           []PublicationDate `xml:"publication_date"`
-	PublisherItem             *PublisherItem    `xml:"publisher_item,omitempty"`
 	Abstract                  []Abstract        `xml:"jats:abstract"`
+	PublicationDate           []PublicationDate `xml:"publication_date"`
 	Pages                     *Pages            `xml:"pages,omitempty"`
+	PublisherItem             *PublisherItem    `xml:"publisher_item,omitempty"`
 	ISSN                      []ISSN            `xml:"issn"`
 	Program                   []Program         `xml:"program"`
 	Crossmark                 *Crossmark        `xml:"crossmark,omitempty"`
@@ -471,7 +480,7 @@ type P struct {
 
 type Pages struct {
 	FirstPage string `xml:"first_page"`
-	LastPage  string `xml:"last_page"`
+	LastPage  string `xml:"last_page,omitempty"`
 }
 
 type PeerReview struct {
@@ -494,7 +503,7 @@ type PersonName struct {
 	ContributorRole string        `xml:"contributor_role,attr"`
 	Sequence        string        `xml:"sequence,attr"`
 	Text            string        `xml:",chardata"`
-	GivenName       string        `xml:"given_name"`
+	GivenName       string        `xml:"given_name,omitempty"`
 	Surname         string        `xml:"surname"`
 	Affiliations    *Affiliations `xml:"affiliations,omitempty"`
 	Affiliation     string        `xml:"affiliation,omitempty"`
@@ -522,8 +531,8 @@ type PostedContent struct {
 type PostedDate struct {
 	XMLName   xml.Name `xml:"posted_date"`
 	MediaType string   `xml:"media_type,attr"`
-	Month     string   `xml:"month"`
-	Day       string   `xml:"day"`
+	Month     string   `xml:"month,omitempty"`
+	Day       string   `xml:"day,omitempty"`
 	Year      string   `xml:"year"`
 }
 
@@ -554,15 +563,15 @@ type Program struct {
 type PublicationDate struct {
 	XMLName   xml.Name `xml:"publication_date"`
 	MediaType string   `xml:"media_type,attr"`
-	Month     string   `xml:"month"`
-	Day       string   `xml:"day"`
+	Month     string   `xml:"month,omitempty"`
+	Day       string   `xml:"day,omitempty"`
 	Year      string   `xml:"year"`
 }
 
 type Publisher struct {
 	XMLName        xml.Name `xml:"publisher"`
 	PublisherName  string   `xml:"publisher_name"`
-	PublisherPlace string   `xml:"publisher_place"`
+	PublisherPlace string   `xml:"publisher_place,omitempty"`
 }
 
 type PublisherItem struct {
@@ -592,8 +601,8 @@ type Resource struct {
 
 type ReviewDate struct {
 	XMLDate xml.Name `xml:"review_date"`
-	Month   string   `xml:"month"`
-	Day     string   `xml:"day"`
+	Month   string   `xml:"month,omitempty"`
+	Day     string   `xml:"day,omitempty"`
 	Year    string   `xml:"year"`
 }
 
@@ -740,12 +749,11 @@ func Get(pid string) (Query, error) {
 	defer resp.Body.Close()
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		fmt.Println("error:", err)
 		return query, err
 	}
 	err = xml.Unmarshal(body, &crossrefResult)
 	if err != nil {
-		fmt.Println("error:", err)
+		slog.Error("error unmarshalling XML", "error", err)
 	}
 	query = crossrefResult.QueryResult.Body.Query
 	return query, err
@@ -981,8 +989,11 @@ func Read(query Query) (commonmeta.Data, error) {
 		if i == -1 {
 			i = 0
 		}
-		identifier = isbn[i].Text
-		identifierType = "ISBN"
+		// normalize to ISBN-13, drop invalid ISBNs
+		if isbn13 := isbnutils.NormalizeISBN(isbn[i].Text); isbn13 != "" {
+			identifier = isbn13
+			identifierType = "ISBN"
+		}
 	}
 
 	data.Container = commonmeta.Container{
@@ -995,6 +1006,9 @@ func Read(query Query) (commonmeta.Data, error) {
 		FirstPage:      pages.FirstPage,
 		LastPage:       pages.LastPage,
 	}
+	if itemNumber.ItemNumberType == "article_number" {
+		data.Container.ArticleNumber = itemNumber.Text
+	}
 
 	if len(contributors.PersonName) > 0 {
 		contrib, err := GetContributors(contributors)
@@ -1077,7 +1091,7 @@ func Read(query Query) (commonmeta.Data, error) {
 		})
 	}
 
-	data.Language = language
+	data.Language = langutils.GetLanguage(language, langutils.ISO6391)
 
 	if len(accessIndicators.LicenseRef) > 0 {
 		// find the first license that applies to the version of record, use the first license if no license is found
@@ -1085,8 +1099,7 @@ func Read(query Query) (commonmeta.Data, error) {
 		if i == -1 {
 			i = 0
 		}
-		url, _ := utils.NormalizeCCUrl(accessIndicators.LicenseRef[i].Text)
-		id := utils.URLToSPDX(url)
+		id, url := spdxutils.Normalize(accessIndicators.LicenseRef[i].Text)
 		data.License = commonmeta.License{
 			ID:  id,
 			URL: url,
@@ -1335,9 +1348,15 @@ func GetContributors(contrib Contributors) ([]commonmeta.Contributor, error) {
 					for _, i := range v.Affiliations.Institution {
 						if i.InstitutionName != "" {
 							if i.InstitutionID != nil && i.InstitutionID.Text != "" {
-								ID = utils.NormalizeROR(i.InstitutionID.Text)
+								var affiliationID string
+								switch i.InstitutionID.Type {
+								case "isni":
+									affiliationID = isniutils.NormalizeISNI(i.InstitutionID.Text)
+								default:
+									affiliationID = utils.NormalizeROR(i.InstitutionID.Text)
+								}
 								affiliations = append(affiliations, &commonmeta.Affiliation{
-									ID:   ID,
+									ID:   affiliationID,
 									Name: i.InstitutionName,
 								})
 							} else {
@@ -3,8 +3,8 @@ package crossrefxml
 import (
 	"bytes"
 	"encoding/xml"
-	"fmt"
 	"io"
+	"log/slog"
 	"mime/multipart"
 	"net/http"
 	"slices"
@@ -37,8 +37,8 @@ type DOIBatch struct {
 	XMLName        xml.Name `xml:"doi_batch"`
 	Xmlns          string   `xml:"xmlns,attr,omitempty"`
 	Version        string   `xml:"version,attr,omitempty"`
-	Xsi            string   `xml:"xsi,attr,omitempty"`
-	SchemaLocation string   `xml:"schemaLocation,attr,omitempty"`
+	Xsi            string   `xml:"xmlns:xsi,attr,omitempty"`
+	SchemaLocation string   `xml:"xsi:schemaLocation,attr,omitempty"`
 	Head           Head     `xml:"head"`
 	Body           Body     `xml:"body"`
 }
@@ -137,7 +137,7 @@ func Convert(data commonmeta.Data) (Body, error) {
 					personName = append(personName, PersonName{
 						ContributorRole: contributorRole,
 						Sequence:        sequence,
-						ORCID:           contributor.ID,
+						ORCID:           utils.NormalizeORCID(contributor.ID),
 						GivenName:       contributor.GivenName,
 						Surname:         contributor.FamilyName,
 						Affiliations:    &affiliations,
@@ -146,7 +146,7 @@ func Convert(data commonmeta.Data) (Body, error) {
 					personName = append(personName, PersonName{
 						ContributorRole: contributorRole,
 						Sequence:        sequence,
-						ORCID:           contributor.ID,
+						ORCID:           utils.NormalizeORCID(contributor.ID),
 						GivenName:       contributor.GivenName,
 						Surname:         contributor.FamilyName,
 					})
@@ -184,7 +184,7 @@ func Convert(data commonmeta.Data) (Body, error) {
 	}
 
 	var issn []ISSN
-	if data.Container.IdentifierType == "issn" {
+	if strings.EqualFold(data.Container.IdentifierType, "ISSN") {
 		issn = append(issn, ISSN{
 			MediaType: "electronic",
 			Text:      data.Container.Identifier,
@@ -213,8 +213,11 @@ func Convert(data commonmeta.Data) (Body, error) {
 		}
 	}
 
-	institution := &Institution{
-		InstitutionName: data.Publisher.Name,
+	var institution *Institution
+	if data.Publisher.Name != "" {
+		institution = &Institution{
+			InstitutionName: data.Publisher.Name,
+		}
 	}
 
 	program := []Program{}
@@ -222,18 +225,15 @@ func Convert(data commonmeta.Data) (Body, error) {
 		assertion := []Assertion{}
 		for _, fundingReference := range data.FundingReferences {
 			a := []Assertion{}
-			f := Assertion{}
-			fi := Assertion{}
+			f := Assertion{
+				Name: "funder_name",
+				Text: fundingReference.FunderName,
+			}
 			if fundingReference.FunderIdentifier != "" {
-				fi = Assertion{
+				f.Assertion = append(f.Assertion, Assertion{
 					Name: "funder_identifier",
 					Text: fundingReference.FunderIdentifier,
-				}
-			}
-			f = Assertion{
-				Name:      "funder_name",
-				Text:      fundingReference.FunderName,
-				Assertion: []Assertion{fi},
+				})
 			}
 			a = append(a, f)
 			if fundingReference.AwardNumber != "" {
@@ -319,11 +319,13 @@ func Convert(data commonmeta.Data) (Body, error) {
 				relatedItem = append(relatedItem, r)
 			}
 		}
-		program = append(program, Program{
-			Name:        "relations",
-			Xmlns:       "http://www.crossref.org/relations.xsd",
-			RelatedItem: relatedItem,
-		})
+		if len(relatedItem) > 0 {
+			program = append(program, Program{
+				Name:        "relations",
+				Xmlns:       "http://www.crossref.org/relations.xsd",
+				RelatedItem: relatedItem,
+			})
+		}
 	}
 
 	citationList := CitationList{}
@@ -331,15 +333,18 @@ func Convert(data commonmeta.Data) (Body, error) {
 		for _, v := range data.References {
 			d, _ := doiutils.ValidateDOI(v.ID)
 			if d != "" || v.Unstructured != "" {
-				citationList.Citation = append(citationList.Citation, Citation{
-					Key: v.Key,
-					DOI: &DOI{
-						Text: d,
-					},
+				citation := Citation{
+					Key:                v.Key,
 					ArticleTitle:       v.Title,
 					CYear:              v.PublicationYear,
 					UnstructedCitation: v.Unstructured,
-				})
+				}
+				if d != "" {
+					citation.DOI = &DOI{
+						Text: d,
+					}
+				}
+				citationList.Citation = append(citationList.Citation, citation)
 			}
 		}
 	}
@@ -350,14 +355,32 @@ func Convert(data commonmeta.Data) (Body, error) {
 			if title.Type == "Subtitle" {
 				titles.Subtitle = title.Title
 			} else if title.Type == "TranslatedTitle" {
-				titles.OriginalLanguageTitle.Text = title.Title
-				titles.OriginalLanguageTitle.Language = title.Language
+				titles.OriginalLanguageTitle = &OriginalLanguageTitle{
+					Text:     title.Title,
+					Language: title.Language,
+				}
 			} else {
 				titles.Title = title.Title
 			}
 		}
 	}
 
+	var publicationDate []PublicationDate
+	if data.Date.Published != "" {
+		datePublished := getDateStruct(data.Date.Published)
+		publicationDate = append(publicationDate, PublicationDate{
+			MediaType: "online",
+			Year:      datePublished.Year,
+			Month:     datePublished.Month,
+			Day:       datePublished.Day,
+		})
+	}
+
+	pages := Pages{
+		FirstPage: data.Container.FirstPage,
+		LastPage:  data.Container.LastPage,
+	}
+
 	switch data.Type {
 	case "Article":
 		var groupTitle string
@@ -366,7 +389,7 @@ func Convert(data commonmeta.Data) (Body, error) {
 		}
 		var postedDate PostedDate
 		if len(data.Date.Published) > 0 {
-			datePublished := dateutils.GetDateStruct(data.Date.Published)
+			datePublished := getDateStruct(data.Date.Published)
 			postedDate = PostedDate{
 				MediaType: "online",
 				Year:      datePublished.Year,
@@ -374,8 +397,12 @@ func Convert(data commonmeta.Data) (Body, error) {
 				Day:       datePublished.Day,
 			}
 		}
+		postedContentType := "other"
+		if data.AdditionalType == "Preprint" {
+			postedContentType = "preprint"
+		}
 		c.PostedContent = append(c.PostedContent, PostedContent{
-			Type:       "other",
+			Type:       postedContentType,
 			Language:   data.Language,
 			GroupTitle: groupTitle,
 			Contributors: Contributors{
@@ -392,9 +419,55 @@ func Convert(data commonmeta.Data) (Body, error) {
 			CitationList: citationList,
 		})
 	case "Book":
-		c.Book = append(c.Book, Book{})
+		isbn, noISBN := getISBN(data, "monograph")
+		c.Book = append(c.Book, Book{
+			BookType: "monograph",
+			BookMetadata: BookMetadata{
+				Language: data.Language,
+				Contributors: Contributors{
+					Organization: organization,
+					PersonName:   personName,
+				},
+				Titles:          titles,
+				Abstract:        abstract,
+				PublicationDate: publicationDate,
+				ISBN:            isbn,
+				NoISBN:          noISBN,
+				Publisher: Publisher{
+					PublisherName: data.Publisher.Name,
+				},
+				DOIData: doiData,
+			},
+		})
 	case "BookChapter":
-		c.Book = append(c.Book, Book{})
+		isbn, noISBN := getISBN(data, "edited_book")
+		c.Book = append(c.Book, Book{
+			BookType: "edited_book",
+			BookMetadata: BookMetadata{
+				Language: data.Language,
+				Titles: Titles{
+					Title: data.Container.Title,
+				},
+				PublicationDate: publicationDate,
+				ISBN:            isbn,
+				NoISBN:          noISBN,
+				Publisher: Publisher{
+					PublisherName: data.Publisher.Name,
+				},
+			},
+			ContentItem: ContentItem{
+				ComponentType: "chapter",
+				Contributors: Contributors{
+					Organization: organization,
+					PersonName:   personName,
+				},
+				Titles:          titles,
+				PublicationDate: publicationDate,
+				Pages:           pages,
+				DOIData:         doiData,
+				CitationList:    citationList,
+			},
+		})
 	case "Component":
 		c.SAComponent = append(c.SAComponent, SAComponent{})
 	case "Dataset":
@@ -427,13 +500,13 @@ func Convert(data commonmeta.Data) (Body, error) {
 				// 	CustomMetadata: customMetadata,
 				// },
 				DOIData: doiData,
-				// Pages:
+				Pages:   &pages,
 				Program: program,
-				// PublicationDate: data.Date.Published,
 				// PublisherItem: PublisherItem{
 				// 	ItemNumber: itemNumber,
 				// },
-				Titles: titles,
+				PublicationDate: publicationDate,
+				Titles:          titles,
 			},
 			JournalMetadata: JournalMetadata{
 				Language:  data.Language,
@@ -441,6 +514,7 @@ func Convert(data commonmeta.Data) (Body, error) {
 				ISSN:      issn,
 			},
 			JournalIssue: JournalIssue{
+				PublicationDate: publicationDate,
 				JournalVolume: JournalVolume{
 					Volume: data.Container.Volume,
 				},
@@ -462,7 +536,7 @@ func Convert(data commonmeta.Data) (Body, error) {
 func Write(data commonmeta.Data, account Account) ([]byte, []gojsonschema.ResultError) {
 	body, err := Convert(data)
 	if err != nil {
-		fmt.Println(err)
+		slog.Error("error converting metadata", "error", err)
 	}
 
 	depositor := Depositor{
@@ -477,10 +551,12 @@ func Write(data commonmeta.Data, account Account) ([]byte, []gojsonschema.Result
 		Registrant: account.Registrant,
 	}
 	doiBatch := DOIBatch{
-		Xmlns:   "http://www.crossref.org/schema/5.3.1",
-		Version: "5.3.1",
-		Head:    head,
-		Body:    body,
+		Xmlns:          "http://www.crossref.org/schema/5.3.1",
+		Version:        "5.3.1",
+		Xsi:            "http://www.w3.org/2001/XMLSchema-instance",
+		SchemaLocation: "http://www.crossref.org/schema/5.3.1 https://www.crossref.org/schemas/crossref5.3.1.xsd",
+		Head:           head,
+		Body:           body,
 	}
 
 	output, _ := xml.MarshalIndent(doiBatch, "", "  ")
@@ -498,7 +574,7 @@ func WriteAll(list []commonmeta.Data, account Account) ([]byte, []gojsonschema.R
 	for _, data := range list {
 		crossref, err := Convert(data)
 		if err != nil {
-			fmt.Println(err)
+			slog.Error("error converting metadata", "error", err)
 		}
 		// workaround to handle the different content types
 		body.Book = append(body.Book, crossref.Book...)
@@ -523,10 +599,12 @@ func WriteAll(list []commonmeta.Data, account Account) ([]byte, []gojsonschema.R
 		Registrant: account.Registrant,
 	}
 	doiBatch := DOIBatch{
-		Xmlns:   "http://www.crossref.org/schema/5.3.1",
-		Version: "5.3.1",
-		Head:    head,
-		Body:    body,
+		Xmlns:          "http://www.crossref.org/schema/5.3.1",
+		Version:        "5.3.1",
+		Xsi:            "http://www.w3.org/2001/XMLSchema-instance",
+		SchemaLocation: "http://www.crossref.org/schema/5.3.1 https://www.crossref.org/schemas/crossref5.3.1.xsd",
+		Head:           head,
+		Body:           body,
 	}
 
 	output, _ := xml.MarshalIndent(doiBatch, "", "  ")
@@ -563,7 +641,6 @@ func Upload(content []byte, account Account) (string, error) {
 	}
 	resp, err := client.Do(req)
 	if err != nil {
-		fmt.Println("Error uploading batch", err)
 		return "", err
 	}
 	defer resp.Body.Close()
@@ -571,7 +648,137 @@ func Upload(content []byte, account Account) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	fmt.Println(string(body))
+	slog.Debug("Crossref deposit response", "body", string(body))
 	message := "Your batch submission was successfully received. " + resp.Status
 	return message, nil
 }
+
+// getDateStruct returns the year, month and day of an ISO 8601 date, leaving
+// out month and day if the date only has year or year-month precision.
+func getDateStruct(str string) dateutils.DateStruct {
+	date := dateutils.GetDateStruct(str)
+	if date.Month == "00" {
+		date.Month = ""
+	}
+	if date.Day == "00" {
+		date.Day = ""
+	}
+	return date
+}
+
+// getISBN returns the ISBN of a book or book chapter, or a noisbn element
+// with the given reason if there is none, as Crossref requires one of the two.
+func getISBN(data commonmeta.Data, reason string) ([]ISBN, *NoISBN) {
+	var isbn []ISBN
+	if data.Container.IdentifierType == "ISBN" && data.Container.Identifier != "" {
+		isbn = append(isbn, ISBN{
+			MediaType: "electronic",
+			Text:      data.Container.Identifier,
+		})
+	}
+	for _, v := range data.Identifiers {
+		if v.IdentifierType == "ISBN" && v.Identifier != "" && !slices.ContainsFunc(isbn, func(i ISBN) bool { return i.Text == v.Identifier }) {
+			isbn = append(isbn, ISBN{
+				MediaType: "electronic",
+				Text:      v.Identifier,
+			})
+		}
+	}
+	if len(isbn) == 0 {
+		return nil, &NoISBN{Reason: reason}
+	}
+	return isbn, nil
+}
+
+// The Crossref schema doesn't allow empty elements for most optional
+// properties, so the following types are only written if they have content.
+
+// MarshalXML omits empty contributors.
+func (c Contributors) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if len(c.Organization) == 0 && len(c.PersonName) == 0 {
+		return nil
+	}
+	type contributors Contributors
+	return e.EncodeElement(contributors(c), start)
+}
+
+// MarshalXML omits empty archive locations.
+func (a ArchiveLocations) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if len(a.Archive) == 0 {
+		return nil
+	}
+	type archiveLocations ArchiveLocations
+	return e.EncodeElement(archiveLocations(a), start)
+}
+
+// MarshalXML omits an empty citation list.
+func (c CitationList) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if len(c.Citation) == 0 {
+		return nil
+	}
+	type citationList CitationList
+	return e.EncodeElement(citationList(c), start)
+}
+
+// MarshalXML omits empty DOI data.
+func (d DOIData) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if d.DOI == "" {
+		return nil
+	}
+	type doiData DOIData
+	return e.EncodeElement(doiData(d), start)
+}
+
+// MarshalXML omits an empty item number.
+func (i ItemNumber) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if i.Text == "" {
+		return nil
+	}
+	type itemNumber ItemNumber
+	return e.EncodeElement(itemNumber(i), start)
+}
+
+// MarshalXML omits a journal issue without volume or issue.
+func (j JournalIssue) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if j.JournalVolume.Volume == "" && j.Issue == "" && j.DOIData == nil {
+		return nil
+	}
+	type journalIssue JournalIssue
+	return e.EncodeElement(journalIssue(j), start)
+}
+
+// MarshalXML omits an empty journal volume.
+func (j JournalVolume) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if j.Volume == "" {
+		return nil
+	}
+	type journalVolume JournalVolume
+	return e.EncodeElement(journalVolume(j), start)
+}
+
+// MarshalXML omits pages without a first page.
+func (p Pages) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if p.FirstPage == "" {
+		return nil
+	}
+	type pages Pages
+	return e.EncodeElement(pages(p), start)
+}
+
+// MarshalXML omits empty book set metadata.
+func (b BookSetMetadata) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if b.SetMetadata.DOIData.DOI == "" && b.SetMetadata.Titles.Title == "" && len(b.ISBN) == 0 {
+		return nil
+	}
+	type bookSetMetadata BookSetMetadata
+	return e.EncodeElement(bookSetMetadata(b), start)
+}
+
+// MarshalXML omits a content item without component type.
+func (c ContentItem) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if c.ComponentType == "" {
+		return nil
+	}
+	type contentItem ContentItem
+	return e.EncodeElement(contentItem(c), start)
+}
@@ -1,5 +1,169 @@
 package crossrefxml_test
 
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/crossrefxml"
+)
+
+var account = crossrefxml.Account{
+	Depositor:  "Front Matter",
+	Email:      "info@front-matter.io",
+	Registrant: "Front Matter",
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name     string
+		filename string
+		want     []string
+	}
+
+	testCases := []testCase{
+		{
+			name:     "journal article",
+			filename: "10.7554_elife.01567.json",
+			want: []string{
+				`<journal_article publication_type="full_text">`,
+				`<full_title>eLife</full_title>`,
+				`<person_name contributor_role="author" sequence="first">`,
+				`<doi>10.7554/elife.01567</doi>`,
+				`<citation_list>`,
+			},
+		},
+		{
+			name:     "book chapter",
+			filename: "10.1007_978-3-662-46370-3_13.json",
+			want: []string{
+				`<book book_type="edited_book">`,
+				`<content_item component_type="chapter">`,
+				`<doi>10.1007/978-3-662-46370-3_13</doi>`,
+			},
+		},
+	}
+	for _, tc := range testCases {
+		data, err := commonmeta.Load(filepath.Join("testdata", tc.filename))
+		if err != nil {
+			t.Fatalf("Crossref XML Write (%v): error %v", tc.name, err)
+		}
+		output, errs := crossrefxml.Write(data, account)
+		if errs != nil {
+			t.Fatalf("Crossref XML Write (%v): error %v", tc.name, errs)
+		}
+		for _, want := range tc.want {
+			if !strings.Contains(string(output), want) {
+				t.Errorf("Crossref XML Write (%v): missing %v", tc.name, want)
+			}
+		}
+		validateXML(t, output)
+	}
+}
+
+func TestWritePreprint(t *testing.T) {
+	t.Parallel()
+	data := commonmeta.Data{
+		ID:             "https://doi.org/10.1101/097196",
+		Type:           "Article",
+		AdditionalType: "Preprint",
+		URL:            "http://biorxiv.org/lookup/doi/10.1101/097196",
+		Contributors: []commonmeta.Contributor{
+			{ID: "https://orcid.org/0000-0001-6444-1436", Type: "Person", GivenName: "Mark", FamilyName: "Rubin", ContributorRoles: []string{"Author"}},
+		},
+		Titles:    []commonmeta.Title{{Title: "The Cost of Replication"}},
+		Publisher: commonmeta.Publisher{Name: "Cold Spring Harbor Laboratory"},
+		Date:      commonmeta.Date{Published: "2016-12-28"},
+	}
+	body, err := crossrefxml.Convert(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(body.PostedContent) != 1 {
+		t.Fatalf("Convert: want 1 posted_content, got %d", len(body.PostedContent))
+	}
+	postedContent := body.PostedContent[0]
+	if postedContent.Type != "preprint" {
+		t.Errorf("Convert posted_content type: want preprint, got %v", postedContent.Type)
+	}
+	if postedContent.DOIData.DOI != "10.1101/097196" {
+		t.Errorf("Convert DOI: want 10.1101/097196, got %v", postedContent.DOIData.DOI)
+	}
+	output, errs := crossrefxml.Write(data, account)
+	if errs != nil {
+		t.Fatal(errs)
+	}
+	want := `<posted_content type="preprint">`
+	if !strings.Contains(string(output), want) {
+		t.Errorf("Write: missing %v in\n%s", want, output)
+	}
+	validateXML(t, output)
+}
+
+// validateXML validates Crossref XML against the Crossref deposit schema in
+// resources/crossref using xmllint. The JATS and MathML schemas imported by
+// the Crossref schema are replaced by permissive stubs, as they are not
+// included in the repository.
+func validateXML(t *testing.T, output []byte) {
+	t.Helper()
+	xmllint, err := exec.LookPath("xmllint")
+	if err != nil {
+		t.Skip("xmllint not found")
+	}
+	dir := t.TempDir()
+	files, err := filepath.Glob(filepath.Join("..", "resources", "crossref", "*.xsd"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	replacer := strings.NewReplacer(
+		`schemaLocation="JATS-journalpublishing1-3d2-mathml3.xsd"`, `schemaLocation="jats-stub.xsd"`,
+		`schemaLocation="http://www.w3.org/Math/XMLSchema/mathml3/mathml3.xsd"`, `schemaLocation="mathml-stub.xsd"`,
+	)
+	for _, file := range files {
+		schema, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(filepath.Join(dir, filepath.Base(file)), []byte(replacer.Replace(string(schema))), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	stubs := map[string][2]string{
+		"jats-stub.xsd":   {"http://www.ncbi.nlm.nih.gov/JATS1", "abstract"},
+		"mathml-stub.xsd": {"http://www.w3.org/1998/Math/MathML", "math"},
+	}
+	for filename, stub := range stubs {
+		schema := `<xsd:schema xmlns:xsd="http://www.w3.org/2001/XMLSchema" targetNamespace="` + stub[0] + `" elementFormDefault="qualified">
+  <xsd:element name="` + stub[1] + `">
+    <xsd:complexType mixed="true">
+      <xsd:sequence>
+        <xsd:any processContents="skip" minOccurs="0" maxOccurs="unbounded"/>
+      </xsd:sequence>
+      <xsd:anyAttribute processContents="skip"/>
+    </xsd:complexType>
+  </xsd:element>
+</xsd:schema>
+`
+		if err := os.WriteFile(filepath.Join(dir, filename), []byte(schema), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	filename := filepath.Join(dir, "output.xml")
+	if err := os.WriteFile(filename, output, 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(xmllint, "--nonet", "--noout", "--schema", filepath.Join(dir, "crossref5.3.1.xsd"), filename)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("XML schema validation failed: %v\n%s", err, out)
+	}
+}
+
 // func TestConvert(t *testing.T) {
 // 	t.Parallel()
 
@@ -1,19 +1,319 @@
-// Package csl provides a function to read CSL JSON and convert it to commonmeta.
+// Package csl provides functions to convert CSL JSON metadata to/from the commonmeta metadata format.
 package csl
 
 import (
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/front-matter/commonmeta/authorutils"
 	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/dateutils"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/langutils"
+	"github.com/front-matter/commonmeta/spdxutils"
+	"github.com/front-matter/commonmeta/textutils"
+	"github.com/front-matter/commonmeta/utils"
 )
 
-type content struct {
-	ID    string `json:"id"`
-	Title string `json:"title"`
+// Content represents the CSL JSON metadata read from a file or API. The type is more
+// flexible than the CSL type, allowing for different formats of some metadata.
+// ISSN can be string or []string, keywords can be provided as categories.
+type Content struct {
+	*CSL
+	Categories []string        `json:"categories,omitempty"`
+	ISSN       json.RawMessage `json:"ISSN,omitempty"`
+}
+
+// Load loads the metadata for a single work from a CSL JSON file
+func Load(filename string) (commonmeta.Data, error) {
+	var data commonmeta.Data
+	var content Content
+
+	extension := path.Ext(filename)
+	if extension != ".json" {
+		return data, errors.New("invalid file extension")
+	}
+	file, err := os.Open(filename)
+	if err != nil {
+		return data, errors.New("error reading file")
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	err = decoder.Decode(&content)
+	if err != nil {
+		return data, err
+	}
+	data, err = Read(content)
+	if err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// LoadAll loads a list of works from a CSL JSON file and converts it to the Commonmeta format
+func LoadAll(filename string) ([]commonmeta.Data, error) {
+	var data []commonmeta.Data
+	var content []Content
+
+	extension := path.Ext(filename)
+	if extension != ".json" {
+		return data, errors.New("invalid file extension")
+	}
+	file, err := os.Open(filename)
+	if err != nil {
+		return data, errors.New("error reading file")
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	err = decoder.Decode(&content)
+	if err != nil {
+		return data, err
+	}
+	data, err = ReadAll(content)
+	if err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// CSLToCMMappings maps CSL types to commonmeta types. It is the inverse of
+// CMToCSLMappings, with the more specific CSL types mapped to the closest
+// commonmeta type, e.g. both article-magazine and post-weblog to Article.
+var CSLToCMMappings = map[string]string{
+	"article":                "Article",
+	"article-journal":        "JournalArticle",
+	"article-magazine":       "Article",
+	"article-newspaper":      "Article",
+	"bill":                   "LegalDocument",
+	"book":                   "Book",
+	"broadcast":              "Audiovisual",
+	"chapter":                "BookChapter",
+	"classic":                "Book",
+	"collection":             "Collection",
+	"dataset":                "Dataset",
+	"document":               "Document",
+	"entry":                  "Entry",
+	"entry-dictionary":       "Entry",
+	"entry-encyclopedia":     "Entry",
+	"event":                  "Event",
+	"figure":                 "Figure",
+	"graphic":                "Image",
+	"legal_case":             "LegalDocument",
+	"legislation":            "LegalDocument",
+	"manuscript":             "Manuscript",
+	"map":                    "Map",
+	"motion_picture":         "Audiovisual",
+	"pamphlet":               "Document",
+	"paper-conference":       "ProceedingsArticle",
+	"patent":                 "Patent",
+	"performance":            "Performance",
+	"periodical":             "Journal",
+	"personal_communication": "PersonalCommunication",
+	"post":                   "Article",
+	"post-weblog":            "Article",
+	"regulation":             "LegalDocument",
+	"report":                 "Report",
+	"review":                 "Review",
+	"review-book":            "Review",
+	"software":               "Software",
+	"speech":                 "Presentation",
+	"standard":               "Standard",
+	"thesis":                 "Dissertation",
+	"treaty":                 "LegalDocument",
+	"webpage":                "WebPage",
 }
 
 // Read reads CSL JSON and converts it to commonmeta.
-func Read(content content) (commonmeta.Data, error) {
+func Read(content Content) (commonmeta.Data, error) {
 	var data commonmeta.Data
+	if content.CSL == nil {
+		return data, errors.New("missing CSL metadata")
+	}
+
+	data.ID = doiutils.NormalizeDOI(content.DOI)
+	if data.ID == "" {
+		data.ID = utils.NormalizeID(content.ID)
+	}
+	if data.ID == "" {
+		data.ID = content.ID
+	}
+
+	data.Type = CSLToCMMappings[content.Type]
+	if data.Type == "" {
+		data.Type = "Other"
+	}
 
-	data.ID = content.ID
+	// parse ISSN as either string or slice of strings
+	var issn string
+	var issnList []string
+	if len(content.ISSN) > 0 {
+		err := json.Unmarshal(content.ISSN, &issn)
+		if err != nil {
+			err = json.Unmarshal(content.ISSN, &issnList)
+			if err != nil {
+				log.Println(err)
+			}
+			if len(issnList) > 0 {
+				issn = issnList[0]
+			}
+		}
+	}
+	var identifier, identifierType string
+	if issn != "" {
+		identifier = issn
+		identifierType = "ISSN"
+	}
+	data.Container = commonmeta.Container{
+		Identifier:     identifier,
+		IdentifierType: identifierType,
+		Type:           commonmeta.ContainerTypes[data.Type],
+		Title:          content.ContainerTitle,
+		Volume:         content.Volume,
+		Issue:          content.Issue,
+	}
+	data.Container.SetPages(content.Page)
+
+	for _, v := range content.Author {
+		data.Contributors = addContributor(data.Contributors, v, "Author")
+	}
+	for _, v := range content.Editor {
+		data.Contributors = addContributor(data.Contributors, v, "Editor")
+	}
+	for _, v := range content.Translator {
+		data.Contributors = addContributor(data.Contributors, v, "Translator")
+	}
+
+	if content.Issued != nil {
+		data.Date.Published = dateutils.GetDateFromCSLParts(content.Issued.DateParts, content.Issued.Raw, content.Issued.Literal)
+	}
+	if content.Submitted != nil {
+		data.Date.Submitted = dateutils.GetDateFromCSLParts(content.Submitted.DateParts, content.Submitted.Raw, content.Submitted.Literal)
+	}
+	if content.Accessed != nil {
+		data.Date.Accessed = dateutils.GetDateFromCSLParts(content.Accessed.DateParts, content.Accessed.Raw, content.Accessed.Literal)
+	}
+
+	if content.Abstract != "" {
+		data.Descriptions = append(data.Descriptions, commonmeta.Description{
+			Description: utils.Sanitize(content.Abstract),
+			Type:        "Abstract",
+		})
+	}
+
+	if doiutils.NormalizeDOI(data.ID) != "" {
+		data.Identifiers = append(data.Identifiers, commonmeta.Identifier{
+			Identifier:     data.ID,
+			IdentifierType: "DOI",
+		})
+	}
+
+	data.Language = langutils.GetLanguage(content.Language, langutils.ISO6391)
+
+	if content.License != "" {
+		id, url := spdxutils.Normalize(content.License)
+		data.License = commonmeta.License{
+			ID:  id,
+			URL: url,
+		}
+	}
+
+	if content.Publisher != "" {
+		data.Publisher = commonmeta.Publisher{
+			Name: content.Publisher,
+		}
+	}
+
+	keywords := content.Categories
+	if content.Keyword != "" {
+		keywords = append(keywords, strings.Split(content.Keyword, ",")...)
+	}
+	for _, v := range keywords {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		subject := commonmeta.Subject{
+			Subject: v,
+		}
+		if !slices.Contains(data.Subjects, subject) {
+			data.Subjects = append(data.Subjects, subject)
+		}
+	}
+
+	if content.Title != "" {
+		data.Titles = append(data.Titles, commonmeta.Title{
+			Title: textutils.DecodeEntities(content.Title),
+		})
+	}
+
+	data.URL = content.URL
+	data.Version = content.Version
+
+	return data, nil
+}
+
+// ReadAll reads a list of CSL JSON works and returns a list of works in Commonmeta format
+func ReadAll(content []Content) ([]commonmeta.Data, error) {
+	var data []commonmeta.Data
+	for _, v := range content {
+		d, err := Read(v)
+		if err != nil {
+			log.Println(err)
+		}
+		data = append(data, d)
+	}
 	return data, nil
 }
+
+// addContributor adds a CSL name with the given role to the contributors. If
+// the contributor is already listed, e.g. as author and editor, only the role
+// is added.
+func addContributor(contributors []commonmeta.Contributor, v Author, role string) []commonmeta.Contributor {
+	var contributor commonmeta.Contributor
+	if v.Family != "" {
+		contributor = commonmeta.Contributor{
+			Type:       "Person",
+			GivenName:  v.Given,
+			FamilyName: v.Family,
+		}
+	} else if v.Literal != "" {
+		// literal names can be personal names, e.g. "John A. Doe"
+		givenName, familyName, orgName := authorutils.ParseName(v.Literal)
+		if orgName != "" {
+			contributor = commonmeta.Contributor{
+				Type: "Organization",
+				Name: orgName,
+			}
+		} else {
+			contributor = commonmeta.Contributor{
+				Type:       "Person",
+				GivenName:  givenName,
+				FamilyName: familyName,
+			}
+		}
+	} else {
+		return contributors
+	}
+	for i, c := range contributors {
+		if c.GivenName == contributor.GivenName && c.FamilyName == contributor.FamilyName && c.Name == contributor.Name {
+			if !slices.Contains(c.ContributorRoles, role) {
+				contributors[i].ContributorRoles = append(contributors[i].ContributorRoles, role)
+			}
+			return contributors
+		}
+	}
+	contributor.ContributorRoles = []string{role}
+	for _, a := range v.Affiliation {
+		if a != nil && (a.ID != "" || a.Name != "") {
+			contributor.Affiliations = append(contributor.Affiliations, &commonmeta.Affiliation{ID: a.ID, Name: a.Name})
+		}
+	}
+	return append(contributors, contributor)
+}
@@ -0,0 +1,244 @@
+package csl_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/csl"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name      string
+		filename  string
+		id        string
+		type_     string
+		published string
+		author    commonmeta.Contributor
+		title     string
+	}
+
+	testCases := []testCase{
+		{
+			name:      "journal article",
+			filename:  "10.7554_elife.01567.json",
+			id:        "https://doi.org/10.7554/elife.01567",
+			type_:     "JournalArticle",
+			published: "2014-02-11",
+			author:    commonmeta.Contributor{Type: "Person", GivenName: "Martial", FamilyName: "Sankar", ContributorRoles: []string{"Author"}},
+			title:     "Automated quantitative histology reveals vascular morphodynamics during Arabidopsis hypocotyl secondary growth",
+		},
+		{
+			name:      "dataset",
+			filename:  "10.5061_dryad.8515.json",
+			id:        "https://doi.org/10.5061/dryad.8515",
+			type_:     "Dataset",
+			published: "2011-02-01",
+			author:    commonmeta.Contributor{Type: "Person", GivenName: "Benjamin", FamilyName: "Ollomo", ContributorRoles: []string{"Author"}},
+			title:     "Data from: A new malaria agent in African hominids.",
+		},
+	}
+	for _, tc := range testCases {
+		got, err := csl.Load(filepath.Join("testdata", tc.filename))
+		if err != nil {
+			t.Fatalf("CSL Load (%v): error %v", tc.filename, err)
+		}
+		if got.ID != tc.id {
+			t.Errorf("CSL Load ID (%v): want %v, got %v", tc.filename, tc.id, got.ID)
+		}
+		if got.Type != tc.type_ {
+			t.Errorf("CSL Load Type (%v): want %v, got %v", tc.filename, tc.type_, got.Type)
+		}
+		if got.Date.Published != tc.published {
+			t.Errorf("CSL Load Date (%v): want %v, got %v", tc.filename, tc.published, got.Date.Published)
+		}
+		if len(got.Contributors) == 0 {
+			t.Fatalf("CSL Load Contributors (%v): no contributors", tc.filename)
+		}
+		if diff := cmp.Diff(tc.author, got.Contributors[0]); diff != "" {
+			t.Errorf("CSL Load Contributors (%s) mismatch (-want +got):\n%s", tc.filename, diff)
+		}
+		if len(got.Titles) == 0 || got.Titles[0].Title != tc.title {
+			t.Errorf("CSL Load Titles (%v): want %v, got %v", tc.filename, tc.title, got.Titles)
+		}
+	}
+}
+
+func TestLoadAll(t *testing.T) {
+	t.Parallel()
+	got, err := csl.LoadAll(filepath.Join("testdata", "citeproc.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("CSL LoadAll: want 1 work, got %d", len(got))
+	}
+	data := got[0]
+	if data.Type != "Article" {
+		t.Errorf("CSL LoadAll Type: want Article, got %v", data.Type)
+	}
+	if data.Container.Title != "DataCite Blog" {
+		t.Errorf("CSL LoadAll Container: want DataCite Blog, got %v", data.Container.Title)
+	}
+	if len(data.Subjects) != 7 || data.Subjects[0].Subject != "Phylogeny" {
+		t.Errorf("CSL LoadAll Subjects: got %v", data.Subjects)
+	}
+}
+
+func TestReadRoundTrip(t *testing.T) {
+	t.Parallel()
+	data, err := csl.Load(filepath.Join("testdata", "10.7554_elife.01567.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := csl.Convert(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := csl.Read(csl.Content{CSL: &c})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(data.Contributors, got.Contributors); diff != "" {
+		t.Errorf("Contributors mismatch (-want +got):\n%s", diff)
+	}
+	if got.ID != data.ID || got.Type != data.Type || got.Date.Published != data.Date.Published {
+		t.Errorf("Read round trip: want %v %v %v, got %v %v %v", data.ID, data.Type, data.Date.Published, got.ID, got.Type, got.Date.Published)
+	}
+}
+
+func TestReadDate(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name  string
+		input string
+		want  commonmeta.Date
+	}
+
+	testCases := []testCase{
+		{name: "date parts", input: `{"id":"1","type":"book","issued":{"date-parts":[[2020,3,15]]},"accessed":{"date-parts":[[2024]]}}`, want: commonmeta.Date{Published: "2020-03-15", Accessed: "2024"}},
+		{name: "raw", input: `{"id":"2","type":"book","issued":{"raw":"2020-03"}}`, want: commonmeta.Date{Published: "2020-03"}},
+		{name: "literal", input: `{"id":"3","type":"book","issued":{"literal":"15 March 2020"}}`, want: commonmeta.Date{Published: "2020-03-15"}},
+		{name: "unparseable literal", input: `{"id":"4","type":"book","issued":{"literal":"Spring semester"}}`, want: commonmeta.Date{}},
+	}
+	for _, tc := range testCases {
+		var content csl.Content
+		if err := json.Unmarshal([]byte(tc.input), &content); err != nil {
+			t.Fatal(err)
+		}
+		got, err := csl.Read(content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(tc.want, got.Date); diff != "" {
+			t.Errorf("Read date (%s) mismatch (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestReadLiteralNames(t *testing.T) {
+	t.Parallel()
+	input := `{"id":"https://doi.org/10.5555/12345678","type":"article-journal","author":[{"literal":"John A. Doe"},{"literal":"van der Berg, Jan"},{"literal":"NASA"}]}`
+	var content csl.Content
+	if err := json.Unmarshal([]byte(input), &content); err != nil {
+		t.Fatal(err)
+	}
+	got, err := csl.Read(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []commonmeta.Contributor{
+		{Type: "Person", GivenName: "John A.", FamilyName: "Doe", ContributorRoles: []string{"Author"}},
+		{Type: "Person", GivenName: "Jan", FamilyName: "van der Berg", ContributorRoles: []string{"Author"}},
+		{Type: "Organization", Name: "NASA", ContributorRoles: []string{"Author"}},
+	}
+	if diff := cmp.Diff(want, got.Contributors); diff != "" {
+		t.Errorf("Read literal names mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadLanguage(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input string
+		want  string
+	}
+	testCases := []testCase{
+		{input: "English", want: "en"},
+		{input: "eng", want: "en"},
+		{input: "en-US", want: "en"},
+		{input: "de", want: "de"},
+	}
+	for _, tc := range testCases {
+		content := csl.Content{CSL: &csl.CSL{ID: "https://doi.org/10.5555/12345678", Type: "article-journal", Language: tc.input}}
+		got, err := csl.Read(content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Language != tc.want {
+			t.Errorf("Read language (%v): want %v, got %v", tc.input, tc.want, got.Language)
+		}
+	}
+}
+
+func TestReadPages(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input     string
+		firstPage string
+		lastPage  string
+	}
+	testCases := []testCase{
+		{input: "1095–1101", firstPage: "1095", lastPage: "1101"},
+		{input: "e1001234", firstPage: "e1001234", lastPage: ""},
+		{input: "12", firstPage: "12", lastPage: ""},
+	}
+	for _, tc := range testCases {
+		content := csl.Content{CSL: &csl.CSL{ID: "https://doi.org/10.5555/12345678", Type: "article-journal", Page: tc.input}}
+		got, err := csl.Read(content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Container.FirstPage != tc.firstPage || got.Container.LastPage != tc.lastPage {
+			t.Errorf("Read pages (%v): want %q, %q, got %q, %q", tc.input, tc.firstPage, tc.lastPage, got.Container.FirstPage, got.Container.LastPage)
+		}
+	}
+}
+
+func TestReadType(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input string
+		want  string
+	}
+	testCases := []testCase{
+		{input: "article-journal", want: "JournalArticle"},
+		{input: "dataset", want: "Dataset"},
+		{input: "book", want: "Book"},
+		{input: "chapter", want: "BookChapter"},
+		{input: "paper-conference", want: "ProceedingsArticle"},
+		{input: "post-weblog", want: "Article"},
+		{input: "song", want: "Other"},
+	}
+	for _, tc := range testCases {
+		content := csl.Content{CSL: &csl.CSL{ID: "https://doi.org/10.5555/12345678", Type: tc.input}}
+		got, err := csl.Read(content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Type != tc.want {
+			t.Errorf("Read type (%v): want %v, got %v", tc.input, tc.want, got.Type)
+		}
+	}
+
+	// every commonmeta type written as CSL is read back as the same type
+	for cmType, cslType := range csl.CMToCSLMappings {
+		if got := csl.CSLToCMMappings[cslType]; got != cmType {
+			t.Errorf("CSLToCMMappings[%v]: want %v, got %v", cslType, cmType, got)
+		}
+	}
+}
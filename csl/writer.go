@@ -2,44 +2,69 @@ package csl
 
 import (
 	"encoding/json"
-	"fmt"
 	"slices"
 	"strings"
 
 	"github.com/front-matter/commonmeta/commonmeta"
 	"github.com/front-matter/commonmeta/dateutils"
 	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/langutils"
 	"github.com/front-matter/commonmeta/schemautils"
+	"github.com/front-matter/commonmeta/spdxutils"
+	"github.com/front-matter/commonmeta/textutils"
+	"github.com/front-matter/commonmeta/utils"
+	"github.com/front-matter/commonmeta/verutils"
 	"github.com/xeipuuv/gojsonschema"
 )
 
 type CSL struct {
-	ID             string             `json:"id"`
-	Type           string             `json:"type"`
-	Abstract       string             `json:"abstract,omitempty"`
-	Accessed       map[string][][]int `json:"accessed,omitempty"`
-	Author         []Author           `json:"author,omitempty"`
-	ContainerTitle string             `json:"container-title,omitempty"`
-	DOI            string             `json:"DOI,omitempty"`
-	ISSN           string             `json:"ISSN,omitempty"`
-	Issue          string             `json:"issue,omitempty"`
-	Issued         map[string][][]int `json:"issued,omitempty"`
-	Keyword        string             `json:"keyword,omitempty"`
-	Language       string             `json:"language,omitempty"`
-	License        string             `json:"license,omitempty"`
-	Page           string             `json:"page,omitempty"`
-	Publisher      string             `json:"publisher,omitempty"`
-	Submitted      map[string][][]int `json:"submitted,omitempty"`
-	Title          string             `json:"title,omitempty"`
-	URL            string             `json:"URL,omitempty"`
-	Version        string             `json:"version,omitempty"`
-	Volume         string             `json:"volume,omitempty"`
+	ID             string   `json:"id"`
+	Type           string   `json:"type"`
+	Abstract       string   `json:"abstract,omitempty"`
+	Accessed       *Date    `json:"accessed,omitempty"`
+	Author         []Author `json:"author,omitempty"`
+	ContainerTitle string   `json:"container-title,omitempty"`
+	DOI            string   `json:"DOI,omitempty"`
+	Editor         []Author `json:"editor,omitempty"`
+	Genre          string   `json:"genre,omitempty"`
+	ISSN           string   `json:"ISSN,omitempty"`
+	Issue          string   `json:"issue,omitempty"`
+	Issued         *Date    `json:"issued,omitempty"`
+	Keyword        string   `json:"keyword,omitempty"`
+	Language       string   `json:"language,omitempty"`
+	License        string   `json:"license,omitempty"`
+	Number         string   `json:"number,omitempty"`
+	Page           string   `json:"page,omitempty"`
+	Publisher      string   `json:"publisher,omitempty"`
+	Submitted      *Date    `json:"submitted,omitempty"`
+	Title          string   `json:"title,omitempty"`
+	Translator     []Author `json:"translator,omitempty"`
+	URL            string   `json:"URL,omitempty"`
+	Version        string   `json:"version,omitempty"`
+	Volume         string   `json:"volume,omitempty"`
 }
 
+// Date represents a date in CSL JSON, given as date parts, or as raw or
+// literal string.
+type Date struct {
+	DateParts [][]int `json:"date-parts,omitempty"`
+	Raw       string  `json:"raw,omitempty"`
+	Literal   string  `json:"literal,omitempty"`
+}
+
+// Author represents an author in CSL. Affiliations are not part of CSL JSON,
+// but are kept as an extension, similar to other CSL JSON producers.
 type Author struct {
-	Given   string `json:"given,omitempty"`
-	Family  string `json:"family,omitempty"`
-	Literal string `json:"literal,omitempty"`
+	Given       string         `json:"given,omitempty"`
+	Family      string         `json:"family,omitempty"`
+	Literal     string         `json:"literal,omitempty"`
+	Affiliation []*Affiliation `json:"affiliation,omitempty"`
+}
+
+// Affiliation represents the affiliation of an author.
+type Affiliation struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
 }
 
 var CMToCSLMappings = map[string]string{
@@ -77,106 +102,243 @@ func Convert(data commonmeta.Data) (CSL, error) {
 
 	csl.ID = data.ID
 	csl.Type = CMToCSLMappings[data.Type]
-	if data.Type == "Software" && data.Version != "" {
-		csl.Type = "book"
-	} else if csl.Type == "" {
-		csl.Type = "Document"
+	if csl.Type == "" {
+		csl.Type = "document"
 	}
-	csl.ContainerTitle = data.Container.Title
+	if data.AdditionalType == "Preprint" {
+		csl.Genre = "preprint"
+	}
+	csl.ContainerTitle = getContainerTitle(data.Container)
 	doi, _ := doiutils.ValidateDOI(data.ID)
 	csl.DOI = doi
+	csl.ISSN = getISSN(data.Container)
 	csl.Issue = data.Container.Issue
 	if len(data.Subjects) > 0 {
 		var keywords []string
 		for _, subject := range data.Subjects {
-			if subject.Subject != "" {
-				keywords = append(keywords, subject.Subject)
+			keyword := strings.TrimSpace(subject.Subject)
+			if keyword != "" {
+				keywords = append(keywords, keyword)
 			}
 		}
 		csl.Keyword = strings.Join(keywords[:], ", ")
 	}
-	csl.Language = data.Language
+	csl.Language = langutils.GetLanguage(data.Language, langutils.ISO6391)
+	csl.License = getLicense(data.License)
 	csl.Page = data.Container.Pages()
-	if len(data.Titles) > 0 {
-		csl.Title = data.Titles[0].Title
+	// articles with an article number instead of pages
+	if csl.Page == "" {
+		csl.Number = data.Container.ArticleNumber
 	}
+	csl.Title = getTitle(data.Titles)
 	csl.URL = data.URL
 	csl.Volume = data.Container.Volume
-	if len(data.Contributors) > 0 {
-		var author Author
-		for _, contributor := range data.Contributors {
-			if slices.Contains(contributor.ContributorRoles, "Author") {
-				if contributor.FamilyName != "" {
-					author = Author{
-						Given:  contributor.GivenName,
-						Family: contributor.FamilyName,
-					}
-				} else {
-					author = Author{
-						Literal: contributor.Name,
-					}
-
-				}
-				csl.Author = append(csl.Author, author)
-			}
+	for _, contributor := range data.Contributors {
+		if slices.Contains(contributor.ContributorRoles, "Author") {
+			csl.Author = append(csl.Author, getAuthor(contributor))
+		}
+		if slices.Contains(contributor.ContributorRoles, "Editor") {
+			csl.Editor = append(csl.Editor, getAuthor(contributor))
+		}
+		if slices.Contains(contributor.ContributorRoles, "Translator") {
+			csl.Translator = append(csl.Translator, getAuthor(contributor))
 		}
 	}
 
-	if data.Date.Published != "" {
-		csl.Issued = dateutils.GetDateParts(data.Date.Published)
-	}
-	if data.Date.Submitted != "" {
-		csl.Submitted = dateutils.GetDateParts(data.Date.Submitted)
-	}
-	if data.Date.Accessed != "" {
-		csl.Accessed = dateutils.GetDateParts(data.Date.Accessed)
-	}
+	csl.Issued = getDate(data.Date.Published)
+	csl.Submitted = getDate(data.Date.Submitted)
+	csl.Accessed = getDate(data.Date.Accessed)
 
-	if len(data.Descriptions) > 0 {
-		csl.Abstract = data.Descriptions[0].Description
-	}
+	csl.Abstract = textutils.StripMarkup(getAbstract(data.Descriptions))
 	csl.Publisher = data.Publisher.Name
-	csl.Version = data.Version
+	csl.Version = verutils.NormalizeVersion(data.Version)
 
 	return csl, nil
 }
 
-// Write writes CSL metadata.
-func Write(data commonmeta.Data) ([]byte, []gojsonschema.ResultError) {
+// Write writes CSL metadata. Errors converting or marshalling the metadata
+// are returned as error, schema validation errors as list together with the
+// output.
+func Write(data commonmeta.Data) ([]byte, []gojsonschema.ResultError, error) {
 	csl, err := Convert(data)
 	if err != nil {
-		fmt.Println(err)
+		return nil, nil, err
 	}
 	output, err := json.Marshal(csl)
 	if err != nil {
-		fmt.Println(err)
+		return nil, nil, err
 	}
-	validation := schemautils.JSONSchemaErrors(output, "csl-data")
+	validation := validate(output)
 	if !validation.Valid() {
-		return nil, validation.Errors()
+		return output, validation.Errors(), nil
 	}
 
-	return output, nil
+	return output, nil, nil
 }
 
 // WriteAll writes a list of CSL metadata.
-func WriteAll(list []commonmeta.Data) ([]byte, []gojsonschema.ResultError) {
+func WriteAll(list []commonmeta.Data) ([]byte, []gojsonschema.ResultError, error) {
 	var cslList []CSL
 	for _, data := range list {
 		csl, err := Convert(data)
 		if err != nil {
-			fmt.Println(err)
+			return nil, nil, err
 		}
 		cslList = append(cslList, csl)
 	}
 	output, err := json.Marshal(cslList)
 	if err != nil {
-		fmt.Println(err)
+		return nil, nil, err
 	}
-	validation := schemautils.JSONSchemaErrors(output, "csl-data")
+	validation := validate(output)
 	if !validation.Valid() {
-		return nil, validation.Errors()
+		return output, validation.Errors(), nil
+	}
+
+	return output, nil, nil
+}
+
+// validate validates CSL JSON against the CSL JSON schema. Affiliations are
+// an extension of CSL JSON, and are removed before validation.
+func validate(output []byte) *gojsonschema.Result {
+	var v any
+	err := json.Unmarshal(output, &v)
+	if err != nil {
+		return schemautils.JSONSchemaErrors(output, "csl-data")
+	}
+	items, ok := v.([]any)
+	if !ok {
+		items = []any{v}
+	}
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		for _, key := range []string{"author", "editor", "translator"} {
+			names, _ := m[key].([]any)
+			for _, name := range names {
+				if n, ok := name.(map[string]any); ok {
+					delete(n, "affiliation")
+				}
+			}
+		}
+	}
+	stripped, err := json.Marshal(v)
+	if err != nil {
+		return schemautils.JSONSchemaErrors(output, "csl-data")
+	}
+	return schemautils.JSONSchemaErrors(stripped, "csl-data")
+}
+
+// getAuthor converts a contributor into a CSL name, used for authors, editors
+// and translators.
+func getAuthor(contributor commonmeta.Contributor) Author {
+	var author Author
+	if contributor.FamilyName != "" {
+		author = Author{
+			Given:  contributor.GivenName,
+			Family: contributor.FamilyName,
+		}
+	} else {
+		author = Author{
+			Literal: contributor.Name,
+		}
+	}
+	for _, a := range contributor.Affiliations {
+		if a != nil && (a.ID != "" || a.Name != "") {
+			author.Affiliation = append(author.Affiliation, &Affiliation{ID: a.ID, Name: a.Name})
+		}
+	}
+	return author
+}
+
+// getDate converts an ISO 8601 date into a CSL date, dates that can't be
+// parsed are left out.
+func getDate(date string) *Date {
+	parts := dateutils.GetDateParts(date)
+	if len(parts["date-parts"]) == 0 {
+		return nil
+	}
+	return &Date{DateParts: parts["date-parts"]}
+}
+
+// getAbstract returns the first description of type Abstract, falling back
+// to the first description without a type, or the first description.
+func getAbstract(descriptions []commonmeta.Description) string {
+	if len(descriptions) == 0 {
+		return ""
+	}
+	for _, v := range descriptions {
+		if v.Type == "Abstract" {
+			return v.Description
+		}
+	}
+	for _, v := range descriptions {
+		if v.Type == "" {
+			return v.Description
+		}
 	}
+	return descriptions[0].Description
+}
 
-	return output, nil
+// getContainerTitle returns the title of the container, unless the container
+// is a repository, which is not cited as container in CSL.
+func getContainerTitle(container commonmeta.Container) string {
+	if slices.Contains([]string{"Repository", "DataRepository"}, container.Type) {
+		return ""
+	}
+	return container.Title
+}
+
+// getISSN returns the ISSN of a journal. Readers store a single ISSN in the
+// container, preferring the electronic over the print ISSN.
+func getISSN(container commonmeta.Container) string {
+	if container.IdentifierType != "ISSN" || !slices.Contains([]string{"Journal", "Periodical"}, container.Type) {
+		return ""
+	}
+	issn, ok := utils.ValidateISSN(container.Identifier)
+	if !ok {
+		return ""
+	}
+	return strings.ToUpper(issn)
+}
+
+// getLicense returns the SPDX license ID of a license, or the license URL
+// if the license has no SPDX license ID.
+func getLicense(license commonmeta.License) string {
+	id := spdxutils.ValidateID(license.ID)
+	if id == "" {
+		id = spdxutils.URLToID(license.URL)
+	}
+	if id != "" {
+		return id
+	}
+	url, _ := spdxutils.NormalizeURL(license.URL)
+	return url
+}
+
+// getTitle returns the main title, followed by the subtitle if there is one.
+// Translated and alternative titles are ignored.
+func getTitle(titles []commonmeta.Title) string {
+	var title, subtitle string
+	for _, v := range titles {
+		switch v.Type {
+		case "":
+			if title == "" {
+				title = v.Title
+			}
+		case "Subtitle":
+			if subtitle == "" {
+				subtitle = v.Title
+			}
+		}
+	}
+	if title == "" && len(titles) > 0 {
+		title = titles[0].Title
+	}
+	if subtitle == "" || subtitle == title {
+		return title
+	}
+	return title + ": " + subtitle
 }
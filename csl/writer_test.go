@@ -2,6 +2,9 @@ package csl_test
 
 import (
 	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -12,6 +15,7 @@ import (
 	"github.com/front-matter/commonmeta/csl"
 	"github.com/front-matter/commonmeta/datacite"
 	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/schemautils"
 	"github.com/google/go-cmp/cmp"
 )
 
@@ -39,7 +43,10 @@ func TestWrite(t *testing.T) {
 		if err != nil {
 			t.Errorf("Crossref Fetch (%v): error %v", tc.id, err)
 		}
-		got, jsErr := csl.Write(data)
+		got, jsErr, err := csl.Write(data)
+		if err != nil {
+			t.Errorf("CSL Write (%v): error %v", tc.id, err)
+		}
 		if jsErr != nil {
 			t.Errorf("CSL Write (%v): error %v", tc.id, jsErr)
 		}
@@ -65,3 +72,390 @@ func TestWrite(t *testing.T) {
 		}
 	}
 }
+
+func TestConvertKeyword(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name     string
+		subjects []commonmeta.Subject
+		want     string
+	}
+
+	testCases := []testCase{
+		{name: "three subjects", subjects: []commonmeta.Subject{{Subject: "Biology"}, {Subject: "Chemistry"}, {Subject: "Physics"}}, want: "Biology, Chemistry, Physics"},
+		{name: "single subject", subjects: []commonmeta.Subject{{Subject: "Biology"}}, want: "Biology"},
+		{name: "whitespace and empty", subjects: []commonmeta.Subject{{Subject: " Biology "}, {Subject: ""}, {Subject: "Physics\n"}}, want: "Biology, Physics"},
+	}
+	for _, tc := range testCases {
+		data := commonmeta.Data{ID: "https://doi.org/10.5555/12345678", Type: "JournalArticle", Subjects: tc.subjects}
+		got, err := csl.Convert(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Keyword != tc.want {
+			t.Errorf("Convert keyword (%s): want %q, got %q", tc.name, tc.want, got.Keyword)
+		}
+	}
+}
+
+func TestConvertUnknownType(t *testing.T) {
+	t.Parallel()
+	data := commonmeta.Data{
+		ID:     "https://doi.org/10.5555/12345678",
+		Type:   "Instrument",
+		Titles: []commonmeta.Title{{Title: "An instrument"}},
+	}
+	got, err := csl.Convert(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Type != "document" {
+		t.Errorf("Convert type: want document, got %v", got.Type)
+	}
+	output, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := schemautils.JSONSchemaErrors(output, "csl-data")
+	if !result.Valid() {
+		t.Errorf("Convert (%v): schema errors %v", data.Type, result.Errors())
+	}
+}
+
+func TestConvertSoftware(t *testing.T) {
+	t.Parallel()
+	data := commonmeta.Data{
+		ID:      "https://doi.org/10.5281/zenodo.8173303",
+		Type:    "Software",
+		Titles:  []commonmeta.Title{{Title: "commonmeta-go"}},
+		Version: "v0.5.2",
+	}
+	got, err := csl.Convert(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Type != "software" {
+		t.Errorf("Convert type: want software, got %v", got.Type)
+	}
+	// the semantic version is normalized without leading v
+	if got.Version != "0.5.2" {
+		t.Errorf("Convert version: want 0.5.2, got %v", got.Version)
+	}
+}
+
+func TestConvertDate(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name string
+		date string
+		want *csl.Date
+	}
+
+	testCases := []testCase{
+		{name: "year", date: "2020", want: &csl.Date{DateParts: [][]int{{2020}}}},
+		{name: "year and month", date: "2020-03", want: &csl.Date{DateParts: [][]int{{2020, 3}}}},
+		{name: "full date", date: "2020-03-15", want: &csl.Date{DateParts: [][]int{{2020, 3, 15}}}},
+		{name: "range", date: "2020-01-01/2020-12-31", want: &csl.Date{DateParts: [][]int{{2020, 1, 1}, {2020, 12, 31}}}},
+		{name: "garbage", date: "not a date", want: nil},
+	}
+	for _, tc := range testCases {
+		data := commonmeta.Data{ID: "https://doi.org/10.5555/12345678", Type: "JournalArticle", Date: commonmeta.Date{Published: tc.date}}
+		got, err := csl.Convert(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(tc.want, got.Issued); diff != "" {
+			t.Errorf("Convert date (%s) mismatch (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestConvertTitle(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name   string
+		titles []commonmeta.Title
+		want   string
+	}
+
+	testCases := []testCase{
+		{name: "title", titles: []commonmeta.Title{{Title: "Main"}}, want: "Main"},
+		{name: "subtitle", titles: []commonmeta.Title{{Title: "Main"}, {Title: "Sub", Type: "Subtitle"}}, want: "Main: Sub"},
+		{name: "subtitle first", titles: []commonmeta.Title{{Title: "Sub", Type: "Subtitle"}, {Title: "Main"}}, want: "Main: Sub"},
+		{name: "translated title", titles: []commonmeta.Title{{Title: "Haupt", Language: "de"}, {Title: "Main", Type: "TranslatedTitle", Language: "en"}}, want: "Haupt"},
+		{name: "no titles", titles: nil, want: ""},
+	}
+	for _, tc := range testCases {
+		data := commonmeta.Data{ID: "https://doi.org/10.5555/12345678", Type: "JournalArticle", Titles: tc.titles}
+		got, err := csl.Convert(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Title != tc.want {
+			t.Errorf("Convert title (%s): want %q, got %q", tc.name, tc.want, got.Title)
+		}
+	}
+}
+
+func TestConvertAbstract(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name         string
+		descriptions []commonmeta.Description
+		want         string
+	}
+
+	testCases := []testCase{
+		{name: "methods first", descriptions: []commonmeta.Description{{Description: "How it was done.", Type: "Methods"}, {Description: "What was found.", Type: "Abstract"}}, want: "What was found."},
+		{name: "untyped", descriptions: []commonmeta.Description{{Description: "How it was done.", Type: "Methods"}, {Description: "What was found."}}, want: "What was found."},
+		{name: "methods only", descriptions: []commonmeta.Description{{Description: "How it was done.", Type: "Methods"}}, want: "How it was done."},
+		{name: "no descriptions", descriptions: nil, want: ""},
+	}
+	for _, tc := range testCases {
+		data := commonmeta.Data{ID: "https://doi.org/10.5555/12345678", Type: "JournalArticle", Descriptions: tc.descriptions}
+		got, err := csl.Convert(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Abstract != tc.want {
+			t.Errorf("Convert abstract (%s): want %q, got %q", tc.name, tc.want, got.Abstract)
+		}
+	}
+}
+
+func TestConvertLicense(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name    string
+		license commonmeta.License
+		want    string
+	}
+
+	testCases := []testCase{
+		{name: "cc-by", license: commonmeta.License{ID: "CC-BY-4.0", URL: "https://creativecommons.org/licenses/by/4.0/legalcode"}, want: "CC-BY-4.0"},
+		{name: "cc-by deed", license: commonmeta.License{URL: "http://creativecommons.org/licenses/by/4.0/"}, want: "CC-BY-4.0"},
+		{name: "unknown url", license: commonmeta.License{URL: "https://example.org/license/"}, want: "https://example.org/license"},
+		{name: "id only", license: commonmeta.License{ID: "mit"}, want: "MIT"},
+		{name: "no license", license: commonmeta.License{}, want: ""},
+	}
+	for _, tc := range testCases {
+		data := commonmeta.Data{ID: "https://doi.org/10.5555/12345678", Type: "JournalArticle", License: tc.license}
+		got, err := csl.Convert(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.License != tc.want {
+			t.Errorf("Convert license (%s): want %q, got %q", tc.name, tc.want, got.License)
+		}
+	}
+}
+
+func TestWriteLicense(t *testing.T) {
+	t.Parallel()
+	data := commonmeta.Data{
+		ID:      "https://doi.org/10.5555/12345678",
+		Type:    "JournalArticle",
+		Titles:  []commonmeta.Title{{Title: "An open access article"}},
+		License: commonmeta.License{ID: "CC-BY-4.0", URL: "https://creativecommons.org/licenses/by/4.0/legalcode"},
+	}
+	output, jsErr, err := csl.Write(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jsErr != nil {
+		t.Fatalf("Write license: schema errors %v", jsErr)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(output, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["license"] != data.License.ID {
+		t.Errorf("Write license: want %q, got %v", data.License.ID, got["license"])
+	}
+}
+
+func TestConvertISSN(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name      string
+		container commonmeta.Container
+		want      string
+	}
+
+	testCases := []testCase{
+		{name: "journal", container: commonmeta.Container{Identifier: "2050-084X", IdentifierType: "ISSN", Type: "Journal", Title: "eLife"}, want: "2050-084X"},
+		{name: "issn url", container: commonmeta.Container{Identifier: "https://portal.issn.org/resource/ISSN/2050-084x", IdentifierType: "ISSN", Type: "Journal"}, want: "2050-084X"},
+		{name: "book series", container: commonmeta.Container{Identifier: "1234-5678", IdentifierType: "ISSN", Type: "BookSeries"}, want: ""},
+		{name: "isbn", container: commonmeta.Container{Identifier: "9783161484100", IdentifierType: "ISBN", Type: "Book"}, want: ""},
+		{name: "invalid", container: commonmeta.Container{Identifier: "n/a", IdentifierType: "ISSN", Type: "Journal"}, want: ""},
+	}
+	for _, tc := range testCases {
+		data := commonmeta.Data{ID: "https://doi.org/10.7554/elife.01567", Type: "JournalArticle", Container: tc.container}
+		got, err := csl.Convert(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.ISSN != tc.want {
+			t.Errorf("Convert ISSN (%s): want %q, got %q", tc.name, tc.want, got.ISSN)
+		}
+	}
+}
+
+func TestConvertContainerTitle(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name      string
+		type_     string
+		container commonmeta.Container
+		want      string
+		wantType  string
+	}
+
+	testCases := []testCase{
+		{name: "journal article", type_: "JournalArticle", container: commonmeta.Container{Type: "Journal", Title: "eLife"}, want: "eLife", wantType: "article-journal"},
+		{name: "book chapter", type_: "BookChapter", container: commonmeta.Container{Identifier: "9783161484100", IdentifierType: "ISBN", Type: "Book", Title: "Handbook of Metadata"}, want: "Handbook of Metadata", wantType: "chapter"},
+		{name: "dataset", type_: "Dataset", container: commonmeta.Container{Type: "DataRepository", Title: "Dryad"}, want: "", wantType: "dataset"},
+	}
+	for _, tc := range testCases {
+		data := commonmeta.Data{ID: "https://doi.org/10.7554/elife.01567", Type: tc.type_, Container: tc.container}
+		got, err := csl.Convert(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.ContainerTitle != tc.want {
+			t.Errorf("Convert container title (%s): want %q, got %q", tc.name, tc.want, got.ContainerTitle)
+		}
+		if got.Type != tc.wantType {
+			t.Errorf("Convert type (%s): want %q, got %q", tc.name, tc.wantType, got.Type)
+		}
+	}
+}
+
+func TestConvertAffiliations(t *testing.T) {
+	t.Parallel()
+	contributors := []commonmeta.Contributor{
+		{
+			Type:             "Person",
+			GivenName:        "Josiah",
+			FamilyName:       "Carberry",
+			ContributorRoles: []string{"Author"},
+			Affiliations:     []*commonmeta.Affiliation{{ID: "https://ror.org/05gq02987", Name: "Brown University"}},
+		},
+	}
+	data := commonmeta.Data{
+		ID:           "https://doi.org/10.5555/12345678",
+		Type:         "JournalArticle",
+		Titles:       []commonmeta.Title{{Title: "Toward a Unified Theory of High-Energy Metaphysics"}},
+		Contributors: contributors,
+	}
+	content, err := csl.Convert(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []csl.Author{
+		{Given: "Josiah", Family: "Carberry", Affiliation: []*csl.Affiliation{{ID: "https://ror.org/05gq02987", Name: "Brown University"}}},
+	}
+	if diff := cmp.Diff(want, content.Author); diff != "" {
+		t.Errorf("Convert author mismatch (-want +got):\n%s", diff)
+	}
+
+	// affiliations are an extension and don't fail schema validation
+	_, jsErr, err := csl.Write(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jsErr != nil {
+		t.Errorf("Write affiliations: schema errors %v", jsErr)
+	}
+
+	got, err := csl.Read(csl.Content{CSL: &content})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(contributors, got.Contributors); diff != "" {
+		t.Errorf("Read contributors mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertEditor(t *testing.T) {
+	t.Parallel()
+	contributors := []commonmeta.Contributor{
+		{Type: "Person", GivenName: "Josiah", FamilyName: "Carberry", ContributorRoles: []string{"Author"}},
+		{Type: "Person", GivenName: "Jane", FamilyName: "Doe", ContributorRoles: []string{"Editor"}},
+		{Type: "Organization", Name: "The Translation Company", ContributorRoles: []string{"Translator"}},
+	}
+	data := commonmeta.Data{
+		ID:           "https://doi.org/10.5555/12345678",
+		Type:         "BookChapter",
+		Titles:       []commonmeta.Title{{Title: "A chapter in an edited volume"}},
+		Contributors: contributors,
+	}
+	content, err := csl.Convert(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]csl.Author{{Given: "Josiah", Family: "Carberry"}}, content.Author); diff != "" {
+		t.Errorf("Convert author mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]csl.Author{{Given: "Jane", Family: "Doe"}}, content.Editor); diff != "" {
+		t.Errorf("Convert editor mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]csl.Author{{Literal: "The Translation Company"}}, content.Translator); diff != "" {
+		t.Errorf("Convert translator mismatch (-want +got):\n%s", diff)
+	}
+
+	got, err := csl.Read(csl.Content{CSL: &content})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(contributors, got.Contributors); diff != "" {
+		t.Errorf("Read contributors mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertCrossrefFetch(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": "ok", "message-type": "work", "message": {
+			"DOI": "10.5555/12345678",
+			"type": "journal-article",
+			"title": ["Toward a Unified Theory of High-Energy Metaphysics"],
+			"author": [{"given": "Josiah", "family": "Carberry", "sequence": "first"}],
+			"container-title": ["Journal of Psychoceramics"],
+			"ISSN": ["0264-3561"],
+			"issn-type": [{"value": "0264-3561", "type": "print"}],
+			"resource": {"primary": {"URL": "https://psychoceramics.labs.crossref.org/10.5555-12345678.html"}},
+			"volume": "5",
+			"issue": "11",
+			"page": "1-3",
+			"issued": {"date-parts": [[2008, 8, 13]]}
+		}}`)
+	}))
+	defer server.Close()
+
+	data, err := crossref.Fetch("10.5555/12345678", crossref.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := csl.Convert(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := csl.CSL{
+		ID:             "https://doi.org/10.5555/12345678",
+		Type:           "article-journal",
+		Author:         []csl.Author{{Given: "Josiah", Family: "Carberry"}},
+		ContainerTitle: "Journal of Psychoceramics",
+		DOI:            "10.5555/12345678",
+		ISSN:           "0264-3561",
+		Issue:          "11",
+		Issued:         &csl.Date{DateParts: [][]int{{2008, 8, 13}}},
+		Page:           "1-3",
+		Title:          "Toward a Unified Theory of High-Energy Metaphysics",
+		URL:            "https://psychoceramics.labs.crossref.org/10.5555-12345678.html",
+		Volume:         "5",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Convert Crossref fetch mismatch (-want +got):\n%s", diff)
+	}
+}
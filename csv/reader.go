@@ -0,0 +1,250 @@
+package csv
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"log"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/front-matter/commonmeta/authorutils"
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/dateutils"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/spdxutils"
+)
+
+// Content represents a row of a CSV file, as a map of lowercase column
+// names to values.
+type Content map[string]string
+
+// ColumnAliases maps alternative column names to the column names used by
+// the reader.
+var ColumnAliases = map[string]string{
+	"author":    "authors",
+	"creator":   "authors",
+	"creators":  "authors",
+	"date":      "year",
+	"journal":   "container",
+	"published": "year",
+}
+
+// Option configures how CSV files are read.
+type Option func(*options)
+
+type options struct {
+	comma           rune
+	authorDelimiter string
+}
+
+// WithComma sets the field delimiter, e.g. '\t' for tab-delimited files. The
+// default is a comma, or a tab for files with .tsv extension.
+func WithComma(comma rune) Option {
+	return func(o *options) {
+		o.comma = comma
+	}
+}
+
+// WithAuthorDelimiter sets the delimiter between authors in the authors
+// column, the default is a semicolon.
+func WithAuthorDelimiter(delimiter string) Option {
+	return func(o *options) {
+		o.authorDelimiter = delimiter
+	}
+}
+
+func getOptions(opts []Option) options {
+	o := options{comma: ',', authorDelimiter: ";"}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// Load loads the metadata for a single work from a CSV file
+func Load(filename string, opts ...Option) (commonmeta.Data, error) {
+	var data commonmeta.Data
+
+	content, err := loadFile(filename, opts)
+	if err != nil {
+		return data, err
+	}
+	if len(content) == 0 {
+		return data, errors.New("no CSV rows found")
+	}
+	return Read(content[0], opts...)
+}
+
+// LoadAll loads a list of works from a CSV file and converts it to the Commonmeta format
+func LoadAll(filename string, opts ...Option) ([]commonmeta.Data, error) {
+	content, err := loadFile(filename, opts)
+	if err != nil {
+		return nil, err
+	}
+	return ReadAll(content, opts...)
+}
+
+func loadFile(filename string, opts []Option) ([]Content, error) {
+	extension := path.Ext(filename)
+	if extension != ".csv" && extension != ".tsv" {
+		return nil, errors.New("invalid file extension")
+	}
+	input, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.New("error reading file")
+	}
+	if extension == ".tsv" {
+		opts = append([]Option{WithComma('\t')}, opts...)
+	}
+	return Parse(input, opts...)
+}
+
+// Parse parses CSV input with a header row into a list of rows.
+func Parse(input []byte, opts ...Option) ([]Content, error) {
+	o := getOptions(opts)
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(input, []byte("\ufeff"))))
+	r.Comma = o.comma
+	r.FieldsPerRecord = -1
+	if o.comma == '\t' {
+		r.LazyQuotes = true
+	}
+
+	header, err := r.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, v := range header {
+		column := strings.ToLower(strings.TrimSpace(v))
+		if alias, ok := ColumnAliases[column]; ok {
+			column = alias
+		}
+		header[i] = column
+	}
+
+	var list []Content
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return list, err
+		}
+		content := make(Content)
+		for i, v := range record {
+			if i < len(header) && strings.TrimSpace(v) != "" {
+				content[header[i]] = strings.TrimSpace(v)
+			}
+		}
+		if len(content) > 0 {
+			list = append(list, content)
+		}
+	}
+	return list, nil
+}
+
+// Read reads a CSV row and converts it to commonmeta. Rows without type are
+// read as type Other.
+func Read(content Content, opts ...Option) (commonmeta.Data, error) {
+	var data commonmeta.Data
+	o := getOptions(opts)
+
+	data.ID = doiutils.NormalizeDOI(content["doi"])
+	if data.ID != "" {
+		data.Identifiers = append(data.Identifiers, commonmeta.Identifier{
+			Identifier:     data.ID,
+			IdentifierType: "DOI",
+		})
+	}
+	data.URL = content["url"]
+	if data.ID == "" {
+		data.ID = data.URL
+	}
+	if data.ID == "" {
+		return data, errors.New("no DOI or URL found")
+	}
+
+	data.Type = content["type"]
+	if data.Type == "" {
+		data.Type = "Other"
+	}
+	if content["title"] != "" {
+		data.Titles = []commonmeta.Title{{Title: content["title"]}}
+	}
+	for _, v := range strings.Split(content["authors"], o.authorDelimiter) {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		contributor := parseName(v)
+		contributor.ContributorRoles = []string{"Author"}
+		data.Contributors = append(data.Contributors, contributor)
+	}
+	data.Date.Published = getDate(content["year"])
+	if content["container"] != "" {
+		data.Container = commonmeta.Container{
+			Type:  commonmeta.ContainerTypes[data.Type],
+			Title: content["container"],
+		}
+	}
+	if content["publisher"] != "" {
+		data.Publisher = commonmeta.Publisher{Name: content["publisher"]}
+	}
+	id, url := spdxutils.Normalize(content["license"])
+	if id != "" || url != "" {
+		data.License = commonmeta.License{ID: id, URL: url}
+	}
+	return data, nil
+}
+
+// ReadAll reads a list of CSV rows and returns a list of works in Commonmeta format
+func ReadAll(content []Content, opts ...Option) ([]commonmeta.Data, error) {
+	var data []commonmeta.Data
+	for _, v := range content {
+		d, err := Read(v, opts...)
+		if err != nil {
+			log.Println(err)
+			continue
+		}
+		data = append(data, d)
+	}
+	return data, nil
+}
+
+// parseName parses a name in "Family, Given" or "Given Family" format
+func parseName(name string) commonmeta.Contributor {
+	family, given, found := strings.Cut(name, ",")
+	if found {
+		return commonmeta.Contributor{
+			Type:       "Person",
+			GivenName:  strings.TrimSpace(given),
+			FamilyName: strings.TrimSpace(family),
+		}
+	}
+	givenName, familyName, orgName := authorutils.ParseName(name)
+	if orgName != "" {
+		return commonmeta.Contributor{
+			Type: "Organization",
+			Name: orgName,
+		}
+	}
+	return commonmeta.Contributor{
+		Type:       "Person",
+		GivenName:  givenName,
+		FamilyName: familyName,
+	}
+}
+
+// getDate returns an ISO 8601 date from a year or date
+func getDate(str string) string {
+	if len(str) == 4 {
+		return str
+	}
+	return dateutils.ParseDate(str)
+}
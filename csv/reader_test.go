@@ -0,0 +1,101 @@
+package csv_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/csv"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoadAll(t *testing.T) {
+	t.Parallel()
+	got, err := csv.LoadAll(filepath.Join("testdata", "references.csv"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []commonmeta.Data{
+		{
+			ID:          "https://doi.org/10.7554/elife.01567",
+			Type:        "JournalArticle",
+			Identifiers: []commonmeta.Identifier{{Identifier: "https://doi.org/10.7554/elife.01567", IdentifierType: "DOI"}},
+			Titles:      []commonmeta.Title{{Title: "Automated quantitative histology reveals vascular morphodynamics during Arabidopsis hypocotyl secondary growth"}},
+			Contributors: []commonmeta.Contributor{
+				{Type: "Person", GivenName: "Martial", FamilyName: "Sankar", ContributorRoles: []string{"Author"}},
+				{Type: "Person", GivenName: "Kaisa", FamilyName: "Nieminen", ContributorRoles: []string{"Author"}},
+				{Type: "Person", GivenName: "Laura", FamilyName: "Ragni", ContributorRoles: []string{"Author"}},
+			},
+			Date:      commonmeta.Date{Published: "2014"},
+			Container: commonmeta.Container{Type: "Journal", Title: "eLife"},
+		},
+		{
+			ID:          "https://doi.org/10.5555/12345678",
+			Type:        "JournalArticle",
+			Identifiers: []commonmeta.Identifier{{Identifier: "https://doi.org/10.5555/12345678", IdentifierType: "DOI"}},
+			Titles:      []commonmeta.Title{{Title: `Toward a "unified theory", revisited`}},
+			Contributors: []commonmeta.Contributor{
+				{Type: "Person", GivenName: "Josiah", FamilyName: "Carberry", ContributorRoles: []string{"Author"}},
+			},
+			Date:      commonmeta.Date{Published: "2008-08-13"},
+			Container: commonmeta.Container{Type: "Journal", Title: "Journal of Psychoceramics"},
+		},
+		{
+			ID:          "https://doi.org/10.5281/zenodo.1234",
+			Type:        "Dataset",
+			Identifiers: []commonmeta.Identifier{{Identifier: "https://doi.org/10.5281/zenodo.1234", IdentifierType: "DOI"}},
+			Titles:      []commonmeta.Title{{Title: "Gene expression matrices"}},
+			Contributors: []commonmeta.Contributor{
+				{Type: "Organization", Name: "The GTEx Consortium", ContributorRoles: []string{"Author"}},
+			},
+			Date: commonmeta.Date{Published: "2017"},
+		},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("LoadAll mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoadTabDelimited(t *testing.T) {
+	t.Parallel()
+	got, err := csv.Load(filepath.Join("testdata", "references.tsv"), csv.WithAuthorDelimiter("|"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := commonmeta.Data{
+		ID:          "https://doi.org/10.5555/tsv",
+		Type:        "Other",
+		Identifiers: []commonmeta.Identifier{{Identifier: "https://doi.org/10.5555/tsv", IdentifierType: "DOI"}},
+		Titles:      []commonmeta.Title{{Title: `Tab-delimited "title"`}},
+		Contributors: []commonmeta.Contributor{
+			{Type: "Person", GivenName: "Josiah", FamilyName: "Carberry", ContributorRoles: []string{"Author"}},
+			{Type: "Person", GivenName: "Jane", FamilyName: "Smith", ContributorRoles: []string{"Author"}},
+		},
+		Date: commonmeta.Date{Published: "2020"},
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Load tsv mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	t.Parallel()
+	output, err := csv.Write(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	content, err := csv.Parse(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := csv.ReadAll(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("Round trip: want 1 work, got %d", len(got))
+	}
+	if got[0].Titles[0].Title != data.Titles[0].Title || len(got[0].Contributors) != 2 || got[0].License.ID != "CC-BY-4.0" {
+		t.Errorf("Round trip: unexpected work %+v", got[0])
+	}
+}
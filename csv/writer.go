@@ -0,0 +1,121 @@
+// Package csv provides functions to convert CSV (RFC 4180) and tab-delimited
+// files to/from the commonmeta metadata format, with one row per work.
+package csv
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/doiutils"
+)
+
+// Columns lists the default columns, in order.
+var Columns = []string{"DOI", "Type", "Title", "Authors", "Year", "Container", "Publisher", "License", "URL"}
+
+// Write writes commonmeta metadata as CSV with a header row. Columns
+// selects and orders the columns, all columns are written by default.
+func Write(data commonmeta.Data, columns ...string) ([]byte, error) {
+	return WriteAll([]commonmeta.Data{data}, columns...)
+}
+
+// WriteAll writes a list of commonmeta metadata as CSV with a header row.
+// Columns selects and orders the columns, all columns are written by default.
+func WriteAll(list []commonmeta.Data, columns ...string) ([]byte, error) {
+	header, err := getColumns(columns)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.UseCRLF = true
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+	for _, data := range list {
+		row := make([]string, len(header))
+		for i, column := range header {
+			row[i] = getValue(data, column)
+		}
+		if err := w.Write(row); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// getColumns returns the column names in canonical spelling, columns are
+// matched case-insensitively.
+func getColumns(columns []string) ([]string, error) {
+	if len(columns) == 0 {
+		return Columns, nil
+	}
+	var header []string
+	for _, column := range columns {
+		i := slices.IndexFunc(Columns, func(c string) bool {
+			return strings.EqualFold(c, strings.TrimSpace(column))
+		})
+		if i == -1 {
+			return nil, fmt.Errorf("unsupported column: %s", column)
+		}
+		header = append(header, Columns[i])
+	}
+	return header, nil
+}
+
+// getValue returns the value of a column for a work.
+func getValue(data commonmeta.Data, column string) string {
+	switch column {
+	case "DOI":
+		doi, _ := doiutils.ValidateDOI(data.ID)
+		return doi
+	case "Type":
+		return data.Type
+	case "Title":
+		if len(data.Titles) > 0 {
+			return data.Titles[0].Title
+		}
+	case "Authors":
+		var authors []string
+		for _, v := range data.Contributors {
+			if !slices.Contains(v.ContributorRoles, "Author") {
+				continue
+			}
+			name := v.Name
+			if v.FamilyName != "" {
+				name = v.FamilyName
+				if v.GivenName != "" {
+					name += ", " + v.GivenName
+				}
+			}
+			if name != "" {
+				authors = append(authors, name)
+			}
+		}
+		return strings.Join(authors, "; ")
+	case "Year":
+		if len(data.Date.Published) >= 4 {
+			return data.Date.Published[:4]
+		}
+	case "Container":
+		return data.Container.Title
+	case "Publisher":
+		return data.Publisher.Name
+	case "License":
+		if data.License.ID != "" {
+			return data.License.ID
+		}
+		return data.License.URL
+	case "URL":
+		return data.URL
+	}
+	return ""
+}
@@ -0,0 +1,62 @@
+package csv_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/csv"
+)
+
+var data = commonmeta.Data{
+	ID:   "https://doi.org/10.5555/12345678",
+	Type: "JournalArticle",
+	URL:  "https://example.org/article",
+	Contributors: []commonmeta.Contributor{
+		{Type: "Person", GivenName: "Josiah", FamilyName: "Carberry", ContributorRoles: []string{"Author"}},
+		{Type: "Organization", Name: "The GTEx Consortium", ContributorRoles: []string{"Author"}},
+		{Type: "Person", GivenName: "Jane", FamilyName: "Editor", ContributorRoles: []string{"Editor"}},
+	},
+	Titles:    []commonmeta.Title{{Title: `Toward a "unified theory", revisited`}},
+	Date:      commonmeta.Date{Published: "2014-02-11"},
+	Container: commonmeta.Container{Type: "Journal", Title: "Journal of Psychoceramics"},
+	Publisher: commonmeta.Publisher{Name: "Brown University"},
+	License:   commonmeta.License{ID: "CC-BY-4.0", URL: "https://creativecommons.org/licenses/by/4.0/legalcode"},
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+	got, err := csv.Write(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `DOI,Type,Title,Authors,Year,Container,Publisher,License,URL
+10.5555/12345678,JournalArticle,"Toward a ""unified theory"", revisited","Carberry, Josiah; The GTEx Consortium",2014,Journal of Psychoceramics,Brown University,CC-BY-4.0,https://example.org/article
+`
+	want = strings.ReplaceAll(want, "\n", "\r\n")
+	if string(got) != want {
+		t.Errorf("Write:\nwant %s\ngot  %s", want, got)
+	}
+}
+
+func TestWriteAllColumns(t *testing.T) {
+	t.Parallel()
+	list := []commonmeta.Data{data, {ID: "https://example.org/post", Type: "Article", Titles: []commonmeta.Title{{Title: "Post"}}}}
+	got, err := csv.WriteAll(list, "title", "doi", "Year")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `Title,DOI,Year
+"Toward a ""unified theory"", revisited",10.5555/12345678,2014
+Post,,
+`
+	want = strings.ReplaceAll(want, "\n", "\r\n")
+	if string(got) != want {
+		t.Errorf("WriteAll:\nwant %s\ngot  %s", want, got)
+	}
+
+	_, err = csv.WriteAll(list, "Title", "Abstract")
+	if err == nil {
+		t.Error("WriteAll: want error for unsupported column, got nil")
+	}
+}
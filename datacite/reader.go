@@ -2,12 +2,14 @@
 package datacite
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
 	"io"
 	"log"
+	"log/slog"
 	"net/http"
+	"net/url"
 	"os"
 	"path"
 	"slices"
@@ -15,9 +17,17 @@ import (
 	"strings"
 	"time"
 
+	"github.com/front-matter/commonmeta/authorutils"
 	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/dateutils"
 	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/langutils"
+	"github.com/front-matter/commonmeta/spdxutils"
+	"github.com/front-matter/commonmeta/textutils"
 
+	"github.com/front-matter/commonmeta/isniutils"
+	"github.com/front-matter/commonmeta/orcidutils"
+	"github.com/front-matter/commonmeta/rorutils"
 	"github.com/front-matter/commonmeta/utils"
 )
 
@@ -118,25 +128,66 @@ type FundingReference struct {
 	FunderIdentifier     string `json:"funderIdentifier,omitempty"`
 	FunderIdentifierType string `json:"funderIdentifierType,omitempty"`
 	AwardNumber          string `json:"awardNumber,omitempty"`
+	AwardTitle           string `json:"awardTitle,omitempty"`
 	AwardURI             string `json:"awardUri,omitempty"`
 }
 
 type GeoLocation struct {
-	GeoLocationPoint `json:"geoLocationPoint,omitempty"`
-	GeoLocationBox   `json:"geoLocationBox,omitempty"`
-	GeoLocationPlace string `json:"geoLocationPlace,omitempty"`
+	GeoLocationPlace    string               `json:"geoLocationPlace,omitempty"`
+	GeoLocationPoint    *GeoLocationPoint    `json:"geoLocationPoint,omitempty"`
+	GeoLocationBox      *GeoLocationBox      `json:"geoLocationBox,omitempty"`
+	GeoLocationPolygons []GeoLocationPolygon `json:"geoLocationPolygons,omitempty"`
 }
 
 type GeoLocationBox struct {
-	WestBoundLongitude float64 `json:"westBoundLongitude,string,omitempty"`
-	EastBoundLongitude float64 `json:"eastBoundLongitude,string,omitempty"`
-	SouthBoundLatitude float64 `json:"southBoundLatitude,string,omitempty"`
-	NorthBoundLatitude float64 `json:"northBoundLatitude,string,omitempty"`
+	WestBoundLongitude float64 `json:"westBoundLongitude"`
+	EastBoundLongitude float64 `json:"eastBoundLongitude"`
+	SouthBoundLatitude float64 `json:"southBoundLatitude"`
+	NorthBoundLatitude float64 `json:"northBoundLatitude"`
+}
+
+// UnmarshalJSON unmarshals a geoLocationBox with coordinates given as
+// numbers or strings.
+func (b *GeoLocationBox) UnmarshalJSON(data []byte) error {
+	var v struct {
+		WestBoundLongitude json.Number `json:"westBoundLongitude"`
+		EastBoundLongitude json.Number `json:"eastBoundLongitude"`
+		SouthBoundLatitude json.Number `json:"southBoundLatitude"`
+		NorthBoundLatitude json.Number `json:"northBoundLatitude"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	b.WestBoundLongitude, _ = v.WestBoundLongitude.Float64()
+	b.EastBoundLongitude, _ = v.EastBoundLongitude.Float64()
+	b.SouthBoundLatitude, _ = v.SouthBoundLatitude.Float64()
+	b.NorthBoundLatitude, _ = v.NorthBoundLatitude.Float64()
+	return nil
 }
 
 type GeoLocationPoint struct {
-	PointLongitude float64 `json:"pointLongitude,string,omitempty"`
-	PointLatitude  float64 `json:"pointLatitude,string,omitempty"`
+	PointLongitude float64 `json:"pointLongitude"`
+	PointLatitude  float64 `json:"pointLatitude"`
+}
+
+// UnmarshalJSON unmarshals a geoLocationPoint with coordinates given as
+// numbers or strings.
+func (p *GeoLocationPoint) UnmarshalJSON(data []byte) error {
+	var v struct {
+		PointLongitude json.Number `json:"pointLongitude"`
+		PointLatitude  json.Number `json:"pointLatitude"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	p.PointLongitude, _ = v.PointLongitude.Float64()
+	p.PointLatitude, _ = v.PointLatitude.Float64()
+	return nil
+}
+
+type GeoLocationPolygon struct {
+	PolygonPoints  []GeoLocationPoint `json:"polygonPoints"`
+	InPolygonPoint *GeoLocationPoint  `json:"inPolygonPoint,omitempty"`
 }
 
 type NameIdentifier struct {
@@ -256,14 +307,79 @@ var CMToDCMappings = map[string]string{
 	"WebPage":               "Text",
 }
 
+// DCToCMContainerTranslations maps DataCite container types to Commonmeta container types
+var DCToCMContainerTranslations = map[string]string{
+	"Book":                 "Book",
+	"Book Series":          "BookSeries",
+	"BookSeries":           "BookSeries",
+	"ConferenceProceeding": "Proceedings",
+	"DataRepository":       "DataRepository",
+	"Journal":              "Journal",
+	"Periodical":           "Periodical",
+	"Proceedings":          "Proceedings",
+	"Repository":           "Repository",
+	"Series":               "Series",
+}
+
+// BaseURL is the base URL of the DataCite REST API.
+var BaseURL = "https://api.datacite.org"
+
+// Option configures how works are fetched from the DataCite API.
+type Option func(*options)
+
+type options struct {
+	baseURL string
+	cache   *utils.Cache
+	retry   utils.Retry
+	timeout time.Duration
+}
+
+// WithBaseURL sets the base URL of the DataCite REST API, the default is
+// BaseURL.
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithCache caches DataCite API responses in dir, and uses them for ttl.
+// A ttl of zero means cached responses never expire.
+func WithCache(dir string, ttl time.Duration) Option {
+	return func(o *options) {
+		o.cache = utils.NewCache(dir, ttl)
+	}
+}
+
+// WithRetry retries failed requests to the DataCite API up to maxAttempts
+// times in total, with exponential backoff starting at baseDelay.
+func WithRetry(maxAttempts int, baseDelay time.Duration) Option {
+	return func(o *options) {
+		o.retry = utils.Retry{MaxAttempts: maxAttempts, BaseDelay: baseDelay}
+	}
+}
+
+// WithTimeout sets the timeout of a single request to the DataCite API, the
+// default is 10 seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
 // Fetch fetches DataCite metadata for a given DOI and returns Commonmeta metadata.
-func Fetch(str string) (commonmeta.Data, error) {
+func Fetch(str string, opts ...Option) (commonmeta.Data, error) {
+	return FetchContext(context.Background(), str, opts...)
+}
+
+// FetchContext is like Fetch, but stops fetching when ctx is done, e.g.
+// when a batch job is canceled.
+func FetchContext(ctx context.Context, str string, opts ...Option) (commonmeta.Data, error) {
 	var data commonmeta.Data
 	id, ok := doiutils.ValidateDOI(str)
 	if !ok {
 		return data, errors.New("invalid doi")
 	}
-	content, err := Get(id)
+	content, err := GetContext(ctx, id, opts...)
 	if err != nil {
 		return data, err
 	}
@@ -274,6 +390,16 @@ func Fetch(str string) (commonmeta.Data, error) {
 	return data, nil
 }
 
+// FetchDataciteList fetches a list of works by DOI from the DataCite REST
+// API, using the given number of concurrent workers. Works are returned in
+// the order of the DOIs.
+func FetchDataciteList(dois []string, workers int, opts ...Option) ([]commonmeta.Data, error) {
+	fetch := func(doi string) (commonmeta.Data, error) {
+		return Fetch(doi, opts...)
+	}
+	return commonmeta.FetchList(dois, workers, fetch)
+}
+
 // FetchAll gets the metadata for a list of works from the DataCite API and returns Commonmeta metadata.
 func FetchAll(number int, sample bool) ([]commonmeta.Data, error) {
 	var data []commonmeta.Data
@@ -323,7 +449,12 @@ func LoadAll(filename string) ([]commonmeta.Data, error) {
 }
 
 // Get gets DataCite metadata for a given DOI
-func Get(id string) (Content, error) {
+func Get(id string, opts ...Option) (Content, error) {
+	return GetContext(context.Background(), id, opts...)
+}
+
+// GetContext is like Get, but stops the request when ctx is done.
+func GetContext(ctx context.Context, id string, opts ...Option) (Content, error) {
 	// the envelope for the JSON response from the DataCite API
 	type Response struct {
 		Data struct {
@@ -337,25 +468,41 @@ func Get(id string) (Content, error) {
 	if !ok {
 		return response.Data.Attributes, errors.New("invalid DOI")
 	}
-	url := "https://api.datacite.org/dois/" + doi
-	client := &http.Client{
-		Timeout: time.Second * 10,
-	}
-	resp, err := client.Get(url)
-	if err != nil {
-		return response.Data.Attributes, err
-	}
-	if resp.StatusCode >= 400 {
-		return response.Data.Attributes, errors.New(resp.Status)
+	o := options{baseURL: BaseURL, retry: utils.DefaultRetry, timeout: 10 * time.Second}
+	for _, opt := range opts {
+		opt(&o)
 	}
-	defer resp.Body.Close()
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return response.Data.Attributes, err
+	key := "datacite/" + doi
+	body, ok := o.cache.Get(key)
+	if !ok {
+		url := o.baseURL + "/dois/" + doi
+		client := &http.Client{
+			Timeout: o.timeout,
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return response.Data.Attributes, err
+		}
+		resp, err := utils.DoWithRetry(client, req, o.retry)
+		if err != nil {
+			return response.Data.Attributes, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= 400 {
+			return response.Data.Attributes, errors.New(resp.Status)
+		}
+		body, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return response.Data.Attributes, err
+		}
+		err = o.cache.Set(key, body)
+		if err != nil {
+			log.Println(err)
+		}
 	}
-	err = json.Unmarshal(body, &response)
+	err := json.Unmarshal(body, &response)
 	if err != nil {
-		fmt.Println("error:", err)
+		slog.Error("error unmarshalling JSON", "error", err)
 	}
 	return response.Data.Attributes, err
 }
@@ -378,10 +525,22 @@ func Read(content Content) (commonmeta.Data, error) {
 		data.AdditionalType = content.Types.ResourceType
 	}
 
+	containerType := DCToCMContainerTranslations[content.Container.Type]
+	if containerType == "" && content.Container.Title != "" {
+		containerType = commonmeta.ContainerTypes[data.Type]
+	}
+	containerIdentifier := content.Container.Identifier
+	// the linking ISSN (ISSN-L) groups the print and electronic ISSN of a
+	// journal, the ISSN is kept if the lookup fails
+	if content.Container.IdentifierType == "ISSN" && containerIdentifier != "" {
+		if issnl, err := utils.GetISSNL(containerIdentifier); err == nil {
+			containerIdentifier = issnl
+		}
+	}
 	data.Container = commonmeta.Container{
-		Identifier:     content.Container.Identifier,
+		Identifier:     containerIdentifier,
 		IdentifierType: content.Container.IdentifierType,
-		Type:           content.Container.Type,
+		Type:           containerType,
 		Title:          content.Container.Title,
 		Volume:         content.Container.Volume,
 		Issue:          content.Container.Issue,
@@ -418,37 +577,45 @@ func Read(content Content) (commonmeta.Data, error) {
 	}
 
 	for _, v := range content.Dates {
+		// DataCite dates are often given in EDTF, e.g. as interval
+		date := v.Date
+		if e, err := dateutils.ParseEDTF(v.Date); err == nil {
+			date = e.String()
+		}
 		if v.DateType == "Accepted" {
-			data.Date.Accepted = v.Date
+			data.Date.Accepted = date
 		}
 		if v.DateType == "Available" {
-			data.Date.Available = v.Date
+			data.Date.Available = date
 		}
 		if v.DateType == "Collected" {
-			data.Date.Collected = v.Date
+			data.Date.Collected = date
+		}
+		if v.DateType == "Copyrighted" {
+			data.Date.Copyrighted = date
 		}
 		if v.DateType == "Created" {
-			data.Date.Created = v.Date
+			data.Date.Created = date
 		}
 		if v.DateType == "Issued" {
-			data.Date.Published = v.Date
+			data.Date.Published = date
 		} else if v.DateType == "Published" {
-			data.Date.Published = v.Date
+			data.Date.Published = date
 		}
 		if v.DateType == "Submitted" {
-			data.Date.Submitted = v.Date
+			data.Date.Submitted = date
 		}
 		if v.DateType == "Updated" {
-			data.Date.Updated = v.Date
+			data.Date.Updated = date
 		}
 		if v.DateType == "Valid" {
-			data.Date.Valid = v.Date
+			data.Date.Valid = date
 		}
 		if v.DateType == "Withdrawn" {
-			data.Date.Withdrawn = v.Date
+			data.Date.Withdrawn = date
 		}
 		if v.DateType == "Other" {
-			data.Date.Other = v.Date
+			data.Date.Other = date
 		}
 	}
 	if data.Date.Published == "" {
@@ -479,44 +646,51 @@ func Read(content Content) (commonmeta.Data, error) {
 			FunderIdentifierType: v.FunderIdentifierType,
 			FunderName:           v.FunderName,
 			AwardNumber:          v.AwardNumber,
+			AwardTitle:           v.AwardTitle,
 			AwardURI:             v.AwardURI,
 		})
 	}
 	for _, v := range content.GeoLocations {
 		geoLocation := commonmeta.GeoLocation{
 			GeoLocationPlace: v.GeoLocationPlace,
-			GeoLocationPoint: commonmeta.GeoLocationPoint{
-				PointLongitude: v.GeoLocationPoint.PointLongitude,
-				PointLatitude:  v.GeoLocationPoint.PointLatitude,
-			},
-			GeoLocationBox: commonmeta.GeoLocationBox{
+		}
+		if v.GeoLocationPoint != nil {
+			geoLocation.GeoLocationPoint = commonmeta.GeoLocationPoint(*v.GeoLocationPoint)
+		}
+		if v.GeoLocationBox != nil {
+			geoLocation.GeoLocationBox = commonmeta.GeoLocationBox{
 				WestBoundLongitude: v.GeoLocationBox.WestBoundLongitude,
 				EastBoundLongitude: v.GeoLocationBox.EastBoundLongitude,
 				SouthBoundLatitude: v.GeoLocationBox.SouthBoundLatitude,
 				NorthBoundLatitude: v.GeoLocationBox.NorthBoundLatitude,
-			},
+			}
 		}
-		data.GeoLocations = append(data.GeoLocations, geoLocation)
-	}
-
-	if len(content.AlternateIdentifiers) > 0 {
-		for _, v := range content.AlternateIdentifiers {
-			identifierType := "Other"
-			if slices.Contains(commonmeta.IdentifierTypes, v.AlternateIdentifierType) {
-				identifierType = v.AlternateIdentifierType
+		for _, p := range v.GeoLocationPolygons {
+			polygon := commonmeta.GeoLocationPolygon{}
+			for _, pp := range p.PolygonPoints {
+				polygon.PolygonPoints = append(polygon.PolygonPoints, commonmeta.GeoLocationPoint(pp))
 			}
-			if v.AlternateIdentifier != "" {
-				data.Identifiers = append(data.Identifiers, commonmeta.Identifier{
-					Identifier:     v.AlternateIdentifier,
-					IdentifierType: identifierType,
-				})
+			if p.InPolygonPoint != nil {
+				polygon.InPolygonPoint = commonmeta.GeoLocationPoint(*p.InPolygonPoint)
 			}
+			geoLocation.GeoLocationPolygons = append(geoLocation.GeoLocationPolygons, polygon)
 		}
+		data.GeoLocations = append(data.GeoLocations, geoLocation)
 	}
+
+	// the DOI is the primary identifier, followed by alternate identifiers
 	data.Identifiers = append(data.Identifiers, commonmeta.Identifier{
 		Identifier:     data.ID,
 		IdentifierType: "DOI",
 	})
+	for _, v := range content.AlternateIdentifiers {
+		if v.AlternateIdentifier != "" {
+			data.Identifiers = append(data.Identifiers, commonmeta.Identifier{
+				Identifier:     v.AlternateIdentifier,
+				IdentifierType: commonmeta.GetIdentifierType(v.AlternateIdentifier, v.AlternateIdentifierType),
+			})
+		}
+	}
 	if len(data.Identifiers) > 1 {
 		data.Identifiers = utils.DedupeSlice(data.Identifiers)
 	}
@@ -552,11 +726,10 @@ func Read(content Content) (commonmeta.Data, error) {
 		}
 	}
 
-	data.Language = content.Language
+	data.Language = langutils.GetLanguage(content.Language, langutils.ISO6391)
 
 	if len(content.RightsList) > 0 {
-		url, _ := utils.NormalizeCCUrl(content.RightsList[0].RightsURI)
-		id := utils.URLToSPDX(url)
+		id, url := spdxutils.Normalize(content.RightsList[0].RightsURI)
 		data.License = commonmeta.License{
 			ID:  id,
 			URL: url,
@@ -593,11 +766,14 @@ func Read(content Content) (commonmeta.Data, error) {
 			"IsOriginalFormOf",
 			"IsIdenticalTo",
 			"IsTranslationOf",
+			"HasTranslation",
 			"IsReviewedBy",
 			"Reviews",
+			"HasReview",
 			"IsPreprintOf",
 			"HasPreprint",
 			"IsSupplementTo",
+			"IsSupplementedBy",
 		}
 		for _, v := range content.RelatedIdentifiers {
 			id := utils.NormalizeID(v.RelatedIdentifier)
@@ -615,11 +791,11 @@ func Read(content Content) (commonmeta.Data, error) {
 
 	for _, v := range content.Titles {
 		var t string
-		if slices.Contains([]string{"MainTitle", "Subtitle", "TranslatedTitle"}, v.TitleType) {
+		if slices.Contains([]string{"AlternativeTitle", "Subtitle", "TranslatedTitle"}, v.TitleType) {
 			t = v.TitleType
 		}
 		data.Titles = append(data.Titles, commonmeta.Title{
-			Title:    v.Title,
+			Title:    textutils.DecodeEntities(v.Title),
 			Type:     t,
 			Language: v.Lang,
 		})
@@ -642,10 +818,27 @@ func GetContributor(v ContentContributor) commonmeta.Contributor {
 		t = v.NameType[:len(v.NameType)-2]
 	}
 	var id string
-	if len(v.NameIdentifiers) > 0 {
-		ni := v.NameIdentifiers[0]
+	var identifiers []commonmeta.Identifier
+	var found bool
+	for _, ni := range v.NameIdentifiers {
+		// ISNIs are kept in the identifier list, the id is taken from
+		// the first other name identifier
+		if ni.NameIdentifierScheme == "ISNI" {
+			isni := isniutils.NormalizeISNI(ni.NameIdentifier)
+			if isni != "" {
+				identifiers = append(identifiers, commonmeta.Identifier{
+					Identifier:     isni,
+					IdentifierType: "ISNI",
+				})
+			}
+			continue
+		}
+		if found {
+			continue
+		}
+		found = true
 		if ni.NameIdentifierScheme == "ORCID" || ni.NameIdentifierScheme == "https://orcid.org/" {
-			id = utils.NormalizeORCID(ni.NameIdentifier)
+			id, _ = orcidutils.ValidateORCID(ni.NameIdentifier)
 			t = "Person"
 		} else if ni.NameIdentifierScheme == "ROR" {
 			id = ni.NameIdentifier
@@ -659,6 +852,9 @@ func GetContributor(v ContentContributor) commonmeta.Contributor {
 	FamilyName := v.FamilyName
 	if t == "" && (v.GivenName != "" || v.FamilyName != "") {
 		t = "Person"
+	} else if t == "" && strings.Contains(name, ",") && authorutils.IsPersonalName(name) {
+		// personal names are given as "Family, Given"
+		t = "Person"
 	} else if t == "" {
 		t = "Organization"
 	}
@@ -672,33 +868,7 @@ func GetContributor(v ContentContributor) commonmeta.Contributor {
 		}
 	}
 
-	//parse Affiliation as either slice of string or slice of struct
-	var affiliationStructs []Affiliation
-	var affiliationNames []string
-	var affiliations []*commonmeta.Affiliation
-	var err error
-	err = json.Unmarshal(v.Affiliation, &affiliationNames)
-	if err != nil {
-		err = json.Unmarshal(v.Affiliation, &affiliationStructs)
-	}
-	if err != nil {
-		log.Println(err)
-	}
-	if len(affiliationStructs) > 0 {
-		for _, v := range affiliationStructs {
-			id := utils.NormalizeROR(v.AffiliationIdentifier)
-			af := commonmeta.Affiliation{
-				ID:   id,
-				Name: v.Name,
-			}
-			affiliations = append(affiliations, &af)
-		}
-	} else if len(affiliationNames) > 0 {
-		af := commonmeta.Affiliation{
-			Name: affiliationNames[0],
-		}
-		affiliations = append(affiliations, &af)
-	}
+	affiliations := GetAffiliations(v.Affiliation)
 
 	var roles []string
 	if slices.Contains(commonmeta.ContributorRoles, v.ContributorType) {
@@ -714,7 +884,50 @@ func GetContributor(v ContentContributor) commonmeta.Contributor {
 		FamilyName:       FamilyName,
 		Affiliations:     affiliations,
 		ContributorRoles: roles,
+		Identifiers:      identifiers,
+	}
+}
+
+// GetAffiliations converts DataCite affiliations into the Commonmeta format.
+// Affiliations can be plain strings or structs with an affiliation identifier,
+// the ROR ID is kept if the identifier scheme is ROR.
+func GetAffiliations(raw json.RawMessage) []*commonmeta.Affiliation {
+	var affiliations []*commonmeta.Affiliation
+	if len(raw) == 0 {
+		return affiliations
+	}
+	var list []json.RawMessage
+	err := json.Unmarshal(raw, &list)
+	if err != nil {
+		// a single affiliation, not wrapped in a list
+		list = []json.RawMessage{raw}
+	}
+	for _, item := range list {
+		var name string
+		if err := json.Unmarshal(item, &name); err == nil {
+			if name != "" {
+				affiliations = append(affiliations, &commonmeta.Affiliation{Name: name})
+			}
+			continue
+		}
+		var a Affiliation
+		if err := json.Unmarshal(item, &a); err != nil {
+			log.Println(err)
+			continue
+		}
+		var id string
+		if a.AffiliationIdentifierScheme == "" || strings.EqualFold(a.AffiliationIdentifierScheme, "ROR") {
+			id = rorutils.NormalizeROR(a.AffiliationIdentifier)
+		}
+		if id == "" && a.Name == "" {
+			continue
+		}
+		affiliations = append(affiliations, &commonmeta.Affiliation{
+			ID:   id,
+			Name: a.Name,
+		})
 	}
+	return affiliations
 }
 
 // GetAll gets the metadata for a list of works from the DataCite API
@@ -749,11 +962,102 @@ func GetAll(number int, sample bool) ([]Content, error) {
 	}
 	err = json.Unmarshal(body, &response)
 	if err != nil {
-		fmt.Println("error:", err)
+		slog.Error("error unmarshalling JSON", "error", err)
 	}
 	return response.Data, nil
 }
 
+// QueryParams are the parameters for a query of the DataCite dois API.
+type QueryParams struct {
+	Query        string // Elasticsearch query string, e.g. "climate"
+	ClientID     string // DataCite repository ID, e.g. cern.zenodo
+	ResourceType string // DataCite resource type ID, e.g. dataset
+	Size         int    // number of works per page, default and maximum 1000
+	Limit        int    // maximum number of works, default is all works
+}
+
+// QueryDois queries the DataCite dois API, following the links.next cursor
+// until all works matching the query (or Limit works) are fetched, and
+// converts them to the Commonmeta format. Use it to harvest all DOIs of a
+// repository.
+func QueryDois(params QueryParams, opts ...Option) ([]commonmeta.Data, error) {
+	// the envelope for the JSON response from the DataCite API
+	type Response struct {
+		Data []struct {
+			ID         string  `json:"id"`
+			Attributes Content `json:"attributes"`
+		} `json:"data"`
+		Links struct {
+			Next string `json:"next"`
+		} `json:"links"`
+	}
+
+	o := options{baseURL: BaseURL, retry: utils.DefaultRetry}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	size := params.Size
+	if size <= 0 || size > 1000 {
+		size = 1000
+	}
+	if params.Limit > 0 && params.Limit < size {
+		size = params.Limit
+	}
+	u, _ := url.Parse(o.baseURL + "/dois")
+	values := u.Query()
+	values.Add("page[cursor]", "1")
+	values.Add("page[size]", strconv.Itoa(size))
+	if params.Query != "" {
+		values.Add("query", params.Query)
+	}
+	if params.ClientID != "" {
+		values.Add("client-id", params.ClientID)
+	}
+	if params.ResourceType != "" {
+		values.Add("resource-type-id", params.ResourceType)
+	}
+	u.RawQuery = values.Encode()
+
+	client := &http.Client{
+		Timeout: 60 * time.Second,
+	}
+	var content []Content
+	next := u.String()
+	for next != "" {
+		req, err := http.NewRequest(http.MethodGet, next, nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := utils.DoWithRetry(client, req, o.retry)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode >= 400 {
+			resp.Body.Close()
+			return nil, errors.New(resp.Status)
+		}
+		var response Response
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, v := range response.Data {
+			content = append(content, v.Attributes)
+		}
+		if params.Limit > 0 && len(content) >= params.Limit {
+			content = content[:params.Limit]
+			break
+		}
+		if len(response.Data) == 0 {
+			break
+		}
+		next = response.Links.Next
+	}
+	return ReadAll(content)
+}
+
 // ReadAll reads a list of DataCite JSON responses and returns a list of works in Commonmeta format
 func ReadAll(content []Content) ([]commonmeta.Data, error) {
 	var data []commonmeta.Data
@@ -772,7 +1076,7 @@ func QueryURL(number int, sample bool) string {
 	if sample {
 		number = 10
 	}
-	url := "https://api.datacite.org/dois?random=true&page[size]=" + strconv.Itoa(number)
+	url := BaseURL + "/dois?random=true&page[size]=" + strconv.Itoa(number)
 	return url
 }
 
@@ -1,12 +1,19 @@
 package datacite_test
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/front-matter/commonmeta/commonmeta"
 	"github.com/front-matter/commonmeta/datacite"
@@ -112,3 +119,368 @@ func TestFetch(t *testing.T) {
 // 		}
 // 	}
 // }
+
+func TestFetchWithCache(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		doi := strings.TrimPrefix(r.URL.Path, "/dois/")
+		fmt.Fprintf(w, `{"data": {"id": %q, "attributes": {"doi": %q, "titles": [{"title": "Title"}], "types": {"resourceTypeGeneral": "Dataset"}, "publicationYear": 2024}}}`, doi, doi)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	first, err := datacite.Fetch("10.5555/12345678", datacite.WithCache(dir, time.Hour), datacite.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := datacite.Fetch("10.5555/12345678", datacite.WithCache(dir, time.Hour), datacite.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if requests != 1 {
+		t.Errorf("Fetch with cache: want 1 request, got %d", requests)
+	}
+	if first.ID != "https://doi.org/10.5555/12345678" {
+		t.Errorf("Fetch with cache ID: want https://doi.org/10.5555/12345678, got %v", first.ID)
+	}
+	if diff := cmp.Diff(first, second); diff != "" {
+		t.Errorf("Fetch with cache mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFetchWithRetry(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&requests, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		doi := strings.TrimPrefix(r.URL.Path, "/dois/")
+		fmt.Fprintf(w, `{"data": {"id": %q, "attributes": {"doi": %q, "titles": [{"title": "Title"}], "types": {"resourceTypeGeneral": "Dataset"}, "publicationYear": 2024}}}`, doi, doi)
+	}))
+	defer server.Close()
+
+	data, err := datacite.Fetch("10.5555/12345678", datacite.WithRetry(3, time.Millisecond), datacite.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("Fetch with retry: error %v", err)
+	}
+	if requests != 3 {
+		t.Errorf("Fetch with retry: want 3 requests, got %d", requests)
+	}
+	if data.ID != "https://doi.org/10.5555/12345678" {
+		t.Errorf("Fetch with retry ID: want https://doi.org/10.5555/12345678, got %v", data.ID)
+	}
+}
+
+func TestGetAffiliations(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name  string
+		input string
+		want  []*commonmeta.Affiliation
+	}
+
+	testCases := []testCase{
+		{
+			name:  "ror",
+			input: `[{"affiliationIdentifier":"https://ror.org/04wxnsj81","affiliationIdentifierScheme":"ROR","name":"DataCite"}]`,
+			want:  []*commonmeta.Affiliation{{ID: "https://ror.org/04wxnsj81", Name: "DataCite"}},
+		},
+		{
+			name:  "invalid ror checksum",
+			input: `[{"affiliationIdentifier":"https://ror.org/04wxnsj82","affiliationIdentifierScheme":"ROR","name":"DataCite"}]`,
+			want:  []*commonmeta.Affiliation{{Name: "DataCite"}},
+		},
+		{
+			name:  "other scheme",
+			input: `[{"affiliationIdentifier":"grid.475826.a","affiliationIdentifierScheme":"GRID","name":"DataCite"}]`,
+			want:  []*commonmeta.Affiliation{{Name: "DataCite"}},
+		},
+		{
+			name:  "strings",
+			input: `["DataCite","Front Matter"]`,
+			want:  []*commonmeta.Affiliation{{Name: "DataCite"}, {Name: "Front Matter"}},
+		},
+		{
+			name:  "single string",
+			input: `"DataCite"`,
+			want:  []*commonmeta.Affiliation{{Name: "DataCite"}},
+		},
+		{
+			name:  "mixed",
+			input: `["Front Matter",{"affiliationIdentifier":"04wxnsj81","affiliationIdentifierScheme":"ROR","name":"DataCite"}]`,
+			want:  []*commonmeta.Affiliation{{Name: "Front Matter"}, {ID: "https://ror.org/04wxnsj81", Name: "DataCite"}},
+		},
+	}
+	for _, tc := range testCases {
+		got := datacite.GetAffiliations(json.RawMessage(tc.input))
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("GetAffiliations (%v) mismatch (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestGetContributorISNI(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name  string
+		input string
+		want  commonmeta.Contributor
+	}
+
+	testCases := []testCase{
+		{
+			name:  "orcid and isni",
+			input: `{"name":"Fenner, Martin","nameType":"Personal","nameIdentifiers":[{"nameIdentifier":"https://orcid.org/0000-0003-1419-2405","nameIdentifierScheme":"ORCID"},{"nameIdentifier":"0000 0001 2103 2683","nameIdentifierScheme":"ISNI"}]}`,
+			want: commonmeta.Contributor{
+				ID:               "https://orcid.org/0000-0003-1419-2405",
+				Type:             "Person",
+				GivenName:        "Martin",
+				FamilyName:       "Fenner",
+				ContributorRoles: []string{"Author"},
+				Identifiers:      []commonmeta.Identifier{{Identifier: "https://isni.org/isni/0000000121032683", IdentifierType: "ISNI"}},
+			},
+		},
+		{
+			name:  "invalid isni",
+			input: `{"name":"British Library","nameType":"Organizational","nameIdentifiers":[{"nameIdentifier":"0000 0001 2281 9550","nameIdentifierScheme":"ISNI"}]}`,
+			want: commonmeta.Contributor{
+				Type:             "Organization",
+				Name:             "British Library",
+				ContributorRoles: []string{"Author"},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		var v datacite.ContentContributor
+		if err := json.Unmarshal([]byte(tc.input), &v); err != nil {
+			t.Fatal(err)
+		}
+		got := datacite.GetContributor(v)
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("GetContributor (%v) mismatch (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestReadEDTFDates(t *testing.T) {
+	t.Parallel()
+
+	input := `{"doi":"10.5555/edtf","types":{"resourceTypeGeneral":"Dataset"},"dates":[{"date":"2019~","dateType":"Created"},{"date":"2020-01/2020-06","dateType":"Collected"},{"date":"1984-XX","dateType":"Issued"},{"date":"2021-01-22T10:12:00Z","dateType":"Updated"}]}`
+	var content datacite.Content
+	if err := json.Unmarshal([]byte(input), &content); err != nil {
+		t.Fatal(err)
+	}
+	got, err := datacite.Read(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := commonmeta.Date{
+		Created:   "2019",
+		Collected: "2020-01/2020-06",
+		Published: "1984",
+		Updated:   "2021-01-22T10:12:00Z",
+	}
+	if diff := cmp.Diff(want, got.Date); diff != "" {
+		t.Errorf("Read EDTF dates mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadGeoLocations(t *testing.T) {
+	t.Parallel()
+
+	// coordinates are given as strings by the DataCite REST API
+	input := `{"doi":"10.5555/geo","types":{"resourceTypeGeneral":"Dataset"},"geoLocations":[{"geoLocationPlace":"Providence Creek","geoLocationPoint":{"pointLongitude":"-119.221094","pointLatitude":"37.047756"}},{"geoLocationBox":{"eastBoundLongitude":-119.182,"westBoundLongitude":-119.211,"southBoundLatitude":37.046,"northBoundLatitude":37.075}}]}`
+	var content datacite.Content
+	if err := json.Unmarshal([]byte(input), &content); err != nil {
+		t.Fatal(err)
+	}
+	got, err := datacite.Read(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []commonmeta.GeoLocation{
+		{
+			GeoLocationPlace: "Providence Creek",
+			GeoLocationPoint: commonmeta.GeoLocationPoint{PointLongitude: -119.221094, PointLatitude: 37.047756},
+		},
+		{
+			GeoLocationBox: commonmeta.GeoLocationBox{
+				EastBoundLongitude: -119.182,
+				WestBoundLongitude: -119.211,
+				SouthBoundLatitude: 37.046,
+				NorthBoundLatitude: 37.075,
+			},
+		},
+	}
+	if diff := cmp.Diff(want, got.GeoLocations); diff != "" {
+		t.Errorf("Read geoLocations mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadTitles(t *testing.T) {
+	t.Parallel()
+
+	input := `{"doi":"10.5555/titles","types":{"resourceTypeGeneral":"Dataset"},"titles":[{"title":"Main"},{"title":"Sub","titleType":"Subtitle"},{"title":"Haupt","titleType":"TranslatedTitle","lang":"de"},{"title":"Other","titleType":"AlternativeTitle"},{"title":"Unknown","titleType":"Other"}]}`
+	var content datacite.Content
+	if err := json.Unmarshal([]byte(input), &content); err != nil {
+		t.Fatal(err)
+	}
+	got, err := datacite.Read(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []commonmeta.Title{
+		{Title: "Main"},
+		{Title: "Sub", Type: "Subtitle"},
+		{Title: "Haupt", Type: "TranslatedTitle", Language: "de"},
+		{Title: "Other", Type: "AlternativeTitle"},
+		{Title: "Unknown"},
+	}
+	if diff := cmp.Diff(want, got.Titles); diff != "" {
+		t.Errorf("Read titles mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadIdentifiers(t *testing.T) {
+	t.Parallel()
+
+	input := `{"doi":"10.5555/identifiers","types":{"resourceTypeGeneral":"JournalArticle"},"alternateIdentifiers":[{"alternateIdentifier":"24567894","alternateIdentifierType":"PubMed"},{"alternateIdentifier":"PMC3926531","alternateIdentifierType":"pmcid"},{"alternateIdentifier":"2101.00001","alternateIdentifierType":""},{"alternateIdentifier":"Z-123","alternateIdentifierType":"Local accession number"}]}`
+	var content datacite.Content
+	if err := json.Unmarshal([]byte(input), &content); err != nil {
+		t.Fatal(err)
+	}
+	got, err := datacite.Read(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != "https://doi.org/10.5555/identifiers" {
+		t.Errorf("Read identifiers ID: want https://doi.org/10.5555/identifiers, got %v", got.ID)
+	}
+	want := []commonmeta.Identifier{
+		{Identifier: "https://doi.org/10.5555/identifiers", IdentifierType: "DOI"},
+		{Identifier: "24567894", IdentifierType: "PMID"},
+		{Identifier: "PMC3926531", IdentifierType: "PMCID"},
+		{Identifier: "2101.00001", IdentifierType: "arXiv"},
+		{Identifier: "Z-123", IdentifierType: "Other"},
+	}
+	if diff := cmp.Diff(want, got.Identifiers); diff != "" {
+		t.Errorf("Read identifiers mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadContainerType(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name  string
+		input string
+		want  string
+	}
+
+	testCases := []testCase{
+		{name: "journal", input: `{"doi":"10.5555/journal","types":{"resourceTypeGeneral":"JournalArticle"},"container":{"type":"Journal","title":"Journal of Metadata"}}`, want: "Journal"},
+		{name: "book series", input: `{"doi":"10.5555/book","types":{"resourceTypeGeneral":"Book"},"container":{"type":"Book Series","title":"Metadata Series"}}`, want: "BookSeries"},
+		{name: "untyped", input: `{"doi":"10.5555/chapter","types":{"resourceTypeGeneral":"BookChapter"},"container":{"title":"Handbook of Metadata"}}`, want: "Book"},
+		{name: "no container", input: `{"doi":"10.5555/dataset","types":{"resourceTypeGeneral":"Dataset"}}`, want: ""},
+	}
+	for _, tc := range testCases {
+		var content datacite.Content
+		if err := json.Unmarshal([]byte(tc.input), &content); err != nil {
+			t.Fatal(err)
+		}
+		got, err := datacite.Read(content)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Container.Type != tc.want {
+			t.Errorf("Read container type (%s): want %q, got %q", tc.name, tc.want, got.Container.Type)
+		}
+	}
+}
+
+func TestQueryDois(t *testing.T) {
+	t.Parallel()
+
+	// five DOIs on three pages of two, linked by links.next
+	var cursors []string
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/dois" || query.Get("client-id") != "cern.zenodo" || query.Get("resource-type-id") != "dataset" || query.Get("query") != "climate" {
+			http.Error(w, "unexpected query", http.StatusBadRequest)
+			return
+		}
+		cursor := query.Get("page[cursor]")
+		cursors = append(cursors, cursor)
+		offset := 0
+		if cursor != "1" {
+			offset, _ = strconv.Atoi(strings.TrimPrefix(cursor, "page-"))
+		}
+		var items []string
+		for i := offset; i < min(offset+2, 5); i++ {
+			items = append(items, fmt.Sprintf(`{"id": "10.5281/zenodo.%d", "attributes": {"doi": "10.5281/zenodo.%d", "types": {"resourceTypeGeneral": "Dataset"}, "titles": [{"title": "Dataset %d"}]}}`, i+1, i+1, i+1))
+		}
+		next := ""
+		if offset+2 < 5 {
+			next = fmt.Sprintf("%s/dois?client-id=cern.zenodo&query=climate&resource-type-id=dataset&page%%5Bcursor%%5D=page-%d&page%%5Bsize%%5D=2", server.URL, offset+2)
+		}
+		fmt.Fprintf(w, `{"data": [%s], "links": {"next": %q}}`, strings.Join(items, ","), next)
+	}))
+	defer server.Close()
+
+	params := datacite.QueryParams{Query: "climate", ClientID: "cern.zenodo", ResourceType: "dataset", Size: 2}
+	data, err := datacite.QueryDois(params, datacite.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, d := range data {
+		got = append(got, d.ID)
+	}
+	want := []string{
+		"https://doi.org/10.5281/zenodo.1",
+		"https://doi.org/10.5281/zenodo.2",
+		"https://doi.org/10.5281/zenodo.3",
+		"https://doi.org/10.5281/zenodo.4",
+		"https://doi.org/10.5281/zenodo.5",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("QueryDois mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"1", "page-2", "page-4"}, cursors); diff != "" {
+		t.Errorf("QueryDois cursors mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestFetchContextCanceled(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+	_, err := datacite.FetchContext(ctx, "10.5555/12345678", datacite.WithTimeout(5*time.Second), datacite.WithBaseURL(server.URL))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("FetchContext: want context.Canceled, got %v", err)
+	}
+}
@@ -2,7 +2,7 @@ package datacite
 
 import (
 	"encoding/json"
-	"fmt"
+	"log/slog"
 	"slices"
 	"strconv"
 
@@ -66,12 +66,13 @@ func Convert(data commonmeta.Data) (Datacite, error) {
 				affiliation := a.Name
 				affiliations = append(affiliations, affiliation)
 			}
+			nameType := commonmeta.GetContributorType(v) + "al"
 			if slices.Contains(v.ContributorRoles, "Author") {
 				contributor := Contributor{
 					Name:            v.Name,
 					GivenName:       v.GivenName,
 					FamilyName:      v.FamilyName,
-					NameType:        v.Type + "al",
+					NameType:        nameType,
 					NameIdentifiers: nameIdentifiers,
 					Affiliation:     affiliations,
 				}
@@ -82,7 +83,7 @@ func Convert(data commonmeta.Data) (Datacite, error) {
 					Name:            v.Name,
 					GivenName:       v.GivenName,
 					FamilyName:      v.FamilyName,
-					NameType:        v.Type + "al",
+					NameType:        nameType,
 					NameIdentifiers: nameIdentifiers,
 					Affiliation:     affiliations,
 					ContributorType: contributorType,
@@ -123,61 +124,30 @@ func Convert(data commonmeta.Data) (Datacite, error) {
 		}
 	}
 
-	if data.Date.Created != "" {
-		datacite.Dates = append(datacite.Dates, Date{
-			Date:     data.Date.Created,
-			DateType: "Created",
-		})
-	} else if data.Date.Submitted != "" {
-		datacite.Dates = append(datacite.Dates, Date{
-			Date:     data.Date.Submitted,
-			DateType: "Submitted",
-		})
-	} else if data.Date.Accepted != "" {
-		datacite.Dates = append(datacite.Dates, Date{
-			Date:     data.Date.Accepted,
-			DateType: "Accepted",
-		})
-	} else if data.Date.Published != "" {
-		datacite.Dates = append(datacite.Dates, Date{
-			Date:     data.Date.Published,
-			DateType: "Issued",
-		})
-	} else if data.Date.Updated != "" {
-		datacite.Dates = append(datacite.Dates, Date{
-			Date:     data.Date.Updated,
-			DateType: "Updated",
-		})
-	} else if data.Date.Accessed != "" {
-		datacite.Dates = append(datacite.Dates, Date{
-			Date:     data.Date.Accessed,
-			DateType: "Accessed",
-		})
-	} else if data.Date.Available != "" {
-		datacite.Dates = append(datacite.Dates, Date{
-			Date:     data.Date.Available,
-			DateType: "Available",
-		})
-	} else if data.Date.Collected != "" {
-		datacite.Dates = append(datacite.Dates, Date{
-			Date:     data.Date.Collected,
-			DateType: "Collected",
-		})
-	} else if data.Date.Valid != "" {
-		datacite.Dates = append(datacite.Dates, Date{
-			Date:     data.Date.Valid,
-			DateType: "Valid",
-		})
-	} else if data.Date.Withdrawn != "" {
-		datacite.Dates = append(datacite.Dates, Date{
-			Date:     data.Date.Withdrawn,
-			DateType: "Withdrawn",
-		})
-	} else if data.Date.Other != "" {
-		datacite.Dates = append(datacite.Dates, Date{
-			Date:     data.Date.Other,
-			DateType: "Other",
-		})
+	// date ranges, e.g. a collection period, are written as ISO 8601 intervals
+	dates := []struct {
+		date     string
+		dateType string
+	}{
+		{data.Date.Accepted, "Accepted"},
+		{data.Date.Available, "Available"},
+		{data.Date.Collected, "Collected"},
+		{data.Date.Copyrighted, "Copyrighted"},
+		{data.Date.Created, "Created"},
+		{data.Date.Published, "Issued"},
+		{data.Date.Submitted, "Submitted"},
+		{data.Date.Updated, "Updated"},
+		{data.Date.Valid, "Valid"},
+		{data.Date.Withdrawn, "Withdrawn"},
+		{data.Date.Other, "Other"},
+	}
+	for _, v := range dates {
+		if v.date != "" {
+			datacite.Dates = append(datacite.Dates, Date{
+				Date:     v.date,
+				DateType: v.dateType,
+			})
+		}
 	}
 
 	if len(data.Descriptions) > 0 {
@@ -198,28 +168,41 @@ func Convert(data commonmeta.Data) (Datacite, error) {
 				FunderIdentifier:     v.FunderIdentifier,
 				FunderIdentifierType: v.FunderIdentifierType,
 				AwardNumber:          v.AwardNumber,
+				AwardTitle:           v.AwardTitle,
 				AwardURI:             v.AwardURI,
 			}
 			datacite.FundingReferences = append(datacite.FundingReferences, fundingReference)
 		}
 	}
-	if len(data.GeoLocations) > 0 {
-		for _, v := range data.GeoLocations {
-			geoLocation := GeoLocation{
-				GeoLocationPlace: v.GeoLocationPlace,
-				GeoLocationPoint: GeoLocationPoint{
-					PointLongitude: v.GeoLocationPoint.PointLongitude,
-					PointLatitude:  v.GeoLocationPoint.PointLatitude,
-				},
-				GeoLocationBox: GeoLocationBox{
-					WestBoundLongitude: v.GeoLocationBox.WestBoundLongitude,
-					EastBoundLongitude: v.GeoLocationBox.EastBoundLongitude,
-					SouthBoundLatitude: v.GeoLocationBox.SouthBoundLatitude,
-					NorthBoundLatitude: v.GeoLocationBox.NorthBoundLatitude,
-				},
+	for _, v := range data.GeoLocations {
+		geoLocation := GeoLocation{
+			GeoLocationPlace: v.GeoLocationPlace,
+		}
+		// the DataCite schema requires all coordinates of a point or box
+		if v.GeoLocationPoint != (commonmeta.GeoLocationPoint{}) {
+			point := GeoLocationPoint(v.GeoLocationPoint)
+			geoLocation.GeoLocationPoint = &point
+		}
+		if v.GeoLocationBox != (commonmeta.GeoLocationBox{}) {
+			geoLocation.GeoLocationBox = &GeoLocationBox{
+				WestBoundLongitude: v.GeoLocationBox.WestBoundLongitude,
+				EastBoundLongitude: v.GeoLocationBox.EastBoundLongitude,
+				SouthBoundLatitude: v.GeoLocationBox.SouthBoundLatitude,
+				NorthBoundLatitude: v.GeoLocationBox.NorthBoundLatitude,
+			}
+		}
+		for _, p := range v.GeoLocationPolygons {
+			polygon := GeoLocationPolygon{}
+			for _, pp := range p.PolygonPoints {
+				polygon.PolygonPoints = append(polygon.PolygonPoints, GeoLocationPoint(pp))
+			}
+			if p.InPolygonPoint != (commonmeta.GeoLocationPoint{}) {
+				point := GeoLocationPoint(p.InPolygonPoint)
+				polygon.InPolygonPoint = &point
 			}
-			datacite.GeoLocations = append(datacite.GeoLocations, geoLocation)
+			geoLocation.GeoLocationPolygons = append(geoLocation.GeoLocationPolygons, polygon)
 		}
+		datacite.GeoLocations = append(datacite.GeoLocations, geoLocation)
 	}
 	datacite.Language = data.Language
 	if len(data.Subjects) > 0 {
@@ -242,8 +225,12 @@ func Convert(data commonmeta.Data) (Datacite, error) {
 			id := doiutils.NormalizeDOI(v.ID)
 			relatedIdentifierType := "DOI"
 			if id == "" {
+				id = v.ID
 				relatedIdentifierType = "URL"
 			}
+			if id == "" {
+				continue
+			}
 			RelatedIdentifier := RelatedIdentifier{
 				RelatedIdentifier:     id,
 				RelatedIdentifierType: relatedIdentifierType,
@@ -258,8 +245,12 @@ func Convert(data commonmeta.Data) (Datacite, error) {
 			id := doiutils.NormalizeDOI(v.ID)
 			relatedIdentifierType := "DOI"
 			if id == "" {
+				id = v.ID
 				relatedIdentifierType = "URL"
 			}
+			if id == "" {
+				continue
+			}
 			RelatedIdentifier := RelatedIdentifier{
 				RelatedIdentifier:     id,
 				RelatedIdentifierType: relatedIdentifierType,
@@ -269,17 +260,6 @@ func Convert(data commonmeta.Data) (Datacite, error) {
 		}
 	}
 
-	if len(data.Relations) > 0 {
-		for _, v := range data.Relations {
-			RelatedIdentifier := RelatedIdentifier{
-				RelatedIdentifier:     v.ID,
-				RelatedIdentifierType: "DOI",
-				RelationType:          v.Type,
-			}
-			datacite.RelatedIdentifiers = append(datacite.RelatedIdentifiers, RelatedIdentifier)
-		}
-	}
-
 	datacite.Version = data.Version
 
 	return datacite, nil
@@ -289,15 +269,15 @@ func Convert(data commonmeta.Data) (Datacite, error) {
 func Write(data commonmeta.Data) ([]byte, []gojsonschema.ResultError) {
 	datacite, err := Convert(data)
 	if err != nil {
-		fmt.Println(err)
+		slog.Error("error converting metadata", "error", err)
 	}
 	output, err := json.Marshal(datacite)
 	if err != nil {
-		fmt.Println(err)
+		slog.Error("error marshalling JSON", "error", err)
 	}
 	validation := schemautils.JSONSchemaErrors(output, "datacite-v4.5")
 	if !validation.Valid() {
-		return nil, validation.Errors()
+		return output, validation.Errors()
 	}
 
 	return output, nil
@@ -309,17 +289,17 @@ func WriteAll(list []commonmeta.Data) ([]byte, []gojsonschema.ResultError) {
 	for _, data := range list {
 		datacite, err := Convert(data)
 		if err != nil {
-			fmt.Println(err)
+			slog.Error("error converting metadata", "error", err)
 		}
 		dataciteList = append(dataciteList, datacite)
 	}
 	output, err := json.Marshal(dataciteList)
 	if err != nil {
-		fmt.Println(err)
+		slog.Error("error marshalling JSON", "error", err)
 	}
 	validation := schemautils.JSONSchemaErrors(output, "datacite-v4.5")
 	if !validation.Valid() {
-		return nil, validation.Errors()
+		return output, validation.Errors()
 	}
 
 	return output, nil
@@ -0,0 +1,183 @@
+package datacite_test
+
+import (
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/datacite"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConvertDates(t *testing.T) {
+	t.Parallel()
+	data := commonmeta.Data{
+		ID:   "https://doi.org/10.5555/collected",
+		Type: "Dataset",
+		Date: commonmeta.Date{
+			Published: "2021-03-01",
+			Collected: "2020-01-01/2020-12-31",
+		},
+	}
+	content, err := datacite.Convert(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []datacite.Date{
+		{Date: "2020-01-01/2020-12-31", DateType: "Collected"},
+		{Date: "2021-03-01", DateType: "Issued"},
+	}
+	if diff := cmp.Diff(want, content.Dates); diff != "" {
+		t.Errorf("Convert dates mismatch (-want +got):\n%s", diff)
+	}
+
+	// the collection period survives the round trip
+	got, err := datacite.Read(datacite.Content{Datacite: &content})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(data.Date, got.Date); diff != "" {
+		t.Errorf("Read dates mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertFundingReferences(t *testing.T) {
+	t.Parallel()
+	fundingReferences := []commonmeta.FundingReference{
+		{
+			FunderIdentifier:     "https://doi.org/10.13039/501100000780",
+			FunderIdentifierType: "Crossref Funder ID",
+			FunderName:           "European Commission",
+			AwardNumber:          "101017536",
+			AwardTitle:           "FAIRCORE4EOSC",
+			AwardURI:             "https://cordis.europa.eu/project/id/101017536",
+		},
+	}
+	data := commonmeta.Data{
+		ID:                "https://doi.org/10.5555/funded",
+		Type:              "Dataset",
+		FundingReferences: fundingReferences,
+	}
+	content, err := datacite.Convert(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := datacite.Read(datacite.Content{Datacite: &content})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(fundingReferences, got.FundingReferences); diff != "" {
+		t.Errorf("Funding references mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertRelations(t *testing.T) {
+	t.Parallel()
+	data := commonmeta.Data{
+		ID:   "https://doi.org/10.5061/dryad.8515",
+		Type: "Dataset",
+		Relations: []commonmeta.Relation{
+			{ID: "https://doi.org/10.1371/journal.ppat.1000446", Type: "IsSupplementTo"},
+			{ID: "https://github.com/front-matter/commonmeta", Type: "IsSupplementedBy"},
+		},
+	}
+	content, err := datacite.Convert(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []datacite.RelatedIdentifier{
+		{RelatedIdentifier: "https://doi.org/10.1371/journal.ppat.1000446", RelatedIdentifierType: "DOI", RelationType: "IsSupplementTo"},
+		{RelatedIdentifier: "https://github.com/front-matter/commonmeta", RelatedIdentifierType: "URL", RelationType: "IsSupplementedBy"},
+	}
+	if diff := cmp.Diff(want, content.RelatedIdentifiers); diff != "" {
+		t.Errorf("Convert relations mismatch (-want +got):\n%s", diff)
+	}
+
+	got, err := datacite.Read(datacite.Content{Datacite: &content})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(data.Relations, got.Relations); diff != "" {
+		t.Errorf("Read relations mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertGeoLocations(t *testing.T) {
+	t.Parallel()
+	geoLocations := []commonmeta.GeoLocation{
+		{
+			GeoLocationPlace: "Providence Creek",
+			GeoLocationPoint: commonmeta.GeoLocationPoint{PointLongitude: -119.221094, PointLatitude: 37.047756},
+			GeoLocationBox: commonmeta.GeoLocationBox{
+				EastBoundLongitude: -119.182,
+				WestBoundLongitude: -119.211,
+				SouthBoundLatitude: 37.046,
+				NorthBoundLatitude: 37.075,
+			},
+		},
+		{
+			GeoLocationPolygons: []commonmeta.GeoLocationPolygon{
+				{
+					PolygonPoints: []commonmeta.GeoLocationPoint{
+						{PointLongitude: -71.032, PointLatitude: 41.090},
+						{PointLongitude: -68.211, PointLatitude: 42.893},
+						{PointLongitude: -72.873, PointLatitude: 41.310},
+						{PointLongitude: -71.032, PointLatitude: 41.090},
+					},
+				},
+			},
+		},
+	}
+	data := commonmeta.Data{
+		ID:           "https://doi.org/10.6071/z7wc73",
+		Type:         "Dataset",
+		Titles:       []commonmeta.Title{{Title: "Providence Creek"}},
+		Contributors: []commonmeta.Contributor{{Type: "Organization", Name: "Critical Zone Observatory", ContributorRoles: []string{"Author"}}},
+		Publisher:    commonmeta.Publisher{Name: "Dryad"},
+		Date:         commonmeta.Date{Published: "2019"},
+		GeoLocations: geoLocations,
+	}
+	content, err := datacite.Convert(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if content.GeoLocations[1].GeoLocationPoint != nil || content.GeoLocations[1].GeoLocationBox != nil {
+		t.Errorf("Convert geoLocations: want no point or box, got %v", content.GeoLocations[1])
+	}
+	got, err := datacite.Read(datacite.Content{Datacite: &content})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(geoLocations, got.GeoLocations); diff != "" {
+		t.Errorf("GeoLocations mismatch (-want +got):\n%s", diff)
+	}
+
+	// coordinates are written as numbers, as required by the DataCite schema
+	_, jsErr := datacite.Write(data)
+	if jsErr != nil {
+		t.Errorf("Write geoLocations: %v", jsErr)
+	}
+}
+
+func TestConvertNameType(t *testing.T) {
+	t.Parallel()
+	data := commonmeta.Data{
+		ID:   "https://doi.org/10.5555/nametype",
+		Type: "Dataset",
+		Contributors: []commonmeta.Contributor{
+			{GivenName: "Josiah", FamilyName: "Carberry", ContributorRoles: []string{"Author"}},
+			{Name: "World Health Organization", ContributorRoles: []string{"Author"}},
+		},
+	}
+	content, err := datacite.Convert(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"Personal", "Organizational"}
+	var got []string
+	for _, v := range content.Creators {
+		got = append(got, v.NameType)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Convert nameType mismatch (-want +got):\n%s", diff)
+	}
+}
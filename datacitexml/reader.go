@@ -0,0 +1,550 @@
+// Package datacitexml provides functions to convert DataCite XML metadata to/from the commonmeta metadata format.
+package datacitexml
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/datacite"
+	"github.com/front-matter/commonmeta/doiutils"
+)
+
+// Content represents the DataCite XML metadata (Metadata Schema 2.2 to 4.5).
+type Content struct {
+	XMLName              xml.Name              `xml:"resource"`
+	Xmlns                string                `xml:"xmlns,attr,omitempty"`
+	Xsi                  string                `xml:"xmlns:xsi,attr,omitempty"`
+	SchemaLocation       string                `xml:"xsi:schemaLocation,attr,omitempty"`
+	Identifier           Identifier            `xml:"identifier"`
+	Creators             []Creator             `xml:"creators>creator"`
+	Titles               []Title               `xml:"titles>title"`
+	Publisher            Publisher             `xml:"publisher"`
+	PublicationYear      string                `xml:"publicationYear,omitempty"`
+	ResourceType         ResourceType          `xml:"resourceType"`
+	Subjects             []Subject             `xml:"subjects>subject"`
+	Contributors         []Contributor         `xml:"contributors>contributor"`
+	Dates                []Date                `xml:"dates>date"`
+	Language             string                `xml:"language,omitempty"`
+	AlternateIdentifiers []AlternateIdentifier `xml:"alternateIdentifiers>alternateIdentifier"`
+	RelatedIdentifiers   []RelatedIdentifier   `xml:"relatedIdentifiers>relatedIdentifier"`
+	Sizes                []string              `xml:"sizes>size"`
+	Formats              []string              `xml:"formats>format"`
+	Version              string                `xml:"version,omitempty"`
+	RightsList           []Rights              `xml:"rightsList>rights"`
+	Descriptions         []Description         `xml:"descriptions>description"`
+	GeoLocations         []GeoLocation         `xml:"geoLocations>geoLocation"`
+	FundingReferences    []FundingReference    `xml:"fundingReferences>fundingReference"`
+}
+
+// Identifier represents the primary identifier of the resource.
+type Identifier struct {
+	IdentifierType string `xml:"identifierType,attr,omitempty"`
+	Text           string `xml:",chardata"`
+}
+
+// Creator represents a creator of the resource.
+type Creator struct {
+	CreatorName     Name             `xml:"creatorName"`
+	GivenName       string           `xml:"givenName,omitempty"`
+	FamilyName      string           `xml:"familyName,omitempty"`
+	NameIdentifiers []NameIdentifier `xml:"nameIdentifier"`
+	Affiliations    []Affiliation    `xml:"affiliation"`
+}
+
+// Contributor represents a contributor to the resource.
+type Contributor struct {
+	ContributorType string           `xml:"contributorType,attr,omitempty"`
+	ContributorName Name             `xml:"contributorName"`
+	GivenName       string           `xml:"givenName,omitempty"`
+	FamilyName      string           `xml:"familyName,omitempty"`
+	NameIdentifiers []NameIdentifier `xml:"nameIdentifier"`
+	Affiliations    []Affiliation    `xml:"affiliation"`
+}
+
+// Name represents a creator or contributor name.
+type Name struct {
+	NameType string `xml:"nameType,attr,omitempty"`
+	Lang     string `xml:"http://www.w3.org/XML/1998/namespace lang,attr,omitempty"`
+	Text     string `xml:",chardata"`
+}
+
+// NameIdentifier represents a name identifier, e.g. ORCID or ROR.
+type NameIdentifier struct {
+	NameIdentifierScheme string `xml:"nameIdentifierScheme,attr,omitempty"`
+	SchemeURI            string `xml:"schemeURI,attr,omitempty"`
+	Text                 string `xml:",chardata"`
+}
+
+// Affiliation represents an affiliation of a creator or contributor.
+type Affiliation struct {
+	AffiliationIdentifier       string `xml:"affiliationIdentifier,attr,omitempty"`
+	AffiliationIdentifierScheme string `xml:"affiliationIdentifierScheme,attr,omitempty"`
+	SchemeURI                   string `xml:"schemeURI,attr,omitempty"`
+	Text                        string `xml:",chardata"`
+}
+
+// Title represents a title of the resource.
+type Title struct {
+	TitleType string `xml:"titleType,attr,omitempty"`
+	Lang      string `xml:"http://www.w3.org/XML/1998/namespace lang,attr,omitempty"`
+	Text      string `xml:",chardata"`
+}
+
+// Publisher represents the publisher of the resource.
+type Publisher struct {
+	PublisherIdentifier       string `xml:"publisherIdentifier,attr,omitempty"`
+	PublisherIdentifierScheme string `xml:"publisherIdentifierScheme,attr,omitempty"`
+	SchemeURI                 string `xml:"schemeURI,attr,omitempty"`
+	Lang                      string `xml:"http://www.w3.org/XML/1998/namespace lang,attr,omitempty"`
+	Text                      string `xml:",chardata"`
+}
+
+// ResourceType represents the type of the resource.
+type ResourceType struct {
+	ResourceTypeGeneral string `xml:"resourceTypeGeneral,attr,omitempty"`
+	Text                string `xml:",chardata"`
+}
+
+// Subject represents a subject, keyword, classification code, or key phrase.
+type Subject struct {
+	SubjectScheme      string `xml:"subjectScheme,attr,omitempty"`
+	SchemeURI          string `xml:"schemeURI,attr,omitempty"`
+	ClassificationCode string `xml:"classificationCode,attr,omitempty"`
+	Lang               string `xml:"http://www.w3.org/XML/1998/namespace lang,attr,omitempty"`
+	Text               string `xml:",chardata"`
+}
+
+// Date represents a date relevant to the resource.
+type Date struct {
+	DateType        string `xml:"dateType,attr,omitempty"`
+	DateInformation string `xml:"dateInformation,attr,omitempty"`
+	Text            string `xml:",chardata"`
+}
+
+// AlternateIdentifier represents an identifier other than the primary identifier.
+type AlternateIdentifier struct {
+	AlternateIdentifierType string `xml:"alternateIdentifierType,attr,omitempty"`
+	Text                    string `xml:",chardata"`
+}
+
+// RelatedIdentifier represents an identifier of a related resource.
+type RelatedIdentifier struct {
+	RelatedIdentifierType string `xml:"relatedIdentifierType,attr,omitempty"`
+	RelationType          string `xml:"relationType,attr,omitempty"`
+	ResourceTypeGeneral   string `xml:"resourceTypeGeneral,attr,omitempty"`
+	Text                  string `xml:",chardata"`
+}
+
+// Rights represents rights information for the resource.
+type Rights struct {
+	RightsURI              string `xml:"rightsURI,attr,omitempty"`
+	RightsIdentifier       string `xml:"rightsIdentifier,attr,omitempty"`
+	RightsIdentifierScheme string `xml:"rightsIdentifierScheme,attr,omitempty"`
+	SchemeURI              string `xml:"schemeURI,attr,omitempty"`
+	Lang                   string `xml:"http://www.w3.org/XML/1998/namespace lang,attr,omitempty"`
+	Text                   string `xml:",chardata"`
+}
+
+// Description represents a description of the resource.
+type Description struct {
+	DescriptionType string `xml:"descriptionType,attr,omitempty"`
+	Lang            string `xml:"http://www.w3.org/XML/1998/namespace lang,attr,omitempty"`
+	Text            string `xml:",chardata"`
+}
+
+// GeoLocation represents a spatial region or named place.
+type GeoLocation struct {
+	GeoLocationPlace string            `xml:"geoLocationPlace,omitempty"`
+	GeoLocationPoint *GeoLocationPoint `xml:"geoLocationPoint"`
+	GeoLocationBox   *GeoLocationBox   `xml:"geoLocationBox"`
+}
+
+// GeoLocationPoint represents a point location. Schema 3 uses a space-separated
+// "latitude longitude" string instead of child elements.
+type GeoLocationPoint struct {
+	PointLongitude string `xml:"pointLongitude,omitempty"`
+	PointLatitude  string `xml:"pointLatitude,omitempty"`
+	Text           string `xml:",chardata"`
+}
+
+// GeoLocationBox represents a bounding box. Schema 3 uses a space-separated
+// "south west north east" string instead of child elements.
+type GeoLocationBox struct {
+	WestBoundLongitude string `xml:"westBoundLongitude,omitempty"`
+	EastBoundLongitude string `xml:"eastBoundLongitude,omitempty"`
+	SouthBoundLatitude string `xml:"southBoundLatitude,omitempty"`
+	NorthBoundLatitude string `xml:"northBoundLatitude,omitempty"`
+	Text               string `xml:",chardata"`
+}
+
+// FundingReference represents information about financial support for the resource.
+type FundingReference struct {
+	FunderName       string            `xml:"funderName,omitempty"`
+	FunderIdentifier *FunderIdentifier `xml:"funderIdentifier"`
+	AwardNumber      *AwardNumber      `xml:"awardNumber"`
+	AwardTitle       string            `xml:"awardTitle,omitempty"`
+}
+
+// FunderIdentifier represents the identifier of a funding entity.
+type FunderIdentifier struct {
+	FunderIdentifierType string `xml:"funderIdentifierType,attr,omitempty"`
+	Text                 string `xml:",chardata"`
+}
+
+// AwardNumber represents the code assigned by the funder to a sponsored award.
+type AwardNumber struct {
+	AwardURI string `xml:"awardURI,attr,omitempty"`
+	Text     string `xml:",chardata"`
+}
+
+// Fetch gets the metadata for a single work from the DataCite API in DataCite XML format
+// and returns Commonmeta metadata.
+func Fetch(str string) (commonmeta.Data, error) {
+	var data commonmeta.Data
+	id, ok := doiutils.ValidateDOI(str)
+	if !ok {
+		return data, errors.New("invalid DOI")
+	}
+	content, err := Get(id)
+	if err != nil {
+		return data, err
+	}
+	data, err = Read(content)
+	if err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// Get gets the metadata for a single work from the DataCite API in DataCite XML format.
+func Get(pid string) (Content, error) {
+	var content Content
+	doi, ok := doiutils.ValidateDOI(pid)
+	if !ok {
+		return content, errors.New("invalid DOI")
+	}
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+	url := "https://api.datacite.org/dois/" + doi
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return content, err
+	}
+	req.Header.Set("Accept", "application/vnd.datacite.datacite+xml")
+	resp, err := client.Do(req)
+	if err != nil {
+		return content, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return content, errors.New(resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return content, err
+	}
+	err = xml.Unmarshal(body, &content)
+	return content, err
+}
+
+// Load loads the metadata for a single work from a DataCite XML file
+func Load(filename string) (commonmeta.Data, error) {
+	var data commonmeta.Data
+
+	extension := path.Ext(filename)
+	if extension != ".xml" {
+		return data, errors.New("invalid file extension")
+	}
+	input, err := os.ReadFile(filename)
+	if err != nil {
+		return data, errors.New("error reading file")
+	}
+	return ReadXML(input)
+}
+
+// ReadXML unmarshals DataCite XML and converts it to commonmeta.
+func ReadXML(input []byte) (commonmeta.Data, error) {
+	var data commonmeta.Data
+	var content Content
+
+	err := xml.Unmarshal(input, &content)
+	if err != nil {
+		return data, err
+	}
+	return Read(content)
+}
+
+// Read reads DataCite XML metadata and converts it to commonmeta. The XML is
+// mapped to the DataCite JSON structure and converted by datacite.Read, so that
+// both serializations of DataCite metadata produce the same commonmeta.
+func Read(content Content) (commonmeta.Data, error) {
+	var data commonmeta.Data
+	if strings.TrimSpace(content.Identifier.Text) == "" {
+		return data, errors.New("missing identifier")
+	}
+	dc, err := ToDatacite(content)
+	if err != nil {
+		return data, err
+	}
+	return datacite.Read(dc)
+}
+
+// ToDatacite converts DataCite XML metadata to the DataCite JSON structure.
+func ToDatacite(content Content) (datacite.Content, error) {
+	dc := datacite.Content{
+		Datacite: &datacite.Datacite{},
+	}
+	if strings.EqualFold(content.Identifier.IdentifierType, "DOI") {
+		dc.DOI = clean(content.Identifier.Text)
+	}
+
+	for _, v := range content.Creators {
+		contributor, err := getContributor(v.CreatorName, v.GivenName, v.FamilyName, v.NameIdentifiers, v.Affiliations, "")
+		if err != nil {
+			return dc, err
+		}
+		dc.Creators = append(dc.Creators, contributor)
+	}
+	for _, v := range content.Contributors {
+		contributor, err := getContributor(v.ContributorName, v.GivenName, v.FamilyName, v.NameIdentifiers, v.Affiliations, v.ContributorType)
+		if err != nil {
+			return dc, err
+		}
+		dc.Contributors = append(dc.Contributors, contributor)
+	}
+
+	for _, v := range content.Titles {
+		dc.Titles = append(dc.Titles, datacite.Title{
+			Title:     clean(v.Text),
+			TitleType: v.TitleType,
+			Lang:      v.Lang,
+		})
+	}
+
+	publisher := datacite.Publisher{
+		Name:                      clean(content.Publisher.Text),
+		PublisherIdentifier:       content.Publisher.PublisherIdentifier,
+		PublisherIdentifierScheme: content.Publisher.PublisherIdentifierScheme,
+		SchemeURI:                 content.Publisher.SchemeURI,
+		Lang:                      content.Publisher.Lang,
+	}
+	p, err := json.Marshal(publisher)
+	if err != nil {
+		return dc, err
+	}
+	dc.Publisher = p
+	if year, err := strconv.Atoi(clean(content.PublicationYear)); err == nil {
+		dc.PublicationYear = json.RawMessage(strconv.Itoa(year))
+	}
+
+	dc.Types = datacite.Types{
+		ResourceTypeGeneral: content.ResourceType.ResourceTypeGeneral,
+		ResourceType:        clean(content.ResourceType.Text),
+	}
+
+	for _, v := range content.Subjects {
+		if subject := clean(v.Text); subject != "" {
+			dc.Subjects = append(dc.Subjects, datacite.Subject{
+				Subject: subject,
+			})
+		}
+	}
+
+	for _, v := range content.Dates {
+		dc.Dates = append(dc.Dates, datacite.Date{
+			Date:            clean(v.Text),
+			DateType:        v.DateType,
+			DateInformation: v.DateInformation,
+		})
+	}
+	dc.Language = clean(content.Language)
+
+	for _, v := range content.AlternateIdentifiers {
+		dc.AlternateIdentifiers = append(dc.AlternateIdentifiers, datacite.AlternateIdentifier{
+			AlternateIdentifier:     clean(v.Text),
+			AlternateIdentifierType: v.AlternateIdentifierType,
+		})
+	}
+	for _, v := range content.RelatedIdentifiers {
+		dc.RelatedIdentifiers = append(dc.RelatedIdentifiers, datacite.RelatedIdentifier{
+			RelatedIdentifier:     clean(v.Text),
+			RelatedIdentifierType: v.RelatedIdentifierType,
+			RelationType:          v.RelationType,
+		})
+	}
+	for _, v := range content.Sizes {
+		dc.Sizes = append(dc.Sizes, clean(v))
+	}
+	for _, v := range content.Formats {
+		dc.Formats = append(dc.Formats, clean(v))
+	}
+	dc.Version = clean(content.Version)
+
+	for _, v := range content.RightsList {
+		dc.RightsList = append(dc.RightsList, datacite.Rights{
+			Rights:                 clean(v.Text),
+			RightsURI:              v.RightsURI,
+			SchemeURI:              v.SchemeURI,
+			RightsIdentifier:       v.RightsIdentifier,
+			RightsIdentifierScheme: v.RightsIdentifierScheme,
+		})
+	}
+
+	for _, v := range content.Descriptions {
+		description := clean(v.Text)
+		if description == "" {
+			continue
+		}
+		if v.DescriptionType == "SeriesInformation" && dc.Container.Title == "" {
+			dc.Container = datacite.Container{
+				Type:  "Series",
+				Title: description,
+			}
+		}
+		dc.Descriptions = append(dc.Descriptions, datacite.Description{
+			Description:     description,
+			DescriptionType: v.DescriptionType,
+			Lang:            v.Lang,
+		})
+	}
+
+	for _, v := range content.GeoLocations {
+		geoLocation := datacite.GeoLocation{
+			GeoLocationPlace: clean(v.GeoLocationPlace),
+		}
+		if v.GeoLocationPoint != nil {
+			point := getGeoLocationPoint(*v.GeoLocationPoint)
+			if point != (datacite.GeoLocationPoint{}) {
+				geoLocation.GeoLocationPoint = &point
+			}
+		}
+		if v.GeoLocationBox != nil {
+			box := getGeoLocationBox(*v.GeoLocationBox)
+			if box != (datacite.GeoLocationBox{}) {
+				geoLocation.GeoLocationBox = &box
+			}
+		}
+		// skip empty geoLocations, e.g. with only a geoLocationPolygon
+		if geoLocation.GeoLocationPlace == "" && geoLocation.GeoLocationPoint == nil && geoLocation.GeoLocationBox == nil {
+			continue
+		}
+		dc.GeoLocations = append(dc.GeoLocations, geoLocation)
+	}
+
+	for _, v := range content.FundingReferences {
+		fundingReference := datacite.FundingReference{
+			FunderName: clean(v.FunderName),
+		}
+		if v.FunderIdentifier != nil {
+			fundingReference.FunderIdentifier = clean(v.FunderIdentifier.Text)
+			fundingReference.FunderIdentifierType = v.FunderIdentifier.FunderIdentifierType
+		}
+		if v.AwardNumber != nil {
+			fundingReference.AwardNumber = clean(v.AwardNumber.Text)
+			fundingReference.AwardURI = v.AwardNumber.AwardURI
+		}
+		fundingReference.AwardTitle = clean(v.AwardTitle)
+		dc.FundingReferences = append(dc.FundingReferences, fundingReference)
+	}
+
+	return dc, nil
+}
+
+// getContributor converts a DataCite XML creator or contributor to the DataCite JSON structure.
+func getContributor(name Name, givenName string, familyName string, nameIdentifiers []NameIdentifier, affiliations []Affiliation, contributorType string) (datacite.ContentContributor, error) {
+	var ids []datacite.NameIdentifier
+	for _, v := range nameIdentifiers {
+		ids = append(ids, datacite.NameIdentifier{
+			NameIdentifier:       clean(v.Text),
+			NameIdentifierScheme: v.NameIdentifierScheme,
+			SchemeURI:            v.SchemeURI,
+		})
+	}
+	var affs []datacite.Affiliation
+	for _, v := range affiliations {
+		affs = append(affs, datacite.Affiliation{
+			AffiliationIdentifier:       v.AffiliationIdentifier,
+			AffiliationIdentifierScheme: v.AffiliationIdentifierScheme,
+			SchemeURI:                   v.SchemeURI,
+			Name:                        clean(v.Text),
+		})
+	}
+	contributor := datacite.ContentContributor{
+		Contributor: &datacite.Contributor{
+			Name:            clean(name.Text),
+			GivenName:       clean(givenName),
+			FamilyName:      clean(familyName),
+			NameType:        name.NameType,
+			NameIdentifiers: ids,
+			ContributorType: contributorType,
+		},
+	}
+	a, err := json.Marshal(affs)
+	if err != nil {
+		return contributor, err
+	}
+	if affs == nil {
+		a = json.RawMessage("[]")
+	}
+	contributor.Affiliation = a
+	return contributor, nil
+}
+
+func getGeoLocationPoint(v GeoLocationPoint) datacite.GeoLocationPoint {
+	if v.PointLongitude == "" && v.PointLatitude == "" {
+		// schema 3: "latitude longitude"
+		parts := strings.Fields(v.Text)
+		if len(parts) == 2 {
+			return datacite.GeoLocationPoint{
+				PointLatitude:  parseFloat(parts[0]),
+				PointLongitude: parseFloat(parts[1]),
+			}
+		}
+	}
+	return datacite.GeoLocationPoint{
+		PointLongitude: parseFloat(v.PointLongitude),
+		PointLatitude:  parseFloat(v.PointLatitude),
+	}
+}
+
+func getGeoLocationBox(v GeoLocationBox) datacite.GeoLocationBox {
+	if v.WestBoundLongitude == "" && v.EastBoundLongitude == "" && v.SouthBoundLatitude == "" && v.NorthBoundLatitude == "" {
+		// schema 3: "south west north east"
+		parts := strings.Fields(v.Text)
+		if len(parts) == 4 {
+			return datacite.GeoLocationBox{
+				SouthBoundLatitude: parseFloat(parts[0]),
+				WestBoundLongitude: parseFloat(parts[1]),
+				NorthBoundLatitude: parseFloat(parts[2]),
+				EastBoundLongitude: parseFloat(parts[3]),
+			}
+		}
+	}
+	return datacite.GeoLocationBox{
+		WestBoundLongitude: parseFloat(v.WestBoundLongitude),
+		EastBoundLongitude: parseFloat(v.EastBoundLongitude),
+		SouthBoundLatitude: parseFloat(v.SouthBoundLatitude),
+		NorthBoundLatitude: parseFloat(v.NorthBoundLatitude),
+	}
+}
+
+func parseFloat(str string) float64 {
+	f, _ := strconv.ParseFloat(strings.TrimSpace(str), 64)
+	return f
+}
+
+// clean removes surrounding whitespace and collapses internal whitespace,
+// as XML text is often indented.
+func clean(str string) string {
+	return strings.Join(strings.Fields(str), " ")
+}
@@ -0,0 +1,143 @@
+package datacitexml_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/datacitexml"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name      string
+		filename  string
+		id        string
+		type_     string
+		published string
+		author    commonmeta.Contributor
+		title     string
+	}
+
+	testCases := []testCase{
+		{
+			name:      "full example",
+			filename:  "datacite-example-full-v4.4.xml",
+			id:        "https://doi.org/10.5072/example-full",
+			type_:     "Software",
+			published: "2014",
+			author:    commonmeta.Contributor{ID: "https://orcid.org/0000-0001-5000-0007", Type: "Person", GivenName: "Elizabeth", FamilyName: "Miller", Affiliations: []*commonmeta.Affiliation{{Name: "DataCite"}}, ContributorRoles: []string{"Author"}},
+			title:     "Full DataCite XML Example",
+		},
+		{
+			name:      "schema 3",
+			filename:  "datacite_schema_3.xml",
+			id:        "https://doi.org/10.5061/dryad.8515",
+			type_:     "Dataset",
+			published: "2011",
+			author:    commonmeta.Contributor{Type: "Person", GivenName: "Benjamin", FamilyName: "Ollomo", ContributorRoles: []string{"Author"}},
+			title:     "Data from: A new malaria agent in African hominids.",
+		},
+		{
+			name:      "schema 2.2 with namespace prefix",
+			filename:  "ns0.xml",
+			id:        "https://doi.org/10.4231/d38g8fk8b",
+			type_:     "Software",
+			published: "2018",
+			author:    commonmeta.Contributor{Type: "Person", GivenName: "Carlos", FamilyName: "PatiÃ±o", ContributorRoles: []string{"Author"}},
+			title:     "LAMMPS Data-File Generator",
+		},
+	}
+	for _, tc := range testCases {
+		got, err := datacitexml.Load(filepath.Join("testdata", tc.filename))
+		if err != nil {
+			t.Fatalf("DataCite XML Load (%v): error %v", tc.filename, err)
+		}
+		if got.ID != tc.id {
+			t.Errorf("DataCite XML Load ID (%v): want %v, got %v", tc.filename, tc.id, got.ID)
+		}
+		if got.Type != tc.type_ {
+			t.Errorf("DataCite XML Load Type (%v): want %v, got %v", tc.filename, tc.type_, got.Type)
+		}
+		if got.Date.Published != tc.published {
+			t.Errorf("DataCite XML Load Date (%v): want %v, got %v", tc.filename, tc.published, got.Date.Published)
+		}
+		if len(got.Contributors) == 0 {
+			t.Fatalf("DataCite XML Load Contributors (%v): no contributors", tc.filename)
+		}
+		if diff := cmp.Diff(tc.author, got.Contributors[0]); diff != "" {
+			t.Errorf("DataCite XML Load Contributors (%s) mismatch (-want +got):\n%s", tc.filename, diff)
+		}
+		if len(got.Titles) == 0 || got.Titles[0].Title != tc.title {
+			t.Errorf("DataCite XML Load Titles (%v): want %v, got %v", tc.filename, tc.title, got.Titles)
+		}
+	}
+}
+
+func TestLoadFull(t *testing.T) {
+	t.Parallel()
+	got, err := datacitexml.Load(filepath.Join("testdata", "datacite-example-full-v4.4.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantTitles := []commonmeta.Title{
+		{Title: "Full DataCite XML Example", Language: "en-US"},
+		{Title: "Demonstration of DataCite Properties.", Type: "Subtitle", Language: "en-US"},
+	}
+	if diff := cmp.Diff(wantTitles, got.Titles); diff != "" {
+		t.Errorf("Titles mismatch (-want +got):\n%s", diff)
+	}
+	wantDescriptions := []commonmeta.Description{
+		{Description: "XML example of all DataCite Metadata Schema v4.4 properties.", Type: "Abstract", Language: "en-US"},
+	}
+	if diff := cmp.Diff(wantDescriptions, got.Descriptions); diff != "" {
+		t.Errorf("Descriptions mismatch (-want +got):\n%s", diff)
+	}
+	if got.Date.Updated != "2021-01-26" {
+		t.Errorf("Date Updated: want 2021-01-26, got %v", got.Date.Updated)
+	}
+	wantSubjects := []commonmeta.Subject{{Subject: "computer science"}}
+	if diff := cmp.Diff(wantSubjects, got.Subjects); diff != "" {
+		t.Errorf("Subjects mismatch (-want +got):\n%s", diff)
+	}
+	wantRelations := []commonmeta.Relation{{ID: "arXiv:0706.0001", Type: "IsReviewedBy"}}
+	if diff := cmp.Diff(wantRelations, got.Relations); diff != "" {
+		t.Errorf("Relations mismatch (-want +got):\n%s", diff)
+	}
+	if got.Contributors[1].FamilyName != "Starr" || got.Contributors[1].ContributorRoles[0] != "ProjectLeader" {
+		t.Errorf("Contributors: got %v", got.Contributors[1])
+	}
+	wantFunding := []commonmeta.FundingReference{{FunderIdentifier: "https://doi.org/10.13039/100000001", FunderIdentifierType: "Crossref Funder ID", FunderName: "National Science Foundation", AwardNumber: "CBET-106", AwardTitle: "Full DataCite XML Example"}}
+	if diff := cmp.Diff(wantFunding, got.FundingReferences); diff != "" {
+		t.Errorf("FundingReferences mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoadGeoLocation(t *testing.T) {
+	t.Parallel()
+	got, err := datacitexml.Load(filepath.Join("testdata", "datacite-example-geolocation.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Type != "Dataset" {
+		t.Errorf("Type: want Dataset, got %v", got.Type)
+	}
+	want := []commonmeta.GeoLocation{
+		{
+			GeoLocationPlace: "Disko Bay",
+			GeoLocationPoint: commonmeta.GeoLocationPoint{PointLongitude: -52, PointLatitude: 69},
+		},
+	}
+	if diff := cmp.Diff(want, got.GeoLocations); diff != "" {
+		t.Errorf("GeoLocations mismatch (-want +got):\n%s", diff)
+	}
+	if got.Publisher.Name != "PANGAEA - Data Publisher for Earth & Environmental Science" {
+		t.Errorf("Publisher: got %v", got.Publisher.Name)
+	}
+	hostingInstitution := commonmeta.Contributor{Type: "Organization", Name: "IFM-GEOMAR Leibniz-Institute of Marine Sciences, Kiel University", ContributorRoles: []string{"HostingInstitution"}}
+	if diff := cmp.Diff(hostingInstitution, got.Contributors[3]); diff != "" {
+		t.Errorf("Contributors mismatch (-want +got):\n%s", diff)
+	}
+}
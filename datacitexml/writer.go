@@ -0,0 +1,374 @@
+package datacitexml
+
+import (
+	"encoding/xml"
+	"errors"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/datacite"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/utils"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// Resources represents a list of DataCite XML records.
+type Resources struct {
+	XMLName   xml.Name  `xml:"resources"`
+	Resources []Content `xml:"resource"`
+}
+
+// ContributorTypes lists the contributor types allowed by the DataCite Metadata Schema.
+// source: https://github.com/datacite/schema/blob/master/source/meta/kernel-4/include/datacite-contributorType-v4.xsd
+var ContributorTypes = []string{
+	"ContactPerson",
+	"DataCollector",
+	"DataCurator",
+	"DataManager",
+	"Distributor",
+	"Editor",
+	"HostingInstitution",
+	"Producer",
+	"ProjectLeader",
+	"ProjectManager",
+	"ProjectMember",
+	"RegistrationAgency",
+	"RegistrationAuthority",
+	"RelatedPerson",
+	"Researcher",
+	"ResearchGroup",
+	"RightsHolder",
+	"Sponsor",
+	"Supervisor",
+	"WorkPackageLeader",
+	"Other",
+}
+
+// encoding/xml writes the wrapper element of an a>b path even for an empty slice
+var emptyWrapperRegexp = regexp.MustCompile(`\n\s*<(subjects|contributors|dates|alternateIdentifiers|relatedIdentifiers|sizes|formats|rightsList|descriptions|geoLocations|fundingReferences)></(subjects|contributors|dates|alternateIdentifiers|relatedIdentifiers|sizes|formats|rightsList|descriptions|geoLocations|fundingReferences)>`)
+
+// Convert converts Commonmeta metadata to DataCite XML metadata
+func Convert(data commonmeta.Data) (Content, error) {
+	content := Content{
+		Xmlns:          "http://datacite.org/schema/kernel-4",
+		Xsi:            "http://www.w3.org/2001/XMLSchema-instance",
+		SchemaLocation: "http://datacite.org/schema/kernel-4 http://schema.datacite.org/meta/kernel-4.5/metadata.xsd",
+	}
+
+	// required properties
+	doi, ok := doiutils.ValidateDOI(data.ID)
+	if !ok {
+		return content, errors.New("invalid DOI")
+	}
+	content.Identifier = Identifier{
+		IdentifierType: "DOI",
+		Text:           doi,
+	}
+
+	for _, v := range data.Contributors {
+		name := getName(v)
+		nameIdentifiers := getNameIdentifiers(v)
+		affiliations := getAffiliations(v)
+		if len(v.ContributorRoles) == 0 || slices.Contains(v.ContributorRoles, "Author") {
+			content.Creators = append(content.Creators, Creator{
+				CreatorName:     name,
+				GivenName:       v.GivenName,
+				FamilyName:      v.FamilyName,
+				NameIdentifiers: nameIdentifiers,
+				Affiliations:    affiliations,
+			})
+		} else {
+			contributorType := v.ContributorRoles[0]
+			if !slices.Contains(ContributorTypes, contributorType) {
+				contributorType = "Other"
+			}
+			content.Contributors = append(content.Contributors, Contributor{
+				ContributorType: contributorType,
+				ContributorName: name,
+				GivenName:       v.GivenName,
+				FamilyName:      v.FamilyName,
+				NameIdentifiers: nameIdentifiers,
+				Affiliations:    affiliations,
+			})
+		}
+	}
+
+	for _, v := range data.Titles {
+		content.Titles = append(content.Titles, Title{
+			TitleType: v.Type,
+			Lang:      v.Language,
+			Text:      v.Title,
+		})
+	}
+
+	content.Publisher = Publisher{
+		Text: data.Publisher.Name,
+	}
+	if ror := utils.NormalizeROR(data.Publisher.ID); ror != "" {
+		content.Publisher.PublisherIdentifier = ror
+		content.Publisher.PublisherIdentifierScheme = "ROR"
+		content.Publisher.SchemeURI = "https://ror.org/"
+	}
+
+	if len(data.Date.Published) >= 4 {
+		if year, err := strconv.Atoi(data.Date.Published[:4]); err == nil {
+			content.PublicationYear = strconv.Itoa(year)
+		}
+	}
+
+	resourceTypeGeneral := datacite.CMToDCMappings[data.Type]
+	if resourceTypeGeneral == "" {
+		resourceTypeGeneral = "Other"
+	}
+	content.ResourceType = ResourceType{
+		ResourceTypeGeneral: resourceTypeGeneral,
+		Text:                data.AdditionalType,
+	}
+
+	// optional properties
+
+	for _, v := range data.Subjects {
+		content.Subjects = append(content.Subjects, Subject{
+			Text: v.Subject,
+		})
+	}
+
+	dates := []struct {
+		date     string
+		dateType string
+	}{
+		{data.Date.Accepted, "Accepted"},
+		{data.Date.Available, "Available"},
+		{data.Date.Collected, "Collected"},
+		{data.Date.Copyrighted, "Copyrighted"},
+		{data.Date.Created, "Created"},
+		{data.Date.Published, "Issued"},
+		{data.Date.Submitted, "Submitted"},
+		{data.Date.Updated, "Updated"},
+		{data.Date.Valid, "Valid"},
+		{data.Date.Withdrawn, "Withdrawn"},
+		{data.Date.Other, "Other"},
+	}
+	for _, v := range dates {
+		if v.date != "" {
+			content.Dates = append(content.Dates, Date{
+				DateType: v.dateType,
+				Text:     v.date,
+			})
+		}
+	}
+
+	content.Language = data.Language
+
+	for _, v := range data.Identifiers {
+		if v.Identifier == data.ID || v.IdentifierType == "DOI" && doiutils.NormalizeDOI(v.Identifier) == data.ID {
+			continue
+		}
+		content.AlternateIdentifiers = append(content.AlternateIdentifiers, AlternateIdentifier{
+			AlternateIdentifierType: v.IdentifierType,
+			Text:                    v.Identifier,
+		})
+	}
+
+	for _, v := range data.References {
+		if relatedIdentifier := getRelatedIdentifier(v.ID, "References"); relatedIdentifier != nil {
+			content.RelatedIdentifiers = append(content.RelatedIdentifiers, *relatedIdentifier)
+		}
+	}
+	for _, v := range data.Relations {
+		if relatedIdentifier := getRelatedIdentifier(v.ID, v.Type); relatedIdentifier != nil {
+			content.RelatedIdentifiers = append(content.RelatedIdentifiers, *relatedIdentifier)
+		}
+	}
+
+	content.Version = data.Version
+
+	if data.License.URL != "" || data.License.ID != "" {
+		rights := Rights{
+			RightsURI: data.License.URL,
+		}
+		if data.License.ID != "" {
+			rights.RightsIdentifier = data.License.ID
+			rights.RightsIdentifierScheme = "SPDX"
+			rights.SchemeURI = "https://spdx.org/licenses/"
+		}
+		content.RightsList = append(content.RightsList, rights)
+	}
+
+	for _, v := range data.Descriptions {
+		descriptionType := v.Type
+		if descriptionType == "" {
+			descriptionType = "Other"
+		}
+		content.Descriptions = append(content.Descriptions, Description{
+			DescriptionType: descriptionType,
+			Lang:            v.Language,
+			Text:            v.Description,
+		})
+	}
+
+	for _, v := range data.GeoLocations {
+		geoLocation := GeoLocation{
+			GeoLocationPlace: v.GeoLocationPlace,
+		}
+		if v.GeoLocationPoint != (commonmeta.GeoLocationPoint{}) {
+			geoLocation.GeoLocationPoint = &GeoLocationPoint{
+				PointLongitude: formatFloat(v.GeoLocationPoint.PointLongitude),
+				PointLatitude:  formatFloat(v.GeoLocationPoint.PointLatitude),
+			}
+		}
+		if v.GeoLocationBox != (commonmeta.GeoLocationBox{}) {
+			geoLocation.GeoLocationBox = &GeoLocationBox{
+				WestBoundLongitude: formatFloat(v.GeoLocationBox.WestBoundLongitude),
+				EastBoundLongitude: formatFloat(v.GeoLocationBox.EastBoundLongitude),
+				SouthBoundLatitude: formatFloat(v.GeoLocationBox.SouthBoundLatitude),
+				NorthBoundLatitude: formatFloat(v.GeoLocationBox.NorthBoundLatitude),
+			}
+		}
+		content.GeoLocations = append(content.GeoLocations, geoLocation)
+	}
+
+	for _, v := range data.FundingReferences {
+		fundingReference := FundingReference{
+			FunderName: v.FunderName,
+		}
+		if v.FunderIdentifier != "" {
+			fundingReference.FunderIdentifier = &FunderIdentifier{
+				FunderIdentifierType: v.FunderIdentifierType,
+				Text:                 v.FunderIdentifier,
+			}
+		}
+		if v.AwardNumber != "" {
+			fundingReference.AwardNumber = &AwardNumber{
+				AwardURI: v.AwardURI,
+				Text:     v.AwardNumber,
+			}
+		}
+		fundingReference.AwardTitle = v.AwardTitle
+		content.FundingReferences = append(content.FundingReferences, fundingReference)
+	}
+
+	return content, nil
+}
+
+// Write writes commonmeta metadata in DataCite XML format.
+func Write(data commonmeta.Data) ([]byte, []gojsonschema.ResultError) {
+	content, err := Convert(data)
+	if err != nil {
+		slog.Error("error converting metadata", "error", err)
+	}
+	output, err := xml.MarshalIndent(content, "", "  ")
+	if err != nil {
+		slog.Error("error marshalling XML", "error", err)
+	}
+	output = emptyWrapperRegexp.ReplaceAll(output, nil)
+	output = []byte(xml.Header + string(output))
+	return output, nil
+}
+
+// WriteAll writes a list of commonmeta metadata in DataCite XML format.
+func WriteAll(list []commonmeta.Data) ([]byte, []gojsonschema.ResultError) {
+	var resources Resources
+	for _, data := range list {
+		content, err := Convert(data)
+		if err != nil {
+			slog.Error("error converting metadata", "error", err)
+		}
+		resources.Resources = append(resources.Resources, content)
+	}
+	output, err := xml.MarshalIndent(resources, "", "  ")
+	if err != nil {
+		slog.Error("error marshalling XML", "error", err)
+	}
+	output = emptyWrapperRegexp.ReplaceAll(output, nil)
+	output = []byte(xml.Header + string(output))
+	return output, nil
+}
+
+// getName returns the creatorName or contributorName of a commonmeta contributor.
+// Names of persons are written as "Family, Given".
+func getName(v commonmeta.Contributor) Name {
+	var name Name
+	if v.Type == "Organization" {
+		name.NameType = "Organizational"
+		name.Text = v.Name
+		return name
+	}
+	if v.Type == "Person" {
+		name.NameType = "Personal"
+	}
+	switch {
+	case v.FamilyName != "" && v.GivenName != "":
+		name.Text = v.FamilyName + ", " + v.GivenName
+	case v.FamilyName != "":
+		name.Text = v.FamilyName
+	default:
+		name.Text = v.Name
+	}
+	return name
+}
+
+// getNameIdentifiers returns the ORCID or ROR identifier of a commonmeta contributor.
+func getNameIdentifiers(v commonmeta.Contributor) []NameIdentifier {
+	if orcid := utils.NormalizeORCID(v.ID); orcid != "" {
+		return []NameIdentifier{{
+			NameIdentifierScheme: "ORCID",
+			SchemeURI:            "https://orcid.org",
+			Text:                 orcid,
+		}}
+	}
+	if ror := utils.NormalizeROR(v.ID); ror != "" {
+		return []NameIdentifier{{
+			NameIdentifierScheme: "ROR",
+			SchemeURI:            "https://ror.org",
+			Text:                 ror,
+		}}
+	}
+	return nil
+}
+
+// getAffiliations returns the affiliations of a commonmeta contributor, using the ROR ID if available.
+func getAffiliations(v commonmeta.Contributor) []Affiliation {
+	var affiliations []Affiliation
+	for _, a := range v.Affiliations {
+		if a == nil || a.Name == "" {
+			continue
+		}
+		affiliation := Affiliation{
+			Text: a.Name,
+		}
+		if ror := utils.NormalizeROR(a.ID); ror != "" {
+			affiliation.AffiliationIdentifier = ror
+			affiliation.AffiliationIdentifierScheme = "ROR"
+			affiliation.SchemeURI = "https://ror.org"
+		}
+		affiliations = append(affiliations, affiliation)
+	}
+	return affiliations
+}
+
+// getRelatedIdentifier returns a relatedIdentifier for a DOI or URL.
+func getRelatedIdentifier(id string, relationType string) *RelatedIdentifier {
+	if id == "" || relationType == "" {
+		return nil
+	}
+	if doi, ok := doiutils.ValidateDOI(id); ok {
+		return &RelatedIdentifier{
+			RelatedIdentifierType: "DOI",
+			RelationType:          relationType,
+			Text:                  doi,
+		}
+	}
+	return &RelatedIdentifier{
+		RelatedIdentifierType: "URL",
+		RelationType:          relationType,
+		Text:                  id,
+	}
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
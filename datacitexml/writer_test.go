@@ -0,0 +1,137 @@
+package datacitexml_test
+
+import (
+	"encoding/xml"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/datacitexml"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+	data := commonmeta.Data{
+		ID:   "https://doi.org/10.5555/12345678",
+		Type: "Dataset",
+		Contributors: []commonmeta.Contributor{
+			{ID: "https://orcid.org/0000-0003-1419-2405", Type: "Person", GivenName: "Martin", FamilyName: "Fenner", ContributorRoles: []string{"Author"}, Affiliations: []*commonmeta.Affiliation{{ID: "https://ror.org/04wxnsj81", Name: "DataCite"}}},
+			{ID: "https://ror.org/04wxnsj81", Type: "Organization", Name: "DataCite", ContributorRoles: []string{"HostingInstitution"}},
+			{Type: "Person", GivenName: "Jane", FamilyName: "Doe", ContributorRoles: []string{"Translator"}},
+		},
+		Titles:    []commonmeta.Title{{Title: "Ein Datensatz", Language: "de"}},
+		Publisher: commonmeta.Publisher{Name: "DataCite"},
+		Date:      commonmeta.Date{Published: "2023-05-01"},
+		License:   commonmeta.License{ID: "CC-BY-4.0", URL: "https://creativecommons.org/licenses/by/4.0/legalcode"},
+		Relations: []commonmeta.Relation{{ID: "https://doi.org/10.5555/87654321", Type: "IsSupplementTo"}},
+	}
+	got, err := datacitexml.Convert(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Identifier != (datacitexml.Identifier{IdentifierType: "DOI", Text: "10.5555/12345678"}) {
+		t.Errorf("Identifier: got %v", got.Identifier)
+	}
+	wantCreators := []datacitexml.Creator{
+		{
+			CreatorName:     datacitexml.Name{NameType: "Personal", Text: "Fenner, Martin"},
+			GivenName:       "Martin",
+			FamilyName:      "Fenner",
+			NameIdentifiers: []datacitexml.NameIdentifier{{NameIdentifierScheme: "ORCID", SchemeURI: "https://orcid.org", Text: "https://orcid.org/0000-0003-1419-2405"}},
+			Affiliations:    []datacitexml.Affiliation{{AffiliationIdentifier: "https://ror.org/04wxnsj81", AffiliationIdentifierScheme: "ROR", SchemeURI: "https://ror.org", Text: "DataCite"}},
+		},
+	}
+	if diff := cmp.Diff(wantCreators, got.Creators); diff != "" {
+		t.Errorf("Creators mismatch (-want +got):\n%s", diff)
+	}
+	wantContributors := []datacitexml.Contributor{
+		{
+			ContributorType: "HostingInstitution",
+			ContributorName: datacitexml.Name{NameType: "Organizational", Text: "DataCite"},
+			NameIdentifiers: []datacitexml.NameIdentifier{{NameIdentifierScheme: "ROR", SchemeURI: "https://ror.org", Text: "https://ror.org/04wxnsj81"}},
+		},
+		{
+			ContributorType: "Other",
+			ContributorName: datacitexml.Name{NameType: "Personal", Text: "Doe, Jane"},
+			GivenName:       "Jane",
+			FamilyName:      "Doe",
+		},
+	}
+	if diff := cmp.Diff(wantContributors, got.Contributors); diff != "" {
+		t.Errorf("Contributors mismatch (-want +got):\n%s", diff)
+	}
+	if got.PublicationYear != "2023" {
+		t.Errorf("PublicationYear: want 2023, got %v", got.PublicationYear)
+	}
+	if got.ResourceType.ResourceTypeGeneral != "Dataset" {
+		t.Errorf("ResourceType: want Dataset, got %v", got.ResourceType.ResourceTypeGeneral)
+	}
+	wantRights := []datacitexml.Rights{{RightsURI: "https://creativecommons.org/licenses/by/4.0/legalcode", RightsIdentifier: "CC-BY-4.0", RightsIdentifierScheme: "SPDX", SchemeURI: "https://spdx.org/licenses/"}}
+	if diff := cmp.Diff(wantRights, got.RightsList); diff != "" {
+		t.Errorf("RightsList mismatch (-want +got):\n%s", diff)
+	}
+	wantRelated := []datacitexml.RelatedIdentifier{{RelatedIdentifierType: "DOI", RelationType: "IsSupplementTo", Text: "10.5555/87654321"}}
+	if diff := cmp.Diff(wantRelated, got.RelatedIdentifiers); diff != "" {
+		t.Errorf("RelatedIdentifiers mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestConvertInvalidDOI(t *testing.T) {
+	t.Parallel()
+	_, err := datacitexml.Convert(commonmeta.Data{ID: "https://example.org/1", Type: "Dataset"})
+	if err == nil {
+		t.Error("Convert: want error for missing DOI, got nil")
+	}
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+	data, err := datacitexml.Load(filepath.Join("testdata", "datacite-example-full-v4.4.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, errs := datacitexml.Write(data)
+	if errs != nil {
+		t.Fatal(errs)
+	}
+	str := string(output)
+	for _, want := range []string{
+		`<resource xmlns="http://datacite.org/schema/kernel-4" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" xsi:schemaLocation="http://datacite.org/schema/kernel-4 http://schema.datacite.org/meta/kernel-4.5/metadata.xsd">`,
+		`<identifier identifierType="DOI">10.5072/example-full</identifier>`,
+		`<creatorName nameType="Personal">Miller, Elizabeth</creatorName>`,
+		`<title xml:lang="en-US">Full DataCite XML Example</title>`,
+		`<description descriptionType="Abstract" xml:lang="en-US">`,
+	} {
+		if !strings.Contains(str, want) {
+			t.Errorf("Write: missing %v in\n%s", want, str)
+		}
+	}
+
+	if strings.Contains(str, "<sizes>") {
+		t.Errorf("Write: unexpected empty sizes element in\n%s", str)
+	}
+
+	// the element structure must be valid XML that reads back to the same metadata
+	var content datacitexml.Content
+	if err := xml.Unmarshal(output, &content); err != nil {
+		t.Fatal(err)
+	}
+	got, err := datacitexml.Read(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(data.Titles, got.Titles); diff != "" {
+		t.Errorf("Titles mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(data.Descriptions, got.Descriptions); diff != "" {
+		t.Errorf("Descriptions mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(data.Contributors, got.Contributors); diff != "" {
+		t.Errorf("Contributors mismatch (-want +got):\n%s", diff)
+	}
+	if got.ID != data.ID || got.Type != data.Type || got.Date.Published != data.Date.Published {
+		t.Errorf("Write round trip: want %v %v %v, got %v %v %v", data.ID, data.Type, data.Date.Published, got.ID, got.Type, got.Date.Published)
+	}
+}
@@ -41,21 +41,44 @@ func ParseDate(date string) string {
 	return t.Format(Iso8601DateFormat)
 }
 
-// GetDateParts return date parts from an ISO 8601 date string
+// GetDateParts return date parts from an ISO 8601 or EDTF date string. Dates
+// with reduced precision return only year, or year and month, and intervals
+// return the date parts of start and end, if given.
 func GetDateParts(iso8601Time string) map[string][][]int {
-	if iso8601Time == "" {
-		return map[string][][]int{"date-parts": {}}
+	dateParts := [][]int{}
+	e, err := ParseEDTF(iso8601Time)
+	if err != nil {
+		return map[string][][]int{"date-parts": dateParts}
+	}
+	for _, date := range []string{e.Start, e.End} {
+		if parts := getPartsFromDate(date); parts != nil {
+			dateParts = append(dateParts, parts)
+		}
 	}
+	return map[string][][]int{"date-parts": dateParts}
+}
 
-	// optionally add missing zeros to the date string
-	if len(iso8601Time) < 10 {
-		iso8601Time = iso8601Time + strings.Repeat("0", 10-len(iso8601Time))
+// getPartsFromDate returns year, month and day of an ISO 8601 date string,
+// as far as they are given.
+func getPartsFromDate(date string) []int {
+	if date == "" {
+		return nil
 	}
-	year, _ := strconv.Atoi(iso8601Time[0:4])
-	month, _ := strconv.Atoi(iso8601Time[5:7])
-	day, _ := strconv.Atoi(iso8601Time[8:10])
-	dateParts := [][]int{{year, month, day}}
-	return map[string][][]int{"date-parts": dateParts}
+	if len(date) > 10 {
+		date = date[:10]
+	}
+	var parts []int
+	for _, s := range strings.SplitN(strings.TrimPrefix(date, "-"), "-", 3) {
+		v, err := strconv.Atoi(s)
+		if err != nil {
+			break
+		}
+		parts = append(parts, v)
+	}
+	if strings.HasPrefix(date, "-") && len(parts) > 0 {
+		parts[0] = -parts[0]
+	}
+	return parts
 }
 
 // GetDateStruct returns struct with date (year, month, day) from an ISO 8601 date string
@@ -89,9 +112,16 @@ func GetDateTimeFromUnixTimestamp(timestamp int64) string {
 	return time.Unix(timestamp, 0).Format(Iso8601DateTimeFormat)
 }
 
-// GetDateFromDateParts returns a date string from date parts
+// GetDateFromDateParts returns a date string from date parts. Month and day
+// given as zero are ignored.
 func GetDateFromDateParts(dateAsParts [][]int) string {
+	if len(dateAsParts) == 0 {
+		return ""
+	}
 	dateParts := dateAsParts[0]
+	for len(dateParts) > 1 && dateParts[len(dateParts)-1] == 0 {
+		dateParts = dateParts[:len(dateParts)-1]
+	}
 	switch len(dateParts) {
 	case 0:
 		return ""
@@ -130,6 +160,34 @@ func GetDateFromParts(parts ...int) string {
 	return strings.Join(arr, "-")
 }
 
+// GetDateFromCSLParts returns a date string from CSL date parts, the inverse
+// of GetDateParts. Date parts with start and end return an interval. The raw
+// and literal CSL date fields can be given as fallback, and are used if they
+// can be parsed as date.
+func GetDateFromCSLParts(dateParts [][]int, fallback ...string) string {
+	var dates []string
+	for _, parts := range dateParts {
+		date := GetDateFromDateParts([][]int{parts})
+		if date != "" {
+			dates = append(dates, date)
+		}
+	}
+	if len(dates) > 0 {
+		return strings.Join(dates[:min(len(dates), 2)], "/")
+	}
+	for _, str := range fallback {
+		e, err := ParseEDTF(str)
+		if err == nil {
+			return e.String()
+		}
+		date := ParseDate(str)
+		if date != "" {
+			return date
+		}
+	}
+	return ""
+}
+
 // GetDateFromCrossrefParts returns a date string from Crossref XML date parts
 func GetDateFromCrossrefParts(strParts ...string) string {
 	parts := make([]int, 0)
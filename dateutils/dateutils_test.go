@@ -35,8 +35,14 @@ func TestGetDateParts(t *testing.T) {
 	}
 	testCases := []testCase{
 		{date: "2021-01-22", want: map[string][][]int{"date-parts": {{2021, 1, 22}}}},
-		{date: "2021-01", want: map[string][][]int{"date-parts": {{2021, 1, 0}}}},
-		{date: "2021", want: map[string][][]int{"date-parts": {{2021, 0, 0}}}},
+		{date: "2021-01", want: map[string][][]int{"date-parts": {{2021, 1}}}},
+		{date: "2021", want: map[string][][]int{"date-parts": {{2021}}}},
+		{date: "2021-01-22T10:12:00Z", want: map[string][][]int{"date-parts": {{2021, 1, 22}}}},
+		{date: "2021-01-22 10:12:00", want: map[string][][]int{"date-parts": {{2021, 1, 22}}}},
+		{date: "2021-13-45", want: map[string][][]int{"date-parts": {}}},
+		{date: "garbage", want: map[string][][]int{"date-parts": {}}},
+		{date: "2019~", want: map[string][][]int{"date-parts": {{2019}}}},
+		{date: "2020-01/2020-06", want: map[string][][]int{"date-parts": {{2020, 1}, {2020, 6}}}},
 		{date: "", want: map[string][][]int{"date-parts": {}}},
 	}
 	for _, tc := range testCases {
@@ -47,6 +53,54 @@ func TestGetDateParts(t *testing.T) {
 	}
 }
 
+func TestGetDateFromDateParts(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		dateParts [][]int
+		want      string
+	}
+	testCases := []testCase{
+		{dateParts: [][]int{{2021, 1, 22}}, want: "2021-01-22"},
+		{dateParts: [][]int{{2021, 1}}, want: "2021-01"},
+		{dateParts: [][]int{{2021, 0, 0}}, want: "2021"},
+		{dateParts: [][]int{{2021}}, want: "2021"},
+		{dateParts: [][]int{{}}, want: ""},
+		{dateParts: [][]int{}, want: ""},
+	}
+	for _, tc := range testCases {
+		got := dateutils.GetDateFromDateParts(tc.dateParts)
+		if tc.want != got {
+			t.Errorf("GetDateFromDateParts(%v): want %v, got %v", tc.dateParts, tc.want, got)
+		}
+	}
+}
+
+func TestGetDateFromCSLParts(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		dateParts [][]int
+		fallback  []string
+		want      string
+	}
+	testCases := []testCase{
+		{dateParts: [][]int{{2020, 3, 15}}, want: "2020-03-15"},
+		{dateParts: [][]int{{2020, 3}}, want: "2020-03"},
+		{dateParts: [][]int{{2020}}, want: "2020"},
+		{dateParts: [][]int{{2020, 1}, {2020, 6}}, want: "2020-01/2020-06"},
+		{dateParts: [][]int{{2020, 3, 15}}, fallback: []string{"2019"}, want: "2020-03-15"},
+		{fallback: []string{"2020-03-15"}, want: "2020-03-15"},
+		{fallback: []string{"", "15 March 2020"}, want: "2020-03-15"},
+		{dateParts: [][]int{{0}}, fallback: []string{"Spring 2020"}, want: ""},
+		{want: ""},
+	}
+	for _, tc := range testCases {
+		got := dateutils.GetDateFromCSLParts(tc.dateParts, tc.fallback...)
+		if tc.want != got {
+			t.Errorf("GetDateFromCSLParts(%v, %v): want %v, got %v", tc.dateParts, tc.fallback, tc.want, got)
+		}
+	}
+}
+
 func TestGetDateStruct(t *testing.T) {
 	t.Parallel()
 	type testCase struct {
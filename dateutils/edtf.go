@@ -0,0 +1,153 @@
+package dateutils
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// EDTF represents a date in Extended Date/Time Format (EDTF, ISO 8601-2),
+// normalized to ISO 8601 dates with year, year-month or year-month-day.
+// End is only set for intervals, including dates with unspecified year digits.
+type EDTF struct {
+	Start       string
+	End         string
+	Approximate bool
+	Uncertain   bool
+	Unspecified bool
+}
+
+var edtfDateRegex = regexp.MustCompile(`^(-?[0-9X]{4})(?:-([0-9X]{2})(?:-([0-9X]{2}))?)?$`)
+
+// unspecified digits are only allowed at the end of the year, e.g. 19XX
+var edtfUnspecifiedYearRegex = regexp.MustCompile(`^-?\d*X+$`)
+
+// ParseEDTF parses a date in EDTF levels 0 and 1: dates with reduced
+// precision, date times, intervals (including open intervals), approximate
+// (~), uncertain (?) and approximate and uncertain (%) dates, unspecified
+// digits (X), and seasons, which are reduced to the year.
+func ParseEDTF(edtf string) (EDTF, error) {
+	var e EDTF
+	edtf = strings.TrimSpace(edtf)
+	if edtf == "" {
+		return e, errors.New("empty date")
+	}
+
+	parts := strings.Split(edtf, "/")
+	switch len(parts) {
+	case 1:
+		start, end, err := parseEDTFDate(parts[0], &e)
+		if err != nil {
+			return EDTF{}, err
+		}
+		e.Start = start
+		if end != start {
+			e.End = end
+		}
+	case 2:
+		if isOpenEDTF(parts[0]) && isOpenEDTF(parts[1]) {
+			return EDTF{}, fmt.Errorf("invalid EDTF interval: %s", edtf)
+		}
+		if !isOpenEDTF(parts[0]) {
+			start, _, err := parseEDTFDate(parts[0], &e)
+			if err != nil {
+				return EDTF{}, err
+			}
+			e.Start = start
+		}
+		if !isOpenEDTF(parts[1]) {
+			_, end, err := parseEDTFDate(parts[1], &e)
+			if err != nil {
+				return EDTF{}, err
+			}
+			e.End = end
+		}
+	default:
+		return EDTF{}, fmt.Errorf("invalid EDTF date: %s", edtf)
+	}
+	return e, nil
+}
+
+// String returns the normalized date, or the interval as start/end
+func (e EDTF) String() string {
+	if e.End == "" {
+		return e.Start
+	}
+	if e.Start == "" {
+		return "../" + e.End
+	}
+	return e.Start + "/" + e.End
+}
+
+// isOpenEDTF returns true for an open or unknown end of an interval
+func isOpenEDTF(s string) bool {
+	return s == "" || s == ".."
+}
+
+// parseEDTFDate parses a single EDTF date and returns the earliest and
+// latest date it can stand for, qualifiers are recorded in e.
+func parseEDTFDate(s string, e *EDTF) (string, string, error) {
+	// date times are kept as they are
+	if len(s) > 10 && (s[10] == 'T' || s[10] == ' ') {
+		if !edtfDateRegex.MatchString(s[:10]) || strings.Contains(s[:10], "X") {
+			return "", "", fmt.Errorf("invalid EDTF date: %s", s)
+		}
+		return s, s, nil
+	}
+
+	date := s
+	if strings.ContainsAny(date, "~%") {
+		e.Approximate = true
+	}
+	if strings.ContainsAny(date, "?%") {
+		e.Uncertain = true
+	}
+	date = strings.NewReplacer("~", "", "?", "", "%", "").Replace(date)
+
+	matched := edtfDateRegex.FindStringSubmatch(date)
+	if len(matched) == 0 {
+		return "", "", fmt.Errorf("invalid EDTF date: %s", s)
+	}
+	year, month, day := matched[1], matched[2], matched[3]
+
+	if strings.Contains(year, "X") {
+		if !edtfUnspecifiedYearRegex.MatchString(year) {
+			return "", "", fmt.Errorf("invalid EDTF date: %s", s)
+		}
+		// the month and day of a year with unspecified digits are ignored
+		e.Unspecified = true
+		return strings.ReplaceAll(year, "X", "0"), strings.ReplaceAll(year, "X", "9"), nil
+	}
+
+	if month == "" {
+		return year, year, nil
+	}
+	if strings.Contains(month, "X") {
+		e.Unspecified = true
+		return year, year, nil
+	}
+	m, _ := strconv.Atoi(month)
+	if m >= 21 && m <= 24 {
+		// seasons
+		return year, year, nil
+	}
+	if m < 1 || m > 12 {
+		return "", "", fmt.Errorf("invalid EDTF date: %s", s)
+	}
+
+	if day == "" {
+		return year + "-" + month, year + "-" + month, nil
+	}
+	if strings.Contains(day, "X") {
+		e.Unspecified = true
+		return year + "-" + month, year + "-" + month, nil
+	}
+	d, _ := strconv.Atoi(day)
+	if d < 1 || d > 31 {
+		return "", "", fmt.Errorf("invalid EDTF date: %s", s)
+	}
+	date = year + "-" + month + "-" + day
+	return date, date, nil
+}
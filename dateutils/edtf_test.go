@@ -0,0 +1,54 @@
+package dateutils_test
+
+import (
+	"testing"
+
+	"github.com/front-matter/commonmeta/dateutils"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseEDTF(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input string
+		want  dateutils.EDTF
+		str   string
+		err   bool
+	}
+	testCases := []testCase{
+		{input: "2021-01-22", want: dateutils.EDTF{Start: "2021-01-22"}, str: "2021-01-22"},
+		{input: "2021-01", want: dateutils.EDTF{Start: "2021-01"}, str: "2021-01"},
+		{input: "2021", want: dateutils.EDTF{Start: "2021"}, str: "2021"},
+		{input: "2021-01-22T10:12:00Z", want: dateutils.EDTF{Start: "2021-01-22T10:12:00Z"}, str: "2021-01-22T10:12:00Z"},
+		{input: "2019~", want: dateutils.EDTF{Start: "2019", Approximate: true}, str: "2019"},
+		{input: "2004-06?", want: dateutils.EDTF{Start: "2004-06", Uncertain: true}, str: "2004-06"},
+		{input: "2004-06-11%", want: dateutils.EDTF{Start: "2004-06-11", Approximate: true, Uncertain: true}, str: "2004-06-11"},
+		{input: "1984-XX", want: dateutils.EDTF{Start: "1984", Unspecified: true}, str: "1984"},
+		{input: "1984-03-XX", want: dateutils.EDTF{Start: "1984-03", Unspecified: true}, str: "1984-03"},
+		{input: "19XX", want: dateutils.EDTF{Start: "1900", End: "1999", Unspecified: true}, str: "1900/1999"},
+		{input: "2001-21", want: dateutils.EDTF{Start: "2001"}, str: "2001"},
+		{input: "2020-01/2020-06", want: dateutils.EDTF{Start: "2020-01", End: "2020-06"}, str: "2020-01/2020-06"},
+		{input: "1964~/2008?", want: dateutils.EDTF{Start: "1964", End: "2008", Approximate: true, Uncertain: true}, str: "1964/2008"},
+		{input: "2019-12/..", want: dateutils.EDTF{Start: "2019-12"}, str: "2019-12"},
+		{input: "/2008", want: dateutils.EDTF{End: "2008"}, str: "../2008"},
+		{input: "198X/199X", want: dateutils.EDTF{Start: "1980", End: "1999", Unspecified: true}, str: "1980/1999"},
+		{input: "2021-13", err: true},
+		{input: "2021-01-32", err: true},
+		{input: "1X84", err: true},
+		{input: "../..", err: true},
+		{input: "January 2021", err: true},
+		{input: "", err: true},
+	}
+	for _, tc := range testCases {
+		got, err := dateutils.ParseEDTF(tc.input)
+		if tc.err != (err != nil) {
+			t.Errorf("ParseEDTF(%v): unexpected error %v", tc.input, err)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("ParseEDTF(%v) mismatch (-want +got):\n%s", tc.input, diff)
+		}
+		if got.String() != tc.str {
+			t.Errorf("ParseEDTF(%v) String: want %v, got %v", tc.input, tc.str, got.String())
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"regexp"
 	"slices"
 	"strings"
+	"time"
 )
 
 // PrefixFromUrl extracts DOI prefix from URL
@@ -28,26 +29,45 @@ func PrefixFromUrl(str string) (string, error) {
 
 // NormalizeDOI normalizes a DOI
 func NormalizeDOI(doi string) string {
+	doiurl, err := ParseDOI(doi)
+	if err != nil {
+		return ""
+	}
+	return doiurl
+}
+
+// ParseDOI parses a DOI given as bare DOI, with doi: prefix or as DOI URL,
+// and returns the canonical lowercase HTTPS URL of the DOI.
+func ParseDOI(doi string) (string, error) {
 	doistr, ok := ValidateDOI(doi)
 	if !ok {
-		return ""
+		return "", fmt.Errorf("invalid DOI: %q", doi)
 	}
-	resolver := DOIResolver(doi, false)
-	return resolver + strings.ToLower(doistr)
+	resolver := DOIResolver(strings.TrimSpace(doi), false)
+	return resolver + strings.ToLower(doistr), nil
 }
 
-// ValidateDOI validates a DOI
+// ValidateDOI validates a DOI and returns the bare DOI. The DOI can be given
+// as bare DOI, with doi: prefix or as DOI URL, percent-encoded characters in
+// DOI URLs are decoded.
 func ValidateDOI(doi string) (string, bool) {
-	r, err := regexp.Compile(`^(?:(http|https):/(/)?(dx\.)?(doi\.org|handle\.stage\.datacite\.org|handle\.test\.datacite\.org)/)?(doi:)?(10\.\d{4,5}/.+)$`)
+	r, err := regexp.Compile(`(?i)^(?:(?:(http|https):/(?:/)?(?:dx\.|www\.)?(?:doi\.org|handle\.stage\.datacite\.org|handle\.test\.datacite\.org)/)|doi:\s*)?(10\.\d{4,5}/.+)$`)
 	if err != nil {
 		log.Printf("Error compiling regex: %v", err)
 		return "", false
 	}
-	matched := r.FindStringSubmatch(doi)
+	matched := r.FindStringSubmatch(strings.TrimSpace(doi))
 	if len(matched) == 0 {
 		return "", false
 	}
-	return matched[6], true
+	doistr := matched[2]
+	if matched[1] != "" {
+		unescaped, err := url.PathUnescape(doistr)
+		if err == nil {
+			doistr = unescaped
+		}
+	}
+	return doistr, true
 }
 
 // ValidatePrefix validates a DOI prefix for a given DOI
@@ -76,34 +96,88 @@ func DOIResolver(doi string, sandbox bool) string {
 	return "https://doi.org/"
 }
 
+// RAURL is the base URL of the service that looks up the DOI registration agency
+var RAURL = "https://doi.org/ra/"
+
 // GetDOIRA returns the DOI registration agency for a given DOI or prefix
 func GetDOIRA(doi string) (string, bool) {
-	prefix, ok := ValidatePrefix(doi)
-	if !ok {
+	prefix := Prefix(doi)
+	if prefix == "" {
 		return "", false
 	}
-	type Response []struct {
-		DOI string `json:"DOI"`
-		RA  string `json:"RA"`
-	}
-	var result Response
-	resp, err := http.Get(fmt.Sprintf("https://doi.org/ra/%s", prefix))
+	ra, err := RegistrationAgency(prefix)
 	if err != nil {
 		return "", false
 	}
-	if resp.StatusCode == 404 {
-		return "", false
+	return ra, true
+}
+
+// Prefix returns the prefix of a DOI, given as DOI or DOI URL in any case
+func Prefix(doi string) string {
+	prefix, ok := ValidatePrefix(strings.ToLower(strings.TrimSpace(doi)))
+	if !ok {
+		return ""
+	}
+	return prefix
+}
+
+// RegistrationAgency looks up the registration agency for a DOI or prefix,
+// e.g. Crossref, DataCite or mEDRA. The request times out after 10 seconds
+// and is retried up to two times on network errors, 429 and 5xx responses.
+// doiutils can't use utils.DoWithRetry, as utils imports doiutils.
+func RegistrationAgency(doi string) (string, error) {
+	doistr, ok := ValidateDOI(strings.ToLower(strings.TrimSpace(doi)))
+	if !ok {
+		doistr = Prefix(doi)
+	}
+	if doistr == "" {
+		return "", fmt.Errorf("invalid DOI: %s", doi)
+	}
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+	var resp *http.Response
+	var err error
+	for attempt := 0; attempt < 3; attempt++ {
+		if attempt > 0 {
+			time.Sleep(500 * time.Millisecond << (attempt - 1))
+		}
+		resp, err = client.Get(RAURL + doistr)
+		if err == nil && resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+			break
+		}
+		if err == nil && attempt < 2 {
+			resp.Body.Close()
+		}
 	}
-	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return "", false
+		return "", err
 	}
 	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("registration agency lookup for %s failed: %s", doistr, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	var result []struct {
+		DOI    string `json:"DOI"`
+		RA     string `json:"RA"`
+		Status string `json:"status"`
+	}
 	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return "", false
+		return "", err
+	}
+	if len(result) == 0 || result[0].RA == "" {
+		status := "DOI does not exist"
+		if len(result) > 0 && result[0].Status != "" {
+			status = result[0].Status
+		}
+		return "", fmt.Errorf("no registration agency found for %s: %s", doistr, status)
 	}
-	return result[0].RA, true
+	return result[0].RA, nil
 }
 
 // IsRogueScholarDOI checks if a DOI is from Rogue Scholar
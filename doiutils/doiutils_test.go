@@ -1,6 +1,11 @@
 package doiutils_test
 
 import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
 
 	"github.com/front-matter/commonmeta/doiutils"
@@ -15,6 +20,10 @@ func TestValidateDOI(t *testing.T) {
 	testCases := []testCase{
 		{input: "10.7554/elife.01567", want: "10.7554/elife.01567"},
 		{input: "https://doi.org/10.7554/elife.01567", want: "10.7554/elife.01567"},
+		{input: "https://dx.doi.org/10.7554/elife.01567", want: "10.7554/elife.01567"},
+		{input: "doi:10.7554/elife.01567", want: "10.7554/elife.01567"},
+		{input: "DOI: 10.7554/eLife.01567", want: "10.7554/eLife.01567"},
+		{input: "https://doi.org/10.1002/%28SICI%291097-4571", want: "10.1002/(SICI)1097-4571"},
 		{input: "https://doi.org/10.7554", want: ""},
 		{input: "10.7554", want: ""},
 		{input: "", want: ""},
@@ -28,6 +37,39 @@ func TestValidateDOI(t *testing.T) {
 	}
 }
 
+func TestParseDOI(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input string
+		want  string
+		err   bool
+	}
+	testCases := []testCase{
+		{input: "10.7554/elife.01567", want: "https://doi.org/10.7554/elife.01567"},
+		{input: "10.7554/eLife.01567", want: "https://doi.org/10.7554/elife.01567"},
+		{input: "doi:10.7554/elife.01567", want: "https://doi.org/10.7554/elife.01567"},
+		{input: "https://doi.org/10.7554/elife.01567", want: "https://doi.org/10.7554/elife.01567"},
+		{input: "http://doi.org/10.7554/elife.01567", want: "https://doi.org/10.7554/elife.01567"},
+		{input: "https://dx.doi.org/10.7554/elife.01567", want: "https://doi.org/10.7554/elife.01567"},
+		{input: "HTTPS://DOI.ORG/10.7554/ELIFE.01567", want: "https://doi.org/10.7554/elife.01567"},
+		{input: " 10.7554/elife.01567 ", want: "https://doi.org/10.7554/elife.01567"},
+		{input: "https://doi.org/10.1002/%28SICI%291097-4571%28199806%2949%3A8%3C693%3A%3AAID-ASI4%3E3.0.CO%3B2-0", want: "https://doi.org/10.1002/(sici)1097-4571(199806)49:8<693::aid-asi4>3.0.co;2-0"},
+		{input: "https://www.example.org/10.7554/elife.01567", err: true},
+		{input: "10.7554", err: true},
+		{input: "", err: true},
+	}
+	for _, tc := range testCases {
+		got, err := doiutils.ParseDOI(tc.input)
+		if tc.err != (err != nil) {
+			t.Errorf("Parse DOI(%v): unexpected error %v", tc.input, err)
+		}
+		if tc.want != got {
+			t.Errorf("Parse DOI(%v): want %v, got %v",
+				tc.input, tc.want, got)
+		}
+	}
+}
+
 func TestValidatePrefix(t *testing.T) {
 	t.Parallel()
 	type testCase struct {
@@ -114,3 +156,84 @@ func TestIsRogueScholarDOI(t *testing.T) {
 		}
 	}
 }
+
+func TestPrefix(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input string
+		want  string
+	}
+	testCases := []testCase{
+		{input: "10.7554/elife.01567", want: "10.7554"},
+		{input: "10.7554/ELIFE.01567", want: "10.7554"},
+		{input: "https://doi.org/10.5061/dryad.8515", want: "10.5061"},
+		{input: "HTTPS://DOI.ORG/10.5061/DRYAD.8515", want: "10.5061"},
+		{input: "doi:10.5061/dryad.8515", want: "10.5061"},
+		{input: "https://www.example.org/10.5061/dryad.8515", want: ""},
+		{input: "", want: ""},
+	}
+	for _, tc := range testCases {
+		got := doiutils.Prefix(tc.input)
+		if tc.want != got {
+			t.Errorf("Prefix(%v): want %v, got %v",
+				tc.input, tc.want, got)
+		}
+	}
+}
+
+func TestRegistrationAgency(t *testing.T) {
+	// not parallel, as the test changes the registration agency URL
+	var unavailable int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		doi := strings.TrimPrefix(r.URL.Path, "/ra/")
+		var ra string
+		switch doiutils.Prefix(doi) {
+		case "10.1234":
+			// the first request fails and is retried
+			if atomic.AddInt32(&unavailable, 1) == 1 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			ra = "Crossref"
+		case "10.7554":
+			ra = "Crossref"
+		case "10.5061":
+			ra = "DataCite"
+		case "10.3280":
+			ra = "mEDRA"
+		default:
+			fmt.Fprintf(w, `[{"DOI":%q,"status":"DOI does not exist"}]`, doi)
+			return
+		}
+		fmt.Fprintf(w, `[{"DOI":%q,"RA":%q}]`, doi, ra)
+	}))
+	defer ts.Close()
+	raURL := doiutils.RAURL
+	doiutils.RAURL = ts.URL + "/ra/"
+	defer func() { doiutils.RAURL = raURL }()
+
+	type testCase struct {
+		input string
+		want  string
+		err   bool
+	}
+	testCases := []testCase{
+		{input: "10.7554/elife.01567", want: "Crossref"},
+		{input: "https://doi.org/10.5061/dryad.8515", want: "DataCite"},
+		{input: "10.3280/ecag2018-002003", want: "mEDRA"},
+		{input: "10.5061", want: "DataCite"},
+		{input: "10.1234/retry", want: "Crossref"},
+		{input: "10.9999/xyz", err: true},
+		{input: "", err: true},
+	}
+	for _, tc := range testCases {
+		got, err := doiutils.RegistrationAgency(tc.input)
+		if tc.err != (err != nil) {
+			t.Errorf("RegistrationAgency(%v): unexpected error %v", tc.input, err)
+		}
+		if tc.want != got {
+			t.Errorf("RegistrationAgency(%v): want %v, got %v",
+				tc.input, tc.want, got)
+		}
+	}
+}
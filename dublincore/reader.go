@@ -0,0 +1,212 @@
+// Package dublincore provides functions to convert Dublin Core (OAI-DC) metadata to/from the commonmeta metadata format.
+package dublincore
+
+import (
+	"encoding/xml"
+	"errors"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/front-matter/commonmeta/authorutils"
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/langutils"
+	"github.com/front-matter/commonmeta/spdxutils"
+	"github.com/front-matter/commonmeta/utils"
+)
+
+// Content represents the Dublin Core metadata in the oai_dc format used by OAI-PMH.
+// Elements are matched by local name, so any namespace prefix can be used.
+type Content struct {
+	XMLName        xml.Name `xml:"dc"`
+	XmlnsOAIDC     string   `xml:"xmlns:oai_dc,attr,omitempty"`
+	XmlnsDC        string   `xml:"xmlns:dc,attr,omitempty"`
+	Xsi            string   `xml:"xmlns:xsi,attr,omitempty"`
+	SchemaLocation string   `xml:"xsi:schemaLocation,attr,omitempty"`
+	Title          []string `xml:"title"`
+	Creator        []string `xml:"creator"`
+	Subject        []string `xml:"subject"`
+	Description    []string `xml:"description"`
+	Publisher      []string `xml:"publisher"`
+	Contributor    []string `xml:"contributor"`
+	Date           []string `xml:"date"`
+	Type           []string `xml:"type"`
+	Format         []string `xml:"format"`
+	Identifier     []string `xml:"identifier"`
+	Source         []string `xml:"source"`
+	Language       []string `xml:"language"`
+	Relation       []string `xml:"relation"`
+	Coverage       []string `xml:"coverage"`
+	Rights         []string `xml:"rights"`
+}
+
+// DCToCMMappings maps Dublin Core types to commonmeta types. Both the DCMI Type
+// Vocabulary and the info:eu-repo/semantics types used by OpenAIRE are supported.
+var DCToCMMappings = map[string]string{
+	"Collection":          "Collection",
+	"Dataset":             "Dataset",
+	"Event":               "Event",
+	"Image":               "Image",
+	"InteractiveResource": "InteractiveResource",
+	"MovingImage":         "Audiovisual",
+	"PhysicalObject":      "PhysicalObject",
+	"Software":            "Software",
+	"Sound":               "Audiovisual",
+	"StillImage":          "Image",
+	"Text":                "Document",
+	"article":             "JournalArticle",
+	"book":                "Book",
+	"bookPart":            "BookChapter",
+	"conferenceObject":    "ProceedingsArticle",
+	"doctoralThesis":      "Dissertation",
+	"masterThesis":        "Dissertation",
+	"preprint":            "Article",
+	"report":              "Report",
+	"workingPaper":        "Report",
+}
+
+// dateRegexp matches the date part of a dc:date value.
+var dateRegexp = regexp.MustCompile(`^\d{4}(-\d{2}(-\d{2})?)?`)
+
+// Load loads the metadata for a single work from a Dublin Core XML file
+func Load(filename string) (commonmeta.Data, error) {
+	var data commonmeta.Data
+
+	extension := path.Ext(filename)
+	if extension != ".xml" {
+		return data, errors.New("invalid file extension")
+	}
+	input, err := os.ReadFile(filename)
+	if err != nil {
+		return data, errors.New("error reading file")
+	}
+	return ReadXML(input)
+}
+
+// ReadXML unmarshals Dublin Core XML and converts it to commonmeta.
+func ReadXML(input []byte) (commonmeta.Data, error) {
+	var data commonmeta.Data
+	var content Content
+
+	err := xml.Unmarshal(input, &content)
+	if err != nil {
+		return data, err
+	}
+	return Read(content)
+}
+
+// Read reads Dublin Core metadata and converts it to commonmeta.
+func Read(content Content) (commonmeta.Data, error) {
+	var data commonmeta.Data
+
+	for _, v := range content.Identifier {
+		v = strings.TrimSpace(v)
+		doi, ok := doiutils.ValidateDOI(strings.TrimPrefix(v, "info:doi/"))
+		if ok {
+			if data.ID == "" {
+				data.ID = doiutils.NormalizeDOI(doi)
+			}
+			data.Identifiers = append(data.Identifiers, commonmeta.Identifier{
+				Identifier:     doiutils.NormalizeDOI(doi),
+				IdentifierType: "DOI",
+			})
+		} else if utils.ValidateURL(v) == "URL" {
+			if data.URL == "" {
+				data.URL = v
+			}
+			data.Identifiers = append(data.Identifiers, commonmeta.Identifier{
+				Identifier:     v,
+				IdentifierType: "URL",
+			})
+		} else if v != "" {
+			data.Identifiers = append(data.Identifiers, commonmeta.Identifier{
+				Identifier:     v,
+				IdentifierType: "Other",
+			})
+		}
+	}
+	if data.ID == "" {
+		data.ID = data.URL
+	}
+	if data.ID == "" {
+		return data, errors.New("missing identifier")
+	}
+
+	data.Type = "Other"
+	for _, v := range content.Type {
+		t, ok := DCToCMMappings[strings.TrimPrefix(strings.TrimSpace(v), "info:eu-repo/semantics/")]
+		if ok {
+			data.Type = t
+			break
+		}
+	}
+
+	for _, v := range content.Creator {
+		contributor := getContributor(v, "Author")
+		if contributor.Name != "" {
+			data.Contributors = append(data.Contributors, contributor)
+		}
+	}
+	for _, v := range content.Contributor {
+		contributor := getContributor(v, "Other")
+		if contributor.Name != "" {
+			data.Contributors = append(data.Contributors, contributor)
+		}
+	}
+
+	for _, v := range content.Title {
+		if strings.TrimSpace(v) != "" {
+			data.Titles = append(data.Titles, commonmeta.Title{Title: strings.TrimSpace(v)})
+		}
+	}
+	for _, v := range content.Description {
+		if strings.TrimSpace(v) != "" {
+			data.Descriptions = append(data.Descriptions, commonmeta.Description{
+				Description: utils.Sanitize(strings.TrimSpace(v)),
+				Type:        "Abstract",
+			})
+		}
+	}
+	if len(content.Publisher) > 0 {
+		data.Publisher = commonmeta.Publisher{Name: strings.TrimSpace(content.Publisher[0])}
+	}
+	if len(content.Date) > 0 {
+		data.Date.Published = dateRegexp.FindString(strings.TrimSpace(content.Date[0]))
+	}
+	for _, v := range content.Subject {
+		if strings.TrimSpace(v) != "" {
+			data.Subjects = append(data.Subjects, commonmeta.Subject{Subject: strings.TrimSpace(v)})
+		}
+	}
+	if len(content.Language) > 0 {
+		data.Language = langutils.GetLanguage(content.Language[0], langutils.ISO6391)
+	}
+	for _, v := range content.Rights {
+		id, url := spdxutils.Normalize(v)
+		if id != "" {
+			data.License = commonmeta.License{
+				ID:  id,
+				URL: url,
+			}
+			break
+		}
+	}
+	return data, nil
+}
+
+// getContributor converts a literal Dublin Core name to a commonmeta contributor.
+// Dublin Core has no given/family name split, so the name is kept as is.
+func getContributor(name string, role string) commonmeta.Contributor {
+	name = strings.TrimSpace(name)
+	contributorType := "Organization"
+	if authorutils.IsPersonalName(name) {
+		contributorType = "Person"
+	}
+	return commonmeta.Contributor{
+		Type:             contributorType,
+		Name:             name,
+		ContributorRoles: []string{role},
+	}
+}
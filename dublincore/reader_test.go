@@ -0,0 +1,67 @@
+package dublincore_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/dublincore"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name string
+		want commonmeta.Data
+	}
+
+	testCases := []testCase{
+		{
+			name: "oai-dc.xml",
+			want: commonmeta.Data{
+				ID:   "https://doi.org/10.7717/peerj-cs.86",
+				Type: "JournalArticle",
+				URL:  "https://peerj.com/articles/cs-86",
+				Contributors: []commonmeta.Contributor{
+					{Type: "Person", Name: "Smith, Arfon M.", ContributorRoles: []string{"Author"}},
+					{Type: "Person", Name: "Katz, Daniel S.", ContributorRoles: []string{"Author"}},
+					{Type: "Person", Name: "Niemeyer, Kyle E.", ContributorRoles: []string{"Author"}},
+					{Type: "Organization", Name: "Software Sustainability Institute", ContributorRoles: []string{"Author"}},
+				},
+				Date: commonmeta.Date{Published: "2016-09-19"},
+				Descriptions: []commonmeta.Description{
+					{Description: "Software is a critical part of modern research and yet there is little support across the scholarly ecosystem for its acknowledgement and citation.", Type: "Abstract"},
+				},
+				Identifiers: []commonmeta.Identifier{
+					{Identifier: "https://peerj.com/articles/cs-86", IdentifierType: "URL"},
+					{Identifier: "https://doi.org/10.7717/peerj-cs.86", IdentifierType: "DOI"},
+				},
+				Language:  "en",
+				License:   commonmeta.License{ID: "CC-BY-4.0", URL: "https://creativecommons.org/licenses/by/4.0/legalcode"},
+				Publisher: commonmeta.Publisher{Name: "PeerJ"},
+				Subjects:  []commonmeta.Subject{{Subject: "Software citation"}, {Subject: "Research software"}},
+				Titles:    []commonmeta.Title{{Title: "Software citation principles"}},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		got, err := dublincore.Load(filepath.Join("testdata", tc.name))
+		if err != nil {
+			t.Fatalf("Dublin Core Load (%v): error %v", tc.name, err)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("Dublin Core Load (%v) mismatch (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestReadXMLMissingIdentifier(t *testing.T) {
+	t.Parallel()
+	input := []byte(`<oai_dc:dc xmlns:oai_dc="http://www.openarchives.org/OAI/2.0/oai_dc/" xmlns:dc="http://purl.org/dc/elements/1.1/"><dc:title>Untitled</dc:title></oai_dc:dc>`)
+	_, err := dublincore.ReadXML(input)
+	if err == nil {
+		t.Error("Dublin Core ReadXML: want error for missing identifier, got nil")
+	}
+}
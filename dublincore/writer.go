@@ -0,0 +1,176 @@
+package dublincore
+
+import (
+	"encoding/xml"
+	"log/slog"
+	"strings"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// CMToDCMappings maps commonmeta types to the DCMI Type Vocabulary.
+var CMToDCMappings = map[string]string{
+	"Article":             "Text",
+	"Audiovisual":         "MovingImage",
+	"Book":                "Text",
+	"BookChapter":         "Text",
+	"Collection":          "Collection",
+	"Dataset":             "Dataset",
+	"Dissertation":        "Text",
+	"Document":            "Text",
+	"Event":               "Event",
+	"Image":               "StillImage",
+	"InteractiveResource": "InteractiveResource",
+	"JournalArticle":      "Text",
+	"PhysicalObject":      "PhysicalObject",
+	"ProceedingsArticle":  "Text",
+	"Report":              "Text",
+	"Software":            "Software",
+}
+
+// oaiDC is the Dublin Core metadata with the element names used for writing
+// oai_dc XML. It has the same fields as Content, which is matched by local
+// name when reading.
+type oaiDC struct {
+	XMLName        xml.Name `xml:"oai_dc:dc"`
+	XmlnsOAIDC     string   `xml:"xmlns:oai_dc,attr,omitempty"`
+	XmlnsDC        string   `xml:"xmlns:dc,attr,omitempty"`
+	Xsi            string   `xml:"xmlns:xsi,attr,omitempty"`
+	SchemaLocation string   `xml:"xsi:schemaLocation,attr,omitempty"`
+	Title          []string `xml:"dc:title"`
+	Creator        []string `xml:"dc:creator"`
+	Subject        []string `xml:"dc:subject"`
+	Description    []string `xml:"dc:description"`
+	Publisher      []string `xml:"dc:publisher"`
+	Contributor    []string `xml:"dc:contributor"`
+	Date           []string `xml:"dc:date"`
+	Type           []string `xml:"dc:type"`
+	Format         []string `xml:"dc:format"`
+	Identifier     []string `xml:"dc:identifier"`
+	Source         []string `xml:"dc:source"`
+	Language       []string `xml:"dc:language"`
+	Relation       []string `xml:"dc:relation"`
+	Coverage       []string `xml:"dc:coverage"`
+	Rights         []string `xml:"dc:rights"`
+}
+
+// records represents a list of Dublin Core records.
+type records struct {
+	XMLName xml.Name `xml:"records"`
+	Records []oaiDC  `xml:"oai_dc:dc"`
+}
+
+// Convert converts commonmeta metadata to Dublin Core metadata
+func Convert(data commonmeta.Data) (Content, error) {
+	content := Content{
+		XmlnsOAIDC:     "http://www.openarchives.org/OAI/2.0/oai_dc/",
+		XmlnsDC:        "http://purl.org/dc/elements/1.1/",
+		Xsi:            "http://www.w3.org/2001/XMLSchema-instance",
+		SchemaLocation: "http://www.openarchives.org/OAI/2.0/oai_dc/ http://www.openarchives.org/OAI/2.0/oai_dc.xsd",
+	}
+
+	for _, v := range data.Titles {
+		if v.Title != "" {
+			content.Title = append(content.Title, v.Title)
+		}
+	}
+	for _, v := range data.Contributors {
+		name := getName(v)
+		if name == "" {
+			continue
+		}
+		if len(v.ContributorRoles) == 0 || v.ContributorRoles[0] == "Author" {
+			content.Creator = append(content.Creator, name)
+		} else {
+			content.Contributor = append(content.Contributor, name)
+		}
+	}
+	for _, v := range data.Subjects {
+		if v.Subject != "" {
+			content.Subject = append(content.Subject, v.Subject)
+		}
+	}
+	for _, v := range data.Descriptions {
+		if v.Description != "" {
+			content.Description = append(content.Description, v.Description)
+		}
+	}
+	if data.Publisher.Name != "" {
+		content.Publisher = []string{data.Publisher.Name}
+	}
+	if data.Date.Published != "" {
+		content.Date = []string{data.Date.Published}
+	}
+	dcType, ok := CMToDCMappings[data.Type]
+	if !ok {
+		dcType = "Text"
+	}
+	content.Type = []string{dcType}
+
+	doi, ok := doiutils.ValidateDOI(data.ID)
+	if ok {
+		content.Identifier = append(content.Identifier, doiutils.NormalizeDOI(doi))
+	} else if data.ID != "" {
+		content.Identifier = append(content.Identifier, data.ID)
+	}
+	if data.URL != "" && data.URL != data.ID {
+		content.Identifier = append(content.Identifier, data.URL)
+	}
+	if data.Language != "" {
+		content.Language = []string{data.Language}
+	}
+	for _, v := range data.Relations {
+		if v.ID != "" {
+			content.Relation = append(content.Relation, v.ID)
+		}
+	}
+	if data.License.URL != "" {
+		content.Rights = []string{data.License.URL}
+	} else if data.License.ID != "" {
+		content.Rights = []string{data.License.ID}
+	}
+	return content, nil
+}
+
+// Write writes commonmeta metadata in Dublin Core (oai_dc) format.
+func Write(data commonmeta.Data) ([]byte, []gojsonschema.ResultError) {
+	content, err := Convert(data)
+	if err != nil {
+		slog.Error("error converting metadata", "error", err)
+	}
+	output, err := xml.MarshalIndent(oaiDC(content), "", "  ")
+	if err != nil {
+		slog.Error("error marshalling XML", "error", err)
+	}
+	output = []byte(xml.Header + string(output))
+	return output, nil
+}
+
+// WriteAll writes a list of commonmeta metadata in Dublin Core (oai_dc) format.
+func WriteAll(list []commonmeta.Data) ([]byte, []gojsonschema.ResultError) {
+	var r records
+	for _, data := range list {
+		content, err := Convert(data)
+		if err != nil {
+			slog.Error("error converting metadata", "error", err)
+		}
+		r.Records = append(r.Records, oaiDC(content))
+	}
+	output, err := xml.MarshalIndent(r, "", "  ")
+	if err != nil {
+		slog.Error("error marshalling XML", "error", err)
+	}
+	output = []byte(xml.Header + string(output))
+	return output, nil
+}
+
+// getName returns the literal name of a commonmeta contributor. Names of
+// persons are written as "Family, Given".
+func getName(v commonmeta.Contributor) string {
+	if v.Name != "" {
+		return v.Name
+	}
+	return strings.Trim(v.FamilyName+", "+v.GivenName, ", ")
+}
@@ -0,0 +1,75 @@
+package dublincore_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/dublincore"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+	data, err := dublincore.Load(filepath.Join("testdata", "oai-dc.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, errs := dublincore.Write(data)
+	if errs != nil {
+		t.Fatal(errs)
+	}
+	str := string(output)
+	for _, want := range []string{
+		`<oai_dc:dc xmlns:oai_dc="http://www.openarchives.org/OAI/2.0/oai_dc/" xmlns:dc="http://purl.org/dc/elements/1.1/"`,
+		`<dc:title>Software citation principles</dc:title>`,
+		`<dc:creator>Smith, Arfon M.</dc:creator>`,
+		`<dc:creator>Software Sustainability Institute</dc:creator>`,
+		`<dc:identifier>https://doi.org/10.7717/peerj-cs.86</dc:identifier>`,
+		`<dc:date>2016-09-19</dc:date>`,
+		`<dc:type>Text</dc:type>`,
+		`<dc:rights>https://creativecommons.org/licenses/by/4.0/legalcode</dc:rights>`,
+	} {
+		if !strings.Contains(str, want) {
+			t.Errorf("Dublin Core Write: missing %v in\n%s", want, str)
+		}
+	}
+
+	// the written record reads back to the same metadata
+	got, err := dublincore.ReadXML(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(data.Contributors, got.Contributors); diff != "" {
+		t.Errorf("Contributors mismatch (-want +got):\n%s", diff)
+	}
+	if got.ID != data.ID || got.Date.Published != data.Date.Published || got.License != data.License {
+		t.Errorf("Dublin Core Write round trip: want %v %v %v, got %v %v %v", data.ID, data.Date.Published, data.License, got.ID, got.Date.Published, got.License)
+	}
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+	data := commonmeta.Data{
+		ID:   "https://doi.org/10.5555/12345678",
+		Type: "Dataset",
+		Contributors: []commonmeta.Contributor{
+			{Type: "Person", GivenName: "Josiah", FamilyName: "Carberry", ContributorRoles: []string{"Author"}},
+			{Type: "Organization", Name: "Brown University", ContributorRoles: []string{"HostingInstitution"}},
+		},
+	}
+	got, err := dublincore.Convert(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"Carberry, Josiah"}, got.Creator); diff != "" {
+		t.Errorf("Creator mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"Brown University"}, got.Contributor); diff != "" {
+		t.Errorf("Contributor mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{"Dataset"}, got.Type); diff != "" {
+		t.Errorf("Type mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,276 @@
+// Package feed provides functions to write lists of commonmeta metadata as
+// RSS 2.0 or Atom feed.
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/doiutils"
+)
+
+// RSS represents an RSS 2.0 feed.
+type RSS struct {
+	XMLName xml.Name `xml:"rss"`
+	Version string   `xml:"version,attr"`
+	XmlnsDC string   `xml:"xmlns:dc,attr"`
+	Channel Channel  `xml:"channel"`
+}
+
+// Channel represents the channel of an RSS 2.0 feed.
+type Channel struct {
+	Title         string `xml:"title"`
+	Link          string `xml:"link"`
+	Description   string `xml:"description"`
+	LastBuildDate string `xml:"lastBuildDate,omitempty"`
+	Items         []Item `xml:"item"`
+}
+
+// Item represents an item in an RSS 2.0 feed.
+type Item struct {
+	Title       string   `xml:"title,omitempty"`
+	Link        string   `xml:"link,omitempty"`
+	GUID        *GUID    `xml:"guid,omitempty"`
+	PubDate     string   `xml:"pubDate,omitempty"`
+	Creators    []string `xml:"dc:creator,omitempty"`
+	Description string   `xml:"description,omitempty"`
+}
+
+// GUID represents the unique identifier of an item in an RSS 2.0 feed.
+type GUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// Atom represents an Atom feed.
+type Atom struct {
+	XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string   `xml:"title"`
+	ID      string   `xml:"id"`
+	Link    *Link    `xml:"link,omitempty"`
+	Updated string   `xml:"updated"`
+	Entries []Entry  `xml:"entry"`
+}
+
+// Entry represents an entry in an Atom feed.
+type Entry struct {
+	Title     string   `xml:"title"`
+	ID        string   `xml:"id"`
+	Link      *Link    `xml:"link,omitempty"`
+	Published string   `xml:"published,omitempty"`
+	Updated   string   `xml:"updated"`
+	Authors   []Author `xml:"author,omitempty"`
+	Summary   string   `xml:"summary,omitempty"`
+}
+
+// Link represents a link in an Atom feed.
+type Link struct {
+	Href string `xml:"href,attr"`
+}
+
+// Author represents an author in an Atom feed.
+type Author struct {
+	Name string `xml:"name"`
+}
+
+// Option configures the feed.
+type Option func(*options)
+
+type options struct {
+	title       string
+	link        string
+	description string
+}
+
+// WithTitle sets the title of the feed.
+func WithTitle(title string) Option {
+	return func(o *options) {
+		o.title = title
+	}
+}
+
+// WithLink sets the link to the website the feed is for.
+func WithLink(link string) Option {
+	return func(o *options) {
+		o.link = link
+	}
+}
+
+// WithDescription sets the description of the feed.
+func WithDescription(description string) Option {
+	return func(o *options) {
+		o.description = description
+	}
+}
+
+// WriteAll writes a list of commonmeta metadata as feed, format is either
+// "rss" or "atom".
+func WriteAll(list []commonmeta.Data, format string, opts ...Option) ([]byte, error) {
+	o := options{title: "commonmeta"}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	var content any
+	switch format {
+	case "rss":
+		content = convertRSS(list, o)
+	case "atom":
+		content = convertAtom(list, o)
+	default:
+		return nil, fmt.Errorf("unsupported feed format: %s", format)
+	}
+	output, err := xml.MarshalIndent(content, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return []byte(xml.Header + string(output)), nil
+}
+
+// convertRSS converts a list of commonmeta metadata into an RSS 2.0 feed.
+func convertRSS(list []commonmeta.Data, o options) RSS {
+	rss := RSS{
+		Version: "2.0",
+		XmlnsDC: "http://purl.org/dc/elements/1.1/",
+		Channel: Channel{
+			Title:       o.title,
+			Link:        o.link,
+			Description: o.description,
+		},
+	}
+	var lastBuildDate time.Time
+	for _, data := range list {
+		item := Item{
+			Title:       getTitle(data),
+			Link:        getLink(data),
+			Description: getAbstract(data),
+		}
+		if item.Link != "" {
+			item.GUID = &GUID{IsPermaLink: true, Value: item.Link}
+		}
+		if t, ok := parseDate(data.Date.Published); ok {
+			item.PubDate = t.Format(time.RFC1123Z)
+			if t.After(lastBuildDate) {
+				lastBuildDate = t
+			}
+		}
+		for _, v := range getAuthors(data) {
+			item.Creators = append(item.Creators, v.Name)
+		}
+		rss.Channel.Items = append(rss.Channel.Items, item)
+	}
+	if !lastBuildDate.IsZero() {
+		rss.Channel.LastBuildDate = lastBuildDate.Format(time.RFC1123Z)
+	}
+	return rss
+}
+
+// convertAtom converts a list of commonmeta metadata into an Atom feed. The
+// feed is updated when the most recent entry was updated.
+func convertAtom(list []commonmeta.Data, o options) Atom {
+	atom := Atom{
+		Title: o.title,
+		ID:    o.link,
+	}
+	if o.link != "" {
+		atom.Link = &Link{Href: o.link}
+	}
+	var updated time.Time
+	for _, data := range list {
+		entry := Entry{
+			Title:   getTitle(data),
+			ID:      data.ID,
+			Authors: getAuthors(data),
+			Summary: getAbstract(data),
+		}
+		if link := getLink(data); link != "" {
+			entry.Link = &Link{Href: link}
+		}
+		published, ok := parseDate(data.Date.Published)
+		if ok {
+			entry.Published = published.Format(time.RFC3339)
+		}
+		entryUpdated, ok := parseDate(data.Date.Updated)
+		if !ok {
+			entryUpdated = published
+		}
+		if !entryUpdated.IsZero() {
+			entry.Updated = entryUpdated.Format(time.RFC3339)
+			if entryUpdated.After(updated) {
+				updated = entryUpdated
+			}
+		}
+		atom.Entries = append(atom.Entries, entry)
+	}
+	if !updated.IsZero() {
+		atom.Updated = updated.Format(time.RFC3339)
+	}
+	return atom
+}
+
+// getAbstract returns the abstract, or the first description.
+func getAbstract(data commonmeta.Data) string {
+	for _, v := range data.Descriptions {
+		if v.Type == "Abstract" {
+			return v.Description
+		}
+	}
+	if len(data.Descriptions) > 0 {
+		return data.Descriptions[0].Description
+	}
+	return ""
+}
+
+// getAuthors returns the names of the authors.
+func getAuthors(data commonmeta.Data) []Author {
+	var authors []Author
+	for _, v := range data.Contributors {
+		if len(v.ContributorRoles) > 0 && !slices.Contains(v.ContributorRoles, "Author") {
+			continue
+		}
+		name := v.Name
+		if name == "" {
+			name = strings.TrimSpace(v.GivenName + " " + v.FamilyName)
+		}
+		if name != "" {
+			authors = append(authors, Author{Name: name})
+		}
+	}
+	return authors
+}
+
+// getLink returns the DOI as URL, or the URL of the work.
+func getLink(data commonmeta.Data) string {
+	if doi, ok := doiutils.ValidateDOI(data.ID); ok {
+		return "https://doi.org/" + doi
+	}
+	return data.URL
+}
+
+// getTitle returns the main title.
+func getTitle(data commonmeta.Data) string {
+	for _, v := range data.Titles {
+		if v.Type == "" {
+			return v.Title
+		}
+	}
+	if len(data.Titles) > 0 {
+		return data.Titles[0].Title
+	}
+	return ""
+}
+
+// parseDate parses an ISO 8601 date or date time with reduced precision.
+func parseDate(date string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02", "2006-01", "2006"} {
+		t, err := time.Parse(layout, date)
+		if err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,100 @@
+package feed_test
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/feed"
+)
+
+var list = []commonmeta.Data{
+	{
+		ID:   "https://doi.org/10.7554/elife.01567",
+		Type: "JournalArticle",
+		Contributors: []commonmeta.Contributor{
+			{Type: "Person", GivenName: "Martial", FamilyName: "Sankar", ContributorRoles: []string{"Author"}},
+			{Type: "Person", GivenName: "Kaisa", FamilyName: "Nieminen", ContributorRoles: []string{"Author"}},
+		},
+		Titles:       []commonmeta.Title{{Title: "Automated quantitative histology reveals vascular morphodynamics during Arabidopsis hypocotyl secondary growth"}},
+		Descriptions: []commonmeta.Description{{Description: "Among various advantages, their small size makes model organisms preferred subjects of investigation.", Type: "Abstract"}},
+		Date:         commonmeta.Date{Published: "2014-02-11", Updated: "2022-03-26T09:21:50Z"},
+	},
+	{
+		ID:     "https://doi.org/10.53731/ybhah-9jy85",
+		Type:   "Article",
+		Titles: []commonmeta.Title{{Title: "Rogue Scholar & friends <news>"}},
+		Date:   commonmeta.Date{Published: "2023-10-04T11:38:24Z"},
+	},
+}
+
+func TestWriteAllRSS(t *testing.T) {
+	t.Parallel()
+	output, err := feed.WriteAll(list, "rss", feed.WithTitle("Recent works"), feed.WithLink("https://example.org"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got feed.RSS
+	if err := xml.Unmarshal(output, &got); err != nil {
+		t.Fatalf("WriteAll rss: invalid XML %v", err)
+	}
+	if got.Channel.Title != "Recent works" {
+		t.Errorf("WriteAll rss title: want Recent works, got %v", got.Channel.Title)
+	}
+	if len(got.Channel.Items) != len(list) {
+		t.Fatalf("WriteAll rss: want %d items, got %d", len(list), len(got.Channel.Items))
+	}
+	item := got.Channel.Items[0]
+	if item.Link != "https://doi.org/10.7554/elife.01567" {
+		t.Errorf("WriteAll rss link: want https://doi.org/10.7554/elife.01567, got %v", item.Link)
+	}
+	if item.PubDate != "Tue, 11 Feb 2014 00:00:00 +0000" {
+		t.Errorf("WriteAll rss pubDate: want Tue, 11 Feb 2014 00:00:00 +0000, got %v", item.PubDate)
+	}
+	if !strings.Contains(string(output), "<dc:creator>Martial Sankar</dc:creator>") {
+		t.Errorf("WriteAll rss: want dc:creator in %s", output)
+	}
+	if got.Channel.Items[1].Title != "Rogue Scholar & friends <news>" {
+		t.Errorf("WriteAll rss title: want escaped title, got %v", got.Channel.Items[1].Title)
+	}
+	if got.Channel.LastBuildDate != "Wed, 04 Oct 2023 11:38:24 +0000" {
+		t.Errorf("WriteAll rss lastBuildDate: want Wed, 04 Oct 2023 11:38:24 +0000, got %v", got.Channel.LastBuildDate)
+	}
+}
+
+func TestWriteAllAtom(t *testing.T) {
+	t.Parallel()
+	output, err := feed.WriteAll(list, "atom", feed.WithLink("https://example.org"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got feed.Atom
+	if err := xml.Unmarshal(output, &got); err != nil {
+		t.Fatalf("WriteAll atom: invalid XML %v", err)
+	}
+	if len(got.Entries) != len(list) {
+		t.Fatalf("WriteAll atom: want %d entries, got %d", len(list), len(got.Entries))
+	}
+	entry := got.Entries[0]
+	if entry.Published != "2014-02-11T00:00:00Z" || entry.Updated != "2022-03-26T09:21:50Z" {
+		t.Errorf("WriteAll atom dates: got published %v, updated %v", entry.Published, entry.Updated)
+	}
+	if len(entry.Authors) != 2 || entry.Authors[1].Name != "Kaisa Nieminen" {
+		t.Errorf("WriteAll atom authors: got %v", entry.Authors)
+	}
+	if entry.Summary == "" {
+		t.Error("WriteAll atom: want summary from abstract")
+	}
+	if got.Updated != "2023-10-04T11:38:24Z" {
+		t.Errorf("WriteAll atom updated: want 2023-10-04T11:38:24Z, got %v", got.Updated)
+	}
+}
+
+func TestWriteAllUnsupportedFormat(t *testing.T) {
+	t.Parallel()
+	_, err := feed.WriteAll(list, "json")
+	if err == nil {
+		t.Error("WriteAll json: want error, got nil")
+	}
+}
@@ -0,0 +1,328 @@
+// Package formats reads metadata in any of the supported formats, dispatching
+// to the package for the format.
+package formats
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/front-matter/commonmeta/bibtex"
+	"github.com/front-matter/commonmeta/cff"
+	"github.com/front-matter/commonmeta/codemeta"
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/crossref"
+	"github.com/front-matter/commonmeta/crossrefxml"
+	"github.com/front-matter/commonmeta/csl"
+	"github.com/front-matter/commonmeta/csv"
+	"github.com/front-matter/commonmeta/datacite"
+	"github.com/front-matter/commonmeta/datacitexml"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/dublincore"
+	"github.com/front-matter/commonmeta/inveniordm"
+	"github.com/front-matter/commonmeta/jats"
+	"github.com/front-matter/commonmeta/jsonfeed"
+	"github.com/front-matter/commonmeta/marc"
+	"github.com/front-matter/commonmeta/openaire"
+	"github.com/front-matter/commonmeta/ris"
+	"github.com/front-matter/commonmeta/schemaorg"
+	"github.com/front-matter/commonmeta/utils"
+)
+
+// ErrUnsupportedFormat is returned for an unknown input format.
+var ErrUnsupportedFormat = errors.New("unsupported format")
+
+// ResolverURL is the URL of the DOI resolver used by ReadURL.
+var ResolverURL = "https://doi.org"
+
+// jsonldRegexp matches the Schema.org JSON-LD embedded in an HTML page.
+var jsonldRegexp = regexp.MustCompile(`(?is)<script[^>]+type=["']?application/ld\+json["']?[^>]*>(.*?)</script>`)
+
+// Load loads the metadata for a single work from a file in the given format.
+func Load(filename string, from string) (commonmeta.Data, error) {
+	switch from {
+	case "commonmeta":
+		return commonmeta.Load(filename)
+	case "bibtex":
+		return bibtex.Load(filename)
+	case "cff":
+		return cff.Load(filename)
+	case "codemeta":
+		return codemeta.Load(filename)
+	case "crossref":
+		return crossref.Load(filename)
+	case "crossrefxml":
+		return crossrefxml.Load(filename)
+	case "csl":
+		return csl.Load(filename)
+	case "csv":
+		return csv.Load(filename)
+	case "datacite":
+		// accept both a DataCite REST API response and its attributes
+		input, err := os.ReadFile(filename)
+		if err != nil {
+			return commonmeta.Data{}, errors.New("error reading file")
+		}
+		return Read(input, from)
+	case "datacitexml":
+		return datacitexml.Load(filename)
+	case "dublincore":
+		return dublincore.Load(filename)
+	case "inveniordm":
+		return inveniordm.Load(filename)
+	case "jats":
+		return jats.Load(filename)
+	case "jsonfeed":
+		return jsonfeed.Load(filename)
+	case "marc":
+		return marc.Load(filename)
+	case "openaire":
+		return openaire.Load(filename)
+	case "ris":
+		return ris.Load(filename)
+	case "schemaorg":
+		return schemaorg.Load(filename)
+	}
+	return commonmeta.Data{}, ErrUnsupportedFormat
+}
+
+// Read reads the metadata for a single work in the given format, e.g. from
+// standard input, and converts it to commonmeta.
+func Read(input []byte, from string) (commonmeta.Data, error) {
+	var data commonmeta.Data
+	var err error
+
+	switch from {
+	case "commonmeta":
+		err = json.Unmarshal(input, &data)
+		return data, err
+	case "crossref":
+		var content crossref.Content
+		if err = json.Unmarshal(input, &content); err != nil {
+			return data, err
+		}
+		return crossref.Read(content)
+	case "crossrefxml":
+		var query crossrefxml.Query
+		if err = xml.Unmarshal(input, &query); err != nil {
+			return data, err
+		}
+		return crossrefxml.Read(query)
+	case "csl":
+		var content csl.Content
+		if err = json.Unmarshal(input, &content); err != nil {
+			return data, err
+		}
+		return csl.Read(content)
+	case "datacite":
+		// accept both a DataCite REST API response and its attributes
+		var response struct {
+			Data struct {
+				Attributes *datacite.Content `json:"attributes"`
+			} `json:"data"`
+		}
+		if err = json.Unmarshal(input, &response); err == nil && response.Data.Attributes != nil {
+			return datacite.Read(*response.Data.Attributes)
+		}
+		var content datacite.Content
+		if err = json.Unmarshal(input, &content); err != nil {
+			return data, err
+		}
+		return datacite.Read(content)
+	case "datacitexml":
+		return datacitexml.ReadXML(input)
+	case "dublincore":
+		return dublincore.ReadXML(input)
+	case "jats":
+		return jats.ReadXML(input)
+	case "codemeta":
+		var content codemeta.Content
+		if err = json.Unmarshal(input, &content); err != nil {
+			return data, err
+		}
+		return codemeta.Read(content)
+	case "inveniordm":
+		var content inveniordm.Content
+		if err = json.Unmarshal(input, &content); err != nil {
+			return data, err
+		}
+		return inveniordm.Read(content)
+	case "jsonfeed":
+		var content jsonfeed.Content
+		if err = json.Unmarshal(input, &content); err != nil {
+			return data, err
+		}
+		return jsonfeed.Read(content)
+	case "openaire":
+		var content openaire.Content
+		if err = json.Unmarshal(input, &content); err != nil {
+			return data, err
+		}
+		return openaire.Read(content)
+	case "schemaorg":
+		var content schemaorg.Content
+		if err = json.Unmarshal(input, &content); err != nil {
+			return data, err
+		}
+		return schemaorg.Read(content)
+	case "cff":
+		content, err := cff.Parse(input)
+		if err != nil {
+			return data, err
+		}
+		return cff.Read(content)
+	case "bibtex":
+		content, err := bibtex.Parse(input)
+		if err != nil || len(content) == 0 {
+			return data, errors.New("no valid BibTeX entry found")
+		}
+		return bibtex.Read(content[0])
+	case "marc":
+		content, err := marc.Parse(input)
+		if err != nil || len(content) == 0 {
+			return data, errors.New("no valid MARCXML record found")
+		}
+		return marc.Read(content[0])
+	case "ris":
+		content, err := ris.Parse(input)
+		if err != nil || len(content) == 0 {
+			return data, errors.New("no valid RIS record found")
+		}
+		return ris.Read(content[0])
+	case "csv":
+		content, err := csv.Parse(input)
+		if err != nil || len(content) == 0 {
+			return data, errors.New("no valid CSV row found")
+		}
+		return csv.Read(content[0])
+	}
+	return data, ErrUnsupportedFormat
+}
+
+// ReadURL reads the metadata for a DOI or the URL of a landing page. DOIs are
+// resolved using content negotiation for CSL JSON or DataCite JSON. URLs, and
+// DOIs without metadata available via content negotiation, fall back to the
+// Schema.org JSON-LD embedded in the HTML page.
+func ReadURL(str string) (commonmeta.Data, error) {
+	var data commonmeta.Data
+	u := strings.TrimSpace(str)
+	doi, isDOI := doiutils.ValidateDOI(u)
+	if isDOI {
+		u = ResolverURL + "/" + doi
+	} else if !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+		return data, errors.New("invalid DOI or URL")
+	}
+
+	client := &http.Client{
+		Timeout: 20 * time.Second,
+	}
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return data, err
+	}
+	req.Header.Set("Accept", "application/vnd.citationstyles.csl+json, application/vnd.datacite.datacite+json;q=0.9, text/html;q=0.5")
+	resp, err := utils.DoWithRetry(client, req, utils.DefaultRetry)
+	if err != nil {
+		return data, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return data, errors.New(resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return data, err
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/vnd.citationstyles.csl+json":
+		return Read(body, "csl")
+	case "application/vnd.datacite.datacite+json":
+		return Read(body, "datacite")
+	}
+	data, err = readJSONLD(body)
+	if err != nil {
+		return data, err
+	}
+	// the URL the request was redirected to is the landing page
+	landingPage := resp.Request.URL.String()
+	if data.ID == "" && isDOI {
+		data.ID = doiutils.NormalizeDOI(doi)
+	} else if data.ID == "" {
+		data.ID = landingPage
+	}
+	if data.URL == "" {
+		data.URL = landingPage
+	}
+	return data, nil
+}
+
+// readJSONLD reads the Schema.org JSON-LD embedded in an HTML page. Pages
+// often describe several things, e.g. in a @graph, the first creative work is
+// used, a web page only if there is no other creative work.
+func readJSONLD(body []byte) (commonmeta.Data, error) {
+	var things []json.RawMessage
+	for _, matched := range jsonldRegexp.FindAllSubmatch(body, -1) {
+		var graph struct {
+			Graph []json.RawMessage `json:"@graph"`
+		}
+		var list []json.RawMessage
+		if err := json.Unmarshal(matched[1], &list); err == nil {
+			things = append(things, list...)
+		} else if err := json.Unmarshal(matched[1], &graph); err == nil && len(graph.Graph) > 0 {
+			things = append(things, graph.Graph...)
+		} else if json.Valid(matched[1]) {
+			things = append(things, matched[1])
+		}
+	}
+
+	var webPage json.RawMessage
+	for _, thing := range things {
+		types := schemaTypes(thing)
+		if slices.Contains(types, "WebPage") || slices.Contains(types, "WebSite") {
+			if webPage == nil {
+				webPage = thing
+			}
+			continue
+		}
+		if slices.ContainsFunc(types, func(t string) bool {
+			_, ok := schemaorg.SOToCMMappings[t]
+			return ok
+		}) {
+			return Read(thing, "schemaorg")
+		}
+	}
+	if webPage != nil {
+		return Read(webPage, "schemaorg")
+	}
+	return commonmeta.Data{}, errors.New("no Schema.org metadata found")
+}
+
+// schemaTypes returns the @type of a Schema.org thing, which can be a string
+// or a list of strings.
+func schemaTypes(thing json.RawMessage) []string {
+	var content struct {
+		Type json.RawMessage `json:"@type"`
+	}
+	if err := json.Unmarshal(thing, &content); err != nil {
+		return nil
+	}
+	var types []string
+	if err := json.Unmarshal(content.Type, &types); err == nil {
+		return types
+	}
+	var t string
+	if err := json.Unmarshal(content.Type, &t); err == nil {
+		return []string{t}
+	}
+	return nil
+}
@@ -0,0 +1,152 @@
+package formats_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/front-matter/commonmeta/formats"
+)
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		from  string
+		input string
+		id    string
+		type_ string
+		title string
+	}
+
+	testCases := []testCase{
+		{from: "commonmeta", input: `{"id":"https://doi.org/10.5555/commonmeta","type":"Dataset","titles":[{"title":"Commonmeta"}]}`, id: "https://doi.org/10.5555/commonmeta", type_: "Dataset", title: "Commonmeta"},
+		{from: "crossref", input: `{"DOI":"10.5555/crossref","type":"journal-article","title":["Crossref"]}`, id: "https://doi.org/10.5555/crossref", type_: "JournalArticle", title: "Crossref"},
+		{from: "datacite", input: `{"data":{"attributes":{"doi":"10.5555/datacite","types":{"resourceTypeGeneral":"Dataset"},"titles":[{"title":"DataCite"}]}}}`, id: "https://doi.org/10.5555/datacite", type_: "Dataset", title: "DataCite"},
+		{from: "csl", input: `{"id":"https://doi.org/10.5555/csl","type":"article-journal","title":"CSL"}`, id: "https://doi.org/10.5555/csl", type_: "JournalArticle", title: "CSL"},
+		{from: "bibtex", input: "@article{key,\n  doi = {10.5555/bibtex},\n  title = {BibTeX}\n}\n", id: "https://doi.org/10.5555/bibtex", type_: "JournalArticle", title: "BibTeX"},
+		{from: "ris", input: "TY  - JOUR\nTI  - RIS\nDO  - 10.5555/ris\nER  - \n", id: "https://doi.org/10.5555/ris", type_: "JournalArticle", title: "RIS"},
+	}
+	for _, tc := range testCases {
+		got, err := formats.Read([]byte(tc.input), tc.from)
+		if err != nil {
+			t.Fatalf("Read (%s): error %v", tc.from, err)
+		}
+		if got.ID != tc.id {
+			t.Errorf("Read ID (%s): want %v, got %v", tc.from, tc.id, got.ID)
+		}
+		if got.Type != tc.type_ {
+			t.Errorf("Read Type (%s): want %v, got %v", tc.from, tc.type_, got.Type)
+		}
+		if len(got.Titles) == 0 || got.Titles[0].Title != tc.title {
+			t.Errorf("Read Title (%s): want %v, got %v", tc.from, tc.title, got.Titles)
+		}
+	}
+}
+
+func TestReadUnsupportedFormat(t *testing.T) {
+	t.Parallel()
+	_, err := formats.Read([]byte(`{}`), "unknown")
+	if !errors.Is(err, formats.ErrUnsupportedFormat) {
+		t.Errorf("Read (unknown): want %v, got %v", formats.ErrUnsupportedFormat, err)
+	}
+	_, err = formats.Load("testdata/unknown.json", "unknown")
+	if !errors.Is(err, formats.ErrUnsupportedFormat) {
+		t.Errorf("Load (unknown): want %v, got %v", formats.ErrUnsupportedFormat, err)
+	}
+}
+
+const landingPage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>Toward a Unified Theory of High-Energy Metaphysics</title>
+  <script type="application/ld+json">
+  {
+    "@context": "https://schema.org",
+    "@graph": [
+      {"@type": "WebSite", "name": "Journal of Psychoceramics", "url": "https://example.org"},
+      {
+        "@type": "ScholarlyArticle",
+        "headline": "Toward a Unified Theory of High-Energy Metaphysics",
+        "author": [{"@type": "Person", "givenName": "Josiah", "familyName": "Carberry"}],
+        "datePublished": "2008-08-13"
+      }
+    ]
+  }
+  </script>
+</head>
+<body></body>
+</html>`
+
+func TestReadURL(t *testing.T) {
+	// not parallel, as the test changes the DOI resolver URL
+	mux := http.NewServeMux()
+	mux.HandleFunc("/10.5555/12345678", func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("Accept"), "application/vnd.citationstyles.csl+json") {
+			http.Error(w, "not acceptable", http.StatusNotAcceptable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.citationstyles.csl+json; charset=utf-8")
+		fmt.Fprint(w, `{"DOI": "10.5555/12345678", "type": "article-journal", "title": "Toward a Unified Theory of High-Energy Metaphysics"}`)
+	})
+	mux.HandleFunc("/10.5555/datacite", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/vnd.datacite.datacite+json")
+		fmt.Fprint(w, `{"doi": "10.5555/datacite", "types": {"resourceTypeGeneral": "Dataset"}, "titles": [{"title": "DataCite"}]}`)
+	})
+	mux.HandleFunc("/10.5555/landing", func(w http.ResponseWriter, r *http.Request) {
+		// no metadata available via content negotiation
+		http.Redirect(w, r, "/landing", http.StatusFound)
+	})
+	mux.HandleFunc("/landing", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, landingPage)
+	})
+	mux.HandleFunc("/empty", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		fmt.Fprint(w, "<html><body></body></html>")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	resolverURL := formats.ResolverURL
+	formats.ResolverURL = server.URL
+	defer func() { formats.ResolverURL = resolverURL }()
+
+	type testCase struct {
+		input string
+		id    string
+		type_ string
+		title string
+	}
+	testCases := []testCase{
+		{input: "https://doi.org/10.5555/12345678", id: "https://doi.org/10.5555/12345678", type_: "JournalArticle", title: "Toward a Unified Theory of High-Energy Metaphysics"},
+		{input: "10.5555/datacite", id: "https://doi.org/10.5555/datacite", type_: "Dataset", title: "DataCite"},
+		{input: "https://doi.org/10.5555/landing", id: "https://doi.org/10.5555/landing", type_: "JournalArticle", title: "Toward a Unified Theory of High-Energy Metaphysics"},
+		{input: server.URL + "/landing", id: server.URL + "/landing", type_: "JournalArticle", title: "Toward a Unified Theory of High-Energy Metaphysics"},
+	}
+	for _, tc := range testCases {
+		got, err := formats.ReadURL(tc.input)
+		if err != nil {
+			t.Fatalf("ReadURL (%s): error %v", tc.input, err)
+		}
+		if got.ID != tc.id {
+			t.Errorf("ReadURL ID (%s): want %v, got %v", tc.input, tc.id, got.ID)
+		}
+		if got.Type != tc.type_ {
+			t.Errorf("ReadURL Type (%s): want %v, got %v", tc.input, tc.type_, got.Type)
+		}
+		if len(got.Titles) == 0 || got.Titles[0].Title != tc.title {
+			t.Errorf("ReadURL Title (%s): want %v, got %v", tc.input, tc.title, got.Titles)
+		}
+	}
+
+	_, err := formats.ReadURL(server.URL + "/empty")
+	if err == nil {
+		t.Error("ReadURL (page without JSON-LD): want error, got nil")
+	}
+	_, err = formats.ReadURL("not a url")
+	if err == nil {
+		t.Error("ReadURL (not a url): want error, got nil")
+	}
+}
@@ -0,0 +1,71 @@
+package formats
+
+import (
+	"fmt"
+
+	"github.com/front-matter/commonmeta/bibtex"
+	"github.com/front-matter/commonmeta/codemeta"
+	"github.com/front-matter/commonmeta/crossref"
+	"github.com/front-matter/commonmeta/crossrefxml"
+	"github.com/front-matter/commonmeta/csl"
+	"github.com/front-matter/commonmeta/datacite"
+	"github.com/front-matter/commonmeta/dublincore"
+	"github.com/front-matter/commonmeta/inveniordm"
+	"github.com/front-matter/commonmeta/marc"
+	"github.com/front-matter/commonmeta/openaire"
+	"github.com/front-matter/commonmeta/ris"
+	"github.com/front-matter/commonmeta/schemaorg"
+)
+
+// readerTypeMappings are the maps used by readers to map the types of a
+// format to commonmeta types.
+var readerTypeMappings = map[string]map[string]string{
+	"bibtex":      bibtex.BibToCMMappings,
+	"codemeta":    codemeta.CodemetaToCMMappings,
+	"crossref":    crossref.CRToCMMappings,
+	"crossrefxml": crossrefxml.CRToCMMappings,
+	"csl":         csl.CSLToCMMappings,
+	"datacite":    datacite.DCToCMMappings,
+	"dublincore":  dublincore.DCToCMMappings,
+	"inveniordm":  inveniordm.InvenioToCMMappings,
+	"marc":        marc.MARCToCMMappings,
+	"openaire":    openaire.OpenAIREToCMMappings,
+	"ris":         ris.RISToCMMappings,
+	"schemaorg":   schemaorg.SOToCMMappings,
+}
+
+// writerTypeMappings are the maps used by writers to map commonmeta types to
+// the types of a format. DataCite JSON and XML share the same map.
+var writerTypeMappings = map[string]map[string]string{
+	"bibtex":      bibtex.CMToBibMappings,
+	"codemeta":    codemeta.CMToCodemetaMappings,
+	"csl":         csl.CMToCSLMappings,
+	"datacite":    datacite.CMToDCMappings,
+	"datacitexml": datacite.CMToDCMappings,
+	"dublincore":  dublincore.CMToDCMappings,
+	"inveniordm":  inveniordm.CMToInvenioMappings,
+	"schemaorg":   schemaorg.CMToSOMappings,
+}
+
+// SetTypeMapping overrides how a type is mapped when converting from one
+// format to another, one of which must be commonmeta. For example,
+// SetTypeMapping("commonmeta", "csl", "Article", "article-journal") writes
+// the commonmeta type Article as CSL type article-journal, and
+// SetTypeMapping("crossref", "commonmeta", "other", "Document") reads the
+// Crossref type other as commonmeta type Document. The mappings are shared
+// by all conversions, so SetTypeMapping should be called before converting,
+// not concurrently with Read or Write.
+func SetTypeMapping(from string, to string, fromType string, toType string) error {
+	var mappings map[string]string
+	switch {
+	case from == "commonmeta":
+		mappings = writerTypeMappings[to]
+	case to == "commonmeta":
+		mappings = readerTypeMappings[from]
+	}
+	if mappings == nil {
+		return fmt.Errorf("%w: no type mapping from %s to %s", ErrUnsupportedFormat, from, to)
+	}
+	mappings[fromType] = toType
+	return nil
+}
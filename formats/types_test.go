@@ -0,0 +1,60 @@
+package formats_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/front-matter/commonmeta/crossref"
+	"github.com/front-matter/commonmeta/csl"
+	"github.com/front-matter/commonmeta/formats"
+)
+
+func TestSetTypeMapping(t *testing.T) {
+	// not parallel, as the test changes the type mappings
+
+	cslType := csl.CMToCSLMappings["Dataset"]
+	defer func() { csl.CMToCSLMappings["Dataset"] = cslType }()
+	err := formats.SetTypeMapping("commonmeta", "csl", "Dataset", "document")
+	if err != nil {
+		t.Fatal(err)
+	}
+	dataset := data
+	dataset.Type = "Dataset"
+	output, err := formats.Write(dataset, "csl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(output, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["type"] != "document" {
+		t.Errorf("SetTypeMapping commonmeta to csl: want type document, got %v", got["type"])
+	}
+
+	crossrefType, ok := crossref.CRToCMMappings["other"]
+	defer func() {
+		if ok {
+			crossref.CRToCMMappings["other"] = crossrefType
+		} else {
+			delete(crossref.CRToCMMappings, "other")
+		}
+	}()
+	err = formats.SetTypeMapping("crossref", "commonmeta", "other", "Document")
+	if err != nil {
+		t.Fatal(err)
+	}
+	read, err := formats.Read([]byte(`{"DOI": "10.5555/12345678", "type": "other", "title": ["Formats"]}`), "crossref")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if read.Type != "Document" {
+		t.Errorf("SetTypeMapping crossref to commonmeta: want type Document, got %v", read.Type)
+	}
+
+	err = formats.SetTypeMapping("csl", "datacite", "article", "Preprint")
+	if !errors.Is(err, formats.ErrUnsupportedFormat) {
+		t.Errorf("SetTypeMapping csl to datacite: want ErrUnsupportedFormat, got %v", err)
+	}
+}
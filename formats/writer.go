@@ -0,0 +1,181 @@
+package formats
+
+import (
+	"fmt"
+
+	"github.com/front-matter/commonmeta/bibtex"
+	"github.com/front-matter/commonmeta/cff"
+	"github.com/front-matter/commonmeta/codemeta"
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/crossrefxml"
+	"github.com/front-matter/commonmeta/csl"
+	"github.com/front-matter/commonmeta/csv"
+	"github.com/front-matter/commonmeta/datacite"
+	"github.com/front-matter/commonmeta/datacitexml"
+	"github.com/front-matter/commonmeta/dublincore"
+	"github.com/front-matter/commonmeta/feed"
+	"github.com/front-matter/commonmeta/inveniordm"
+	"github.com/front-matter/commonmeta/ris"
+	"github.com/front-matter/commonmeta/schemaorg"
+	"github.com/front-matter/commonmeta/schemautils"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// ValidationError is returned if the output does not validate against the
+// JSON Schema of the format.
+type ValidationError struct {
+	Format string
+	Errors []gojsonschema.ResultError
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Format, e.SchemaErrors().Error())
+}
+
+// SchemaErrors returns the validation errors with JSON pointer paths and
+// readable messages.
+func (e *ValidationError) SchemaErrors() schemautils.SchemaErrors {
+	return schemautils.NewSchemaErrors(e.Errors)
+}
+
+// Option configures how metadata are written.
+type Option func(*options)
+
+type options struct {
+	account crossrefxml.Account
+	columns []string
+	strict  bool
+}
+
+// WithAccount sets the Crossref account used for the deposit in the
+// crossrefxml format.
+func WithAccount(account crossrefxml.Account) Option {
+	return func(o *options) {
+		o.account = account
+	}
+}
+
+// WithColumns selects and orders the columns of the csv format.
+func WithColumns(columns []string) Option {
+	return func(o *options) {
+		o.columns = columns
+	}
+}
+
+// WithStrict suppresses the output if it doesn't validate against the JSON
+// Schema of the format. By default invalid output is returned together with
+// the validation errors.
+func WithStrict(strict bool) Option {
+	return func(o *options) {
+		o.strict = strict
+	}
+}
+
+// Write writes the metadata for a single work in the given format. Schema
+// validation errors are returned as *ValidationError, together with the
+// output unless in strict mode.
+func Write(data commonmeta.Data, to string, opts ...Option) ([]byte, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	var output []byte
+	var jsErr []gojsonschema.ResultError
+	var err error
+
+	switch to {
+	case "commonmeta":
+		output, jsErr, err = commonmeta.Write(data)
+	case "bibtex":
+		output, err = bibtex.Write(data)
+	case "cff":
+		output, jsErr = cff.Write(data)
+	case "codemeta":
+		output, jsErr = codemeta.Write(data)
+	case "crossrefxml":
+		output, jsErr = crossrefxml.Write(data, o.account)
+	case "csv":
+		output, err = csv.Write(data, o.columns...)
+	case "csl":
+		output, jsErr, err = csl.Write(data)
+	case "datacite":
+		output, jsErr = datacite.Write(data)
+	case "datacitexml":
+		output, jsErr = datacitexml.Write(data)
+	case "dublincore":
+		output, jsErr = dublincore.Write(data)
+	case "inveniordm":
+		output, jsErr = inveniordm.Write(data)
+	case "rss", "atom":
+		// a feed with a single item
+		output, err = feed.WriteAll([]commonmeta.Data{data}, to)
+	case "ris":
+		output, err = ris.Write(data)
+	case "schemaorg":
+		output, jsErr = schemaorg.Write(data)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, to)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return o.result(to, output, jsErr)
+}
+
+// WriteAll writes the metadata for a list of works in the given format.
+// Schema validation errors are returned as *ValidationError, together with
+// the output unless in strict mode.
+func WriteAll(list []commonmeta.Data, to string, opts ...Option) ([]byte, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	var output []byte
+	var jsErr []gojsonschema.ResultError
+	var err error
+
+	switch to {
+	case "commonmeta":
+		output, jsErr, err = commonmeta.WriteAll(list)
+	case "bibtex":
+		output, err = bibtex.WriteAll(list)
+	case "crossrefxml":
+		output, jsErr = crossrefxml.WriteAll(list, o.account)
+	case "csv":
+		output, err = csv.WriteAll(list, o.columns...)
+	case "csl":
+		output, jsErr, err = csl.WriteAll(list)
+	case "datacite":
+		output, jsErr = datacite.WriteAll(list)
+	case "datacitexml":
+		output, jsErr = datacitexml.WriteAll(list)
+	case "dublincore":
+		output, jsErr = dublincore.WriteAll(list)
+	case "rss", "atom":
+		output, err = feed.WriteAll(list, to)
+	case "inveniordm":
+		output, jsErr = inveniordm.WriteAll(list)
+	case "ris":
+		output, err = ris.WriteAll(list)
+	case "schemaorg":
+		output, jsErr = schemaorg.WriteAll(list)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFormat, to)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return o.result(to, output, jsErr)
+}
+
+// result wraps JSON Schema validation errors, and drops invalid output in
+// strict mode.
+func (o options) result(format string, output []byte, jsErr []gojsonschema.ResultError) ([]byte, error) {
+	if len(jsErr) == 0 {
+		return output, nil
+	}
+	if o.strict {
+		output = nil
+	}
+	return output, &ValidationError{Format: format, Errors: jsErr}
+}
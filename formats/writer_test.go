@@ -0,0 +1,121 @@
+package formats_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/formats"
+)
+
+var data = commonmeta.Data{
+	ID:   "https://doi.org/10.5555/formats",
+	Type: "JournalArticle",
+	URL:  "https://example.org/formats",
+	Contributors: []commonmeta.Contributor{
+		{Type: "Person", GivenName: "Josiah", FamilyName: "Carberry", ContributorRoles: []string{"Author"}},
+	},
+	Titles:    []commonmeta.Title{{Title: "Formats"}},
+	Date:      commonmeta.Date{Published: "2024-01-15"},
+	Publisher: commonmeta.Publisher{Name: "Front Matter"},
+}
+
+func TestWriteCSL(t *testing.T) {
+	t.Parallel()
+	output, err := formats.Write(data, "csl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got map[string]any
+	if err := json.Unmarshal(output, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got["DOI"] != "10.5555/formats" || got["title"] != "Formats" || got["type"] != "article-journal" {
+		t.Errorf("Write csl: unexpected output %s", output)
+	}
+}
+
+func TestWriteDataciteXML(t *testing.T) {
+	t.Parallel()
+	output, err := formats.Write(data, "datacitexml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<identifier identifierType="DOI">10.5555/formats</identifier>`,
+		`<title>Formats</title>`,
+		`<familyName>Carberry</familyName>`,
+	} {
+		if !strings.Contains(string(output), want) {
+			t.Errorf("Write datacitexml: want %s in output %s", want, output)
+		}
+	}
+}
+
+func TestWriteValidationError(t *testing.T) {
+	t.Parallel()
+	invalid := data
+	invalid.Type = "Unknown"
+	output, err := formats.Write(invalid, "commonmeta")
+	var jsErr *formats.ValidationError
+	if !errors.As(err, &jsErr) {
+		t.Fatalf("Write (invalid): want ValidationError, got %v", err)
+	}
+	if jsErr.Format != "commonmeta" || len(jsErr.Errors) == 0 {
+		t.Errorf("Write (invalid): unexpected validation error %v", jsErr)
+	}
+	if len(output) == 0 {
+		t.Error("Write (invalid): want output in lenient mode")
+	}
+
+	output, err = formats.Write(invalid, "commonmeta", formats.WithStrict(true))
+	if !errors.As(err, &jsErr) {
+		t.Fatalf("Write (invalid, strict): want ValidationError, got %v", err)
+	}
+	if output != nil {
+		t.Errorf("Write (invalid, strict): want no output, got %s", output)
+	}
+
+	output, err = formats.Write(data, "commonmeta", formats.WithStrict(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(output) == 0 {
+		t.Error("Write (valid, strict): want output")
+	}
+}
+
+func TestWriteFeed(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		to   string
+		want string
+	}
+	testCases := []testCase{
+		{to: "rss", want: "<title>Formats</title>"},
+		{to: "atom", want: "<title>Formats</title>"},
+	}
+	for _, tc := range testCases {
+		output, err := formats.Write(data, tc.to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(output), tc.want) {
+			t.Errorf("Write %s: want %s in output %s", tc.to, tc.want, output)
+		}
+	}
+}
+
+func TestWriteUnsupportedFormat(t *testing.T) {
+	t.Parallel()
+	_, err := formats.Write(data, "unknown")
+	if !errors.Is(err, formats.ErrUnsupportedFormat) {
+		t.Errorf("Write (unknown): want %v, got %v", formats.ErrUnsupportedFormat, err)
+	}
+	_, err = formats.WriteAll([]commonmeta.Data{data}, "cff")
+	if !errors.Is(err, formats.ErrUnsupportedFormat) {
+		t.Errorf("WriteAll (cff): want %v, got %v", formats.ErrUnsupportedFormat, err)
+	}
+}
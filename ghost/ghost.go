@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"strings"
@@ -92,7 +93,6 @@ func UpdateGhostPost(id string, apiKey string, apiURL string) (string, error) {
 	addGhostHeaders(req, token)
 	resp, err := client.Do(req)
 	if err != nil {
-		fmt.Println("Error fetching post", err)
 		return "", err
 	}
 	if resp.StatusCode >= 400 {
@@ -105,7 +105,7 @@ func UpdateGhostPost(id string, apiKey string, apiURL string) (string, error) {
 	}
 	err = json.Unmarshal(body, &content)
 	if err != nil {
-		fmt.Println("error:", err)
+		slog.Error("error unmarshalling JSON", "error", err)
 	}
 	ghostPost := content.Posts[0]
 	guid := ghostPost.ID
@@ -3,18 +3,268 @@ package inveniordm
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/langutils"
+	"github.com/front-matter/commonmeta/spdxutils"
+	"github.com/front-matter/commonmeta/utils"
 )
 
-// Content represents the InvenioRDM JSON API response.
+// Content represents an InvenioRDM record, as returned by the InvenioRDM REST API
+// and as posted to it to create a draft.
 type Content struct {
-	ID    string `json:"id"`
+	ID       string         `json:"id,omitempty"`
+	Pids     map[string]PID `json:"pids,omitempty"`
+	Access   *Access        `json:"access,omitempty"`
+	Files    *Files         `json:"files,omitempty"`
+	Metadata Metadata       `json:"metadata"`
+	Links    *Links         `json:"links,omitempty"`
+}
+
+// PID represents a persistent identifier of an InvenioRDM record, e.g. a DOI.
+type PID struct {
+	Identifier string `json:"identifier"`
+	Provider   string `json:"provider,omitempty"`
+	Client     string `json:"client,omitempty"`
+}
+
+// Access represents the access settings of an InvenioRDM record.
+type Access struct {
+	Record string `json:"record"`
+	Files  string `json:"files"`
+}
+
+// Files represents the files settings of an InvenioRDM record.
+type Files struct {
+	Enabled bool `json:"enabled"`
+}
+
+// Links represents the links of an InvenioRDM record.
+type Links struct {
+	Self     string `json:"self,omitempty"`
+	SelfHTML string `json:"self_html,omitempty"`
+}
+
+// Metadata represents the metadata of an InvenioRDM record.
+type Metadata struct {
+	ResourceType           Type                    `json:"resource_type"`
+	Creators               []Creator               `json:"creators"`
+	Title                  string                  `json:"title"`
+	AdditionalTitles       []AdditionalTitle       `json:"additional_titles,omitempty"`
+	Publisher              string                  `json:"publisher,omitempty"`
+	PublicationDate        string                  `json:"publication_date"`
+	Subjects               []Subject               `json:"subjects,omitempty"`
+	Contributors           []Creator               `json:"contributors,omitempty"`
+	Dates                  []Date                  `json:"dates,omitempty"`
+	Languages              []Type                  `json:"languages,omitempty"`
+	Identifiers            []Identifier            `json:"identifiers,omitempty"`
+	RelatedIdentifiers     []RelatedIdentifier     `json:"related_identifiers,omitempty"`
+	Rights                 []Rights                `json:"rights,omitempty"`
+	Description            string                  `json:"description,omitempty"`
+	AdditionalDescriptions []AdditionalDescription `json:"additional_descriptions,omitempty"`
+	Version                string                  `json:"version,omitempty"`
+}
+
+// Type represents a controlled vocabulary entry, e.g. a resource type or a language.
+type Type struct {
+	ID string `json:"id"`
+}
+
+// Creator represents a creator or contributor of an InvenioRDM record.
+type Creator struct {
+	PersonOrOrg  PersonOrOrg   `json:"person_or_org"`
+	Role         *Type         `json:"role,omitempty"`
+	Affiliations []Affiliation `json:"affiliations,omitempty"`
+}
+
+// PersonOrOrg represents a person or organization.
+type PersonOrOrg struct {
+	Type        string       `json:"type"`
+	Name        string       `json:"name,omitempty"`
+	GivenName   string       `json:"given_name,omitempty"`
+	FamilyName  string       `json:"family_name,omitempty"`
+	Identifiers []Identifier `json:"identifiers,omitempty"`
+}
+
+// Affiliation represents an affiliation, identified by its ROR ID.
+type Affiliation struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// Identifier represents an identifier with its scheme, e.g. an ORCID.
+type Identifier struct {
+	Identifier string `json:"identifier"`
+	Scheme     string `json:"scheme"`
+}
+
+// AdditionalTitle represents an additional title.
+type AdditionalTitle struct {
 	Title string `json:"title"`
+	Type  Type   `json:"type"`
+	Lang  *Type  `json:"lang,omitempty"`
+}
+
+// AdditionalDescription represents an additional description.
+type AdditionalDescription struct {
+	Description string `json:"description"`
+	Type        Type   `json:"type"`
+	Lang        *Type  `json:"lang,omitempty"`
+}
+
+// Subject represents a subject, either from a vocabulary or as free text.
+type Subject struct {
+	ID      string `json:"id,omitempty"`
+	Subject string `json:"subject,omitempty"`
+}
+
+// Date represents a date with its type.
+type Date struct {
+	Date string `json:"date"`
+	Type Type   `json:"type"`
+}
+
+// RelatedIdentifier represents a related identifier.
+type RelatedIdentifier struct {
+	Identifier   string `json:"identifier"`
+	Scheme       string `json:"scheme"`
+	RelationType Type   `json:"relation_type"`
+	ResourceType *Type  `json:"resource_type,omitempty"`
+}
+
+// Rights represents the license of an InvenioRDM record.
+type Rights struct {
+	ID    string            `json:"id,omitempty"`
+	Title map[string]string `json:"title,omitempty"`
+	Link  string            `json:"link,omitempty"`
+	Props *RightsProps      `json:"props,omitempty"`
+}
+
+// RightsProps represents the properties of a license from the licenses vocabulary.
+type RightsProps struct {
+	URL    string `json:"url,omitempty"`
+	Scheme string `json:"scheme,omitempty"`
+}
+
+// InvenioToCMMappings maps InvenioRDM resource types to Commonmeta types
+// source: https://github.com/inveniosoftware/invenio-rdm-records/blob/master/invenio_rdm_records/fixtures/data/vocabularies/resource_types.yaml
+var InvenioToCMMappings = map[string]string{
+	"book":                              "Book",
+	"dataset":                           "Dataset",
+	"event":                             "Event",
+	"image":                             "Image",
+	"image-diagram":                     "Image",
+	"image-drawing":                     "Image",
+	"image-figure":                      "Image",
+	"image-photo":                       "Image",
+	"image-plot":                        "Image",
+	"lesson":                            "InteractiveResource",
+	"other":                             "Other",
+	"physicalobject":                    "PhysicalObject",
+	"poster":                            "Presentation",
+	"presentation":                      "Presentation",
+	"publication":                       "Document",
+	"publication-annotationcollection":  "Collection",
+	"publication-article":               "JournalArticle",
+	"publication-book":                  "Book",
+	"publication-conferencepaper":       "ProceedingsArticle",
+	"publication-conferenceproceeding":  "Proceedings",
+	"publication-datamanagementplan":    "Document",
+	"publication-deliverable":           "Report",
+	"publication-dissertation":          "Dissertation",
+	"publication-journal":               "Journal",
+	"publication-milestone":             "Report",
+	"publication-other":                 "Document",
+	"publication-patent":                "Document",
+	"publication-peerreview":            "PeerReview",
+	"publication-preprint":              "Article",
+	"publication-proposal":              "Document",
+	"publication-report":                "Report",
+	"publication-section":               "BookChapter",
+	"publication-softwaredocumentation": "Document",
+	"publication-standard":              "Standard",
+	"publication-taxonomictreatment":    "Document",
+	"publication-technicalnote":         "Report",
+	"publication-thesis":                "Dissertation",
+	"publication-workingpaper":          "Report",
+	"software":                          "Software",
+	"software-computationalnotebook":    "Software",
+	"video":                             "Audiovisual",
+	"workflow":                          "Other",
+}
+
+// InvenioToCMRoleMappings maps InvenioRDM contributor roles to Commonmeta contributor roles
+var InvenioToCMRoleMappings = map[string]string{
+	"contactperson":         "ContactPerson",
+	"datacollector":         "DataCollector",
+	"datacurator":           "DataCuration",
+	"datamanager":           "DataManager",
+	"distributor":           "Distributor",
+	"editor":                "Editor",
+	"hostinginstitution":    "HostingInstitution",
+	"other":                 "Other",
+	"producer":              "Producer",
+	"projectleader":         "ProjectLeader",
+	"projectmanager":        "ProjectManager",
+	"projectmember":         "ProjectMember",
+	"registrationagency":    "RegistrationAgency",
+	"registrationauthority": "RegistrationAuthority",
+	"relatedperson":         "RelatedPerson",
+	"researcher":            "Researcher",
+	"researchgroup":         "ResearchGroup",
+	"rightsholder":          "RightsHolder",
+	"sponsor":               "Sponsor",
+	"supervisor":            "Supervision",
+	"workpackageleader":     "WorkPackageLeader",
+}
+
+// RelationTypes are the relation types supported by Commonmeta. InvenioRDM
+// uses the same relation types in lowercase.
+var RelationTypes = []string{
+	"IsNewVersionOf",
+	"IsPreviousVersionOf",
+	"IsVersionOf",
+	"HasVersion",
+	"IsPartOf",
+	"HasPart",
+	"IsVariantFormOf",
+	"IsOriginalFormOf",
+	"IsIdenticalTo",
+	"IsTranslationOf",
+	"HasTranslation",
+	"IsReviewedBy",
+	"Reviews",
+	"HasReview",
+	"IsPreprintOf",
+	"HasPreprint",
+	"IsSupplementTo",
+	"IsSupplementedBy",
+}
+
+// Fetch gets the metadata for a single record from the Zenodo API and converts it to Commonmeta format.
+func Fetch(id string) (commonmeta.Data, error) {
+	var data commonmeta.Data
+	content, err := Get(id)
+	if err != nil {
+		return data, err
+	}
+	data, err = Read(content)
+	if err != nil {
+		return data, err
+	}
+	return data, nil
 }
 
 // Get retrieves InvenioRDM metadata.
@@ -24,7 +274,12 @@ func Get(id string) (Content, error) {
 		Timeout: time.Second * 10,
 	}
 	url := "https://zenodo.org/api/records/" + id
-	resp, err := client.Get(url)
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return content, err
+	}
+	req.Header.Set("Accept", "application/vnd.inveniordm.v1+json")
+	resp, err := client.Do(req)
 	if err != nil {
 		return content, err
 	}
@@ -38,14 +293,282 @@ func Get(id string) (Content, error) {
 	}
 	err = json.Unmarshal(body, &content)
 	if err != nil {
-		fmt.Println("error:", err)
+		slog.Error("error unmarshalling JSON", "error", err)
 	}
 	return content, err
 }
 
+// Load loads the metadata for a single record from an InvenioRDM JSON file
+func Load(filename string) (commonmeta.Data, error) {
+	var data commonmeta.Data
+
+	content, err := ReadJSON(filename)
+	if err != nil {
+		return data, err
+	}
+	data, err = Read(content)
+	if err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// ReadJSON reads an InvenioRDM record from a JSON file.
+func ReadJSON(filename string) (Content, error) {
+	var content Content
+
+	extension := path.Ext(filename)
+	if extension != ".json" {
+		return content, errors.New("invalid file extension")
+	}
+	file, err := os.Open(filename)
+	if err != nil {
+		return content, errors.New("error reading file")
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	err = decoder.Decode(&content)
+	if err != nil {
+		return content, err
+	}
+	return content, nil
+}
+
 // Read reads InvenioRDM JSON API response and converts it into Commonmeta metadata.
 func Read(content Content) (commonmeta.Data, error) {
 	var data commonmeta.Data
-	data.ID = content.ID
+	metadata := content.Metadata
+
+	doi, ok := content.Pids["doi"]
+	if ok {
+		data.ID = doiutils.NormalizeDOI(doi.Identifier)
+	}
+	if content.Links != nil {
+		data.URL = content.Links.SelfHTML
+	}
+	if data.ID == "" {
+		data.ID = data.URL
+	}
+
+	data.Type = InvenioToCMMappings[metadata.ResourceType.ID]
+	if data.Type == "" {
+		data.Type = "Other"
+	}
+
+	for _, v := range metadata.Creators {
+		contributor := GetContributor(v, "Author")
+		data.Contributors = append(data.Contributors, contributor)
+	}
+	for _, v := range metadata.Contributors {
+		role := "Other"
+		if v.Role != nil && InvenioToCMRoleMappings[v.Role.ID] != "" {
+			role = InvenioToCMRoleMappings[v.Role.ID]
+		}
+		contributor := GetContributor(v, role)
+		data.Contributors = append(data.Contributors, contributor)
+	}
+
+	if metadata.Title != "" {
+		data.Titles = append(data.Titles, commonmeta.Title{Title: metadata.Title})
+	}
+	for _, v := range metadata.AdditionalTitles {
+		var t string
+		switch v.Type.ID {
+		case "alternative-title":
+			t = "AlternativeTitle"
+		case "subtitle":
+			t = "Subtitle"
+		case "translated-title":
+			t = "TranslatedTitle"
+		}
+		data.Titles = append(data.Titles, commonmeta.Title{
+			Title: v.Title,
+			Type:  t,
+		})
+	}
+
+	if metadata.Description != "" {
+		data.Descriptions = append(data.Descriptions, commonmeta.Description{
+			Description: utils.Sanitize(metadata.Description),
+			Type:        "Abstract",
+		})
+	}
+	for _, v := range metadata.AdditionalDescriptions {
+		t := "Other"
+		switch v.Type.ID {
+		case "abstract":
+			t = "Abstract"
+		case "methods":
+			t = "Methods"
+		case "technical-info":
+			t = "TechnicalInfo"
+		}
+		data.Descriptions = append(data.Descriptions, commonmeta.Description{
+			Description: utils.Sanitize(v.Description),
+			Type:        t,
+		})
+	}
+
+	data.Date.Published = metadata.PublicationDate
+	for _, v := range metadata.Dates {
+		switch v.Type.ID {
+		case "accepted":
+			data.Date.Accepted = v.Date
+		case "available":
+			data.Date.Available = v.Date
+		case "collected":
+			data.Date.Collected = v.Date
+		case "copyrighted":
+			data.Date.Copyrighted = v.Date
+		case "created":
+			data.Date.Created = v.Date
+		case "submitted":
+			data.Date.Submitted = v.Date
+		case "updated":
+			data.Date.Updated = v.Date
+		case "valid":
+			data.Date.Valid = v.Date
+		case "withdrawn":
+			data.Date.Withdrawn = v.Date
+		case "other":
+			data.Date.Other = v.Date
+		}
+	}
+
+	if metadata.Publisher != "" {
+		data.Publisher = commonmeta.Publisher{Name: metadata.Publisher}
+	}
+
+	for _, v := range metadata.Subjects {
+		s := v.Subject
+		if s == "" {
+			s = v.ID
+		}
+		subject := commonmeta.Subject{Subject: s}
+		if s != "" && !slices.Contains(data.Subjects, subject) {
+			data.Subjects = append(data.Subjects, subject)
+		}
+	}
+
+	if len(metadata.Languages) > 0 {
+		data.Language = langutils.GetLanguage(metadata.Languages[0].ID, langutils.ISO6391)
+	}
+
+	if len(metadata.Rights) > 0 {
+		data.License = getLicense(metadata.Rights[0])
+	}
+
+	for _, v := range metadata.Identifiers {
+		identifierType := getIdentifierType(v.Scheme)
+		data.Identifiers = append(data.Identifiers, commonmeta.Identifier{
+			Identifier:     v.Identifier,
+			IdentifierType: identifierType,
+		})
+	}
+	if doiutils.NormalizeDOI(data.ID) != "" {
+		data.Identifiers = append(data.Identifiers, commonmeta.Identifier{
+			Identifier:     data.ID,
+			IdentifierType: "DOI",
+		})
+	}
+
+	for i, v := range metadata.RelatedIdentifiers {
+		id := utils.NormalizeID(v.Identifier)
+		if id == "" {
+			continue
+		}
+		if v.RelationType.ID == "cites" || v.RelationType.ID == "references" {
+			data.References = append(data.References, commonmeta.Reference{
+				Key: "ref" + strconv.Itoa(i+1),
+				ID:  id,
+			})
+			continue
+		}
+		for _, t := range RelationTypes {
+			if strings.EqualFold(t, v.RelationType.ID) {
+				relation := commonmeta.Relation{
+					ID:   id,
+					Type: t,
+				}
+				if !slices.Contains(data.Relations, relation) {
+					data.Relations = append(data.Relations, relation)
+				}
+				break
+			}
+		}
+	}
+
+	data.Version = metadata.Version
+
 	return data, nil
 }
+
+// GetContributor converts an InvenioRDM creator or contributor into the Commonmeta format
+func GetContributor(v Creator, role string) commonmeta.Contributor {
+	contributor := commonmeta.Contributor{
+		ContributorRoles: []string{role},
+	}
+	for _, i := range v.PersonOrOrg.Identifiers {
+		switch i.Scheme {
+		case "orcid":
+			contributor.ID = utils.NormalizeORCID(i.Identifier)
+		case "ror":
+			contributor.ID = utils.NormalizeROR(i.Identifier)
+		}
+	}
+	if v.PersonOrOrg.Type == "organizational" {
+		contributor.Type = "Organization"
+		contributor.Name = v.PersonOrOrg.Name
+	} else {
+		contributor.Type = "Person"
+		contributor.GivenName = v.PersonOrOrg.GivenName
+		contributor.FamilyName = v.PersonOrOrg.FamilyName
+		if contributor.FamilyName == "" {
+			contributor.Name = v.PersonOrOrg.Name
+		}
+	}
+	for _, a := range v.Affiliations {
+		if a.ID == "" && a.Name == "" {
+			continue
+		}
+		contributor.Affiliations = append(contributor.Affiliations, &commonmeta.Affiliation{
+			ID:   utils.NormalizeROR(a.ID),
+			Name: a.Name,
+		})
+	}
+	return contributor
+}
+
+// getLicense returns the license from InvenioRDM rights. The URL is taken
+// from the licenses vocabulary or the link, the ID is the SPDX identifier.
+func getLicense(v Rights) commonmeta.License {
+	var url string
+	if v.Props != nil {
+		url = v.Props.URL
+	}
+	if url == "" {
+		url = v.Link
+	}
+	id, url := spdxutils.Normalize(url)
+	if id == "" {
+		id = spdxutils.ValidateID(v.ID)
+	}
+	if url == "" {
+		url = spdxutils.IDToURL(id)
+	}
+	return commonmeta.License{
+		ID:  id,
+		URL: url,
+	}
+}
+
+// getIdentifierType returns the Commonmeta identifier type for an InvenioRDM scheme.
+func getIdentifierType(scheme string) string {
+	for _, t := range commonmeta.IdentifierTypes {
+		if strings.EqualFold(t, scheme) {
+			return t
+		}
+	}
+	return "Other"
+}
@@ -1,9 +1,12 @@
 package inveniordm_test
 
 import (
+	"path/filepath"
 	"testing"
 
+	"github.com/front-matter/commonmeta/commonmeta"
 	"github.com/front-matter/commonmeta/inveniordm"
+	"github.com/google/go-cmp/cmp"
 )
 
 func TestGet(t *testing.T) {
@@ -16,23 +19,74 @@ func TestGet(t *testing.T) {
 	}
 
 	publication := inveniordm.Content{
-		ID:    "5244404",
-		Title: "The Origins of SARS-CoV-2: A Critical Review",
+		ID:       "5244404",
+		Metadata: inveniordm.Metadata{Title: "The Origins of SARS-CoV-2: A Critical Review"},
 	}
 	presentation := inveniordm.Content{
-		ID:    "8173303",
-		Title: "11 July 2023 (Day 2) CERN – NASA Open Science Summit Sketch Notes",
+		ID:       "8173303",
+		Metadata: inveniordm.Metadata{Title: "11 July 2023 (Day 2) CERN – NASA Open Science Summit Sketch Notes"},
 	}
 
 	testCases := []testCase{
-		{pid: presentation.ID, want: presentation.Title, err: nil},
-		{pid: publication.ID, want: publication.Title, err: nil},
+		{pid: presentation.ID, want: presentation.Metadata.Title, err: nil},
+		{pid: publication.ID, want: publication.Metadata.Title, err: nil},
 	}
 	for _, tc := range testCases {
 		got, err := inveniordm.Get(tc.pid)
-		if tc.want != got.Title {
+		if tc.want != got.Metadata.Title {
 			t.Errorf("InvenioRDM ID(%v): want %v, got %v, error %v",
 				tc.pid, tc.want, got, err)
 		}
 	}
 }
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name string
+		want commonmeta.Data
+	}
+
+	testCases := []testCase{
+		{
+			name: "zenodo-dataset.json",
+			want: commonmeta.Data{
+				ID:   "https://doi.org/10.5281/zenodo.7752775",
+				Type: "Dataset",
+				Contributors: []commonmeta.Contributor{
+					{ID: "https://orcid.org/0000-0003-1419-2405", Type: "Person", GivenName: "Martin", FamilyName: "Fenner", Affiliations: []*commonmeta.Affiliation{{ID: "https://ror.org/04wxnsj81", Name: "DataCite"}}, ContributorRoles: []string{"Author"}},
+					{ID: "https://ror.org/01ggx4157", Type: "Organization", Name: "CERN", ContributorRoles: []string{"Author"}},
+					{Type: "Person", GivenName: "Jane", FamilyName: "Doe", ContributorRoles: []string{"DataCuration"}},
+				},
+				Date:         commonmeta.Date{Published: "2023-03-20", Collected: "2023-03-01"},
+				Descriptions: []commonmeta.Description{{Description: "Metadata for blog posts from the <strong>Rogue Scholar</strong> science blog archive.", Type: "Abstract"}},
+				Identifiers: []commonmeta.Identifier{
+					{Identifier: "https://rogue-scholar.org", IdentifierType: "URL"},
+					{Identifier: "https://doi.org/10.5281/zenodo.7752775", IdentifierType: "DOI"},
+				},
+				Language:   "en",
+				License:    commonmeta.License{ID: "CC-BY-4.0", URL: "https://creativecommons.org/licenses/by/4.0/legalcode"},
+				Publisher:  commonmeta.Publisher{Name: "Zenodo"},
+				References: []commonmeta.Reference{{Key: "ref2", ID: "https://doi.org/10.53731/r79z0kh-97aq74v-ag5hb"}},
+				Relations:  []commonmeta.Relation{{ID: "https://doi.org/10.5281/zenodo.7752774", Type: "IsVersionOf"}},
+				Subjects:   []commonmeta.Subject{{Subject: "scholarly blogging"}, {Subject: "metadata"}},
+				Titles: []commonmeta.Title{
+					{Title: "Example Dataset of Scholarly Blog Posts"},
+					{Title: "Metadata for 1,000 blog posts", Type: "Subtitle"},
+				},
+				URL:     "https://zenodo.org/records/7752775",
+				Version: "1.0",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		got, err := inveniordm.Load(filepath.Join("testdata", tc.name))
+		if err != nil {
+			t.Fatalf("InvenioRDM Load (%v): error %v", tc.name, err)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("InvenioRDM Load (%v) mismatch (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
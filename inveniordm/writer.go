@@ -0,0 +1,290 @@
+package inveniordm
+
+import (
+	"encoding/json"
+	"log/slog"
+	"strings"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/utils"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// CMToInvenioMappings maps Commonmeta types to InvenioRDM resource types
+var CMToInvenioMappings = map[string]string{
+	"Article":             "publication-preprint",
+	"Audiovisual":         "video",
+	"Book":                "publication-book",
+	"BookChapter":         "publication-section",
+	"Collection":          "publication-annotationcollection",
+	"Dataset":             "dataset",
+	"Dissertation":        "publication-thesis",
+	"Document":            "publication-other",
+	"Event":               "event",
+	"Image":               "image",
+	"InteractiveResource": "lesson",
+	"Journal":             "publication-journal",
+	"JournalArticle":      "publication-article",
+	"PeerReview":          "publication-peerreview",
+	"PhysicalObject":      "physicalobject",
+	"Presentation":        "presentation",
+	"Proceedings":         "publication-conferenceproceeding",
+	"ProceedingsArticle":  "publication-conferencepaper",
+	"Report":              "publication-report",
+	"Software":            "software",
+	"Standard":            "publication-standard",
+}
+
+// CMToInvenioRoleMappings maps Commonmeta contributor roles to InvenioRDM contributor roles
+var CMToInvenioRoleMappings = map[string]string{
+	"ContactPerson":         "contactperson",
+	"DataCollector":         "datacollector",
+	"DataCuration":          "datacurator",
+	"DataManager":           "datamanager",
+	"Distributor":           "distributor",
+	"Editor":                "editor",
+	"HostingInstitution":    "hostinginstitution",
+	"Producer":              "producer",
+	"ProjectLeader":         "projectleader",
+	"ProjectManager":        "projectmanager",
+	"ProjectMember":         "projectmember",
+	"RegistrationAgency":    "registrationagency",
+	"RegistrationAuthority": "registrationauthority",
+	"RelatedPerson":         "relatedperson",
+	"Researcher":            "researcher",
+	"ResearchGroup":         "researchgroup",
+	"RightsHolder":          "rightsholder",
+	"Sponsor":               "sponsor",
+	"Supervision":           "supervisor",
+	"WorkPackageLeader":     "workpackageleader",
+}
+
+// Convert converts Commonmeta metadata to an InvenioRDM record that can be
+// posted to the InvenioRDM REST API to create a draft.
+func Convert(data commonmeta.Data) (Content, error) {
+	content := Content{
+		Access: &Access{Record: "public", Files: "public"},
+		Files:  &Files{Enabled: false},
+	}
+
+	doi, ok := doiutils.ValidateDOI(data.ID)
+	if ok {
+		provider := "external"
+		if strings.HasPrefix(doi, "10.5281/zenodo.") {
+			provider = "datacite"
+		}
+		content.Pids = map[string]PID{
+			"doi": {Identifier: doi, Provider: provider},
+		}
+	}
+
+	resourceType, ok := CMToInvenioMappings[data.Type]
+	if !ok {
+		resourceType = "other"
+	}
+	content.Metadata.ResourceType = Type{ID: resourceType}
+
+	for _, v := range data.Contributors {
+		creator := getCreator(v)
+		if len(v.ContributorRoles) == 0 || v.ContributorRoles[0] == "Author" {
+			content.Metadata.Creators = append(content.Metadata.Creators, creator)
+		} else {
+			role, ok := CMToInvenioRoleMappings[v.ContributorRoles[0]]
+			if !ok {
+				role = "other"
+			}
+			creator.Role = &Type{ID: role}
+			content.Metadata.Contributors = append(content.Metadata.Contributors, creator)
+		}
+	}
+
+	for _, v := range data.Titles {
+		switch v.Type {
+		case "":
+			if content.Metadata.Title == "" {
+				content.Metadata.Title = v.Title
+			}
+		case "AlternativeTitle", "Subtitle", "TranslatedTitle":
+			t := map[string]string{
+				"AlternativeTitle": "alternative-title",
+				"Subtitle":         "subtitle",
+				"TranslatedTitle":  "translated-title",
+			}[v.Type]
+			content.Metadata.AdditionalTitles = append(content.Metadata.AdditionalTitles, AdditionalTitle{
+				Title: v.Title,
+				Type:  Type{ID: t},
+			})
+		}
+	}
+
+	for _, v := range data.Descriptions {
+		if v.Type == "Abstract" && content.Metadata.Description == "" {
+			content.Metadata.Description = v.Description
+			continue
+		}
+		t := map[string]string{
+			"Abstract":      "abstract",
+			"Methods":       "methods",
+			"TechnicalInfo": "technical-info",
+		}[v.Type]
+		if t == "" {
+			t = "other"
+		}
+		content.Metadata.AdditionalDescriptions = append(content.Metadata.AdditionalDescriptions, AdditionalDescription{
+			Description: v.Description,
+			Type:        Type{ID: t},
+		})
+	}
+
+	content.Metadata.PublicationDate = data.Date.Published
+	dates := []struct {
+		date string
+		t    string
+	}{
+		{data.Date.Accepted, "accepted"},
+		{data.Date.Available, "available"},
+		{data.Date.Collected, "collected"},
+		{data.Date.Copyrighted, "copyrighted"},
+		{data.Date.Created, "created"},
+		{data.Date.Submitted, "submitted"},
+		{data.Date.Updated, "updated"},
+		{data.Date.Valid, "valid"},
+		{data.Date.Withdrawn, "withdrawn"},
+		{data.Date.Other, "other"},
+	}
+	for _, v := range dates {
+		if v.date != "" {
+			content.Metadata.Dates = append(content.Metadata.Dates, Date{Date: v.date, Type: Type{ID: v.t}})
+		}
+	}
+
+	content.Metadata.Publisher = data.Publisher.Name
+
+	for _, v := range data.Subjects {
+		content.Metadata.Subjects = append(content.Metadata.Subjects, Subject{Subject: v.Subject})
+	}
+
+	if data.License.ID != "" {
+		content.Metadata.Rights = []Rights{{ID: strings.ToLower(data.License.ID)}}
+	} else if data.License.URL != "" {
+		content.Metadata.Rights = []Rights{{Link: data.License.URL}}
+	}
+
+	for _, v := range data.Identifiers {
+		if v.Identifier == data.ID || v.IdentifierType == "" {
+			continue
+		}
+		content.Metadata.Identifiers = append(content.Metadata.Identifiers, Identifier{
+			Identifier: v.Identifier,
+			Scheme:     strings.ToLower(v.IdentifierType),
+		})
+	}
+
+	for _, v := range data.References {
+		relatedIdentifier := getRelatedIdentifier(v.ID, "references")
+		if relatedIdentifier != nil {
+			content.Metadata.RelatedIdentifiers = append(content.Metadata.RelatedIdentifiers, *relatedIdentifier)
+		}
+	}
+	for _, v := range data.Relations {
+		relatedIdentifier := getRelatedIdentifier(v.ID, strings.ToLower(v.Type))
+		if relatedIdentifier != nil {
+			content.Metadata.RelatedIdentifiers = append(content.Metadata.RelatedIdentifiers, *relatedIdentifier)
+		}
+	}
+
+	content.Metadata.Version = data.Version
+
+	return content, nil
+}
+
+// Write writes commonmeta metadata as an InvenioRDM record.
+func Write(data commonmeta.Data) ([]byte, []gojsonschema.ResultError) {
+	content, err := Convert(data)
+	if err != nil {
+		slog.Error("error converting metadata", "error", err)
+	}
+	output, err := json.Marshal(content)
+	if err != nil {
+		slog.Error("error marshalling JSON", "error", err)
+	}
+	return output, nil
+}
+
+// WriteAll writes a list of commonmeta metadata as InvenioRDM records.
+func WriteAll(list []commonmeta.Data) ([]byte, []gojsonschema.ResultError) {
+	var contentList []Content
+	for _, data := range list {
+		content, err := Convert(data)
+		if err != nil {
+			slog.Error("error converting metadata", "error", err)
+		}
+		contentList = append(contentList, content)
+	}
+	output, err := json.Marshal(contentList)
+	if err != nil {
+		slog.Error("error marshalling JSON", "error", err)
+	}
+	return output, nil
+}
+
+// getCreator converts a Commonmeta contributor to an InvenioRDM creator.
+func getCreator(v commonmeta.Contributor) Creator {
+	var creator Creator
+	if v.Type == "Organization" {
+		creator.PersonOrOrg = PersonOrOrg{
+			Type: "organizational",
+			Name: v.Name,
+		}
+		ror, ok := utils.ValidateROR(v.ID)
+		if ok {
+			creator.PersonOrOrg.Identifiers = []Identifier{{Identifier: ror, Scheme: "ror"}}
+		}
+	} else {
+		creator.PersonOrOrg = PersonOrOrg{
+			Type:       "personal",
+			GivenName:  v.GivenName,
+			FamilyName: v.FamilyName,
+		}
+		if v.FamilyName == "" {
+			creator.PersonOrOrg.FamilyName = v.Name
+		}
+		orcid, ok := utils.ValidateORCID(v.ID)
+		if ok {
+			creator.PersonOrOrg.Identifiers = []Identifier{{Identifier: orcid, Scheme: "orcid"}}
+		}
+	}
+	for _, a := range v.Affiliations {
+		if a == nil {
+			continue
+		}
+		affiliation := Affiliation{Name: a.Name}
+		ror, ok := utils.ValidateROR(a.ID)
+		if ok {
+			affiliation.ID = ror
+		}
+		creator.Affiliations = append(creator.Affiliations, affiliation)
+	}
+	return creator
+}
+
+// getRelatedIdentifier returns an InvenioRDM related identifier for a DOI or URL.
+func getRelatedIdentifier(id string, relationType string) *RelatedIdentifier {
+	doi, ok := doiutils.ValidateDOI(id)
+	if ok {
+		return &RelatedIdentifier{
+			Identifier:   doi,
+			Scheme:       "doi",
+			RelationType: Type{ID: relationType},
+		}
+	}
+	if utils.ValidateURL(id) == "URL" {
+		return &RelatedIdentifier{
+			Identifier:   id,
+			Scheme:       "url",
+			RelationType: Type{ID: relationType},
+		}
+	}
+	return nil
+}
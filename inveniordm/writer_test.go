@@ -0,0 +1,79 @@
+package inveniordm_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/front-matter/commonmeta/inveniordm"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+	data, err := inveniordm.Load(filepath.Join("testdata", "zenodo-dataset.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, errs := inveniordm.Write(data)
+	if errs != nil {
+		t.Fatal(errs)
+	}
+	var content inveniordm.Content
+	if err := json.Unmarshal(output, &content); err != nil {
+		t.Fatal(err)
+	}
+
+	// the payload needs access, files and metadata to be posted as a draft
+	if content.Access == nil || content.Access.Record != "public" || content.Files == nil {
+		t.Errorf("InvenioRDM Write: missing access or files in %s", output)
+	}
+	if content.Pids["doi"].Identifier != "10.5281/zenodo.7752775" {
+		t.Errorf("InvenioRDM Write DOI: want 10.5281/zenodo.7752775, got %v", content.Pids["doi"].Identifier)
+	}
+	if content.Metadata.ResourceType.ID != "dataset" {
+		t.Errorf("InvenioRDM Write resource type: want dataset, got %v", content.Metadata.ResourceType.ID)
+	}
+	wantCreators := []inveniordm.Creator{
+		{
+			PersonOrOrg:  inveniordm.PersonOrOrg{Type: "personal", GivenName: "Martin", FamilyName: "Fenner", Identifiers: []inveniordm.Identifier{{Identifier: "0000-0003-1419-2405", Scheme: "orcid"}}},
+			Affiliations: []inveniordm.Affiliation{{ID: "04wxnsj81", Name: "DataCite"}},
+		},
+		{
+			PersonOrOrg: inveniordm.PersonOrOrg{Type: "organizational", Name: "CERN", Identifiers: []inveniordm.Identifier{{Identifier: "01ggx4157", Scheme: "ror"}}},
+		},
+	}
+	if diff := cmp.Diff(wantCreators, content.Metadata.Creators); diff != "" {
+		t.Errorf("Creators mismatch (-want +got):\n%s", diff)
+	}
+	wantContributors := []inveniordm.Creator{
+		{
+			PersonOrOrg: inveniordm.PersonOrOrg{Type: "personal", GivenName: "Jane", FamilyName: "Doe"},
+			Role:        &inveniordm.Type{ID: "datacurator"},
+		},
+	}
+	if diff := cmp.Diff(wantContributors, content.Metadata.Contributors); diff != "" {
+		t.Errorf("Contributors mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]inveniordm.Rights{{ID: "cc-by-4.0"}}, content.Metadata.Rights); diff != "" {
+		t.Errorf("Rights mismatch (-want +got):\n%s", diff)
+	}
+
+	// the written record reads back to the same metadata
+	got, err := inveniordm.Read(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(data.Contributors, got.Contributors); diff != "" {
+		t.Errorf("Contributors round trip mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(data.Titles, got.Titles); diff != "" {
+		t.Errorf("Titles round trip mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(data.Relations, got.Relations); diff != "" {
+		t.Errorf("Relations round trip mismatch (-want +got):\n%s", diff)
+	}
+	if got.ID != data.ID || got.Type != data.Type || got.Date != data.Date || got.License.ID != data.License.ID {
+		t.Errorf("InvenioRDM Write round trip: want %v %v %v %v, got %v %v %v %v", data.ID, data.Type, data.Date, data.License.ID, got.ID, got.Type, got.Date, got.License.ID)
+	}
+}
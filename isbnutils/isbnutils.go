@@ -0,0 +1,87 @@
+// Package isbnutils provides a set of functions to work with ISBNs
+package isbnutils
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// isbnRegexp matches an ISBN-10 or ISBN-13, with optional ISBN or URN
+// prefix, and hyphens or spaces separating the parts.
+var isbnRegexp = regexp.MustCompile(`(?i)^(?:urn:isbn:|isbn(?:-1[03])?:?\s*)?([0-9][0-9 -]{8,15}[0-9X])$`)
+
+// NormalizeISBN normalizes an ISBN-10 or ISBN-13 into an ISBN-13 without
+// hyphens, e.g. 9780201134476. Returns an empty string if the ISBN is not
+// valid.
+func NormalizeISBN(isbn string) string {
+	isbnstr, ok := ValidateISBN(isbn)
+	if !ok {
+		return ""
+	}
+	if len(isbnstr) == 13 {
+		return isbnstr
+	}
+	isbn13 := "978" + isbnstr[:9]
+	return isbn13 + checkDigit13(isbn13)
+}
+
+// ValidateISBN validates an ISBN-10 or ISBN-13, including its check digit,
+// and returns it without hyphens or spaces.
+func ValidateISBN(isbn string) (string, bool) {
+	matched := isbnRegexp.FindStringSubmatch(strings.TrimSpace(isbn))
+	if len(matched) == 0 {
+		return "", false
+	}
+	digits := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(matched[1]))
+	switch len(digits) {
+	case 10:
+		if checkDigit10(digits[:9]) != digits[9:] {
+			return "", false
+		}
+	case 13:
+		if !strings.HasPrefix(digits, "978") && !strings.HasPrefix(digits, "979") {
+			return "", false
+		}
+		if checkDigit13(digits[:12]) != digits[12:] {
+			return "", false
+		}
+	default:
+		return "", false
+	}
+	return digits, true
+}
+
+// checkDigit10 calculates the check digit of an ISBN-10 from its first nine
+// digits (modulus 11 with weights 10 to 2).
+func checkDigit10(digits string) string {
+	total := 0
+	for i, c := range digits {
+		if c < '0' || c > '9' {
+			return ""
+		}
+		total += (10 - i) * int(c-'0')
+	}
+	result := (11 - total%11) % 11
+	if result == 10 {
+		return "X"
+	}
+	return strconv.Itoa(result)
+}
+
+// checkDigit13 calculates the check digit of an ISBN-13 from its first twelve
+// digits (modulus 10 with alternating weights 1 and 3).
+func checkDigit13(digits string) string {
+	total := 0
+	for i, c := range digits {
+		if c < '0' || c > '9' {
+			return ""
+		}
+		weight := 1
+		if i%2 == 1 {
+			weight = 3
+		}
+		total += weight * int(c-'0')
+	}
+	return strconv.Itoa((10 - total%10) % 10)
+}
@@ -0,0 +1,57 @@
+package isbnutils_test
+
+import (
+	"testing"
+
+	"github.com/front-matter/commonmeta/isbnutils"
+)
+
+func TestValidateISBN(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input string
+		want  string
+	}
+	testCases := []testCase{
+		{input: "0-201-13447-0", want: "0201134470"},
+		{input: "080442957X", want: "080442957X"},
+		{input: "0-8044-2957-x", want: "080442957X"},
+		{input: "978-90-386-4503-2", want: "9789038645032"},
+		{input: "ISBN 978-3-662-46370-3", want: "9783662463703"},
+		{input: "urn:isbn:9783662463703", want: "9783662463703"},
+		{input: "0-201-13447-1", want: ""},
+		{input: "978-3-662-46370-4", want: ""},
+		{input: "123-4-567-89012-8", want: ""},
+		{input: "0-201-13447", want: ""},
+		{input: "", want: ""},
+	}
+	for _, tc := range testCases {
+		got, ok := isbnutils.ValidateISBN(tc.input)
+		if tc.want != got {
+			t.Errorf("Validate ISBN(%v): want %v, got %v, ok %v",
+				tc.input, tc.want, got, ok)
+		}
+	}
+}
+
+func TestNormalizeISBN(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input string
+		want  string
+	}
+	testCases := []testCase{
+		{input: "0-201-13447-0", want: "9780201134476"},
+		{input: "080442957X", want: "9780804429573"},
+		{input: "978-90-386-4503-2", want: "9789038645032"},
+		{input: "9783662463703", want: "9783662463703"},
+		{input: "0-201-13447-1", want: ""},
+		{input: "", want: ""},
+	}
+	for _, tc := range testCases {
+		got := isbnutils.NormalizeISBN(tc.input)
+		if tc.want != got {
+			t.Errorf("Normalize ISBN(%v): want %v, got %v", tc.input, tc.want, got)
+		}
+	}
+}
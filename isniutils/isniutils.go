@@ -0,0 +1,39 @@
+// Package isniutils provides a set of functions to work with ISNIs
+package isniutils
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/front-matter/commonmeta/orcidutils"
+)
+
+// isniRegexp matches an ISNI, given as ID, as isni.org URL or with an ISNI
+// prefix, with or without separators.
+var isniRegexp = regexp.MustCompile(`(?i)^(?:(?:http|https)://(?:www\.)?isni\.org/(?:isni/)?|isni:?\s*)?(\d{4})[ -]?(\d{4})[ -]?(\d{4})[ -]?(\d{3}[0-9X])$`)
+
+// NormalizeISNI normalizes an ISNI into an ISNI URL
+func NormalizeISNI(isni string) string {
+	isnistr, ok := ValidateISNI(isni)
+	if !ok {
+		return ""
+	}
+	return "https://isni.org/isni/" + strings.ReplaceAll(isnistr, " ", "")
+}
+
+// ValidateISNI validates an ISNI, given either as ID or as URL, and returns
+// it formatted in four blocks of four digits, e.g. 0000 0001 2281 955X.
+// ISNIs use the same check digit as ORCID iDs (ISO/IEC 7064 MOD 11-2).
+func ValidateISNI(isni string) (string, bool) {
+	matched := isniRegexp.FindStringSubmatch(strings.TrimSpace(isni))
+	if len(matched) == 0 {
+		return "", false
+	}
+	parts := matched[1:]
+	parts[3] = strings.ToUpper(parts[3])
+	digits := strings.Join(parts, "")
+	if orcidutils.CheckDigit(digits[:15]) != digits[15:] {
+		return "", false
+	}
+	return strings.Join(parts, " "), true
+}
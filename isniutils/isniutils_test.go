@@ -0,0 +1,50 @@
+package isniutils_test
+
+import (
+	"testing"
+
+	"github.com/front-matter/commonmeta/isniutils"
+)
+
+func TestValidateISNI(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input string
+		want  string
+	}
+	testCases := []testCase{
+		{input: "0000 0001 2281 955X", want: "0000 0001 2281 955X"},
+		{input: "000000012281955x", want: "0000 0001 2281 955X"},
+		{input: "ISNI 0000 0001 2103 2683", want: "0000 0001 2103 2683"},
+		{input: "https://isni.org/isni/0000000121032683", want: "0000 0001 2103 2683"},
+		{input: "0000 0001 2281 9550", want: ""},
+		{input: "0000 0001 2281", want: ""},
+		{input: "", want: ""},
+	}
+	for _, tc := range testCases {
+		got, ok := isniutils.ValidateISNI(tc.input)
+		if tc.want != got {
+			t.Errorf("Validate ISNI(%v): want %v, got %v, ok %v",
+				tc.input, tc.want, got, ok)
+		}
+	}
+}
+
+func TestNormalizeISNI(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input string
+		want  string
+	}
+	testCases := []testCase{
+		{input: "0000 0001 2281 955X", want: "https://isni.org/isni/000000012281955X"},
+		{input: "0000 0001 2281 9550", want: ""},
+	}
+	for _, tc := range testCases {
+		got := isniutils.NormalizeISNI(tc.input)
+		if tc.want != got {
+			t.Errorf("Normalize ISNI(%v): want %v, got %v",
+				tc.input, tc.want, got)
+		}
+	}
+}
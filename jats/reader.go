@@ -0,0 +1,416 @@
+// Package jats provides functions to convert JATS XML metadata to the commonmeta metadata format.
+package jats
+
+import (
+	"encoding/xml"
+	"errors"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/dateutils"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/langutils"
+	"github.com/front-matter/commonmeta/spdxutils"
+	"github.com/front-matter/commonmeta/utils"
+)
+
+// Content represents a JATS article. Only the metadata in front is used.
+type Content struct {
+	XMLName     xml.Name `xml:"article"`
+	ArticleType string   `xml:"article-type,attr"`
+	Lang        string   `xml:"http://www.w3.org/XML/1998/namespace lang,attr"`
+	Front       Front    `xml:"front"`
+}
+
+// Front represents the front matter of a JATS article.
+type Front struct {
+	JournalMeta JournalMeta `xml:"journal-meta"`
+	ArticleMeta ArticleMeta `xml:"article-meta"`
+}
+
+// JournalMeta represents the journal metadata of a JATS article.
+type JournalMeta struct {
+	JournalTitle  string `xml:"journal-title-group>journal-title"`
+	ISSN          []ISSN `xml:"issn"`
+	PublisherName string `xml:"publisher>publisher-name"`
+}
+
+// ISSN represents the ISSN of a journal.
+type ISSN struct {
+	PubType string `xml:"pub-type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// ArticleMeta represents the article metadata of a JATS article.
+type ArticleMeta struct {
+	ArticleIDs    []ArticleID    `xml:"article-id"`
+	ArticleTitle  InnerXML       `xml:"title-group>article-title"`
+	Subtitle      InnerXML       `xml:"title-group>subtitle"`
+	ContribGroups []ContribGroup `xml:"contrib-group"`
+	Affs          []Aff          `xml:"aff"`
+	PubDates      []PubDate      `xml:"pub-date"`
+	Volume        string         `xml:"volume"`
+	Issue         string         `xml:"issue"`
+	FPage         string         `xml:"fpage"`
+	LPage         string         `xml:"lpage"`
+	ELocationID   string         `xml:"elocation-id"`
+	Permissions   Permissions    `xml:"permissions"`
+	Abstracts     []Abstract     `xml:"abstract"`
+	KwdGroups     []KwdGroup     `xml:"kwd-group"`
+}
+
+// ArticleID represents an identifier of a JATS article.
+type ArticleID struct {
+	PubIDType string `xml:"pub-id-type,attr"`
+	Text      string `xml:",chardata"`
+}
+
+// InnerXML represents an element that can contain inline formatting.
+type InnerXML struct {
+	Text string `xml:",innerxml"`
+}
+
+// ContribGroup represents a group of contributors.
+type ContribGroup struct {
+	Contribs []Contrib `xml:"contrib"`
+	Affs     []Aff     `xml:"aff"`
+}
+
+// Contrib represents a contributor.
+type Contrib struct {
+	ContribType string      `xml:"contrib-type,attr"`
+	ContribIDs  []ContribID `xml:"contrib-id"`
+	Name        Name        `xml:"name"`
+	Collab      InnerXML    `xml:"collab"`
+	Xrefs       []Xref      `xml:"xref"`
+	Affs        []Aff       `xml:"aff"`
+}
+
+// ContribID represents an identifier of a contributor, e.g. an ORCID.
+type ContribID struct {
+	ContribIDType string `xml:"contrib-id-type,attr"`
+	Text          string `xml:",chardata"`
+}
+
+// Name represents the name of a person.
+type Name struct {
+	Surname    string `xml:"surname"`
+	GivenNames string `xml:"given-names"`
+}
+
+// Xref represents a cross-reference, e.g. to an affiliation.
+type Xref struct {
+	RefType string `xml:"ref-type,attr"`
+	Rid     string `xml:"rid,attr"`
+}
+
+// Aff represents an affiliation.
+type Aff struct {
+	ID   string `xml:"id,attr"`
+	Text string `xml:",innerxml"`
+}
+
+// PubDate represents a publication date.
+type PubDate struct {
+	PubType  string `xml:"pub-type,attr"`
+	DateType string `xml:"date-type,attr"`
+	Day      string `xml:"day"`
+	Month    string `xml:"month"`
+	Year     string `xml:"year"`
+}
+
+// Permissions represents the copyright and license information.
+type Permissions struct {
+	License []License `xml:"license"`
+}
+
+// License represents a license, either as xlink:href or as ali:license_ref.
+type License struct {
+	Href       string `xml:"http://www.w3.org/1999/xlink href,attr"`
+	LicenseRef string `xml:"license_ref"`
+}
+
+// Abstract represents an abstract.
+type Abstract struct {
+	AbstractType string `xml:"abstract-type,attr"`
+	Text         string `xml:",innerxml"`
+}
+
+// KwdGroup represents a group of keywords.
+type KwdGroup struct {
+	Kwd []InnerXML `xml:"kwd"`
+}
+
+// JATSToCMAbstractMappings maps JATS abstract types to commonmeta description types.
+var JATSToCMAbstractMappings = map[string]string{
+	"":                  "Abstract",
+	"executive-summary": "Summary",
+	"summary":           "Summary",
+	"toc":               "Summary",
+	"short":             "Abstract",
+	"graphical":         "Other",
+	"methods":           "Methods",
+}
+
+// Load loads the metadata for a single work from a JATS XML file
+func Load(filename string) (commonmeta.Data, error) {
+	var data commonmeta.Data
+
+	extension := path.Ext(filename)
+	if extension != ".xml" {
+		return data, errors.New("invalid file extension")
+	}
+	input, err := os.ReadFile(filename)
+	if err != nil {
+		return data, errors.New("error reading file")
+	}
+	return ReadXML(input)
+}
+
+// ReadXML unmarshals JATS XML and converts it to commonmeta.
+func ReadXML(input []byte) (commonmeta.Data, error) {
+	var data commonmeta.Data
+	var content Content
+
+	decoder := xml.NewDecoder(strings.NewReader(string(input)))
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+	err := decoder.Decode(&content)
+	if err != nil {
+		return data, err
+	}
+	return Read(content)
+}
+
+// Read reads JATS metadata and converts it to commonmeta.
+func Read(content Content) (commonmeta.Data, error) {
+	var data commonmeta.Data
+	meta := content.Front.ArticleMeta
+
+	for _, v := range meta.ArticleIDs {
+		if v.PubIDType == "doi" {
+			data.ID = doiutils.NormalizeDOI(strings.TrimSpace(v.Text))
+			break
+		}
+	}
+	if data.ID == "" {
+		return data, errors.New("missing DOI")
+	}
+	data.Type = "JournalArticle"
+	data.Identifiers = append(data.Identifiers, commonmeta.Identifier{
+		Identifier:     data.ID,
+		IdentifierType: "DOI",
+	})
+
+	affs := make(map[string]string)
+	for _, v := range meta.Affs {
+		affs[v.ID] = getText(v.Text)
+	}
+	for _, group := range meta.ContribGroups {
+		for _, v := range group.Affs {
+			affs[v.ID] = getText(v.Text)
+		}
+	}
+	for _, group := range meta.ContribGroups {
+		for _, v := range group.Contribs {
+			contributor := getContributor(v, affs)
+			if contributor.Name != "" || contributor.FamilyName != "" {
+				data.Contributors = append(data.Contributors, contributor)
+			}
+		}
+	}
+
+	title := getText(meta.ArticleTitle.Text)
+	if title != "" {
+		data.Titles = append(data.Titles, commonmeta.Title{Title: title})
+	}
+	subtitle := getText(meta.Subtitle.Text)
+	if subtitle != "" {
+		data.Titles = append(data.Titles, commonmeta.Title{Title: subtitle, Type: "Subtitle"})
+	}
+
+	for _, v := range meta.Abstracts {
+		description := getText(v.Text)
+		if description == "" {
+			continue
+		}
+		descriptionType, ok := JATSToCMAbstractMappings[v.AbstractType]
+		if !ok {
+			descriptionType = "Other"
+		}
+		data.Descriptions = append(data.Descriptions, commonmeta.Description{
+			Description: description,
+			Type:        descriptionType,
+		})
+	}
+
+	data.Date.Published = getPubDate(meta.PubDates)
+
+	journal := content.Front.JournalMeta
+	data.Container = commonmeta.Container{
+		Type:      "Journal",
+		Title:     strings.TrimSpace(journal.JournalTitle),
+		Volume:    strings.TrimSpace(meta.Volume),
+		Issue:     strings.TrimSpace(meta.Issue),
+		FirstPage: strings.TrimSpace(meta.FPage),
+		LastPage:  strings.TrimSpace(meta.LPage),
+	}
+	issn := getISSN(journal.ISSN)
+	if issn != "" {
+		data.Container.Identifier = issn
+		data.Container.IdentifierType = "ISSN"
+	}
+	if journal.PublisherName != "" {
+		data.Publisher = commonmeta.Publisher{Name: strings.TrimSpace(journal.PublisherName)}
+	}
+
+	for _, v := range meta.Permissions.License {
+		href := v.Href
+		if href == "" {
+			href = v.LicenseRef
+		}
+		id, url := spdxutils.Normalize(href)
+		if id != "" {
+			data.License = commonmeta.License{
+				ID:  id,
+				URL: url,
+			}
+			break
+		}
+	}
+
+	for _, group := range meta.KwdGroups {
+		for _, v := range group.Kwd {
+			subject := getText(v.Text)
+			if subject != "" {
+				data.Subjects = append(data.Subjects, commonmeta.Subject{Subject: subject})
+			}
+		}
+	}
+	data.Language = langutils.GetLanguage(content.Lang, langutils.ISO6391)
+	return data, nil
+}
+
+// getContributor converts a JATS contrib to a commonmeta contributor.
+func getContributor(v Contrib, affs map[string]string) commonmeta.Contributor {
+	var contributor commonmeta.Contributor
+	for _, id := range v.ContribIDs {
+		if id.ContribIDType == "orcid" {
+			contributor.ID = utils.NormalizeORCID(strings.TrimSpace(id.Text))
+		}
+	}
+	collab := getText(v.Collab.Text)
+	if collab != "" {
+		contributor.Type = "Organization"
+		contributor.Name = collab
+	} else {
+		contributor.Type = "Person"
+		contributor.GivenName = strings.TrimSpace(v.Name.GivenNames)
+		contributor.FamilyName = strings.TrimSpace(v.Name.Surname)
+	}
+	switch v.ContribType {
+	case "author", "":
+		contributor.ContributorRoles = []string{"Author"}
+	case "editor":
+		contributor.ContributorRoles = []string{"Editor"}
+	case "translator":
+		contributor.ContributorRoles = []string{"Translator"}
+	default:
+		contributor.ContributorRoles = []string{"Other"}
+	}
+	for _, xref := range v.Xrefs {
+		name, ok := affs[xref.Rid]
+		if xref.RefType == "aff" && ok && name != "" {
+			contributor.Affiliations = append(contributor.Affiliations, &commonmeta.Affiliation{Name: name})
+		}
+	}
+	for _, aff := range v.Affs {
+		name := getText(aff.Text)
+		if name != "" {
+			contributor.Affiliations = append(contributor.Affiliations, &commonmeta.Affiliation{Name: name})
+		}
+	}
+	return contributor
+}
+
+// getPubDate returns the publication date, preferring the electronic
+// publication date over the date of the collection.
+func getPubDate(pubDates []PubDate) string {
+	var date string
+	for _, v := range pubDates {
+		d := dateutils.GetDateFromCrossrefParts(v.Year, v.Month, v.Day)
+		if d == "" {
+			continue
+		}
+		if v.PubType == "epub" || v.DateType == "pub" {
+			return d
+		}
+		if date == "" {
+			date = d
+		}
+	}
+	return date
+}
+
+// getISSN returns the ISSN of a journal, preferring the electronic ISSN.
+func getISSN(issns []ISSN) string {
+	var issn string
+	for _, v := range issns {
+		if v.PubType == "epub" {
+			return strings.TrimSpace(v.Text)
+		}
+		if issn == "" {
+			issn = strings.TrimSpace(v.Text)
+		}
+	}
+	return issn
+}
+
+// skippedElements are JATS elements whose text is not part of a title,
+// abstract or affiliation.
+var skippedElements = map[string]bool{
+	"label":     true,
+	"object-id": true,
+	"title":     true,
+	"xref":      true,
+}
+
+// getText returns the text content of JATS XML, stripping inline formatting
+// such as italic or bold and separating paragraphs with a space.
+func getText(innerXML string) string {
+	if strings.TrimSpace(innerXML) == "" {
+		return ""
+	}
+	decoder := xml.NewDecoder(strings.NewReader("<text>" + innerXML + "</text>"))
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+
+	var sb strings.Builder
+	skip := 0
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if skippedElements[t.Name.Local] || skip > 0 {
+				skip++
+			}
+			if t.Name.Local == "p" {
+				sb.WriteString(" ")
+			}
+		case xml.EndElement:
+			if skip > 0 {
+				skip--
+			}
+		case xml.CharData:
+			if skip == 0 {
+				sb.Write(t)
+			}
+		}
+	}
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
@@ -0,0 +1,200 @@
+package jats_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/csl"
+	"github.com/front-matter/commonmeta/jats"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name string
+		want commonmeta.Data
+	}
+
+	lausanne := []*commonmeta.Affiliation{{Name: "Department of Plant Molecular Biology, University of Lausanne, Lausanne, Switzerland"}}
+	testCases := []testCase{
+		{
+			name: "elife-01567.xml",
+			want: commonmeta.Data{
+				ID:   "https://doi.org/10.7554/elife.01567",
+				Type: "JournalArticle",
+				Container: commonmeta.Container{
+					Identifier:     "2050-084X",
+					IdentifierType: "ISSN",
+					Type:           "Journal",
+					Title:          "eLife",
+					Volume:         "3",
+				},
+				Contributors: []commonmeta.Contributor{
+					{Type: "Person", GivenName: "Martial", FamilyName: "Sankar", Affiliations: lausanne, ContributorRoles: []string{"Author"}},
+					{Type: "Person", GivenName: "Kaisa", FamilyName: "Nieminen", Affiliations: lausanne, ContributorRoles: []string{"Author"}},
+					{Type: "Person", GivenName: "Laura", FamilyName: "Ragni", Affiliations: lausanne, ContributorRoles: []string{"Author"}},
+					{Type: "Person", GivenName: "Ioannis", FamilyName: "Xenarios", Affiliations: []*commonmeta.Affiliation{{Name: "Vital-IT, Swiss Institute of Bioinformatics, Lausanne, Switzerland"}}, ContributorRoles: []string{"Author"}},
+					{Type: "Person", GivenName: "Christian S", FamilyName: "Hardtke", Affiliations: lausanne, ContributorRoles: []string{"Author"}},
+					{Type: "Person", GivenName: "Detlef", FamilyName: "Weigel", Affiliations: []*commonmeta.Affiliation{{Name: "Max Planck Institute for Developmental Biology, Germany"}}, ContributorRoles: []string{"Editor"}},
+				},
+				Date: commonmeta.Date{Published: "2014-02-11"},
+				Descriptions: []commonmeta.Description{
+					{Description: "Among various advantages, their small size makes model organisms preferred subjects of investigation. Yet, even in model systems detailed analysis of numerous developmental processes at cellular level is severely hampered by their scale. For instance, secondary growth of Arabidopsis hypocotyls creates a radial pattern of highly specialized tissues that comprises several thousand cells starting from a few dozen. This dynamic process is difficult to follow because of its scale and because it can only be investigated invasively, precluding comprehensive understanding of the cell proliferation, differentiation, and patterning events involved. To overcome such limitation, we established an automated quantitative histology approach. We acquired hypocotyl cross-sections from tiled high-resolution images and extracted their information content using custom high-throughput image processing and segmentation. Coupled with automated cell type recognition through machine learning, we could establish a cellular resolution atlas that reveals vascular morphodynamics during secondary growth, for example equidistant phloem pole formation.", Type: "Abstract"},
+				},
+				Identifiers: []commonmeta.Identifier{{Identifier: "https://doi.org/10.7554/elife.01567", IdentifierType: "DOI"}},
+				Language:    "en",
+				License:     commonmeta.License{ID: "CC-BY-3.0", URL: "https://creativecommons.org/licenses/by/3.0/legalcode"},
+				Publisher:   commonmeta.Publisher{Name: "eLife Sciences Publications, Ltd"},
+				Subjects: []commonmeta.Subject{
+					{Subject: "quantitative histology"},
+					{Subject: "machine learning"},
+					{Subject: "vascular morphodynamics"},
+					{Subject: "secondary growth"},
+					{Subject: "hypocotyl"},
+					{Subject: "Arabidopsis"},
+				},
+				Titles: []commonmeta.Title{{Title: "Automated quantitative histology reveals vascular morphodynamics during Arabidopsis hypocotyl secondary growth"}},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		got, err := jats.Load(filepath.Join("testdata", tc.name))
+		if err != nil {
+			t.Fatalf("JATS Load (%v): error %v", tc.name, err)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("JATS Load (%v) mismatch (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestReadXML(t *testing.T) {
+	t.Parallel()
+	input := []byte(`<article xmlns:xlink="http://www.w3.org/1999/xlink" article-type="research-article">
+  <front>
+    <journal-meta>
+      <journal-title-group><journal-title>Journal of Examples</journal-title></journal-title-group>
+      <issn pub-type="ppub">1234-5678</issn>
+    </journal-meta>
+    <article-meta>
+      <article-id pub-id-type="doi">10.5555/12345678</article-id>
+      <title-group>
+        <article-title>The <bold>H<sub>2</sub>O</bold> molecule &amp; <italic>E.&#160;coli</italic></article-title>
+      </title-group>
+      <contrib-group>
+        <contrib contrib-type="author">
+          <contrib-id contrib-id-type="orcid" authenticated="true">https://orcid.org/0000-0002-1825-0097</contrib-id>
+          <name><surname>Carberry</surname><given-names>Josiah</given-names></name>
+          <aff>Brown University</aff>
+        </contrib>
+        <contrib contrib-type="author"><collab>The Example Consortium</collab></contrib>
+      </contrib-group>
+      <pub-date pub-type="ppub"><month>3</month><year>2020</year></pub-date>
+      <volume>12</volume>
+      <issue>3</issue>
+      <fpage>101</fpage>
+      <lpage>110</lpage>
+      <abstract><title>Abstract</title><p>First paragraph.</p><p>Second paragraph with <italic>formatting</italic>.</p></abstract>
+    </article-meta>
+  </front>
+</article>`)
+	got, err := jats.ReadXML(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantContributors := []commonmeta.Contributor{
+		{ID: "https://orcid.org/0000-0002-1825-0097", Type: "Person", GivenName: "Josiah", FamilyName: "Carberry", Affiliations: []*commonmeta.Affiliation{{Name: "Brown University"}}, ContributorRoles: []string{"Author"}},
+		{Type: "Organization", Name: "The Example Consortium", ContributorRoles: []string{"Author"}},
+	}
+	if diff := cmp.Diff(wantContributors, got.Contributors); diff != "" {
+		t.Errorf("Contributors mismatch (-want +got):\n%s", diff)
+	}
+	wantTitles := []commonmeta.Title{{Title: "The H2O molecule & E. coli"}}
+	if diff := cmp.Diff(wantTitles, got.Titles); diff != "" {
+		t.Errorf("Titles mismatch (-want +got):\n%s", diff)
+	}
+	wantDescriptions := []commonmeta.Description{{Description: "First paragraph. Second paragraph with formatting.", Type: "Abstract"}}
+	if diff := cmp.Diff(wantDescriptions, got.Descriptions); diff != "" {
+		t.Errorf("Descriptions mismatch (-want +got):\n%s", diff)
+	}
+	wantContainer := commonmeta.Container{Identifier: "1234-5678", IdentifierType: "ISSN", Type: "Journal", Title: "Journal of Examples", Volume: "12", Issue: "3", FirstPage: "101", LastPage: "110"}
+	if diff := cmp.Diff(wantContainer, got.Container); diff != "" {
+		t.Errorf("Container mismatch (-want +got):\n%s", diff)
+	}
+	if got.Date.Published != "2020-03" {
+		t.Errorf("Date: want 2020-03, got %v", got.Date.Published)
+	}
+}
+
+func TestReadXMLMissingDOI(t *testing.T) {
+	t.Parallel()
+	_, err := jats.ReadXML([]byte(`<article><front><article-meta><title-group><article-title>No DOI</article-title></title-group></article-meta></front></article>`))
+	if err == nil {
+		t.Error("JATS ReadXML: want error for missing DOI, got nil")
+	}
+}
+
+func TestReadXMLSubtitle(t *testing.T) {
+	t.Parallel()
+	input := []byte(`<article>
+  <front>
+    <article-meta>
+      <article-id pub-id-type="doi">10.5555/12345678</article-id>
+      <title-group>
+        <article-title>Main</article-title>
+        <subtitle>Sub</subtitle>
+      </title-group>
+    </article-meta>
+  </front>
+</article>`)
+	got, err := jats.ReadXML(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantTitles := []commonmeta.Title{{Title: "Main"}, {Title: "Sub", Type: "Subtitle"}}
+	if diff := cmp.Diff(wantTitles, got.Titles); diff != "" {
+		t.Errorf("Titles mismatch (-want +got):\n%s", diff)
+	}
+	citation, err := csl.Convert(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if citation.Title != "Main: Sub" {
+		t.Errorf("CSL title: want %q, got %q", "Main: Sub", citation.Title)
+	}
+}
+
+func TestReadXMLAbstractTypes(t *testing.T) {
+	t.Parallel()
+	input := []byte(`<article>
+  <front>
+    <article-meta>
+      <article-id pub-id-type="doi">10.5555/12345678</article-id>
+      <title-group><article-title>Main</article-title></title-group>
+      <abstract abstract-type="methods"><p>How it was done.</p></abstract>
+      <abstract><p>What was found.</p></abstract>
+    </article-meta>
+  </front>
+</article>`)
+	got, err := jats.ReadXML(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantDescriptions := []commonmeta.Description{
+		{Description: "How it was done.", Type: "Methods"},
+		{Description: "What was found.", Type: "Abstract"},
+	}
+	if diff := cmp.Diff(wantDescriptions, got.Descriptions); diff != "" {
+		t.Errorf("Descriptions mismatch (-want +got):\n%s", diff)
+	}
+	citation, err := csl.Convert(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if citation.Abstract != "What was found." {
+		t.Errorf("CSL abstract: want %q, got %q", "What was found.", citation.Abstract)
+	}
+}
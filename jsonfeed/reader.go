@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
 	"os"
@@ -19,6 +20,8 @@ import (
 	"github.com/front-matter/commonmeta/commonmeta"
 	"github.com/front-matter/commonmeta/dateutils"
 	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/langutils"
+	"github.com/front-matter/commonmeta/spdxutils"
 	"github.com/front-matter/commonmeta/utils"
 )
 
@@ -136,7 +139,7 @@ func Get(id string) (Content, error) {
 	}
 	err = json.Unmarshal(body, &content)
 	if err != nil {
-		fmt.Println("error:", err)
+		slog.Error("error unmarshalling JSON", "error", err)
 	}
 	return content, err
 }
@@ -292,13 +295,13 @@ func Read(content Content) (commonmeta.Data, error) {
 		IdentifierType: "UUID",
 	})
 
-	data.Language = content.Language
+	data.Language = langutils.GetLanguage(content.Language, langutils.ISO6391)
 
 	licenseURL, err := utils.NormalizeURL(content.Blog.License, true, true)
 	if err != nil {
 		return data, err
 	}
-	licenseID := utils.URLToSPDX(licenseURL)
+	licenseID := spdxutils.URLToID(licenseURL)
 	data.License = commonmeta.License{
 		ID:  licenseID,
 		URL: licenseURL,
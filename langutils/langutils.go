@@ -0,0 +1,144 @@
+// Package langutils provides a set of functions to work with language codes
+package langutils
+
+import (
+	"strings"
+)
+
+// Language represents a language with its ISO 639-1 and ISO 639-3 codes.
+type Language struct {
+	ISO6391 string
+	ISO6393 string
+	Name    string
+}
+
+// Formats for GetLanguage
+const (
+	ISO6391 = "iso639-1"
+	ISO6393 = "iso639-3"
+	Name    = "name"
+)
+
+// Languages is an abbreviated list of languages from
+// https://iso639-3.sil.org/code_tables/639/data
+var Languages = []Language{
+	{ISO6391: "ar", ISO6393: "ara", Name: "Arabic"},
+	{ISO6391: "bg", ISO6393: "bul", Name: "Bulgarian"},
+	{ISO6391: "bn", ISO6393: "ben", Name: "Bengali"},
+	{ISO6391: "ca", ISO6393: "cat", Name: "Catalan"},
+	{ISO6391: "cs", ISO6393: "ces", Name: "Czech"},
+	{ISO6391: "cy", ISO6393: "cym", Name: "Welsh"},
+	{ISO6391: "da", ISO6393: "dan", Name: "Danish"},
+	{ISO6391: "de", ISO6393: "deu", Name: "German"},
+	{ISO6391: "el", ISO6393: "ell", Name: "Greek"},
+	{ISO6391: "en", ISO6393: "eng", Name: "English"},
+	{ISO6391: "eo", ISO6393: "epo", Name: "Esperanto"},
+	{ISO6391: "es", ISO6393: "spa", Name: "Spanish"},
+	{ISO6391: "et", ISO6393: "est", Name: "Estonian"},
+	{ISO6391: "eu", ISO6393: "eus", Name: "Basque"},
+	{ISO6391: "fa", ISO6393: "fas", Name: "Persian"},
+	{ISO6391: "fi", ISO6393: "fin", Name: "Finnish"},
+	{ISO6391: "fr", ISO6393: "fra", Name: "French"},
+	{ISO6391: "ga", ISO6393: "gle", Name: "Irish"},
+	{ISO6391: "gl", ISO6393: "glg", Name: "Galician"},
+	{ISO6391: "he", ISO6393: "heb", Name: "Hebrew"},
+	{ISO6391: "hi", ISO6393: "hin", Name: "Hindi"},
+	{ISO6391: "hr", ISO6393: "hrv", Name: "Croatian"},
+	{ISO6391: "hu", ISO6393: "hun", Name: "Hungarian"},
+	{ISO6391: "hy", ISO6393: "hye", Name: "Armenian"},
+	{ISO6391: "id", ISO6393: "ind", Name: "Indonesian"},
+	{ISO6391: "is", ISO6393: "isl", Name: "Icelandic"},
+	{ISO6391: "it", ISO6393: "ita", Name: "Italian"},
+	{ISO6391: "ja", ISO6393: "jpn", Name: "Japanese"},
+	{ISO6391: "ka", ISO6393: "kat", Name: "Georgian"},
+	{ISO6391: "ko", ISO6393: "kor", Name: "Korean"},
+	{ISO6391: "la", ISO6393: "lat", Name: "Latin"},
+	{ISO6391: "lt", ISO6393: "lit", Name: "Lithuanian"},
+	{ISO6391: "lv", ISO6393: "lav", Name: "Latvian"},
+	{ISO6391: "mk", ISO6393: "mkd", Name: "Macedonian"},
+	{ISO6391: "ms", ISO6393: "msa", Name: "Malay"},
+	{ISO6391: "nl", ISO6393: "nld", Name: "Dutch"},
+	{ISO6391: "no", ISO6393: "nor", Name: "Norwegian"},
+	{ISO6391: "pl", ISO6393: "pol", Name: "Polish"},
+	{ISO6391: "pt", ISO6393: "por", Name: "Portuguese"},
+	{ISO6391: "ro", ISO6393: "ron", Name: "Romanian"},
+	{ISO6391: "ru", ISO6393: "rus", Name: "Russian"},
+	{ISO6391: "sk", ISO6393: "slk", Name: "Slovak"},
+	{ISO6391: "sl", ISO6393: "slv", Name: "Slovenian"},
+	{ISO6391: "sq", ISO6393: "sqi", Name: "Albanian"},
+	{ISO6391: "sr", ISO6393: "srp", Name: "Serbian"},
+	{ISO6391: "sv", ISO6393: "swe", Name: "Swedish"},
+	{ISO6391: "sw", ISO6393: "swa", Name: "Swahili"},
+	{ISO6391: "ta", ISO6393: "tam", Name: "Tamil"},
+	{ISO6391: "th", ISO6393: "tha", Name: "Thai"},
+	{ISO6391: "tr", ISO6393: "tur", Name: "Turkish"},
+	{ISO6391: "uk", ISO6393: "ukr", Name: "Ukrainian"},
+	{ISO6391: "ur", ISO6393: "urd", Name: "Urdu"},
+	{ISO6391: "vi", ISO6393: "vie", Name: "Vietnamese"},
+	{ISO6391: "zh", ISO6393: "zho", Name: "Chinese"},
+}
+
+// bibliographicCodes maps ISO 639-2/B codes to ISO 639-3 codes, e.g. "ger"
+// to "deu". Other ISO 639-2 codes are the same as in ISO 639-3.
+var bibliographicCodes = map[string]string{
+	"alb": "sqi",
+	"arm": "hye",
+	"baq": "eus",
+	"chi": "zho",
+	"cze": "ces",
+	"dut": "nld",
+	"fre": "fra",
+	"geo": "kat",
+	"ger": "deu",
+	"gre": "ell",
+	"ice": "isl",
+	"mac": "mkd",
+	"may": "msa",
+	"per": "fas",
+	"rum": "ron",
+	"slo": "slk",
+	"wel": "cym",
+}
+
+// GetLanguage returns a language, given as name, ISO 639-1, ISO 639-2 or
+// ISO 639-3 code, e.g. "english", "en" or "eng", in the requested format:
+// ISO6391 (the default), ISO6393 or Name. Region subtags, e.g. "en-US", are
+// ignored. An empty string is returned for unknown languages.
+func GetLanguage(lang string, format string) string {
+	language, ok := FindLanguage(lang)
+	if !ok {
+		return ""
+	}
+	switch format {
+	case ISO6393:
+		return language.ISO6393
+	case Name:
+		return language.Name
+	default:
+		return language.ISO6391
+	}
+}
+
+// FindLanguage looks up a language by name, ISO 639-1, ISO 639-2 or
+// ISO 639-3 code. The comparison is case-insensitive.
+func FindLanguage(lang string) (Language, bool) {
+	lang = strings.ToLower(strings.TrimSpace(lang))
+	// remove region and script subtags, e.g. "en-US" or "zh_Hant"
+	if i := strings.IndexAny(lang, "-_"); i > 0 && i <= 3 {
+		lang = lang[:i]
+	}
+	if code, ok := bibliographicCodes[lang]; ok {
+		lang = code
+	}
+	for _, l := range Languages {
+		switch {
+		case len(lang) == 2 && l.ISO6391 == lang:
+			return l, true
+		case len(lang) == 3 && l.ISO6393 == lang:
+			return l, true
+		case strings.EqualFold(l.Name, lang):
+			return l, true
+		}
+	}
+	return Language{}, false
+}
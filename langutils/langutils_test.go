@@ -0,0 +1,44 @@
+package langutils_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/front-matter/commonmeta/langutils"
+)
+
+func TestGetLanguage(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input  string
+		format string
+		want   string
+	}
+	testCases := []testCase{
+		{input: "english", format: langutils.ISO6391, want: "en"},
+		{input: "English", format: langutils.ISO6393, want: "eng"},
+		{input: "en", format: langutils.ISO6391, want: "en"},
+		{input: "en", format: langutils.ISO6393, want: "eng"},
+		{input: "eng", format: langutils.ISO6391, want: "en"},
+		{input: "en-US", format: langutils.ISO6391, want: "en"},
+		{input: "GER", format: langutils.ISO6391, want: "de"},
+		{input: "deu", format: langutils.Name, want: "German"},
+		{input: "de", format: "", want: "de"},
+		{input: "klingon", format: langutils.ISO6391, want: ""},
+		{input: "", format: langutils.ISO6391, want: ""},
+	}
+	for _, tc := range testCases {
+		got := langutils.GetLanguage(tc.input, tc.format)
+		if tc.want != got {
+			t.Errorf("GetLanguage(%v, %v): want %v, got %v",
+				tc.input, tc.format, tc.want, got)
+		}
+	}
+}
+
+func ExampleGetLanguage() {
+	s := langutils.GetLanguage("eng", langutils.ISO6391)
+	fmt.Println(s)
+	// Output:
+	// en
+}
@@ -7,6 +7,16 @@ import (
 	"github.com/front-matter/commonmeta/cmd"
 )
 
+// version, commit and date are set by GoReleaser with ldflags.
+var (
+	version string
+	commit  string
+	date    string
+)
+
 func main() {
+	if version != "" {
+		cmd.SetVersionInfo(version, commit, date)
+	}
 	cmd.Execute()
 }
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	_ "github.com/front-matter/commonmeta/arxiv"
+	_ "github.com/front-matter/commonmeta/authorutils"
+	_ "github.com/front-matter/commonmeta/bibtex"
+	_ "github.com/front-matter/commonmeta/cff"
+	_ "github.com/front-matter/commonmeta/citation"
+	_ "github.com/front-matter/commonmeta/codemeta"
+	_ "github.com/front-matter/commonmeta/commonmeta"
+	_ "github.com/front-matter/commonmeta/crockford"
+	_ "github.com/front-matter/commonmeta/crossref"
+	_ "github.com/front-matter/commonmeta/crossrefxml"
+	_ "github.com/front-matter/commonmeta/csl"
+	_ "github.com/front-matter/commonmeta/csv"
+	_ "github.com/front-matter/commonmeta/datacite"
+	_ "github.com/front-matter/commonmeta/datacitexml"
+	_ "github.com/front-matter/commonmeta/dateutils"
+	_ "github.com/front-matter/commonmeta/doiutils"
+	_ "github.com/front-matter/commonmeta/dublincore"
+	_ "github.com/front-matter/commonmeta/feed"
+	_ "github.com/front-matter/commonmeta/formats"
+	_ "github.com/front-matter/commonmeta/ghost"
+	_ "github.com/front-matter/commonmeta/inveniordm"
+	_ "github.com/front-matter/commonmeta/isbnutils"
+	_ "github.com/front-matter/commonmeta/isniutils"
+	_ "github.com/front-matter/commonmeta/jats"
+	_ "github.com/front-matter/commonmeta/jsonfeed"
+	_ "github.com/front-matter/commonmeta/langutils"
+	_ "github.com/front-matter/commonmeta/marc"
+	_ "github.com/front-matter/commonmeta/openaire"
+	_ "github.com/front-matter/commonmeta/orcid"
+	_ "github.com/front-matter/commonmeta/orcidutils"
+	_ "github.com/front-matter/commonmeta/pubmed"
+	_ "github.com/front-matter/commonmeta/ris"
+	_ "github.com/front-matter/commonmeta/rorutils"
+	_ "github.com/front-matter/commonmeta/schemaorg"
+	_ "github.com/front-matter/commonmeta/schemautils"
+	_ "github.com/front-matter/commonmeta/spdxutils"
+	_ "github.com/front-matter/commonmeta/textutils"
+	_ "github.com/front-matter/commonmeta/utils"
+	_ "github.com/front-matter/commonmeta/verutils"
+)
+
+// modulePath is the module path all packages of the project are imported by.
+const modulePath = "github.com/front-matter/commonmeta"
+
+// TestImports checks that all packages are imported by the module path, and
+// that imported packages of the module exist. Importing all packages above
+// makes sure they build as part of the module.
+func TestImports(t *testing.T) {
+	fset := token.NewFileSet()
+	err := filepath.WalkDir(".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && path != "." && (d.Name() == "testdata" || strings.HasPrefix(d.Name(), ".")) {
+			return filepath.SkipDir
+		}
+		if d.IsDir() || filepath.Ext(path) != ".go" {
+			return nil
+		}
+		f, err := parser.ParseFile(fset, path, nil, parser.ImportsOnly)
+		if err != nil {
+			return err
+		}
+		for _, v := range f.Imports {
+			importPath, _ := strconv.Unquote(v.Path.Value)
+			if strings.HasPrefix(importPath, "commonmeta/") {
+				t.Errorf("%s: import %s by module path %s", path, importPath, modulePath)
+			}
+			if rel, ok := strings.CutPrefix(importPath, modulePath+"/"); ok {
+				if _, err := os.Stat(rel); err != nil {
+					t.Errorf("%s: imported package %s not found", path, importPath)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
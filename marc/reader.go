@@ -0,0 +1,351 @@
+// Package marc provides functions to convert MARC 21 bibliographic records in MARCXML to the commonmeta metadata format.
+package marc
+
+import (
+	"encoding/xml"
+	"errors"
+	"log"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/utils"
+)
+
+// Content represents a MARCXML record.
+type Content struct {
+	XMLName       xml.Name       `xml:"record"`
+	Leader        string         `xml:"leader"`
+	ControlFields []ControlField `xml:"controlfield"`
+	DataFields    []DataField    `xml:"datafield"`
+}
+
+// Collection represents a MARCXML collection of records.
+type Collection struct {
+	XMLName xml.Name  `xml:"collection"`
+	Records []Content `xml:"record"`
+}
+
+// ControlField represents a MARC control field (tags 001-009).
+type ControlField struct {
+	Tag  string `xml:"tag,attr"`
+	Text string `xml:",chardata"`
+}
+
+// DataField represents a MARC data field with indicators and subfields.
+type DataField struct {
+	Tag       string     `xml:"tag,attr"`
+	Ind1      string     `xml:"ind1,attr"`
+	Ind2      string     `xml:"ind2,attr"`
+	Subfields []Subfield `xml:"subfield"`
+}
+
+// Subfield represents a MARC subfield.
+type Subfield struct {
+	Code string `xml:"code,attr"`
+	Text string `xml:",chardata"`
+}
+
+// MARCToCMMappings maps the type of record and bibliographic level
+// (leader positions 06 and 07) to commonmeta types.
+var MARCToCMMappings = map[string]string{
+	"aa": "BookChapter",
+	"ab": "JournalArticle",
+	"am": "Book",
+	"as": "Journal",
+	"ac": "Collection",
+	"tm": "Book",
+	"e":  "Image",
+	"f":  "Image",
+	"g":  "Audiovisual",
+	"i":  "Audiovisual",
+	"j":  "Audiovisual",
+	"k":  "Image",
+	"m":  "Software",
+	"p":  "Collection",
+	"r":  "PhysicalObject",
+}
+
+// MARCToCMRoleMappings maps MARC relator codes and terms to commonmeta contributor roles.
+var MARCToCMRoleMappings = map[string]string{
+	"aut":        "Author",
+	"author":     "Author",
+	"edt":        "Editor",
+	"editor":     "Editor",
+	"trl":        "Translator",
+	"translator": "Translator",
+}
+
+// yearRegexp matches a four digit year, e.g. in "c2016." or "[2016]".
+var yearRegexp = regexp.MustCompile(`\d{4}`)
+
+// Load loads the metadata for a single work from a MARCXML file
+func Load(filename string) (commonmeta.Data, error) {
+	var data commonmeta.Data
+
+	content, err := loadFile(filename)
+	if err != nil {
+		return data, err
+	}
+	if len(content) == 0 {
+		return data, errors.New("no MARC records found")
+	}
+	data, err = Read(content[0])
+	if err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// LoadAll loads a list of works from a MARCXML file and converts it to the Commonmeta format
+func LoadAll(filename string) ([]commonmeta.Data, error) {
+	var data []commonmeta.Data
+
+	content, err := loadFile(filename)
+	if err != nil {
+		return data, err
+	}
+	data, err = ReadAll(content)
+	if err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+func loadFile(filename string) ([]Content, error) {
+	extension := path.Ext(filename)
+	if extension != ".xml" {
+		return nil, errors.New("invalid file extension")
+	}
+	bytes, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.New("error reading file")
+	}
+	return Parse(bytes)
+}
+
+// Parse parses MARCXML input into a list of records. The input can be a
+// single record or a collection of records.
+func Parse(input []byte) ([]Content, error) {
+	var collection Collection
+	err := xml.Unmarshal(input, &collection)
+	if err == nil {
+		return collection.Records, nil
+	}
+	var content Content
+	err = xml.Unmarshal(input, &content)
+	if err != nil {
+		return nil, err
+	}
+	return []Content{content}, nil
+}
+
+// Read reads a MARCXML record and converts it to commonmeta.
+func Read(content Content) (commonmeta.Data, error) {
+	var data commonmeta.Data
+
+	data.Type = getType(content.Leader)
+
+	for _, field := range content.Fields("024") {
+		if strings.EqualFold(field.Get("2"), "doi") {
+			doi := doiutils.NormalizeDOI(field.Get("a"))
+			if doi != "" {
+				data.ID = doi
+				data.Identifiers = append(data.Identifiers, commonmeta.Identifier{
+					Identifier:     doi,
+					IdentifierType: "DOI",
+				})
+			}
+		}
+	}
+	for _, field := range content.Fields("020") {
+		isbn := getISBN(field.Get("a"))
+		if isbn != "" {
+			data.Identifiers = append(data.Identifiers, commonmeta.Identifier{
+				Identifier:     isbn,
+				IdentifierType: "ISBN",
+			})
+		}
+	}
+	for _, field := range content.Fields("856") {
+		u := strings.TrimSpace(field.Get("u"))
+		if u != "" && data.URL == "" {
+			data.URL = u
+		}
+	}
+	if data.ID == "" {
+		data.ID = data.URL
+	}
+
+	for _, field := range content.Fields("245") {
+		title := clean(field.Get("a"))
+		if title != "" {
+			data.Titles = append(data.Titles, commonmeta.Title{Title: title})
+		}
+		subtitle := clean(field.Get("b"))
+		if subtitle != "" {
+			data.Titles = append(data.Titles, commonmeta.Title{Title: subtitle, Type: "Subtitle"})
+		}
+	}
+
+	for _, field := range append(content.Fields("100"), content.Fields("700")...) {
+		contributor := getContributor(field)
+		if contributor.Name != "" || contributor.FamilyName != "" {
+			data.Contributors = append(data.Contributors, contributor)
+		}
+	}
+
+	// 264 with second indicator 1 is the publication statement in RDA records,
+	// 260 is used in older AACR2 records
+	for _, field := range append(content.Fields("264"), content.Fields("260")...) {
+		if field.Tag == "264" && field.Ind2 != "1" {
+			continue
+		}
+		if data.Publisher.Name == "" {
+			publisher := clean(field.Get("b"))
+			if publisher != "" {
+				data.Publisher = commonmeta.Publisher{Name: publisher}
+			}
+		}
+		if data.Date.Published == "" {
+			data.Date.Published = yearRegexp.FindString(field.Get("c"))
+		}
+	}
+
+	for _, field := range content.Fields("520") {
+		description := strings.TrimSpace(field.Get("a"))
+		if description != "" {
+			data.Descriptions = append(data.Descriptions, commonmeta.Description{
+				Description: utils.Sanitize(description),
+				Type:        "Abstract",
+			})
+		}
+	}
+
+	for _, field := range content.Fields("650") {
+		subject := clean(field.Get("a"))
+		if subject != "" {
+			data.Subjects = append(data.Subjects, commonmeta.Subject{Subject: subject})
+		}
+	}
+
+	return data, nil
+}
+
+// ReadAll reads a list of MARCXML records and returns a list of works in Commonmeta format
+func ReadAll(content []Content) ([]commonmeta.Data, error) {
+	var data []commonmeta.Data
+	for _, v := range content {
+		d, err := Read(v)
+		if err != nil {
+			log.Println(err)
+		}
+		data = append(data, d)
+	}
+	return data, nil
+}
+
+// Fields returns all data fields with the given tag.
+func (c Content) Fields(tag string) []DataField {
+	var fields []DataField
+	for _, field := range c.DataFields {
+		if field.Tag == tag {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+// Get returns the value of the first subfield with the given code.
+func (f DataField) Get(code string) string {
+	for _, subfield := range f.Subfields {
+		if subfield.Code == code {
+			return subfield.Text
+		}
+	}
+	return ""
+}
+
+// GetAll returns the values of all subfields with the given code.
+func (f DataField) GetAll(code string) []string {
+	var values []string
+	for _, subfield := range f.Subfields {
+		if subfield.Code == code {
+			values = append(values, subfield.Text)
+		}
+	}
+	return values
+}
+
+// getType returns the commonmeta type from the leader of a MARC record.
+func getType(leader string) string {
+	if len(leader) < 8 {
+		return "Other"
+	}
+	t, ok := MARCToCMMappings[leader[6:8]]
+	if ok {
+		return t
+	}
+	t, ok = MARCToCMMappings[leader[6:7]]
+	if ok {
+		return t
+	}
+	return "Other"
+}
+
+// getContributor converts a 100 or 700 personal name field to a commonmeta
+// contributor. Dates in subfield d are not part of the name. The relator
+// term in subfield e or the relator code in subfield 4 determine the role.
+func getContributor(field DataField) commonmeta.Contributor {
+	contributor := commonmeta.Contributor{Type: "Person"}
+	name := clean(field.Get("a"))
+	// first indicator 1 is a surname in "Family, Given" order
+	parts := strings.SplitN(name, ",", 2)
+	if field.Ind1 == "1" && len(parts) == 2 {
+		contributor.FamilyName = strings.TrimSpace(parts[0])
+		contributor.GivenName = strings.TrimSpace(parts[1])
+	} else {
+		contributor.Name = name
+	}
+
+	for _, v := range append(field.GetAll("e"), field.GetAll("4")...) {
+		role, ok := MARCToCMRoleMappings[strings.ToLower(clean(v))]
+		if ok && !slices.Contains(contributor.ContributorRoles, role) {
+			contributor.ContributorRoles = append(contributor.ContributorRoles, role)
+		}
+	}
+	if len(contributor.ContributorRoles) == 0 {
+		contributor.ContributorRoles = []string{"Author"}
+	}
+	return contributor
+}
+
+// getISBN returns the ISBN from subfield a of the 020 field, which may be
+// followed by a qualifier, e.g. "9780134190440 (paperback)".
+func getISBN(str string) string {
+	fields := strings.Fields(str)
+	if len(fields) == 0 {
+		return ""
+	}
+	return strings.ReplaceAll(fields[0], "-", "")
+}
+
+// clean removes the ISBD punctuation at the end of a MARC subfield, e.g. the
+// " :" before a subtitle or the "," after a name. A final period is only
+// removed if it does not end an initial, e.g. in "Donovan, Alan A. A.".
+func clean(str string) string {
+	str = strings.TrimSpace(str)
+	str = strings.TrimRight(str, " ,:;/=")
+	if strings.HasSuffix(str, ".") {
+		words := strings.Fields(str)
+		last := words[len(words)-1]
+		if len(last) > 2 {
+			str = strings.TrimSuffix(str, ".")
+		}
+	}
+	return strings.TrimSpace(str)
+}
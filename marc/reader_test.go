@@ -0,0 +1,115 @@
+package marc_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/marc"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name string
+		want commonmeta.Data
+	}
+
+	testCases := []testCase{
+		{
+			name: "gopl.xml",
+			want: commonmeta.Data{
+				ID:   "https://www.gopl.io/",
+				Type: "Book",
+				Contributors: []commonmeta.Contributor{
+					{Type: "Person", GivenName: "Alan A. A.", FamilyName: "Donovan", ContributorRoles: []string{"Author"}},
+					{Type: "Person", GivenName: "Brian W.", FamilyName: "Kernighan", ContributorRoles: []string{"Author"}},
+				},
+				Date: commonmeta.Date{Published: "2016"},
+				Descriptions: []commonmeta.Description{
+					{Description: "The Go Programming Language is the authoritative resource for any programmer who wants to learn Go. It shows how to write clear and idiomatic Go to solve real-world problems.", Type: "Abstract"},
+				},
+				Identifiers: []commonmeta.Identifier{
+					{Identifier: "9780134190440", IdentifierType: "ISBN"},
+					{Identifier: "0134190440", IdentifierType: "ISBN"},
+				},
+				Publisher: commonmeta.Publisher{Name: "Addison-Wesley"},
+				Subjects: []commonmeta.Subject{
+					{Subject: "Go (Computer program language)"},
+					{Subject: "Open source software"},
+					{Subject: "Computer programming"},
+				},
+				Titles: []commonmeta.Title{{Title: "The Go programming language"}},
+				URL:    "https://www.gopl.io/",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		got, err := marc.Load(filepath.Join("testdata", tc.name))
+		if err != nil {
+			t.Fatalf("MARC Load (%v): error %v", tc.name, err)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("MARC Load (%v) mismatch (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	input := []byte(`<record xmlns="http://www.loc.gov/MARC21/slim">
+  <leader>00000nam a2200000 a 4500</leader>
+  <datafield tag="024" ind1="7" ind2=" ">
+    <subfield code="a">10.5555/12345678</subfield>
+    <subfield code="2">doi</subfield>
+  </datafield>
+  <datafield tag="100" ind1="0" ind2=" ">
+    <subfield code="a">Homer.</subfield>
+  </datafield>
+  <datafield tag="245" ind1="1" ind2="4">
+    <subfield code="a">The Odyssey :</subfield>
+    <subfield code="b">a new translation /</subfield>
+    <subfield code="c">Homer ; translated by Emily Wilson.</subfield>
+  </datafield>
+  <datafield tag="260" ind1=" " ind2=" ">
+    <subfield code="a">New York :</subfield>
+    <subfield code="b">W.W. Norton &amp; Company,</subfield>
+    <subfield code="c">c2018.</subfield>
+  </datafield>
+  <datafield tag="700" ind1="1" ind2=" ">
+    <subfield code="a">Wilson, Emily R.,</subfield>
+    <subfield code="d">1971-</subfield>
+    <subfield code="4">trl</subfield>
+  </datafield>
+</record>`)
+	content, err := marc.Parse(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(content) != 1 {
+		t.Fatalf("MARC Parse: want 1 record, got %d", len(content))
+	}
+	got, err := marc.Read(content[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != "https://doi.org/10.5555/12345678" {
+		t.Errorf("MARC Read ID: want https://doi.org/10.5555/12345678, got %v", got.ID)
+	}
+	wantContributors := []commonmeta.Contributor{
+		{Type: "Person", Name: "Homer", ContributorRoles: []string{"Author"}},
+		{Type: "Person", GivenName: "Emily R.", FamilyName: "Wilson", ContributorRoles: []string{"Translator"}},
+	}
+	if diff := cmp.Diff(wantContributors, got.Contributors); diff != "" {
+		t.Errorf("Contributors mismatch (-want +got):\n%s", diff)
+	}
+	wantTitles := []commonmeta.Title{{Title: "The Odyssey"}, {Title: "a new translation", Type: "Subtitle"}}
+	if diff := cmp.Diff(wantTitles, got.Titles); diff != "" {
+		t.Errorf("Titles mismatch (-want +got):\n%s", diff)
+	}
+	if got.Publisher.Name != "W.W. Norton & Company" || got.Date.Published != "2018" {
+		t.Errorf("MARC Read publisher: want W.W. Norton & Company 2018, got %v %v", got.Publisher.Name, got.Date.Published)
+	}
+}
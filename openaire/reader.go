@@ -0,0 +1,345 @@
+// Package openaire provides functions to convert OpenAIRE Research Graph metadata to the commonmeta metadata format.
+package openaire
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/langutils"
+	"github.com/front-matter/commonmeta/spdxutils"
+	"github.com/front-matter/commonmeta/utils"
+)
+
+// Content represents an OpenAIRE Research Graph result, as found in the
+// OpenAIRE Graph dumps.
+type Content struct {
+	ID              string      `json:"id"`
+	Type            string      `json:"type"`
+	Pid             []PID       `json:"pid"`
+	MainTitle       string      `json:"maintitle"`
+	SubTitle        string      `json:"subtitle"`
+	Author          []Author    `json:"author"`
+	Description     []string    `json:"description"`
+	PublicationDate string      `json:"publicationdate"`
+	Publisher       string      `json:"publisher"`
+	Language        Language    `json:"language"`
+	Subjects        []Subject   `json:"subjects"`
+	BestAccessRight AccessRight `json:"bestaccessright"`
+	Container       Container   `json:"container"`
+	Instance        []Instance  `json:"instance"`
+	Projects        []Project   `json:"projects"`
+	Version         string      `json:"version"`
+}
+
+// PID represents a persistent identifier with its scheme.
+type PID struct {
+	Scheme string `json:"scheme"`
+	Value  string `json:"value"`
+}
+
+// Author represents an author of an OpenAIRE result.
+type Author struct {
+	FullName string     `json:"fullname"`
+	Name     string     `json:"name"`
+	Surname  string     `json:"surname"`
+	Rank     int        `json:"rank"`
+	Pid      *AuthorPID `json:"pid"`
+}
+
+// AuthorPID represents the persistent identifier of an author, e.g. an ORCID.
+type AuthorPID struct {
+	ID PID `json:"id"`
+}
+
+// Language represents the language of an OpenAIRE result.
+type Language struct {
+	Code  string `json:"code"`
+	Label string `json:"label"`
+}
+
+// Subject represents a subject with its scheme.
+type Subject struct {
+	Subject PID `json:"subject"`
+}
+
+// AccessRight represents an access right from the COAR access rights vocabulary.
+type AccessRight struct {
+	Code   string `json:"code"`
+	Label  string `json:"label"`
+	Scheme string `json:"scheme"`
+}
+
+// Container represents the journal an OpenAIRE result is published in.
+type Container struct {
+	Name        string `json:"name"`
+	IssnPrinted string `json:"issnPrinted"`
+	IssnOnline  string `json:"issnOnline"`
+	Vol         string `json:"vol"`
+	Iss         string `json:"iss"`
+	Sp          string `json:"sp"`
+	Ep          string `json:"ep"`
+}
+
+// Instance represents a manifestation of an OpenAIRE result, e.g. in a repository.
+type Instance struct {
+	Type        string      `json:"type"`
+	URL         []string    `json:"url"`
+	License     string      `json:"license"`
+	AccessRight AccessRight `json:"accessright"`
+	Pid         []PID       `json:"pid"`
+}
+
+// Project represents a project that funded an OpenAIRE result.
+type Project struct {
+	ID      string `json:"id"`
+	Code    string `json:"code"`
+	Acronym string `json:"acronym"`
+	Title   string `json:"title"`
+	Funder  Funder `json:"funder"`
+}
+
+// Funder represents the funder of a project.
+type Funder struct {
+	ShortName     string `json:"shortName"`
+	Name          string `json:"name"`
+	Jurisdiction  string `json:"jurisdiction"`
+	FundingStream string `json:"fundingStream"`
+}
+
+// OpenAIREToCMMappings maps OpenAIRE instance types to Commonmeta types
+// source: https://api.openaire.eu/vocabularies/dnet:publication_resource
+var OpenAIREToCMMappings = map[string]string{
+	"Article":                         "JournalArticle",
+	"Audio":                           "Audiovisual",
+	"Bachelor thesis":                 "Dissertation",
+	"Book":                            "Book",
+	"Conference object":               "ProceedingsArticle",
+	"Data Paper":                      "JournalArticle",
+	"Dataset":                         "Dataset",
+	"Doctoral thesis":                 "Dissertation",
+	"Film":                            "Audiovisual",
+	"Image":                           "Image",
+	"Lecture":                         "Presentation",
+	"Master thesis":                   "Dissertation",
+	"Part of book or chapter of book": "BookChapter",
+	"Preprint":                        "Article",
+	"Report":                          "Report",
+	"Review":                          "PeerReview",
+	"Software":                        "Software",
+	"Software Paper":                  "JournalArticle",
+	"Thesis":                          "Dissertation",
+}
+
+// ResultTypeMappings maps OpenAIRE result types to Commonmeta types, used if the
+// instance type is not known.
+var ResultTypeMappings = map[string]string{
+	"publication": "Document",
+	"dataset":     "Dataset",
+	"software":    "Software",
+	"other":       "Other",
+}
+
+// FunderIdentifiers maps the short names of OpenAIRE funders to Crossref Funder IDs
+var FunderIdentifiers = map[string]string{
+	"DFG":  "https://doi.org/10.13039/501100001659",
+	"EC":   "https://doi.org/10.13039/501100000780",
+	"NIH":  "https://doi.org/10.13039/100000002",
+	"NSF":  "https://doi.org/10.13039/100000001",
+	"SNSF": "https://doi.org/10.13039/501100001711",
+}
+
+// Load loads the metadata for a single result from an OpenAIRE JSON file
+func Load(filename string) (commonmeta.Data, error) {
+	var data commonmeta.Data
+
+	content, err := ReadJSON(filename)
+	if err != nil {
+		return data, err
+	}
+	data, err = Read(content)
+	if err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// ReadJSON reads an OpenAIRE result from a JSON file.
+func ReadJSON(filename string) (Content, error) {
+	var content Content
+
+	extension := path.Ext(filename)
+	if extension != ".json" {
+		return content, errors.New("invalid file extension")
+	}
+	file, err := os.Open(filename)
+	if err != nil {
+		return content, errors.New("error reading file")
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	err = decoder.Decode(&content)
+	if err != nil {
+		return content, err
+	}
+	return content, nil
+}
+
+// Read reads an OpenAIRE result and converts it to commonmeta. As commonmeta
+// has no access rights, the best access right is used to select the instance
+// that provides the URL and license.
+func Read(content Content) (commonmeta.Data, error) {
+	var data commonmeta.Data
+
+	for _, v := range content.Pid {
+		if v.Scheme == "doi" {
+			data.ID = doiutils.NormalizeDOI(v.Value)
+			break
+		}
+	}
+	if data.ID != "" {
+		data.Identifiers = append(data.Identifiers, commonmeta.Identifier{
+			Identifier:     data.ID,
+			IdentifierType: "DOI",
+		})
+	}
+
+	instance := getBestInstance(content)
+	if instance != nil {
+		if len(instance.URL) > 0 {
+			data.URL = instance.URL[0]
+		}
+		id, url := spdxutils.Normalize(instance.License)
+		if id != "" {
+			data.License = commonmeta.License{
+				ID:  id,
+				URL: url,
+			}
+		}
+	}
+	if data.ID == "" {
+		data.ID = data.URL
+	}
+
+	if len(content.Instance) > 0 {
+		data.Type = OpenAIREToCMMappings[content.Instance[0].Type]
+	}
+	if data.Type == "" {
+		data.Type = ResultTypeMappings[content.Type]
+	}
+	if data.Type == "" {
+		data.Type = "Other"
+	}
+
+	for _, v := range content.Author {
+		data.Contributors = append(data.Contributors, GetContributor(v))
+	}
+
+	if content.MainTitle != "" {
+		data.Titles = append(data.Titles, commonmeta.Title{Title: content.MainTitle})
+	}
+	if content.SubTitle != "" {
+		data.Titles = append(data.Titles, commonmeta.Title{Title: content.SubTitle, Type: "Subtitle"})
+	}
+	for _, v := range content.Description {
+		data.Descriptions = append(data.Descriptions, commonmeta.Description{
+			Description: utils.Sanitize(v),
+			Type:        "Abstract",
+		})
+	}
+	data.Date.Published = content.PublicationDate
+	data.Language = langutils.GetLanguage(content.Language.Code, langutils.ISO6391)
+	if content.Publisher != "" {
+		data.Publisher = commonmeta.Publisher{Name: content.Publisher}
+	}
+	for _, v := range content.Subjects {
+		if v.Subject.Value != "" {
+			data.Subjects = append(data.Subjects, commonmeta.Subject{Subject: v.Subject.Value})
+		}
+	}
+
+	if content.Container.Name != "" {
+		data.Container = commonmeta.Container{
+			Type:      "Journal",
+			Title:     content.Container.Name,
+			Volume:    content.Container.Vol,
+			Issue:     content.Container.Iss,
+			FirstPage: content.Container.Sp,
+			LastPage:  content.Container.Ep,
+		}
+		issn := content.Container.IssnOnline
+		if issn == "" {
+			issn = content.Container.IssnPrinted
+		}
+		if issn != "" {
+			data.Container.Identifier = issn
+			data.Container.IdentifierType = "ISSN"
+		}
+	}
+
+	for _, v := range content.Projects {
+		data.FundingReferences = append(data.FundingReferences, GetFundingReference(v))
+	}
+
+	data.Version = content.Version
+
+	return data, nil
+}
+
+// GetContributor converts an OpenAIRE author into the Commonmeta format
+func GetContributor(v Author) commonmeta.Contributor {
+	contributor := commonmeta.Contributor{
+		Type:             "Person",
+		ContributorRoles: []string{"Author"},
+	}
+	if v.Pid != nil && strings.HasPrefix(v.Pid.ID.Scheme, "orcid") {
+		contributor.ID = utils.NormalizeORCID(v.Pid.ID.Value)
+	}
+	if v.Surname != "" {
+		contributor.GivenName = v.Name
+		contributor.FamilyName = v.Surname
+	} else {
+		contributor.Name = v.FullName
+	}
+	return contributor
+}
+
+// GetFundingReference converts an OpenAIRE project into a Commonmeta funding
+// reference. Projects funded by the European Commission link to CORDIS.
+func GetFundingReference(v Project) commonmeta.FundingReference {
+	fundingReference := commonmeta.FundingReference{
+		FunderName:  v.Funder.Name,
+		AwardNumber: v.Code,
+	}
+	if fundingReference.FunderName == "" {
+		fundingReference.FunderName = v.Funder.ShortName
+	}
+	funderIdentifier, ok := FunderIdentifiers[v.Funder.ShortName]
+	if ok {
+		fundingReference.FunderIdentifier = funderIdentifier
+		fundingReference.FunderIdentifierType = "Crossref Funder ID"
+	}
+	if v.Funder.ShortName == "EC" && v.Code != "" {
+		fundingReference.AwardURI = "https://cordis.europa.eu/project/id/" + v.Code
+	}
+	return fundingReference
+}
+
+// getBestInstance returns the first instance with the best access right of
+// the result, or the first instance.
+func getBestInstance(content Content) *Instance {
+	if len(content.Instance) == 0 {
+		return nil
+	}
+	for i, v := range content.Instance {
+		if v.AccessRight.Code != "" && v.AccessRight.Code == content.BestAccessRight.Code {
+			return &content.Instance[i]
+		}
+	}
+	return &content.Instance[0]
+}
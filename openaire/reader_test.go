@@ -0,0 +1,87 @@
+package openaire_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/openaire"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name string
+		want commonmeta.Data
+	}
+
+	testCases := []testCase{
+		{
+			name: "openaire-publication.json",
+			want: commonmeta.Data{
+				ID:   "https://doi.org/10.5555/fairsfair.2020.001",
+				Type: "Report",
+				Contributors: []commonmeta.Contributor{
+					{ID: "https://orcid.org/0000-0002-1825-0097", Type: "Person", GivenName: "Josiah", FamilyName: "Carberry", ContributorRoles: []string{"Author"}},
+					{Type: "Person", GivenName: "Jane", FamilyName: "Doe", ContributorRoles: []string{"Author"}},
+				},
+				Date:         commonmeta.Date{Published: "2020-10-12"},
+				Descriptions: []commonmeta.Description{{Description: "This document describes a set of metrics for the automated assessment of <em>FAIR</em> data objects.", Type: "Abstract"}},
+				FundingReferences: []commonmeta.FundingReference{
+					{FunderIdentifier: "https://doi.org/10.13039/501100000780", FunderIdentifierType: "Crossref Funder ID", FunderName: "European Commission", AwardNumber: "831558", AwardURI: "https://cordis.europa.eu/project/id/831558"},
+					{FunderIdentifier: "https://doi.org/10.13039/100000001", FunderIdentifierType: "Crossref Funder ID", FunderName: "National Science Foundation", AwardNumber: "1839030"},
+				},
+				Identifiers: []commonmeta.Identifier{{Identifier: "https://doi.org/10.5555/fairsfair.2020.001", IdentifierType: "DOI"}},
+				Language:    "en",
+				License:     commonmeta.License{ID: "CC-BY-4.0", URL: "https://creativecommons.org/licenses/by/4.0/legalcode"},
+				Publisher:   commonmeta.Publisher{Name: "Zenodo"},
+				Subjects:    []commonmeta.Subject{{Subject: "FAIR data"}, {Subject: "research data management"}},
+				Titles: []commonmeta.Title{
+					{Title: "FAIR Data Object Assessment Metrics"},
+					{Title: "Specification for automated assessment", Type: "Subtitle"},
+				},
+				URL: "https://zenodo.org/records/4081213",
+			},
+		},
+	}
+	for _, tc := range testCases {
+		got, err := openaire.Load(filepath.Join("testdata", tc.name))
+		if err != nil {
+			t.Fatalf("OpenAIRE Load (%v): error %v", tc.name, err)
+		}
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("OpenAIRE Load (%v) mismatch (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestGetFundingReference(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name    string
+		project openaire.Project
+		want    commonmeta.FundingReference
+	}
+
+	testCases := []testCase{
+		{
+			name:    "European Commission",
+			project: openaire.Project{Code: "101017536", Funder: openaire.Funder{ShortName: "EC", Name: "European Commission", FundingStream: "H2020"}},
+			want:    commonmeta.FundingReference{FunderIdentifier: "https://doi.org/10.13039/501100000780", FunderIdentifierType: "Crossref Funder ID", FunderName: "European Commission", AwardNumber: "101017536", AwardURI: "https://cordis.europa.eu/project/id/101017536"},
+		},
+		{
+			name:    "unknown funder with short name only",
+			project: openaire.Project{Code: "A-123", Funder: openaire.Funder{ShortName: "XYZ"}},
+			want:    commonmeta.FundingReference{FunderName: "XYZ", AwardNumber: "A-123"},
+		},
+	}
+	for _, tc := range testCases {
+		got := openaire.GetFundingReference(tc.project)
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("OpenAIRE GetFundingReference (%v) mismatch (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
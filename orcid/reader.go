@@ -0,0 +1,297 @@
+// Package orcid provides functions to convert the works of an ORCID record to
+// the commonmeta metadata format, using the ORCID public API.
+package orcid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/front-matter/commonmeta/authorutils"
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/crossref"
+	"github.com/front-matter/commonmeta/datacite"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/orcidutils"
+	"github.com/front-matter/commonmeta/utils"
+)
+
+// Works represents the works summary of an ORCID record, with works from
+// different sources describing the same work grouped together.
+type Works struct {
+	Group []struct {
+		WorkSummary []Work `json:"work-summary"`
+	} `json:"group"`
+}
+
+// Work represents a work in an ORCID record. The works summary contains the
+// same fields as the full work, except contributors and description.
+type Work struct {
+	PutCode          int              `json:"put-code"`
+	Title            Title            `json:"title"`
+	JournalTitle     Value            `json:"journal-title"`
+	ShortDescription string           `json:"short-description"`
+	Type             string           `json:"type"`
+	PublicationDate  PublicationDate  `json:"publication-date"`
+	ExternalIDs      ExternalIDs      `json:"external-ids"`
+	URL              Value            `json:"url"`
+	Contributors     WorkContributors `json:"contributors"`
+	LanguageCode     string           `json:"language-code"`
+}
+
+// Value represents a string value, which the ORCID API wraps in an object.
+type Value struct {
+	Value string `json:"value"`
+}
+
+// Title represents the title and subtitle of a work.
+type Title struct {
+	Title    Value `json:"title"`
+	Subtitle Value `json:"subtitle"`
+}
+
+// PublicationDate represents the publication date of a work, month and day
+// are optional.
+type PublicationDate struct {
+	Year  Value `json:"year"`
+	Month Value `json:"month"`
+	Day   Value `json:"day"`
+}
+
+// ExternalIDs represents the identifiers of a work, e.g. a DOI.
+type ExternalIDs struct {
+	ExternalID []struct {
+		Type         string `json:"external-id-type"`
+		Value        string `json:"external-id-value"`
+		Relationship string `json:"external-id-relationship"`
+	} `json:"external-id"`
+}
+
+// WorkContributors represents the contributors of a work.
+type WorkContributors struct {
+	Contributor []struct {
+		ContributorORCID struct {
+			URI string `json:"uri"`
+		} `json:"contributor-orcid"`
+		CreditName            Value `json:"credit-name"`
+		ContributorAttributes struct {
+			ContributorRole string `json:"contributor-role"`
+		} `json:"contributor-attributes"`
+	} `json:"contributor"`
+}
+
+// BaseURL is the base URL of the ORCID public API.
+var BaseURL = "https://pub.orcid.org/v3.0"
+
+// ORCIDToCMMappings maps ORCID work types to commonmeta types.
+var ORCIDToCMMappings = map[string]string{
+	"book":                           "Book",
+	"book-chapter":                   "BookChapter",
+	"conference-paper":               "ProceedingsArticle",
+	"conference-proceedings":         "Proceedings",
+	"data-set":                       "Dataset",
+	"dissertation-thesis":            "Dissertation",
+	"edited-book":                    "Book",
+	"journal-article":                "JournalArticle",
+	"journal-issue":                  "JournalIssue",
+	"other":                          "Other",
+	"physical-object":                "PhysicalObject",
+	"preprint":                       "Article",
+	"report":                         "Report",
+	"research-tool":                  "Software",
+	"software":                       "Software",
+	"standards-and-policy":           "Standard",
+	"supervised-student-publication": "Dissertation",
+	"working-paper":                  "Article",
+}
+
+// workers is the number of concurrent requests used by FetchWorks.
+const workers = 5
+
+// FetchWorks fetches the works of an ORCID record and converts them to the
+// Commonmeta format. Works with a Crossref or DataCite DOI are fetched from
+// the registration agency, all other works from the ORCID record. Works that
+// could not be fetched are skipped and their errors returned joined.
+func FetchWorks(str string) ([]commonmeta.Data, error) {
+	orcid, ok := orcidutils.ValidateORCID(str)
+	if !ok {
+		return nil, errors.New("invalid ORCID iD")
+	}
+	id := strings.TrimPrefix(orcid, "https://orcid.org/")
+	works, err := GetWorks(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// use the preferred source for each group of works, listed first
+	putCodes := make([]string, 0, len(works.Group))
+	summaries := make(map[string]Work)
+	for _, g := range works.Group {
+		if len(g.WorkSummary) == 0 {
+			continue
+		}
+		putCode := strconv.Itoa(g.WorkSummary[0].PutCode)
+		putCodes = append(putCodes, putCode)
+		summaries[putCode] = g.WorkSummary[0]
+	}
+	fetch := func(putCode string) (commonmeta.Data, error) {
+		summary := summaries[putCode]
+		if doi := getDOI(summary); doi != "" {
+			data, err := fetchDOI(doi)
+			if err == nil {
+				return data, nil
+			}
+		}
+		work, err := GetWork(id, putCode)
+		if err != nil {
+			return commonmeta.Data{}, err
+		}
+		return Read(work)
+	}
+	return commonmeta.FetchList(putCodes, workers, fetch)
+}
+
+// GetWorks gets the works summary of an ORCID record from the ORCID API.
+func GetWorks(orcid string) (Works, error) {
+	var works Works
+	err := getJSON(BaseURL+"/"+orcid+"/works", &works)
+	return works, err
+}
+
+// GetWork gets a work of an ORCID record by its put-code from the ORCID API.
+func GetWork(orcid string, putCode string) (Work, error) {
+	var work Work
+	err := getJSON(BaseURL+"/"+orcid+"/work/"+putCode, &work)
+	return work, err
+}
+
+// Read reads a work from an ORCID record and converts it to commonmeta. The
+// DOI is used as ID if the work has one, otherwise the URL of the work.
+func Read(work Work) (commonmeta.Data, error) {
+	var data commonmeta.Data
+
+	for _, v := range work.ExternalIDs.ExternalID {
+		if v.Relationship != "self" || v.Value == "" {
+			continue
+		}
+		identifierType := commonmeta.GetIdentifierType(v.Value, v.Type)
+		identifier := v.Value
+		if identifierType == "DOI" {
+			identifier = doiutils.NormalizeDOI(v.Value)
+		}
+		data.Identifiers = append(data.Identifiers, commonmeta.Identifier{Identifier: identifier, IdentifierType: identifierType})
+	}
+	data.ID = getDOI(work)
+	data.URL = work.URL.Value
+	if data.ID == "" {
+		data.ID = data.URL
+	}
+	if data.ID == "" {
+		return data, fmt.Errorf("no DOI or URL found for put-code %d", work.PutCode)
+	}
+
+	data.Type = ORCIDToCMMappings[work.Type]
+	if data.Type == "" {
+		data.Type = "Other"
+	}
+
+	for _, v := range work.Contributors.Contributor {
+		contributor := commonmeta.Contributor{
+			ID:               v.ContributorORCID.URI,
+			Type:             "Person",
+			ContributorRoles: []string{"Author"},
+		}
+		if role := v.ContributorAttributes.ContributorRole; role != "" && role != "author" {
+			contributor.ContributorRoles = []string{"Other"}
+		}
+		contributor.GivenName, contributor.FamilyName, contributor.Name = authorutils.ParseName(v.CreditName.Value)
+		data.Contributors = append(data.Contributors, contributor)
+	}
+
+	if work.Title.Title.Value != "" {
+		data.Titles = []commonmeta.Title{{Title: work.Title.Title.Value}}
+	}
+	if work.Title.Subtitle.Value != "" {
+		data.Titles = append(data.Titles, commonmeta.Title{Title: work.Title.Subtitle.Value, Type: "Subtitle"})
+	}
+	if work.ShortDescription != "" {
+		data.Descriptions = []commonmeta.Description{{Description: work.ShortDescription, Type: "Abstract"}}
+	}
+	data.Date.Published = getDate(work.PublicationDate)
+	if work.JournalTitle.Value != "" {
+		data.Container = commonmeta.Container{
+			Title: work.JournalTitle.Value,
+		}
+		if containerType, ok := commonmeta.ContainerTypes[data.Type]; ok {
+			data.Container.Type = containerType
+		}
+	}
+	data.Language = work.LanguageCode
+	data.Provider = "ORCID"
+	return data, nil
+}
+
+// fetchDOI fetches a DOI from Crossref or DataCite, depending on the
+// registration agency.
+func fetchDOI(doi string) (commonmeta.Data, error) {
+	ra, err := doiutils.RegistrationAgency(doi)
+	if err != nil {
+		return commonmeta.Data{}, err
+	}
+	switch ra {
+	case "Crossref":
+		return crossref.Fetch(doi)
+	case "DataCite":
+		return datacite.Fetch(doi)
+	}
+	return commonmeta.Data{}, fmt.Errorf("unsupported registration agency %s", ra)
+}
+
+// getDOI returns the DOI of a work as URL, ignoring the DOIs of works the
+// work is part of.
+func getDOI(work Work) string {
+	for _, v := range work.ExternalIDs.ExternalID {
+		if v.Type == "doi" && v.Relationship == "self" {
+			return doiutils.NormalizeDOI(v.Value)
+		}
+	}
+	return ""
+}
+
+// getDate returns the publication date as ISO 8601 date, with the precision
+// given.
+func getDate(date PublicationDate) string {
+	parts := []string{date.Year.Value, date.Month.Value, date.Day.Value}
+	for i, v := range parts {
+		if v == "" {
+			parts = parts[:i]
+			break
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// getJSON gets JSON from the ORCID API and unmarshals it into v.
+func getJSON(u string, v any) error {
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := utils.DoWithRetry(client, req, utils.DefaultRetry)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return errors.New(resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
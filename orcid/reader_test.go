@@ -0,0 +1,136 @@
+package orcid_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/crossref"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/orcid"
+	"github.com/google/go-cmp/cmp"
+)
+
+const works = `{
+  "group": [
+    {
+      "work-summary": [
+        {
+          "put-code": 1001,
+          "title": {"title": {"value": "Toward a Unified Theory of High-Energy Metaphysics"}, "subtitle": null},
+          "external-ids": {"external-id": [
+            {"external-id-type": "doi", "external-id-value": "10.5555/12345678", "external-id-relationship": "self"},
+            {"external-id-type": "issn", "external-id-value": "0264-3561", "external-id-relationship": "part-of"}
+          ]},
+          "type": "journal-article",
+          "publication-date": {"year": {"value": "2008"}, "month": {"value": "08"}, "day": {"value": "13"}}
+        },
+        {
+          "put-code": 1002,
+          "title": {"title": {"value": "Toward a Unified Theory of High-Energy Metaphysics"}},
+          "type": "journal-article"
+        }
+      ]
+    },
+    {
+      "work-summary": [
+        {
+          "put-code": 1003,
+          "title": {"title": {"value": "Psychoceramics for Beginners"}},
+          "external-ids": {"external-id": []},
+          "type": "book",
+          "publication-date": {"year": {"value": "2012"}, "month": null, "day": null}
+        }
+      ]
+    }
+  ]
+}`
+
+const work = `{
+  "put-code": 1003,
+  "title": {"title": {"value": "Psychoceramics for Beginners"}, "subtitle": {"value": "A Practical Guide"}},
+  "short-description": "An introduction to psychoceramics.",
+  "type": "book",
+  "publication-date": {"year": {"value": "2012"}, "month": null, "day": null},
+  "external-ids": {"external-id": [
+    {"external-id-type": "isbn", "external-id-value": "9780000000002", "external-id-relationship": "self"}
+  ]},
+  "url": {"value": "https://example.org/psychoceramics"},
+  "contributors": {"contributor": [
+    {
+      "contributor-orcid": {"uri": "https://orcid.org/0000-0002-1825-0097"},
+      "credit-name": {"value": "Josiah Carberry"},
+      "contributor-attributes": {"contributor-sequence": "first", "contributor-role": "author"}
+    }
+  ]},
+  "language-code": "en"
+}`
+
+func TestFetchWorks(t *testing.T) {
+	// not parallel, as the test changes the API base URLs
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orcid/0000-0002-1825-0097/works", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") != "application/json" {
+			http.Error(w, "not acceptable", http.StatusNotAcceptable)
+			return
+		}
+		fmt.Fprint(w, works)
+	})
+	mux.HandleFunc("/orcid/0000-0002-1825-0097/work/1003", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, work)
+	})
+	mux.HandleFunc("/ra/10.5555/12345678", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"DOI": "10.5555/12345678", "RA": "Crossref"}]`)
+	})
+	mux.HandleFunc("/works/10.5555/12345678", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"status": "ok", "message-type": "work", "message": {"DOI": "10.5555/12345678", "type": "journal-article", "title": ["Toward a Unified Theory of High-Energy Metaphysics"]}}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	baseURL, crossrefURL, raURL := orcid.BaseURL, crossref.BaseURL, doiutils.RAURL
+	orcid.BaseURL = server.URL + "/orcid"
+	crossref.BaseURL = server.URL
+	doiutils.RAURL = server.URL + "/ra/"
+	defer func() {
+		orcid.BaseURL, crossref.BaseURL, doiutils.RAURL = baseURL, crossrefURL, raURL
+	}()
+
+	got, err := orcid.FetchWorks("https://orcid.org/0000-0002-1825-0097")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("FetchWorks: want 2 works, got %d", len(got))
+	}
+	if got[0].ID != "https://doi.org/10.5555/12345678" || got[0].Provider != "Crossref" {
+		t.Errorf("FetchWorks: want work fetched from Crossref, got %v from %v", got[0].ID, got[0].Provider)
+	}
+	want := commonmeta.Data{
+		ID:   "https://example.org/psychoceramics",
+		Type: "Book",
+		Contributors: []commonmeta.Contributor{
+			{ID: "https://orcid.org/0000-0002-1825-0097", Type: "Person", GivenName: "Josiah", FamilyName: "Carberry", ContributorRoles: []string{"Author"}},
+		},
+		Date:         commonmeta.Date{Published: "2012"},
+		Descriptions: []commonmeta.Description{{Description: "An introduction to psychoceramics.", Type: "Abstract"}},
+		Identifiers:  []commonmeta.Identifier{{Identifier: "9780000000002", IdentifierType: "ISBN"}},
+		Language:     "en",
+		Provider:     "ORCID",
+		Titles: []commonmeta.Title{
+			{Title: "Psychoceramics for Beginners"},
+			{Title: "A Practical Guide", Type: "Subtitle"},
+		},
+		URL: "https://example.org/psychoceramics",
+	}
+	if diff := cmp.Diff(want, got[1]); diff != "" {
+		t.Errorf("FetchWorks mismatch (-want +got):\n%s", diff)
+	}
+
+	_, err = orcid.FetchWorks("0000-0002-1825-0098")
+	if err == nil {
+		t.Error("FetchWorks(0000-0002-1825-0098): want error for invalid checksum, got nil")
+	}
+}
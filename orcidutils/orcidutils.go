@@ -0,0 +1,124 @@
+// Package orcidutils provides a set of functions to work with ORCID iDs
+package orcidutils
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/front-matter/commonmeta/utils"
+)
+
+// BaseURL is the base URL of the ORCID public API.
+var BaseURL = "https://pub.orcid.org/v3.0"
+
+// Option configures requests to the ORCID public API.
+type Option func(*options)
+
+type options struct {
+	baseURL string
+}
+
+// getOptions returns the options with defaults applied.
+func getOptions(opts []Option) options {
+	o := options{baseURL: BaseURL}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithBaseURL sets the base URL of the ORCID public API, the default is BaseURL.
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
+// SearchResult represents a record found with the ORCID expanded search.
+type SearchResult struct {
+	ORCID           string   `json:"orcid-id"`
+	GivenNames      string   `json:"given-names"`
+	FamilyNames     string   `json:"family-names"`
+	CreditName      string   `json:"credit-name"`
+	OtherNames      []string `json:"other-name"`
+	InstitutionName []string `json:"institution-name"`
+}
+
+// ValidateORCID validates an ORCID iD, given either as ID or as URL, and
+// returns it as https://orcid.org/ URL. The checksum is validated by
+// utils.ValidateORCID.
+func ValidateORCID(orcid string) (string, bool) {
+	id, ok := utils.ValidateORCID(orcid)
+	if !ok {
+		return "", false
+	}
+	return "https://orcid.org/" + id, true
+}
+
+// CheckDigit calculates the ORCID check digit for the first 15 digits of an
+// ORCID iD. It is the same as utils.ORCIDCheckDigit.
+func CheckDigit(digits string) string {
+	return utils.ORCIDCheckDigit(digits)
+}
+
+// Search searches the ORCID public API for records with the given and family
+// name, and the affiliation if not empty, returning at most 10 records.
+// Responses are cached if cache is not nil.
+func Search(givenName string, familyName string, affiliation string, cache *utils.Cache, opts ...Option) ([]SearchResult, error) {
+	terms := []string{"family-name:" + quote(familyName)}
+	if givenName != "" {
+		terms = append(terms, "given-names:"+quote(givenName))
+	}
+	if affiliation != "" {
+		terms = append(terms, "affiliation-org-name:"+quote(affiliation))
+	}
+	query := strings.Join(terms, " AND ")
+	key := "orcid.org/search/" + query
+	body, ok := cache.Get(key)
+	if !ok {
+		client := &http.Client{
+			Timeout: 10 * time.Second,
+		}
+		u := getOptions(opts).baseURL + "/expanded-search/?rows=10&q=" + url.QueryEscape(query)
+		req, err := http.NewRequest(http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/json")
+		resp, err := utils.DoWithRetry(client, req, utils.DefaultRetry)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= 400 {
+			return nil, errors.New(resp.Status)
+		}
+		body, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+		err = cache.Set(key, body)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	var result struct {
+		ExpandedResult []SearchResult `json:"expanded-result"`
+	}
+	err := json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, err
+	}
+	return result.ExpandedResult, nil
+}
+
+// quote quotes a search term as phrase, removing quotes in the term.
+func quote(str string) string {
+	return `"` + strings.ReplaceAll(strings.TrimSpace(str), `"`, "") + `"`
+}
@@ -0,0 +1,44 @@
+package orcidutils_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/front-matter/commonmeta/orcidutils"
+)
+
+func TestValidateORCID(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input string
+		want  string
+	}
+	testCases := []testCase{
+		{input: "0000-0002-1825-0097", want: "https://orcid.org/0000-0002-1825-0097"},
+		{input: "https://orcid.org/0000-0002-1825-0097", want: "https://orcid.org/0000-0002-1825-0097"},
+		{input: "http://orcid.org/0000-0002-1825-0097", want: "https://orcid.org/0000-0002-1825-0097"},
+		{input: "https://sandbox.orcid.org/0000-0002-1825-0097", want: "https://orcid.org/0000-0002-1825-0097"},
+		{input: "0000 0002 1825 0097", want: "https://orcid.org/0000-0002-1825-0097"},
+		{input: "0000-0002-1694-233X", want: "https://orcid.org/0000-0002-1694-233X"},
+		{input: "https://orcid.org/0000-0002-1694-233x", want: "https://orcid.org/0000-0002-1694-233X"},
+		{input: "0000-0002-1825-0098", want: ""},
+		{input: "0000-0002-1694-2330", want: ""},
+		{input: "0000-0002-1825", want: ""},
+		{input: "https://www.example.org/0000-0002-1825-0097", want: ""},
+		{input: "", want: ""},
+	}
+	for _, tc := range testCases {
+		got, ok := orcidutils.ValidateORCID(tc.input)
+		if tc.want != got {
+			t.Errorf("Validate ORCID(%v): want %v, got %v, ok %v",
+				tc.input, tc.want, got, ok)
+		}
+	}
+}
+
+func ExampleCheckDigit() {
+	s := orcidutils.CheckDigit("000000021694233")
+	fmt.Println(s)
+	// Output:
+	// X
+}
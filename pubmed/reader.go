@@ -0,0 +1,369 @@
+// Package pubmed provides functions to convert PubMed metadata to the
+// commonmeta metadata format, using the NCBI ID Converter and E-utilities APIs.
+package pubmed
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/langutils"
+	"github.com/front-matter/commonmeta/utils"
+)
+
+// Content represents a PubMed document summary returned by the E-utilities
+// esummary API.
+type Content struct {
+	UID             string      `json:"uid"`
+	PubDate         string      `json:"pubdate"`
+	SortPubDate     string      `json:"sortpubdate"`
+	Source          string      `json:"source"`
+	Authors         []Author    `json:"authors"`
+	Title           string      `json:"title"`
+	Volume          string      `json:"volume"`
+	Issue           string      `json:"issue"`
+	Pages           string      `json:"pages"`
+	Lang            []string    `json:"lang"`
+	ISSN            string      `json:"issn"`
+	ESSN            string      `json:"essn"`
+	PubType         []string    `json:"pubtype"`
+	ArticleIDs      []ArticleID `json:"articleids"`
+	FullJournalName string      `json:"fulljournalname"`
+	ELocationID     string      `json:"elocationid"`
+}
+
+// Author represents an author in a PubMed document summary, with the name
+// given as family name and initials, e.g. "Sankar M".
+type Author struct {
+	Name     string `json:"name"`
+	AuthType string `json:"authtype"`
+}
+
+// ArticleID represents an identifier of a PubMed article, e.g. a DOI.
+type ArticleID struct {
+	IDType string `json:"idtype"`
+	Value  string `json:"value"`
+}
+
+// IDs are the identifiers of an article returned by the NCBI ID Converter.
+type IDs struct {
+	PMID  string `json:"pmid"`
+	PMCID string `json:"pmcid"`
+	DOI   string `json:"doi"`
+}
+
+// ConverterURL is the URL of the NCBI ID Converter API.
+var ConverterURL = "https://www.ncbi.nlm.nih.gov/pmc/utils/idconv/v1.0/"
+
+// EutilsURL is the base URL of the NCBI E-utilities API.
+var EutilsURL = "https://eutils.ncbi.nlm.nih.gov/entrez/eutils"
+
+// Option configures how articles are fetched from the NCBI APIs.
+type Option func(*options)
+
+type options struct {
+	converterURL string
+	eutilsURL    string
+}
+
+// getOptions returns the options with defaults applied.
+func getOptions(opts []Option) options {
+	o := options{converterURL: ConverterURL, eutilsURL: EutilsURL}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithConverterURL sets the URL of the NCBI ID Converter API, the default is
+// ConverterURL.
+func WithConverterURL(converterURL string) Option {
+	return func(o *options) {
+		o.converterURL = converterURL
+	}
+}
+
+// WithEutilsURL sets the base URL of the NCBI E-utilities API, the default
+// is EutilsURL.
+func WithEutilsURL(eutilsURL string) Option {
+	return func(o *options) {
+		o.eutilsURL = eutilsURL
+	}
+}
+
+// PubTypeToCMMappings maps PubMed publication types to commonmeta types.
+var PubTypeToCMMappings = map[string]string{
+	"Dataset":         "Dataset",
+	"Editorial":       "JournalArticle",
+	"Journal Article": "JournalArticle",
+	"Letter":          "JournalArticle",
+	"Preprint":        "Article",
+	"Review":          "JournalArticle",
+}
+
+var (
+	pmidRegexp  = regexp.MustCompile(`^\d+$`)
+	pmcidRegexp = regexp.MustCompile(`(?i)^PMC\d+$`)
+)
+
+// Fetch fetches the metadata for a PubMed ID (PMID) or PubMed Central ID
+// (PMCID), and converts it to the Commonmeta format. The DOI is used as ID
+// if the article has one.
+func Fetch(str string, opts ...Option) (commonmeta.Data, error) {
+	var data commonmeta.Data
+	id := strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(str, "https://pubmed.ncbi.nlm.nih.gov/"), "pmid:"))
+	id = strings.TrimSuffix(id, "/")
+	if !pmidRegexp.MatchString(id) && !pmcidRegexp.MatchString(id) {
+		return data, errors.New("invalid PMID or PMCID")
+	}
+	// the ID Converter only knows articles in PubMed Central
+	ids, err := Convert(id, opts...)
+	if err != nil {
+		if !pmidRegexp.MatchString(id) {
+			return data, err
+		}
+		ids = IDs{PMID: id}
+	}
+	content, err := Get(ids.PMID, opts...)
+	if err != nil {
+		return data, err
+	}
+	data, err = Read(content)
+	if err != nil {
+		return data, err
+	}
+
+	// the ID Converter knows DOIs missing in the document summary
+	if doi := doiutils.NormalizeDOI(ids.DOI); doi != "" && !strings.HasPrefix(data.ID, "https://doi.org/") {
+		data.ID = doi
+		data.Identifiers = append([]commonmeta.Identifier{{Identifier: doi, IdentifierType: "DOI"}}, data.Identifiers...)
+	}
+	return data, nil
+}
+
+// Convert resolves a PMID or PMCID to the PMID, PMCID and DOI of the article
+// using the NCBI ID Converter.
+func Convert(id string, opts ...Option) (IDs, error) {
+	// the envelope for the JSON response from the ID Converter
+	type Response struct {
+		Status  string `json:"status"`
+		Records []struct {
+			IDs
+			Status string `json:"status"`
+			ErrMsg string `json:"errmsg"`
+		} `json:"records"`
+	}
+
+	var response Response
+	u, _ := url.Parse(getOptions(opts).converterURL)
+	values := u.Query()
+	values.Add("ids", id)
+	values.Add("format", "json")
+	values.Add("tool", "commonmeta")
+	u.RawQuery = values.Encode()
+	err := getJSON(u.String(), &response)
+	if err != nil {
+		return IDs{}, err
+	}
+	if len(response.Records) == 0 {
+		return IDs{}, fmt.Errorf("%s not found", id)
+	}
+	record := response.Records[0]
+	if record.Status == "error" {
+		return IDs{}, fmt.Errorf("%s: %s", id, record.ErrMsg)
+	}
+	if record.PMID == "" && pmidRegexp.MatchString(id) {
+		record.PMID = id
+	}
+	if record.PMID == "" {
+		return IDs{}, fmt.Errorf("no PMID found for %s", id)
+	}
+	return record.IDs, nil
+}
+
+// Get gets the document summary for a PMID from the E-utilities API.
+func Get(pmid string, opts ...Option) (Content, error) {
+	// the envelope for the JSON response from the esummary API, the
+	// document summaries are keyed by PMID
+	type Response struct {
+		Result map[string]json.RawMessage `json:"result"`
+	}
+
+	var response Response
+	var content Content
+	u, _ := url.Parse(getOptions(opts).eutilsURL + "/esummary.fcgi")
+	values := u.Query()
+	values.Add("db", "pubmed")
+	values.Add("id", pmid)
+	values.Add("retmode", "json")
+	values.Add("tool", "commonmeta")
+	u.RawQuery = values.Encode()
+	err := getJSON(u.String(), &response)
+	if err != nil {
+		return content, err
+	}
+	raw, ok := response.Result[pmid]
+	if !ok {
+		return content, fmt.Errorf("PMID %s not found", pmid)
+	}
+	err = json.Unmarshal(raw, &content)
+	if err != nil {
+		return content, err
+	}
+	if content.UID == "" {
+		return content, fmt.Errorf("PMID %s not found", pmid)
+	}
+	return content, nil
+}
+
+// Read reads a PubMed document summary and converts it to commonmeta.
+func Read(content Content) (commonmeta.Data, error) {
+	var data commonmeta.Data
+
+	pmidURL := "https://pubmed.ncbi.nlm.nih.gov/" + content.UID + "/"
+	var doi, pmcid string
+	for _, v := range content.ArticleIDs {
+		switch v.IDType {
+		case "doi":
+			doi = doiutils.NormalizeDOI(v.Value)
+		case "pmc":
+			pmcid = v.Value
+		}
+	}
+	if doi == "" {
+		doi = doiutils.NormalizeDOI(strings.TrimPrefix(content.ELocationID, "doi: "))
+	}
+	data.ID = doi
+	if data.ID == "" {
+		data.ID = pmidURL
+	} else {
+		data.Identifiers = append(data.Identifiers, commonmeta.Identifier{Identifier: doi, IdentifierType: "DOI"})
+	}
+	data.Identifiers = append(data.Identifiers, commonmeta.Identifier{Identifier: content.UID, IdentifierType: "PMID"})
+	if pmcid != "" {
+		data.Identifiers = append(data.Identifiers, commonmeta.Identifier{Identifier: pmcid, IdentifierType: "PMCID"})
+	}
+	data.URL = pmidURL
+
+	data.Type = "JournalArticle"
+	for _, v := range content.PubType {
+		if t, ok := PubTypeToCMMappings[v]; ok {
+			data.Type = t
+			break
+		}
+	}
+
+	for _, v := range content.Authors {
+		contributor := commonmeta.Contributor{
+			ContributorRoles: []string{"Author"},
+		}
+		if v.AuthType == "CollectiveName" {
+			contributor.Type = "Organization"
+			contributor.Name = v.Name
+		} else {
+			contributor.Type = "Person"
+			contributor.GivenName, contributor.FamilyName = parseName(v.Name)
+		}
+		data.Contributors = append(data.Contributors, contributor)
+	}
+
+	if content.Title != "" {
+		// PubMed titles end with a period
+		data.Titles = []commonmeta.Title{{Title: strings.TrimSuffix(content.Title, ".")}}
+	}
+	data.Date.Published = getDate(content)
+
+	journal := content.FullJournalName
+	if journal == "" {
+		journal = content.Source
+	}
+	if journal != "" {
+		data.Container = commonmeta.Container{
+			Type:   "Journal",
+			Title:  journal,
+			Volume: content.Volume,
+			Issue:  content.Issue,
+		}
+		issn := content.ESSN
+		if issn == "" {
+			issn = content.ISSN
+		}
+		if issn != "" {
+			data.Container.Identifier = issn
+			data.Container.IdentifierType = "ISSN"
+		}
+		data.Container.SetPages(content.Pages)
+	}
+
+	if len(content.Lang) > 0 {
+		data.Language = langutils.GetLanguage(content.Lang[0], langutils.ISO6391)
+	}
+	data.Provider = "PubMed"
+	return data, nil
+}
+
+// parseName parses a PubMed author name given as family name and initials,
+// e.g. "Sankar M" or "van der Berg JA".
+func parseName(name string) (string, string) {
+	i := strings.LastIndex(name, " ")
+	if i == -1 {
+		return "", name
+	}
+	initials := name[i+1:]
+	if strings.ToUpper(initials) != initials || len(initials) > 3 {
+		return "", name
+	}
+	return initials, name[:i]
+}
+
+// getDate returns the publication date as ISO 8601 date, using the sortable
+// publication date, e.g. "2014/02/11 00:00", or the year of the publication
+// date.
+func getDate(content Content) string {
+	t, err := time.Parse("2006/01/02 15:04", content.SortPubDate)
+	if err == nil {
+		// dates without day or month are sorted as first day or month
+		parts := strings.Fields(content.PubDate)
+		switch {
+		case len(parts) == 1:
+			return t.Format("2006")
+		case len(parts) == 2:
+			if _, err := time.Parse("Jan", parts[1]); err == nil {
+				return t.Format("2006-01")
+			}
+			return t.Format("2006")
+		}
+		return t.Format("2006-01-02")
+	}
+	if len(content.PubDate) >= 4 && pmidRegexp.MatchString(content.PubDate[:4]) {
+		return content.PubDate[:4]
+	}
+	return ""
+}
+
+// getJSON gets JSON from an NCBI API and unmarshals it into v.
+func getJSON(u string, v any) error {
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := utils.DoWithRetry(client, req, utils.DefaultRetry)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 400 {
+		return errors.New(resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,97 @@
+package pubmed_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/pubmed"
+	"github.com/google/go-cmp/cmp"
+)
+
+const summary = `{"header":{"type":"esummary","version":"0.3"},"result":{"uids":["24567894"],"24567894":{"uid":"24567894","pubdate":"2014 Feb 11","sortpubdate":"2014/02/11 00:00","source":"Elife","authors":[{"name":"Sankar M","authtype":"Author"},{"name":"Nieminen K","authtype":"Author"},{"name":"Plant Consortium","authtype":"CollectiveName"}],"title":"Automated quantitative histology reveals vascular morphodynamics during Arabidopsis hypocotyl secondary growth.","volume":"3","issue":"","pages":"e01567","lang":["eng"],"issn":"","essn":"2050-084X","pubtype":["Journal Article"],"articleids":[{"idtype":"pubmed","value":"24567894"},{"idtype":"pmc","value":"PMC3917233"}],"fulljournalname":"eLife"}}}`
+
+// newServer returns a mock of the NCBI ID Converter and E-utilities APIs.
+func newServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/idconv/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("ids") {
+		case "24567894", "PMC3917233":
+			fmt.Fprint(w, `{"status":"ok","records":[{"pmcid":"PMC3917233","pmid":"24567894","doi":"10.7554/eLife.01567"}]}`)
+		default:
+			fmt.Fprint(w, `{"status":"ok","records":[{"pmid":"1","status":"error","errmsg":"invalid article id"}]}`)
+		}
+	})
+	mux.HandleFunc("/eutils/esummary.fcgi", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("id") != "24567894" {
+			fmt.Fprint(w, `{"result":{"uids":[]}}`)
+			return
+		}
+		fmt.Fprint(w, summary)
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetch(t *testing.T) {
+	t.Parallel()
+	server := newServer(t)
+	opts := []pubmed.Option{pubmed.WithConverterURL(server.URL + "/idconv/"), pubmed.WithEutilsURL(server.URL + "/eutils")}
+
+	want := commonmeta.Data{
+		ID:   "https://doi.org/10.7554/elife.01567",
+		Type: "JournalArticle",
+		URL:  "https://pubmed.ncbi.nlm.nih.gov/24567894/",
+		Identifiers: []commonmeta.Identifier{
+			{Identifier: "https://doi.org/10.7554/elife.01567", IdentifierType: "DOI"},
+			{Identifier: "24567894", IdentifierType: "PMID"},
+			{Identifier: "PMC3917233", IdentifierType: "PMCID"},
+		},
+		Contributors: []commonmeta.Contributor{
+			{Type: "Person", GivenName: "M", FamilyName: "Sankar", ContributorRoles: []string{"Author"}},
+			{Type: "Person", GivenName: "K", FamilyName: "Nieminen", ContributorRoles: []string{"Author"}},
+			{Type: "Organization", Name: "Plant Consortium", ContributorRoles: []string{"Author"}},
+		},
+		Titles:    []commonmeta.Title{{Title: "Automated quantitative histology reveals vascular morphodynamics during Arabidopsis hypocotyl secondary growth"}},
+		Date:      commonmeta.Date{Published: "2014-02-11"},
+		Container: commonmeta.Container{Identifier: "2050-084X", IdentifierType: "ISSN", Type: "Journal", Title: "eLife", FirstPage: "e01567", Volume: "3"},
+		Language:  "en",
+		Provider:  "PubMed",
+	}
+	for _, id := range []string{"24567894", "PMC3917233", "https://pubmed.ncbi.nlm.nih.gov/24567894/"} {
+		got, err := pubmed.Fetch(id, opts...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Errorf("Fetch(%s) mismatch (-want +got):\n%s", id, diff)
+		}
+	}
+
+	_, err := pubmed.Fetch("1", opts...)
+	if err == nil {
+		t.Error("Fetch(1): want error for unknown PMID, got nil")
+	}
+	_, err = pubmed.Fetch("10.7554/elife.01567", opts...)
+	if err == nil {
+		t.Error("Fetch(10.7554/elife.01567): want error for DOI, got nil")
+	}
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+	server := newServer(t)
+
+	got, err := pubmed.Convert("24567894", pubmed.WithConverterURL(server.URL+"/idconv/"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := pubmed.IDs{PMID: "24567894", PMCID: "PMC3917233", DOI: "10.7554/eLife.01567"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Convert mismatch (-want +got):\n%s", diff)
+	}
+}
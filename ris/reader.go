@@ -0,0 +1,340 @@
+// Package ris provides functions to convert RIS metadata to/from the commonmeta metadata format.
+package ris
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/front-matter/commonmeta/authorutils"
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/dateutils"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/langutils"
+	"github.com/front-matter/commonmeta/utils"
+)
+
+// Content represents a single RIS record as a map of tags to values.
+// Tags that can repeat, e.g. AU or KW, have multiple values.
+type Content map[string][]string
+
+// RISToCMMappings maps RIS types to commonmeta types
+var RISToCMMappings = map[string]string{
+	"ABST":   "Article",
+	"BLOG":   "Article",
+	"BOOK":   "Book",
+	"CHAP":   "BookChapter",
+	"COMP":   "Software",
+	"CONF":   "Proceedings",
+	"CPAPER": "ProceedingsArticle",
+	"CTLG":   "Collection",
+	"DATA":   "Dataset",
+	"DICT":   "Entry",
+	"EBOOK":  "Book",
+	"ECHAP":  "BookChapter",
+	"EJOUR":  "JournalArticle",
+	"ELEC":   "WebPage",
+	"ENCYC":  "Entry",
+	"FIGURE": "Figure",
+	"GEN":    "Other",
+	"JFULL":  "Journal",
+	"JOUR":   "JournalArticle",
+	"MAP":    "Map",
+	"MGZN":   "Article",
+	"NEWS":   "Article",
+	"PAT":    "Patent",
+	"PCOMM":  "PersonalCommunication",
+	"RPRT":   "Report",
+	"SOUND":  "Sound",
+	"STAND":  "Standard",
+	"THES":   "Dissertation",
+	"UNPB":   "Manuscript",
+	"VIDEO":  "Audiovisual",
+	"WEB":    "WebPage",
+}
+
+var tagRegexp = regexp.MustCompile(`^([A-Z][A-Z0-9])\s+-\s?(.*)$`)
+
+// Load loads the metadata for a single work from a RIS file
+func Load(filename string) (commonmeta.Data, error) {
+	var data commonmeta.Data
+
+	content, err := loadFile(filename)
+	if err != nil {
+		return data, err
+	}
+	if len(content) == 0 {
+		return data, errors.New("no RIS records found")
+	}
+	data, err = Read(content[0])
+	if err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// LoadAll loads a list of works from a RIS file and converts it to the Commonmeta format
+func LoadAll(filename string) ([]commonmeta.Data, error) {
+	var data []commonmeta.Data
+
+	content, err := loadFile(filename)
+	if err != nil {
+		return data, err
+	}
+	data, err = ReadAll(content)
+	if err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+func loadFile(filename string) ([]Content, error) {
+	extension := path.Ext(filename)
+	if extension != ".ris" {
+		return nil, errors.New("invalid file extension")
+	}
+	bytes, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, errors.New("error reading file")
+	}
+	return Parse(bytes)
+}
+
+// Parse parses RIS input into a list of records. Records start with a TY tag
+// and end with an ER tag. Lines without a tag continue the previous value.
+func Parse(input []byte) ([]Content, error) {
+	var list []Content
+	var content Content
+	var lastTag string
+
+	scanner := bufio.NewScanner(bytes.NewReader(input))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(strings.TrimPrefix(scanner.Text(), "\ufeff"), " \r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		match := tagRegexp.FindStringSubmatch(line)
+		if match == nil {
+			// continuation of a multi-line value
+			if content != nil && lastTag != "" {
+				values := content[lastTag]
+				values[len(values)-1] += " " + strings.TrimSpace(line)
+			}
+			continue
+		}
+		tag, value := match[1], strings.TrimSpace(match[2])
+		switch tag {
+		case "TY":
+			if content != nil {
+				list = append(list, content)
+			}
+			content = Content{"TY": {value}}
+		case "ER":
+			if content != nil {
+				list = append(list, content)
+				content = nil
+			}
+			// some exports omit the line break after the end-of-record marker
+			if m := tagRegexp.FindStringSubmatch(value); m != nil && m[1] == "TY" {
+				content = Content{"TY": {strings.TrimSpace(m[2])}}
+			}
+		default:
+			if content == nil {
+				return list, errors.New("RIS tag " + tag + " outside of record")
+			}
+			content[tag] = append(content[tag], value)
+		}
+		lastTag = tag
+	}
+	if err := scanner.Err(); err != nil {
+		return list, err
+	}
+	if content != nil {
+		list = append(list, content)
+	}
+	return list, nil
+}
+
+// Read reads a RIS record and converts it to commonmeta.
+func Read(content Content) (commonmeta.Data, error) {
+	var data commonmeta.Data
+
+	data.ID = doiutils.NormalizeDOI(content.Get("DO"))
+	if data.ID == "" {
+		data.ID = utils.NormalizeID(content.Get("UR"))
+	}
+	if data.ID == "" {
+		data.ID = content.Get("ID")
+	}
+
+	data.Type = RISToCMMappings[content.Get("TY")]
+	if data.Type == "" {
+		data.Type = "Other"
+	}
+
+	authors := slices.Concat(content["AU"], content["A1"])
+	for _, v := range authors {
+		contributor := parseName(v)
+		contributor.ContributorRoles = []string{"Author"}
+		data.Contributors = append(data.Contributors, contributor)
+	}
+	editors := slices.Concat(content["A2"], content["ED"])
+	for _, v := range editors {
+		contributor := parseName(v)
+		contributor.ContributorRoles = []string{"Editor"}
+		data.Contributors = append(data.Contributors, contributor)
+	}
+
+	containerTitle := content.Get("T2", "JO", "JF", "BT", "JA")
+	var identifier, identifierType string
+	if sn := content.Get("SN"); sn != "" {
+		identifier = sn
+		digits := strings.Map(func(r rune) rune {
+			if (r >= '0' && r <= '9') || r == 'X' || r == 'x' {
+				return r
+			}
+			return -1
+		}, sn)
+		if len(digits) > 8 {
+			identifierType = "ISBN"
+		} else {
+			identifierType = "ISSN"
+		}
+	}
+	data.Container = commonmeta.Container{
+		Identifier:     identifier,
+		IdentifierType: identifierType,
+		Type:           commonmeta.ContainerTypes[data.Type],
+		Title:          containerTitle,
+		Volume:         content.Get("VL"),
+		Issue:          content.Get("IS"),
+		FirstPage:      content.Get("SP"),
+		LastPage:       content.Get("EP"),
+	}
+	// some exports put the page range into SP
+	if data.Container.LastPage == "" && strings.Contains(data.Container.FirstPage, "-") {
+		pages := strings.SplitN(data.Container.FirstPage, "-", 2)
+		data.Container.FirstPage = strings.TrimSpace(pages[0])
+		data.Container.LastPage = strings.TrimSpace(pages[1])
+	}
+
+	data.Date.Published = getDate(content.Get("PY", "Y1", "DA"))
+
+	if abstract := content.Get("AB", "N2"); abstract != "" {
+		data.Descriptions = append(data.Descriptions, commonmeta.Description{
+			Description: utils.Sanitize(abstract),
+			Type:        "Abstract",
+		})
+	}
+
+	if doiutils.NormalizeDOI(data.ID) != "" {
+		data.Identifiers = append(data.Identifiers, commonmeta.Identifier{
+			Identifier:     data.ID,
+			IdentifierType: "DOI",
+		})
+	}
+
+	data.Language = langutils.GetLanguage(content.Get("LA"), langutils.ISO6391)
+
+	if publisher := content.Get("PB"); publisher != "" {
+		data.Publisher = commonmeta.Publisher{
+			Name: publisher,
+		}
+	}
+
+	for _, v := range content["KW"] {
+		if v != "" {
+			data.Subjects = append(data.Subjects, commonmeta.Subject{
+				Subject: v,
+			})
+		}
+	}
+
+	if title := content.Get("TI", "T1"); title != "" {
+		data.Titles = append(data.Titles, commonmeta.Title{
+			Title: title,
+		})
+	}
+
+	if url := content.Get("UR"); url != "" {
+		u, err := utils.NormalizeURL(url, true, false)
+		if err != nil {
+			u = url
+		}
+		data.URL = u
+	}
+
+	return data, nil
+}
+
+// ReadAll reads a list of RIS records and returns a list of works in Commonmeta format
+func ReadAll(content []Content) ([]commonmeta.Data, error) {
+	var data []commonmeta.Data
+	for _, v := range content {
+		d, err := Read(v)
+		if err != nil {
+			log.Println(err)
+		}
+		data = append(data, d)
+	}
+	return data, nil
+}
+
+// Get returns the first value found for the given tags, in order.
+func (c Content) Get(tags ...string) string {
+	for _, tag := range tags {
+		if len(c[tag]) > 0 && c[tag][0] != "" {
+			return c[tag][0]
+		}
+	}
+	return ""
+}
+
+// parseName parses a RIS name in "Family, Given" or "Given Family" format
+func parseName(name string) commonmeta.Contributor {
+	parts := strings.Split(name, ",")
+	if len(parts) > 1 {
+		// "Family, Given" or "Family, Given, Suffix"
+		return commonmeta.Contributor{
+			Type:       "Person",
+			GivenName:  strings.TrimSpace(parts[1]),
+			FamilyName: strings.TrimSpace(parts[0]),
+		}
+	}
+	givenName, familyName, orgName := authorutils.ParseName(name)
+	if orgName != "" {
+		return commonmeta.Contributor{
+			Type: "Organization",
+			Name: orgName,
+		}
+	}
+	return commonmeta.Contributor{
+		Type:       "Person",
+		GivenName:  givenName,
+		FamilyName: familyName,
+	}
+}
+
+// getDate returns an ISO 8601 date from a RIS date in YYYY/MM/DD/other format
+func getDate(str string) string {
+	var parts []int
+	for _, s := range strings.Split(str, "/") {
+		v, err := strconv.Atoi(strings.TrimSpace(s))
+		if err != nil || v == 0 {
+			break
+		}
+		parts = append(parts, v)
+	}
+	if len(parts) > 3 {
+		parts = parts[:3]
+	}
+	return dateutils.GetDateFromParts(parts...)
+}
@@ -0,0 +1,138 @@
+package ris_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/ris"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name      string
+		filename  string
+		id        string
+		type_     string
+		published string
+		author    commonmeta.Contributor
+		title     string
+	}
+
+	testCases := []testCase{
+		{
+			name:      "journal article",
+			filename:  "crossref.ris",
+			id:        "https://doi.org/10.7554/elife.01567",
+			type_:     "JournalArticle",
+			published: "2014",
+			author:    commonmeta.Contributor{Type: "Person", GivenName: "Martial", FamilyName: "Sankar", ContributorRoles: []string{"Author"}},
+			title:     "Automated quantitative histology reveals vascular morphodynamics during Arabidopsis hypocotyl secondary growth",
+		},
+		{
+			name:      "dissertation",
+			filename:  "pure.ris",
+			id:        "",
+			type_:     "Dissertation",
+			published: "2018-04-25",
+			author:    commonmeta.Contributor{Type: "Person", GivenName: "Y.", FamilyName: "Toparlar", ContributorRoles: []string{"Author"}},
+			title:     "A multiscale analysis of the urban heat island effect",
+		},
+	}
+	for _, tc := range testCases {
+		got, err := ris.Load(filepath.Join("testdata", tc.filename))
+		if err != nil {
+			t.Fatalf("RIS Load (%v): error %v", tc.filename, err)
+		}
+		if got.ID != tc.id {
+			t.Errorf("RIS Load ID (%v): want %v, got %v", tc.filename, tc.id, got.ID)
+		}
+		if got.Type != tc.type_ {
+			t.Errorf("RIS Load Type (%v): want %v, got %v", tc.filename, tc.type_, got.Type)
+		}
+		if got.Date.Published != tc.published {
+			t.Errorf("RIS Load Date (%v): want %v, got %v", tc.filename, tc.published, got.Date.Published)
+		}
+		if len(got.Contributors) == 0 {
+			t.Fatalf("RIS Load Contributors (%v): no contributors", tc.filename)
+		}
+		if diff := cmp.Diff(tc.author, got.Contributors[0]); diff != "" {
+			t.Errorf("RIS Load Contributors (%s) mismatch (-want +got):\n%s", tc.filename, diff)
+		}
+		if len(got.Titles) == 0 || got.Titles[0].Title != tc.title {
+			t.Errorf("RIS Load Titles (%v): want %v, got %v", tc.filename, tc.title, got.Titles)
+		}
+	}
+}
+
+func TestLoadAll(t *testing.T) {
+	t.Parallel()
+	got, err := ris.LoadAll(filepath.Join("testdata", "records.ris"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("RIS LoadAll: want 2 works, got %d", len(got))
+	}
+
+	article := got[0]
+	if article.ID != "https://doi.org/10.5555/12345678" || article.Type != "JournalArticle" {
+		t.Errorf("RIS LoadAll article: got %v %v", article.ID, article.Type)
+	}
+	if len(article.Contributors) != 2 || article.Contributors[1].FamilyName != "Smith" {
+		t.Errorf("RIS LoadAll Contributors: got %v", article.Contributors)
+	}
+	wantContainer := commonmeta.Container{
+		Type:      "Journal",
+		Title:     "Journal of Metadata",
+		Volume:    "7",
+		Issue:     "2",
+		FirstPage: "45",
+		LastPage:  "67",
+	}
+	if diff := cmp.Diff(wantContainer, article.Container); diff != "" {
+		t.Errorf("RIS LoadAll Container mismatch (-want +got):\n%s", diff)
+	}
+	if article.Date.Published != "2020-05-17" {
+		t.Errorf("RIS LoadAll Date: got %v", article.Date.Published)
+	}
+	if len(article.Subjects) != 2 {
+		t.Errorf("RIS LoadAll Subjects: got %v", article.Subjects)
+	}
+
+	chapter := got[1]
+	if chapter.Type != "BookChapter" {
+		t.Errorf("RIS LoadAll Type: want BookChapter, got %v", chapter.Type)
+	}
+	wantContributors := []commonmeta.Contributor{
+		{Type: "Person", GivenName: "Anna", FamilyName: "Müller", ContributorRoles: []string{"Author"}},
+		{Type: "Person", GivenName: "Charles", FamilyName: "Brown", ContributorRoles: []string{"Editor"}},
+	}
+	if diff := cmp.Diff(wantContributors, chapter.Contributors); diff != "" {
+		t.Errorf("RIS LoadAll Contributors mismatch (-want +got):\n%s", diff)
+	}
+	wantContainer = commonmeta.Container{
+		Identifier:     "978-3-16-148410-0",
+		IdentifierType: "ISBN",
+		Type:           "Book",
+		Title:          "Handbook of Scholarly Communication",
+		FirstPage:      "101",
+		LastPage:       "120",
+	}
+	if diff := cmp.Diff(wantContainer, chapter.Container); diff != "" {
+		t.Errorf("RIS LoadAll Container mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestLoadIrregularSpacing(t *testing.T) {
+	t.Parallel()
+	got, err := ris.Load(filepath.Join("testdata", "ris_bug.ris"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Type != "Book" || got.ID != "https://doi.org/10.17918/ernk-6431" {
+		t.Errorf("RIS Load: got %v %v", got.Type, got.ID)
+	}
+}
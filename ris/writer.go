@@ -1,5 +1,15 @@
 package ris
 
+import (
+	"slices"
+	"strings"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/textutils"
+)
+
+// CMToRISMappings maps commonmeta types to RIS types
 var CMToRISMappings = map[string]string{
 	"Article":               "JOUR",
 	"Audiovisual":           "VIDEO",
@@ -30,3 +40,100 @@ var CMToRISMappings = map[string]string{
 	"Standard":              "STAND",
 	"WebPage":               "WEB",
 }
+
+// tagOrder is the order in which tags are written, TY first and ER last.
+var tagOrder = []string{"TY", "AU", "A2", "TI", "T2", "AB", "KW", "PY", "DA", "VL", "IS", "SP", "EP", "PB", "SN", "LA", "DO", "UR"}
+
+// Convert converts commonmeta metadata to a RIS record.
+func Convert(data commonmeta.Data) (Content, error) {
+	content := Content{}
+	add := func(tag string, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			content[tag] = append(content[tag], value)
+		}
+	}
+
+	ty := CMToRISMappings[data.Type]
+	if ty == "" {
+		ty = "GEN"
+	}
+	add("TY", ty)
+	for _, v := range data.Contributors {
+		name := v.Name
+		if v.FamilyName != "" {
+			name = v.FamilyName
+			if v.GivenName != "" {
+				name += ", " + v.GivenName
+			}
+		}
+		if slices.Contains(v.ContributorRoles, "Author") {
+			add("AU", name)
+		} else if slices.Contains(v.ContributorRoles, "Editor") {
+			add("A2", name)
+		}
+	}
+	if len(data.Titles) > 0 {
+		add("TI", data.Titles[0].Title)
+	}
+	add("T2", data.Container.Title)
+	if len(data.Descriptions) > 0 {
+		add("AB", textutils.StripMarkup(data.Descriptions[0].Description))
+	}
+	for _, v := range data.Subjects {
+		add("KW", v.Subject)
+	}
+	if len(data.Date.Published) >= 4 {
+		add("PY", data.Date.Published[:4])
+	}
+	if len(data.Date.Published) > 4 {
+		add("DA", strings.ReplaceAll(data.Date.Published[:min(len(data.Date.Published), 10)], "-", "/"))
+	}
+	add("VL", data.Container.Volume)
+	add("IS", data.Container.Issue)
+	add("SP", data.Container.FirstPage)
+	add("EP", data.Container.LastPage)
+	add("PB", data.Publisher.Name)
+	if data.Container.IdentifierType == "ISSN" || data.Container.IdentifierType == "ISBN" {
+		add("SN", data.Container.Identifier)
+	}
+	add("LA", data.Language)
+	doi, _ := doiutils.ValidateDOI(data.ID)
+	add("DO", doi)
+	add("UR", data.URL)
+	return content, nil
+}
+
+// Write writes RIS metadata.
+func Write(data commonmeta.Data) ([]byte, error) {
+	content, err := Convert(data)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(content.String()), nil
+}
+
+// WriteAll writes a list of RIS records.
+func WriteAll(list []commonmeta.Data) ([]byte, error) {
+	var records []string
+	for _, data := range list {
+		content, err := Convert(data)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, content.String())
+	}
+	return []byte(strings.Join(records, "\n")), nil
+}
+
+// String formats a RIS record, with one line per value and an ER line ending
+// the record.
+func (c Content) String() string {
+	var b strings.Builder
+	for _, tag := range tagOrder {
+		for _, v := range c[tag] {
+			b.WriteString(tag + "  - " + v + "\n")
+		}
+	}
+	b.WriteString("ER  - \n")
+	return b.String()
+}
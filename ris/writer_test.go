@@ -0,0 +1,67 @@
+package ris_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/front-matter/commonmeta/crossref"
+	"github.com/front-matter/commonmeta/ris"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+	// crossref to ris, using a Crossref REST API response
+	filename := filepath.Join("..", "crossref", "testdata", "works", "10.5555_12345679.json")
+	file, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var response struct {
+		Message crossref.Content `json:"message"`
+	}
+	if err := json.Unmarshal(file, &response); err != nil {
+		t.Fatal(err)
+	}
+	data, err := crossref.Read(response.Message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `TY  - CHAP
+AU  - Carberry, Josiah
+TI  - Example Chapter in a Book Series
+T2  - Theory and Practice of Digital Libraries
+PY  - 2015
+SP  - 16
+EP  - 27
+PB  - Crossref Test Publisher
+SN  - 9783319242774
+DO  - 10.5555/12345679
+ER  - 
+`
+	got, err := ris.Write(data)
+	if err != nil {
+		t.Errorf("RIS Write (%v): error %v", data.ID, err)
+	}
+	if diff := cmp.Diff(want, string(got)); diff != "" {
+		t.Errorf("RIS Write (%v) mismatch (-want +got):\n%s", data.ID, diff)
+	}
+
+	// the output re-parses back to equivalent fields
+	records, err := ris.Parse(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("RIS Parse: want 1 record, got %d", len(records))
+	}
+	roundtrip, err := ris.Read(records[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if roundtrip.ID != data.ID || roundtrip.Type != data.Type || roundtrip.Container.FirstPage != "16" {
+		t.Errorf("RIS Read: got %v %v %v", roundtrip.ID, roundtrip.Type, roundtrip.Container.FirstPage)
+	}
+}
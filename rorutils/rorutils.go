@@ -0,0 +1,186 @@
+// Package rorutils provides a set of functions to work with ROR IDs
+package rorutils
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/front-matter/commonmeta/utils"
+)
+
+// BaseURL is the base URL of the ROR API.
+var BaseURL = "https://api.ror.org/v2"
+
+// Option configures requests to the ROR API.
+type Option func(*options)
+
+type options struct {
+	baseURL string
+}
+
+// getOptions returns the options with defaults applied.
+func getOptions(opts []Option) options {
+	o := options{baseURL: BaseURL}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// WithBaseURL sets the base URL of the ROR API, the default is BaseURL.
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
+// Organizations represents a list of organizations returned by the ROR API.
+type Organizations struct {
+	Items []struct {
+		ID          string `json:"id"`
+		ExternalIDs []struct {
+			Type string   `json:"type"`
+			All  []string `json:"all"`
+		} `json:"external_ids"`
+	} `json:"items"`
+}
+
+// funderIDRegexp matches a Crossref Funder ID, given as DOI, DOI URL or
+// number, e.g. 10.13039/100000001.
+var funderIDRegexp = regexp.MustCompile(`^(?:(?:https?://)?(?:dx\.)?(?:doi\.org/)?10\.13039/)?(\d{6,})$`)
+
+// Matches represents the response of the ROR affiliation matching API.
+type Matches struct {
+	Items []struct {
+		Chosen       bool    `json:"chosen"`
+		Score        float64 `json:"score"`
+		MatchingType string  `json:"matching_type"`
+		Organization struct {
+			ID string `json:"id"`
+		} `json:"organization"`
+	} `json:"items"`
+}
+
+// NormalizeROR returns a normalized ROR URL, or an empty string if the ROR
+// ID is invalid. It is the same as utils.NormalizeROR.
+func NormalizeROR(ror string) string {
+	return utils.NormalizeROR(ror)
+}
+
+// ValidateROR validates a ROR ID, given either as ID or as URL, including
+// its checksum. It is the same as utils.ValidateROR.
+func ValidateROR(ror string) (string, bool) {
+	return utils.ValidateROR(ror)
+}
+
+// MatchAffiliation matches an affiliation string, e.g. "MIT", to an
+// organization using the ROR affiliation matching API, and returns the ROR
+// URL of the match ROR chose. Returns an empty string if there is no match
+// confident enough to be chosen. Responses are cached if cache is not nil.
+func MatchAffiliation(affiliation string, cache *utils.Cache, opts ...Option) (string, error) {
+	affiliation = strings.TrimSpace(affiliation)
+	if affiliation == "" {
+		return "", nil
+	}
+	key := "ror.org/affiliation/" + affiliation
+	body, ok := cache.Get(key)
+	if !ok {
+		client := &http.Client{
+			Timeout: 10 * time.Second,
+		}
+		u := getOptions(opts).baseURL + "/organizations?affiliation=" + url.QueryEscape(affiliation)
+		req, err := http.NewRequest(http.MethodGet, u, nil)
+		if err != nil {
+			return "", err
+		}
+		resp, err := utils.DoWithRetry(client, req, utils.DefaultRetry)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= 400 {
+			return "", errors.New(resp.Status)
+		}
+		body, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return "", err
+		}
+		err = cache.Set(key, body)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	var matches Matches
+	err := json.Unmarshal(body, &matches)
+	if err != nil {
+		return "", err
+	}
+	for _, v := range matches.Items {
+		if v.Chosen {
+			return NormalizeROR(v.Organization.ID), nil
+		}
+	}
+	return "", nil
+}
+
+// FunderToROR maps a Crossref Funder ID, e.g. https://doi.org/10.13039/100000001,
+// to the ROR URL of the organization, using the Funder Registry IDs stored as
+// external IDs of type fundref by ROR. Returns an empty string if no
+// organization has the Funder ID. Responses are cached if cache is not nil.
+func FunderToROR(funderID string, cache *utils.Cache, opts ...Option) (string, error) {
+	matched := funderIDRegexp.FindStringSubmatch(strings.TrimSpace(funderID))
+	if len(matched) == 0 {
+		return "", errors.New("invalid Crossref Funder ID")
+	}
+	id := matched[1]
+	key := "ror.org/fundref/" + id
+	body, ok := cache.Get(key)
+	if !ok {
+		client := &http.Client{
+			Timeout: 10 * time.Second,
+		}
+		u := getOptions(opts).baseURL + "/organizations?query.advanced=" + url.QueryEscape("external_ids.all:"+id)
+		req, err := http.NewRequest(http.MethodGet, u, nil)
+		if err != nil {
+			return "", err
+		}
+		resp, err := utils.DoWithRetry(client, req, utils.DefaultRetry)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= 400 {
+			return "", errors.New(resp.Status)
+		}
+		body, err = io.ReadAll(resp.Body)
+		if err != nil {
+			return "", err
+		}
+		err = cache.Set(key, body)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+	var organizations Organizations
+	err := json.Unmarshal(body, &organizations)
+	if err != nil {
+		return "", err
+	}
+	// the query also matches other types of external IDs with the same value
+	for _, v := range organizations.Items {
+		for _, e := range v.ExternalIDs {
+			if e.Type == "fundref" && slices.Contains(e.All, id) {
+				return NormalizeROR(v.ID), nil
+			}
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,101 @@
+package rorutils_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/front-matter/commonmeta/rorutils"
+)
+
+func TestValidateROR(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input string
+		want  string
+	}
+	testCases := []testCase{
+		{input: "0342dzm54", want: "0342dzm54"},
+		{input: "https://ror.org/0342dzm54", want: "0342dzm54"},
+		{input: "http://ror.org/05dxps055", want: "05dxps055"},
+		{input: "ror.org/04wxnsj81", want: "04wxnsj81"},
+		{input: "https://ror.org/04WXNSJ81", want: "04wxnsj81"},
+		{input: "https://ror.org/0342dzm55", want: ""},
+		{input: "https://ror.org/1342dzm54", want: ""},
+		{input: "https://ror.org/0342dzu54", want: ""},
+		{input: "https://www.example.org/0342dzm54", want: ""},
+		{input: "https://0abcde123", want: ""},
+		{input: "", want: ""},
+	}
+	for _, tc := range testCases {
+		got, ok := rorutils.ValidateROR(tc.input)
+		if tc.want != got {
+			t.Errorf("Validate ROR(%v): want %v, got %v, ok %v",
+				tc.input, tc.want, got, ok)
+		}
+	}
+}
+
+func TestNormalizeROR(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input string
+		want  string
+	}
+	testCases := []testCase{
+		{input: "0342dzm54", want: "https://ror.org/0342dzm54"},
+		{input: "https://ror.org/0342dzm54", want: "https://ror.org/0342dzm54"},
+		{input: "0342dzm55", want: ""},
+		{input: "Freie Universität Berlin", want: ""},
+	}
+	for _, tc := range testCases {
+		got := rorutils.NormalizeROR(tc.input)
+		if tc.want != got {
+			t.Errorf("Normalize ROR(%v): want %v, got %v",
+				tc.input, tc.want, got)
+		}
+	}
+}
+
+func TestFunderToROR(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("query.advanced") {
+		case "external_ids.all:100000001":
+			fmt.Fprint(w, `{"number_of_results": 1, "items": [{"id": "https://ror.org/021nxhr62", "external_ids": [
+				{"type": "fundref", "all": ["100000001"], "preferred": "100000001"},
+				{"type": "isni", "all": ["0000 0001 1945 4215"], "preferred": null}
+			]}]}`)
+		case "external_ids.all:100000002":
+			// matches a different type of external ID only
+			fmt.Fprint(w, `{"number_of_results": 1, "items": [{"id": "https://ror.org/042nb2s44", "external_ids": [
+				{"type": "wikidata", "all": ["100000002"], "preferred": null}
+			]}]}`)
+		default:
+			fmt.Fprint(w, `{"number_of_results": 0, "items": []}`)
+		}
+	}))
+	defer server.Close()
+
+	type testCase struct {
+		input string
+		want  string
+		err   bool
+	}
+	testCases := []testCase{
+		{input: "https://doi.org/10.13039/100000001", want: "https://ror.org/021nxhr62"},
+		{input: "10.13039/100000001", want: "https://ror.org/021nxhr62"},
+		{input: "100000001", want: "https://ror.org/021nxhr62"},
+		{input: "https://doi.org/10.13039/100000002", want: ""},
+		{input: "https://doi.org/10.13039/501100000780", want: ""},
+		{input: "https://doi.org/10.5555/12345678", want: "", err: true},
+	}
+	for _, tc := range testCases {
+		got, err := rorutils.FunderToROR(tc.input, nil, rorutils.WithBaseURL(server.URL))
+		if tc.want != got || tc.err != (err != nil) {
+			t.Errorf("FunderToROR(%v): want %v, got %v, error %v", tc.input, tc.want, got, err)
+		}
+	}
+}
@@ -2,21 +2,482 @@ package schemaorg
 
 import (
 	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"path"
+	"strings"
+	"time"
 
+	"github.com/front-matter/commonmeta/authorutils"
 	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/dateutils"
+	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/langutils"
+	"github.com/front-matter/commonmeta/spdxutils"
+	"github.com/front-matter/commonmeta/utils"
 )
 
 // Content represents the SchemaOrg metadata returned from SchemaOrg sources. The type is more
 // flexible than the SchemaOrg type, allowing for different formats of some metadata.
-// Identifier can be string or []string.
+// Identifier can be string, object or a list of both, @type can be string or []string,
+// author, citation, editor, encoding, isPartOf, provider and publisher can be an object
+// or a list of objects.
 type Content struct {
 	*SchemaOrg
-	Identifier json.RawMessage `json:"identifier"`
+	Type                  json.RawMessage `json:"@type"`
+	Author                json.RawMessage `json:"author,omitempty"`
+	Citation              json.RawMessage `json:"citation,omitempty"`
+	Creator               json.RawMessage `json:"creator,omitempty"`
+	Description           json.RawMessage `json:"description,omitempty"`
+	Distribution          json.RawMessage `json:"distribution,omitempty"`
+	Editor                json.RawMessage `json:"editor,omitempty"`
+	Encoding              json.RawMessage `json:"encoding,omitempty"`
+	Headline              string          `json:"headline,omitempty"`
+	Identifier            json.RawMessage `json:"identifier,omitempty"`
+	IncludedInDataCatalog json.RawMessage `json:"includedInDataCatalog,omitempty"`
+	InLanguage            json.RawMessage `json:"inLanguage,omitempty"`
+	IsPartOf              json.RawMessage `json:"isPartOf,omitempty"`
+	Keywords              json.RawMessage `json:"keywords,omitempty"`
+	License               json.RawMessage `json:"license,omitempty"`
+	Periodical            json.RawMessage `json:"periodical,omitempty"`
+	Provider              json.RawMessage `json:"provider,omitempty"`
+	Publisher             json.RawMessage `json:"publisher,omitempty"`
+	Version               json.RawMessage `json:"version,omitempty"`
+}
+
+// Thing represents the common properties of the Schema.org objects used for
+// authors, publishers, containers and identifiers.
+type Thing struct {
+	ID          string          `json:"@id,omitempty"`
+	Type        json.RawMessage `json:"@type,omitempty"`
+	Name        string          `json:"name,omitempty"`
+	GivenName   string          `json:"givenName,omitempty"`
+	FamilyName  string          `json:"familyName,omitempty"`
+	Affiliation json.RawMessage `json:"affiliation,omitempty"`
+	ISSN        string          `json:"issn,omitempty"`
+	URL         string          `json:"url,omitempty"`
+	Value       string          `json:"value,omitempty"`
+}
+
+// SOToCMMappings maps Schema.org types to Commonmeta types.
+var SOToCMMappings = map[string]string{
+	"Article":                     "Article",
+	"BlogPosting":                 "Article",
+	"Book":                        "Book",
+	"BookChapter":                 "BookChapter",
+	"Chapter":                     "BookChapter",
+	"Collection":                  "Collection",
+	"CreativeWork":                "Other",
+	"DataCatalog":                 "Dataset",
+	"Dataset":                     "Dataset",
+	"Dissertation":                "Dissertation",
+	"Event":                       "Event",
+	"ImageObject":                 "Image",
+	"Legislation":                 "LegalDocument",
+	"Map":                         "Map",
+	"MediaObject":                 "Audiovisual",
+	"NewsArticle":                 "Article",
+	"PresentationDigitalDocument": "Presentation",
+	"Report":                      "Report",
+	"Review":                      "Review",
+	"ScholarlyArticle":            "JournalArticle",
+	"SoftwareApplication":         "Software",
+	"SoftwareSourceCode":          "Software",
+	"Thesis":                      "Dissertation",
+	"VideoObject":                 "Audiovisual",
+	"WebPage":                     "WebPage",
+	"WebSite":                     "WebPage",
+}
+
+// Load loads the metadata for a single work from a Schema.org JSON-LD file
+func Load(filename string) (commonmeta.Data, error) {
+	var data commonmeta.Data
+	var content Content
+
+	extension := path.Ext(filename)
+	if extension != ".json" && extension != ".jsonld" {
+		return data, errors.New("invalid file extension")
+	}
+	file, err := os.Open(filename)
+	if err != nil {
+		return data, errors.New("error reading file")
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	err = decoder.Decode(&content)
+	if err != nil {
+		return data, err
+	}
+	data, err = Read(content)
+	if err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// LoadAll loads a list of works from a Schema.org JSON-LD file and converts it to the Commonmeta format
+func LoadAll(filename string) ([]commonmeta.Data, error) {
+	var data []commonmeta.Data
+	var content []Content
+
+	extension := path.Ext(filename)
+	if extension != ".json" && extension != ".jsonld" {
+		return data, errors.New("invalid file extension")
+	}
+	file, err := os.Open(filename)
+	if err != nil {
+		return data, errors.New("error reading file")
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	err = decoder.Decode(&content)
+	if err != nil {
+		return data, err
+	}
+	data, err = ReadAll(content)
+	if err != nil {
+		return data, err
+	}
+	return data, nil
 }
 
 // Read reads Schema.org metadata and converts it to commonmeta.
 func Read(content Content) (commonmeta.Data, error) {
 	var data commonmeta.Data
-	data.ID = content.ID
+	if content.SchemaOrg == nil {
+		content.SchemaOrg = &SchemaOrg{}
+	}
+
+	// use @id, then identifier, then url as the ID
+	data.ID = utils.NormalizeID(content.ID)
+	if data.ID == "" {
+		for _, identifier := range getIdentifiers(content.Identifier) {
+			data.ID = utils.NormalizeID(identifier)
+			if data.ID != "" {
+				break
+			}
+		}
+	}
+	if data.ID == "" {
+		data.ID = utils.NormalizeID(content.URL)
+	}
+
+	for _, t := range getStrings(content.Type) {
+		if v, ok := SOToCMMappings[t]; ok {
+			data.Type = v
+			break
+		}
+	}
+	if data.Type == "" {
+		data.Type = "Other"
+	}
+	data.AdditionalType = content.AdditionalType
+
+	authors := getThings(content.Author)
+	if len(authors) == 0 {
+		authors = getThings(content.Creator)
+	}
+	for _, v := range authors {
+		contributor, ok := getContributor(v)
+		if ok {
+			contributor.ContributorRoles = []string{"Author"}
+			data.Contributors = append(data.Contributors, contributor)
+		}
+	}
+	for _, v := range getThings(content.Editor) {
+		contributor, ok := getContributor(v)
+		if ok {
+			contributor.ContributorRoles = []string{"Editor"}
+			data.Contributors = append(data.Contributors, contributor)
+		}
+	}
+
+	containers := getThings(content.IsPartOf)
+	if len(containers) == 0 {
+		containers = getThings(content.Periodical)
+	}
+	if len(containers) == 0 {
+		containers = getThings(content.IncludedInDataCatalog)
+	}
+	if len(containers) > 0 {
+		container := containers[0]
+		var identifier, identifierType string
+		if container.ISSN != "" {
+			identifier = container.ISSN
+			identifierType = "ISSN"
+		} else if container.ID != "" || container.URL != "" {
+			identifier = container.ID
+			if identifier == "" {
+				identifier = container.URL
+			}
+			identifierType = "URL"
+		}
+		containerType := commonmeta.ContainerTypes[data.Type]
+		types := getStrings(container.Type)
+		if len(types) > 0 && types[0] != "Periodical" && types[0] != "DataCatalog" {
+			containerType = types[0]
+		}
+		data.Container = commonmeta.Container{
+			Identifier:     identifier,
+			IdentifierType: identifierType,
+			Type:           containerType,
+			Title:          container.Name,
+			FirstPage:      content.PageStart,
+			LastPage:       content.PageEnd,
+		}
+	} else {
+		data.Container = commonmeta.Container{
+			FirstPage: content.PageStart,
+			LastPage:  content.PageEnd,
+		}
+	}
+
+	data.Date.Created = parseDate(content.DateCreated)
+	data.Date.Published = parseDate(content.DatePublished)
+	data.Date.Updated = parseDate(content.DateModified)
+
+	for _, v := range getStrings(content.Description) {
+		if v != "" {
+			data.Descriptions = append(data.Descriptions, commonmeta.Description{
+				Description: utils.Sanitize(v),
+				Type:        "Abstract",
+			})
+		}
+	}
+
+	for _, v := range getIdentifiers(content.Identifier) {
+		if doiutils.NormalizeDOI(v) == data.ID || v == data.ID {
+			continue
+		}
+		data.Identifiers = append(data.Identifiers, commonmeta.Identifier{
+			Identifier:     v,
+			IdentifierType: "Other",
+		})
+	}
+	if doiutils.NormalizeDOI(data.ID) != "" {
+		data.Identifiers = append([]commonmeta.Identifier{{
+			Identifier:     data.ID,
+			IdentifierType: "DOI",
+		}}, data.Identifiers...)
+	}
+
+	languages := getStrings(content.InLanguage)
+	if len(languages) > 0 {
+		data.Language = languages[0]
+	} else if things := getThings(content.InLanguage); len(things) > 0 {
+		data.Language = things[0].Name
+		var language struct {
+			AlternateName string `json:"alternateName"`
+		}
+		if err := json.Unmarshal(content.InLanguage, &language); err == nil && language.AlternateName != "" {
+			data.Language = language.AlternateName
+		}
+	}
+	data.Language = langutils.GetLanguage(data.Language, langutils.ISO6391)
+
+	licenses := getStrings(content.License)
+	if len(licenses) > 0 && licenses[0] != "" {
+		id, url := spdxutils.Normalize(licenses[0])
+		if url == "" {
+			url = licenses[0]
+		}
+		data.License = commonmeta.License{
+			ID:  id,
+			URL: url,
+		}
+	}
+
+	publishers := getStrings(content.Publisher)
+	if len(publishers) > 0 {
+		data.Publisher = commonmeta.Publisher{
+			Name: publishers[0],
+		}
+	} else if things := getThings(content.Publisher); len(things) > 0 {
+		data.Publisher = commonmeta.Publisher{
+			Name: things[0].Name,
+		}
+	}
+
+	var keywords []string
+	for _, v := range getStrings(content.Keywords) {
+		keywords = append(keywords, strings.Split(v, ",")...)
+	}
+	for _, v := range keywords {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			data.Subjects = append(data.Subjects, commonmeta.Subject{
+				Subject: v,
+			})
+		}
+	}
+
+	title := content.Name
+	if title == "" {
+		title = content.Headline
+	}
+	if title != "" {
+		data.Titles = append(data.Titles, commonmeta.Title{
+			Title: title,
+		})
+	}
+
+	data.URL = content.URL
+	versions := getStrings(content.Version)
+	if len(versions) > 0 {
+		data.Version = versions[0]
+	}
+
 	return data, nil
 }
+
+// ReadAll reads a list of Schema.org JSON-LD objects and returns a list of works in Commonmeta format
+func ReadAll(content []Content) ([]commonmeta.Data, error) {
+	var data []commonmeta.Data
+	for _, v := range content {
+		d, err := Read(v)
+		if err != nil {
+			log.Println(err)
+		}
+		data = append(data, d)
+	}
+	return data, nil
+}
+
+// getContributor converts a Schema.org Person or Organization to a commonmeta contributor.
+func getContributor(v Thing) (commonmeta.Contributor, bool) {
+	var contributor commonmeta.Contributor
+	var id string
+	if orcid := utils.NormalizeORCID(v.ID); orcid != "" {
+		id = orcid
+	} else if ror := utils.NormalizeROR(v.ID); ror != "" {
+		id = ror
+	}
+
+	types := getStrings(v.Type)
+	var contributorType string
+	if len(types) > 0 && (types[0] == "Person" || types[0] == "Organization") {
+		contributorType = types[0]
+	} else if v.GivenName != "" || v.FamilyName != "" || authorutils.IsPersonalName(v.Name) {
+		contributorType = "Person"
+	} else {
+		contributorType = "Organization"
+	}
+
+	var affiliations []*commonmeta.Affiliation
+	for _, name := range getStrings(v.Affiliation) {
+		affiliations = append(affiliations, &commonmeta.Affiliation{Name: name})
+	}
+	for _, a := range getThings(v.Affiliation) {
+		affiliations = append(affiliations, &commonmeta.Affiliation{
+			ID:   utils.NormalizeROR(a.ID),
+			Name: a.Name,
+		})
+	}
+
+	if contributorType == "Person" {
+		givenName, familyName := v.GivenName, v.FamilyName
+		if familyName == "" && v.Name != "" {
+			givenName, familyName, _ = authorutils.ParseName(v.Name)
+		}
+		if familyName == "" {
+			return contributor, false
+		}
+		contributor = commonmeta.Contributor{
+			ID:           id,
+			Type:         "Person",
+			GivenName:    givenName,
+			FamilyName:   familyName,
+			Affiliations: affiliations,
+		}
+	} else {
+		if v.Name == "" {
+			return contributor, false
+		}
+		contributor = commonmeta.Contributor{
+			ID:           id,
+			Type:         "Organization",
+			Name:         v.Name,
+			Affiliations: affiliations,
+		}
+	}
+	return contributor, true
+}
+
+// getStrings returns the values of a JSON string, number or list of strings.
+func getStrings(raw json.RawMessage) []string {
+	if len(raw) == 0 {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return []string{s}
+	}
+	var list []string
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return list
+	}
+	var n json.Number
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return []string{n.String()}
+	}
+	return nil
+}
+
+// getThings returns the values of a JSON object or list of objects.
+func getThings(raw json.RawMessage) []Thing {
+	if len(raw) == 0 {
+		return nil
+	}
+	var thing Thing
+	if err := json.Unmarshal(raw, &thing); err == nil {
+		return []Thing{thing}
+	}
+	var list []Thing
+	if err := json.Unmarshal(raw, &list); err == nil {
+		return list
+	}
+	return nil
+}
+
+// getIdentifiers returns identifiers provided as string, PropertyValue object or a list of both.
+func getIdentifiers(raw json.RawMessage) []string {
+	var identifiers []string
+	identifiers = append(identifiers, getStrings(raw)...)
+	for _, v := range getThings(raw) {
+		if v.Value != "" {
+			identifiers = append(identifiers, v.Value)
+		} else if v.ID != "" {
+			identifiers = append(identifiers, v.ID)
+		}
+	}
+	if len(identifiers) == 0 {
+		// list mixing strings and objects
+		var list []json.RawMessage
+		if err := json.Unmarshal(raw, &list); err == nil {
+			for _, item := range list {
+				identifiers = append(identifiers, getIdentifiers(item)...)
+			}
+		}
+	}
+	return identifiers
+}
+
+// parseDate returns an ISO 8601 date or datetime.
+func parseDate(str string) string {
+	if str == "" {
+		return ""
+	}
+	if len(str) > 10 {
+		for _, layout := range []string{time.RFC3339, time.RFC3339Nano, "2006-01-02T15:04:05.000-07:00"} {
+			t, err := time.Parse(layout, str)
+			if err == nil {
+				return t.UTC().Format(dateutils.Iso8601DateTimeFormat)
+			}
+		}
+		str = str[:10]
+	}
+	return dateutils.ParseDate(str)
+}
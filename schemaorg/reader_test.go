@@ -0,0 +1,141 @@
+package schemaorg_test
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/front-matter/commonmeta/commonmeta"
+	"github.com/front-matter/commonmeta/schemaorg"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name      string
+		filename  string
+		id        string
+		type_     string
+		published string
+		author    commonmeta.Contributor
+		container commonmeta.Container
+		title     string
+	}
+
+	testCases := []testCase{
+		{
+			name:      "blog post",
+			filename:  "schema_org.json",
+			id:        "https://doi.org/10.5438/4k3m-nyvg",
+			type_:     "Article",
+			published: "2016-12-20",
+			author:    commonmeta.Contributor{ID: "https://orcid.org/0000-0003-1419-2405", Type: "Person", GivenName: "Martin", FamilyName: "Fenner", ContributorRoles: []string{"Author"}},
+			container: commonmeta.Container{Identifier: "https://doi.org/10.5438/0000-00SS", IdentifierType: "URL", Type: "Blog", Title: "DataCite Blog"},
+			title:     "Eating your own Dog Food",
+		},
+		{
+			name:      "single author object",
+			filename:  "schema_org_front-matter.json",
+			id:        "https://doi.org/10.53731/r9nqx6h-97aq74v-ag7bw",
+			type_:     "Article",
+			published: "2021-09-06T07:50:05Z",
+			author:    commonmeta.Contributor{ID: "https://orcid.org/0000-0003-1419-2405", Type: "Person", GivenName: "Martin", FamilyName: "Fenner", ContributorRoles: []string{"Author"}},
+			container: commonmeta.Container{Identifier: "2749-9952", IdentifierType: "ISSN", Type: "Blog", Title: "Front Matter"},
+			title:     "Editorial by more than 200 health journals: Call for emergency action to limit global temperature increases, restore biodiversity, and protect health",
+		},
+		{
+			name:      "type as array",
+			filename:  "schema_org_type_as_array.json",
+			id:        "https://doi.org/10.5438/4k3m-nyvg",
+			type_:     "Article",
+			published: "2016-12-20",
+			author:    commonmeta.Contributor{ID: "https://orcid.org/0000-0003-1419-2405", Type: "Person", GivenName: "Martin", FamilyName: "Fenner", Affiliations: []*commonmeta.Affiliation{{Name: "DataCite"}}, ContributorRoles: []string{"Author"}},
+			container: commonmeta.Container{Identifier: "https://doi.org/10.5438/0000-00SS", IdentifierType: "URL", Type: "Blog", Title: "DataCite Blog"},
+			title:     "Eating your own Dog Food",
+		},
+		{
+			name:      "dataset",
+			filename:  "schema_org_gtex.json",
+			id:        "https://doi.org/10.25491/d50j-3083",
+			type_:     "Dataset",
+			published: "2017-01-01",
+			author:    commonmeta.Contributor{Type: "Organization", Name: "The GTEx Consortium", ContributorRoles: []string{"Author"}},
+			container: commonmeta.Container{Type: "DataRepository", Title: "GTEx"},
+			title:     "Fully processed, filtered and normalized gene expression matrices (in BED format) for each tissue, which were used as input into FastQTL for eQTL discovery",
+		},
+	}
+	for _, tc := range testCases {
+		got, err := schemaorg.Load(filepath.Join("testdata", tc.filename))
+		if err != nil {
+			t.Fatalf("Schemaorg Load (%v): error %v", tc.filename, err)
+		}
+		if got.ID != tc.id {
+			t.Errorf("Schemaorg Load ID (%v): want %v, got %v", tc.filename, tc.id, got.ID)
+		}
+		if got.Type != tc.type_ {
+			t.Errorf("Schemaorg Load Type (%v): want %v, got %v", tc.filename, tc.type_, got.Type)
+		}
+		if got.Date.Published != tc.published {
+			t.Errorf("Schemaorg Load Date (%v): want %v, got %v", tc.filename, tc.published, got.Date.Published)
+		}
+		if len(got.Contributors) == 0 {
+			t.Fatalf("Schemaorg Load Contributors (%v): no contributors", tc.filename)
+		}
+		if diff := cmp.Diff(tc.author, got.Contributors[0]); diff != "" {
+			t.Errorf("Schemaorg Load Contributors (%s) mismatch (-want +got):\n%s", tc.filename, diff)
+		}
+		if diff := cmp.Diff(tc.container, got.Container); diff != "" {
+			t.Errorf("Schemaorg Load Container (%s) mismatch (-want +got):\n%s", tc.filename, diff)
+		}
+		if len(got.Titles) == 0 || got.Titles[0].Title != tc.title {
+			t.Errorf("Schemaorg Load Titles (%v): want %v, got %v", tc.filename, tc.title, got.Titles)
+		}
+	}
+}
+
+func TestLoadORCIDAuthors(t *testing.T) {
+	t.Parallel()
+	got, err := schemaorg.Load(filepath.Join("testdata", "aida.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []commonmeta.Contributor{
+		{ID: "https://orcid.org/0000-0003-1298-517X", Type: "Person", GivenName: "Karin", FamilyName: "Lindman", ContributorRoles: []string{"Author"}},
+		{Type: "Person", GivenName: "Jerónimo", FamilyName: "Rose", ContributorRoles: []string{"Author"}},
+		{Type: "Person", GivenName: "Martin", FamilyName: "Lindvall", ContributorRoles: []string{"Author"}},
+		{ID: "https://orcid.org/0000-0001-7250-234X", Type: "Person", GivenName: "Caroline", FamilyName: "Bivik Stadler", ContributorRoles: []string{"Author"}},
+	}
+	if diff := cmp.Diff(want, got.Contributors); diff != "" {
+		t.Errorf("Schemaorg Load Contributors mismatch (-want +got):\n%s", diff)
+	}
+	if got.Publisher.Name != "AIDA" {
+		t.Errorf("Schemaorg Load Publisher: want AIDA, got %v", got.Publisher.Name)
+	}
+}
+
+func TestReadRoundTrip(t *testing.T) {
+	t.Parallel()
+	data, err := schemaorg.Load(filepath.Join("testdata", "schema_org.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	output, jsErr := schemaorg.Write(data)
+	if jsErr != nil {
+		t.Fatal(jsErr)
+	}
+	var content schemaorg.Content
+	if err := json.Unmarshal(output, &content); err != nil {
+		t.Fatal(err)
+	}
+	got, err := schemaorg.Read(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(data.Contributors, got.Contributors); diff != "" {
+		t.Errorf("Contributors mismatch (-want +got):\n%s", diff)
+	}
+	if got.ID != data.ID || got.Type != data.Type || got.Date.Published != data.Date.Published {
+		t.Errorf("Read round trip: want %v %v %v, got %v %v %v", data.ID, data.Type, data.Date.Published, got.ID, got.Type, got.Date.Published)
+	}
+}
@@ -3,7 +3,7 @@ package schemaorg
 
 import (
 	"encoding/json"
-	"fmt"
+	"log/slog"
 	"slices"
 	"strconv"
 	"strings"
@@ -29,16 +29,16 @@ type SchemaOrg struct {
 	Editor                []Editor      `json:"editor,omitempty"`
 	Encoding              []MediaObject `json:"encoding,omitempty"`
 	Identifier            []string      `json:"identifier,omitempty"`
-	IncludedInDataCatalog DataCatalog   `json:"includedInDataCatalog,omitempty"`
+	IncludedInDataCatalog *DataCatalog  `json:"includedInDataCatalog,omitempty"`
 	InLanguage            string        `json:"inLanguage,omitempty"`
 	Keywords              string        `json:"keywords,omitempty"`
 	License               string        `json:"license,omitempty"`
 	Name                  string        `json:"name,omitempty"`
 	PageStart             string        `json:"pageStart,omitempty"`
 	PageEnd               string        `json:"pageEnd,omitempty"`
-	Periodical            Periodical    `json:"periodical,omitempyt"`
-	Provider              Provider      `json:"provider,omitempty"`
-	Publisher             Publisher     `json:"publisher,omitempty"`
+	Periodical            *Periodical   `json:"periodical,omitempty"`
+	Provider              *Provider     `json:"provider,omitempty"`
+	Publisher             *Publisher    `json:"publisher,omitempty"`
 	URL                   string        `json:"url,omitempty"`
 	Version               string        `json:"version,omitempty"`
 }
@@ -48,7 +48,7 @@ type Author struct {
 	ID           string         `json:"@id,omitempty"`
 	Type         string         `json:"@type,omitempty"`
 	GivenName    string         `json:"givenName,omitempty"`
-	FamilyName   string         `json:"familyName"`
+	FamilyName   string         `json:"familyName,omitempty"`
 	Name         string         `json:"name,omitempty"`
 	Affiliations []Organization `json:"affiliations,omitempty"`
 }
@@ -76,7 +76,7 @@ type Editor struct {
 	ID           string         `json:"@id,omitempty"`
 	Type         string         `json:"@type,omitempty"`
 	GivenName    string         `json:"givenName,omitempty"`
-	FamilyName   string         `json:"familyName"`
+	FamilyName   string         `json:"familyName,omitempty"`
 	Name         string         `json:"name,omitempty"`
 	Affiliations []Organization `json:"affiliations,omitempty"`
 }
@@ -152,12 +152,16 @@ func Convert(data commonmeta.Data) (SchemaOrg, error) {
 	schemaorg.Context = "http://schema.org"
 	schemaorg.ID = data.ID
 	schemaorg.Type = CMToSOMappings[data.Type]
+	if schemaorg.Type == "" {
+		schemaorg.Type = "CreativeWork"
+	}
 
 	schemaorg.AdditionalType = data.AdditionalType
 	if len(data.Contributors) > 0 {
 		for _, c := range data.Contributors {
+			t := commonmeta.GetContributorType(c)
 			if slices.Contains(c.ContributorRoles, "Author") {
-				if c.Type == "Person" {
+				if t == "Person" {
 					var affiliations []Organization
 					for _, affiliation := range c.Affiliations {
 						affiliations = append(affiliations, Organization{
@@ -172,7 +176,7 @@ func Convert(data commonmeta.Data) (SchemaOrg, error) {
 						FamilyName:   c.FamilyName,
 						Affiliations: affiliations,
 					})
-				} else if c.Type == "Organization" {
+				} else if t == "Organization" {
 					schemaorg.Author = append(schemaorg.Author, Author{
 						ID:   c.ID,
 						Type: "Organization",
@@ -180,7 +184,7 @@ func Convert(data commonmeta.Data) (SchemaOrg, error) {
 					})
 				}
 			} else if slices.Contains(c.ContributorRoles, "Editor") {
-				if c.Type == "Person" {
+				if t == "Person" {
 					var affiliations []Organization
 					for _, affiliation := range c.Affiliations {
 						affiliations = append(affiliations, Organization{
@@ -190,13 +194,15 @@ func Convert(data commonmeta.Data) (SchemaOrg, error) {
 					}
 					schemaorg.Editor = append(schemaorg.Editor, Editor{
 						ID:           c.ID,
+						Type:         "Person",
 						GivenName:    c.GivenName,
 						FamilyName:   c.FamilyName,
 						Affiliations: affiliations,
 					})
-				} else if c.Type == "Organization" {
+				} else if t == "Organization" {
 					schemaorg.Editor = append(schemaorg.Editor, Editor{
 						ID:   c.ID,
+						Type: "Organization",
 						Name: c.Name,
 					})
 				}
@@ -220,20 +226,21 @@ func Convert(data commonmeta.Data) (SchemaOrg, error) {
 		}
 	}
 
-	if data.Type == "Dataset" {
-		schemaorg.IncludedInDataCatalog = DataCatalog{
+	hasContainer := data.Container.Title != "" || data.Container.Identifier != ""
+	if hasContainer && data.Type == "Dataset" {
+		schemaorg.IncludedInDataCatalog = &DataCatalog{
 			ID:   data.Container.Identifier,
 			Type: "DataCatalog",
 			Name: data.Container.Title,
 		}
-	} else {
+	} else if hasContainer {
 		var ISSN string
 		var ID string
 		if data.Container.IdentifierType == "ISSN" {
 			ISSN = data.Container.Identifier
 			ID = ""
 		}
-		schemaorg.Periodical = Periodical{
+		schemaorg.Periodical = &Periodical{
 			ID:   ID,
 			Type: "Periodical",
 			Name: data.Container.Title,
@@ -292,13 +299,17 @@ func Convert(data commonmeta.Data) (SchemaOrg, error) {
 	}
 	schemaorg.PageStart = data.Container.FirstPage
 	schemaorg.PageEnd = data.Container.LastPage
-	schemaorg.Provider = Provider{
-		Type: "Organization",
-		Name: data.Provider,
+	if data.Provider != "" {
+		schemaorg.Provider = &Provider{
+			Type: "Organization",
+			Name: data.Provider,
+		}
 	}
-	schemaorg.Publisher = Publisher{
-		Type: "Organization",
-		Name: data.Publisher.Name,
+	if data.Publisher.Name != "" {
+		schemaorg.Publisher = &Publisher{
+			Type: "Organization",
+			Name: data.Publisher.Name,
+		}
 	}
 	schemaorg.URL = data.URL
 	schemaorg.Version = data.Version
@@ -310,11 +321,11 @@ func Convert(data commonmeta.Data) (SchemaOrg, error) {
 func Write(data commonmeta.Data) ([]byte, []gojsonschema.ResultError) {
 	schemaorg, err := Convert(data)
 	if err != nil {
-		fmt.Println(err)
+		slog.Error("error converting metadata", "error", err)
 	}
 	output, err := json.Marshal(schemaorg)
 	if err != nil {
-		fmt.Println(err)
+		slog.Error("error marshalling JSON", "error", err)
 	}
 
 	return output, nil
@@ -326,13 +337,13 @@ func WriteAll(list []commonmeta.Data) ([]byte, []gojsonschema.ResultError) {
 	for _, data := range list {
 		csl, err := Convert(data)
 		if err != nil {
-			fmt.Println(err)
+			slog.Error("error converting metadata", "error", err)
 		}
 		schemaorgList = append(schemaorgList, csl)
 	}
 	output, err := json.Marshal(schemaorgList)
 	if err != nil {
-		fmt.Println(err)
+		slog.Error("error marshalling JSON", "error", err)
 	}
 
 	return output, nil
@@ -68,3 +68,111 @@ func TestWrite(t *testing.T) {
 		}
 	}
 }
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name      string
+		data      commonmeta.Data
+		type_     string
+		container string
+	}
+
+	author := commonmeta.Contributor{
+		ID:               "https://orcid.org/0000-0003-1419-2405",
+		Type:             "Person",
+		GivenName:        "Martin",
+		FamilyName:       "Fenner",
+		ContributorRoles: []string{"Author"},
+	}
+	testCases := []testCase{
+		{
+			name: "journal article",
+			data: commonmeta.Data{
+				ID:           "https://doi.org/10.5555/12345678",
+				Type:         "JournalArticle",
+				Contributors: []commonmeta.Contributor{author},
+				Titles:       []commonmeta.Title{{Title: "An article"}},
+				Container:    commonmeta.Container{Identifier: "2050-084X", IdentifierType: "ISSN", Title: "eLife"},
+				Date:         commonmeta.Date{Published: "2014-02-11"},
+				Publisher:    commonmeta.Publisher{Name: "eLife Sciences Publications, Ltd"},
+				License:      commonmeta.License{ID: "CC-BY-4.0", URL: "https://creativecommons.org/licenses/by/4.0/legalcode"},
+			},
+			type_:     "ScholarlyArticle",
+			container: "periodical",
+		},
+		{
+			name: "dataset",
+			data: commonmeta.Data{
+				ID:           "https://doi.org/10.5061/dryad.8515",
+				Type:         "Dataset",
+				Contributors: []commonmeta.Contributor{author},
+				Titles:       []commonmeta.Title{{Title: "A dataset"}},
+				Container:    commonmeta.Container{Title: "Dryad"},
+				Date:         commonmeta.Date{Published: "2011-02-01"},
+				Publisher:    commonmeta.Publisher{Name: "Dryad"},
+				License:      commonmeta.License{ID: "CC0-1.0", URL: "https://creativecommons.org/publicdomain/zero/1.0/legalcode"},
+			},
+			type_:     "Dataset",
+			container: "includedInDataCatalog",
+		},
+	}
+	for _, tc := range testCases {
+		output, jsErr := schemaorg.Write(tc.data)
+		if jsErr != nil {
+			t.Errorf("Schemaorg Write (%v): error %v", tc.name, jsErr)
+		}
+		var got map[string]any
+		if err := json.Unmarshal(output, &got); err != nil {
+			t.Fatal(err)
+		}
+		want := map[string]any{
+			"@context":      "http://schema.org",
+			"@id":           tc.data.ID,
+			"@type":         tc.type_,
+			"name":          tc.data.Titles[0].Title,
+			"datePublished": tc.data.Date.Published,
+			"license":       tc.data.License.URL,
+		}
+		for k, v := range want {
+			if got[k] != v {
+				t.Errorf("Schemaorg Write (%v) %v: want %v, got %v", tc.name, k, v, got[k])
+			}
+		}
+		authors, ok := got["author"].([]any)
+		if !ok || len(authors) != 1 {
+			t.Fatalf("Schemaorg Write (%v): invalid author %v", tc.name, got["author"])
+		}
+		wantAuthor := map[string]any{
+			"@id":        "https://orcid.org/0000-0003-1419-2405",
+			"@type":      "Person",
+			"givenName":  "Martin",
+			"familyName": "Fenner",
+		}
+		if diff := cmp.Diff(wantAuthor, authors[0]); diff != "" {
+			t.Errorf("Schemaorg Write (%s) author mismatch (-want +got):\n%s", tc.name, diff)
+		}
+		wantPublisher := map[string]any{"@type": "Organization", "name": tc.data.Publisher.Name}
+		if diff := cmp.Diff(wantPublisher, got["publisher"]); diff != "" {
+			t.Errorf("Schemaorg Write (%s) publisher mismatch (-want +got):\n%s", tc.name, diff)
+		}
+		if _, ok := got[tc.container]; !ok {
+			t.Errorf("Schemaorg Write (%v): missing %v", tc.name, tc.container)
+		}
+		if _, ok := got["provider"]; ok {
+			t.Errorf("Schemaorg Write (%v): unexpected empty provider", tc.name)
+		}
+	}
+}
+
+func TestConvertUnknownType(t *testing.T) {
+	t.Parallel()
+	got, err := schemaorg.Convert(commonmeta.Data{ID: "https://doi.org/10.5555/12345678", Type: "Standard"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Type != "CreativeWork" {
+		t.Errorf("Schemaorg Convert type: want CreativeWork, got %v", got.Type)
+	}
+}
@@ -0,0 +1,205 @@
+package schemautils
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// SchemaError is a JSON Schema validation error, with the path of the field
+// as JSON pointer, e.g. /contributors/0/type, the offending value and a
+// message describing the error. For values not allowed by the schema, the
+// closest allowed value is suggested.
+type SchemaError struct {
+	Path       string
+	Value      any
+	Message    string
+	Suggestion string
+}
+
+// Error returns the path and message of the error, and the suggestion if
+// there is one.
+func (e SchemaError) Error() string {
+	msg := e.Message
+	if e.Suggestion != "" {
+		msg += fmt.Sprintf(", did you mean %q?", e.Suggestion)
+	}
+	if e.Path == "" {
+		return msg
+	}
+	return e.Path + ": " + msg
+}
+
+// SchemaErrors is a list of JSON Schema validation errors.
+type SchemaErrors []SchemaError
+
+// NewSchemaErrors converts the errors returned by gojsonschema. Errors that
+// a value doesn't match any of several schemas (anyOf or oneOf) are dropped
+// if there are other errors, as they only repeat them.
+func NewSchemaErrors(errs []gojsonschema.ResultError) SchemaErrors {
+	var schemaErrors SchemaErrors
+	for _, v := range errs {
+		if (v.Type() == "number_any_of" || v.Type() == "number_one_of") && len(errs) > 1 {
+			continue
+		}
+		schemaErrors = append(schemaErrors, newSchemaError(v))
+	}
+	return schemaErrors
+}
+
+// Error returns the errors joined by semicolons.
+func (errs SchemaErrors) Error() string {
+	messages := make([]string, len(errs))
+	for i, v := range errs {
+		messages[i] = v.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Table renders the errors as a table with the columns path, value and
+// message.
+func (errs SchemaErrors) Table() string {
+	var b strings.Builder
+	w := tabwriter.NewWriter(&b, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "PATH\tVALUE\tERROR")
+	for _, v := range errs {
+		path := v.Path
+		if path == "" {
+			path = "/"
+		}
+		msg := v.Message
+		if v.Suggestion != "" {
+			msg += fmt.Sprintf(", did you mean %q?", v.Suggestion)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", path, formatValue(v.Value), msg)
+	}
+	w.Flush()
+	return b.String()
+}
+
+// newSchemaError converts a gojsonschema error, using the details of the
+// error for the message.
+func newSchemaError(v gojsonschema.ResultError) SchemaError {
+	details := v.Details()
+	e := SchemaError{
+		Path:    jsonPointer(v.Field()),
+		Value:   v.Value(),
+		Message: v.Description(),
+	}
+	switch v.Type() {
+	case "required":
+		e.Path += "/" + escapePointer(fmt.Sprint(details["property"]))
+		e.Value = nil
+		e.Message = "missing required property"
+	case "additional_property_not_allowed":
+		e.Path += "/" + escapePointer(fmt.Sprint(details["property"]))
+		e.Value = nil
+		e.Message = "property not allowed"
+	case "enum":
+		allowed := parseAllowed(fmt.Sprint(details["allowed"]))
+		e.Message = fmt.Sprintf("%s is not an allowed value", formatValue(v.Value()))
+		if s, ok := v.Value().(string); ok {
+			e.Suggestion = closest(s, allowed)
+		}
+	case "invalid_type":
+		e.Message = fmt.Sprintf("expected %v, got %v", details["expected"], details["given"])
+	case "format":
+		e.Message = fmt.Sprintf("%s is not a valid %v", formatValue(v.Value()), details["format"])
+	case "string_gte":
+		e.Message = fmt.Sprintf("must be at least %v characters long", details["min"])
+	case "array_min_items":
+		e.Message = fmt.Sprintf("must have at least %v items", details["min"])
+	}
+	return e
+}
+
+// jsonPointer converts the field of a gojsonschema error, e.g.
+// contributors.0.type, to a JSON pointer, e.g. /contributors/0/type. The
+// root is the empty string.
+func jsonPointer(field string) string {
+	if field == "" || field == "(root)" {
+		return ""
+	}
+	parts := strings.Split(field, ".")
+	for i, part := range parts {
+		parts[i] = escapePointer(part)
+	}
+	return "/" + strings.Join(parts, "/")
+}
+
+// escapePointer escapes ~ and / in a JSON pointer reference token.
+func escapePointer(str string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(str)
+}
+
+// parseAllowed parses the allowed values of an enum error, given as a comma
+// separated list of JSON values, e.g. "Article", "Book".
+func parseAllowed(str string) []string {
+	var allowed []string
+	for _, v := range strings.Split(str, ", ") {
+		if s, err := strconv.Unquote(v); err == nil {
+			allowed = append(allowed, s)
+		}
+	}
+	return allowed
+}
+
+// formatValue formats a value as JSON, shortened to 40 characters.
+func formatValue(value any) string {
+	if value == nil {
+		return ""
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	if s := []rune(string(b)); len(s) > 40 {
+		return string(s[:39]) + "…"
+	}
+	return string(b)
+}
+
+// closest returns the allowed value closest to str: a value differing only
+// in case, hyphens or underscores, or else a value at most a few edits away.
+func closest(str string, allowed []string) string {
+	normalize := strings.NewReplacer("-", "", "_", "", " ", "")
+	for _, v := range allowed {
+		if strings.EqualFold(normalize.Replace(v), normalize.Replace(str)) {
+			return v
+		}
+	}
+	best := ""
+	bestDistance := max(2, len(str)/4) + 1
+	for _, v := range allowed {
+		if d := levenshtein(strings.ToLower(str), strings.ToLower(v)); d < bestDistance {
+			best, bestDistance = v, d
+		}
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between two strings.
+func levenshtein(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	prev := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		curr := make([]int, len(t)+1)
+		curr[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(t)]
+}
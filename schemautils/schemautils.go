@@ -5,9 +5,11 @@ import (
 	"embed"
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"slices"
+	"sync"
 
 	"github.com/xeipuuv/gojsonschema"
 )
@@ -19,6 +21,33 @@ var JSONSchemas embed.FS
 
 const schemaVersion = "commonmeta_v0.14"
 
+// JSON Schema files stored locally to validate against
+var schemata = []string{schemaVersion, "datacite-v4.5", "crossref-v0.2", "csl-data", "cff_v1.2.0"}
+
+// JSON pointers to the part of a JSON Schema file to validate against, if
+// not the root. The commonmeta schema defines a single object or an array of
+// objects under commonmeta.
+var schemaRoots = map[string]string{
+	schemaVersion: "#/commonmeta",
+}
+
+// compiled schemas, each schema is compiled once on first use
+var (
+	schemaCache = make(map[string]*gojsonschema.Schema)
+	schemaMutex sync.RWMutex
+)
+
+// PreloadSchemas compiles all JSON Schema files, e.g. when starting a server,
+// so that the first validation against a schema is as fast as all others.
+func PreloadSchemas() error {
+	for _, s := range schemata {
+		if _, err := loadSchema(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // JSONSchemaErrors validates a JSON document against a JSON Schema file.
 func JSONSchemaErrors(document []byte, schema ...string) *gojsonschema.Result {
 
@@ -28,28 +57,72 @@ func JSONSchemaErrors(document []byte, schema ...string) *gojsonschema.Result {
 	}
 	s := schema[len(schema)-1]
 
-	// JSON Schema files stored locally to validate against
-	schemata := []string{schemaVersion, "datacite-v4.5", "crossref-v0.2", "csl-data", "cff_v1.2.0"}
 	if !slices.Contains(schemata, s) {
 		log.Fatalf("Schema %s not found", s)
 	}
-	dir := "schemas"
-	data, err := JSONSchemas.ReadFile(filepath.Join(dir, s+".json"))
+	compiled, err := loadSchema(s)
 	if err != nil {
-		ex, err := os.Executable()
-		if err != nil {
-			panic(err)
-		}
-		exPath := filepath.Dir(ex)
-		fmt.Println(exPath)
 		fmt.Print(err)
+		panic(err.Error())
 	}
-	schemaLoader := gojsonschema.NewStringLoader(string(data))
 	documentLoader := gojsonschema.NewBytesLoader(document)
-	result, err := gojsonschema.Validate(schemaLoader, documentLoader)
+	result, err := compiled.Validate(documentLoader)
 	if err != nil {
 		fmt.Print(err)
 		panic(err.Error())
 	}
 	return result
 }
+
+// loadSchema returns the compiled JSON Schema, compiling it from the
+// embedded file if it is not cached yet.
+func loadSchema(s string) (*gojsonschema.Schema, error) {
+	schemaMutex.RLock()
+	compiled, ok := schemaCache[s]
+	schemaMutex.RUnlock()
+	if ok {
+		return compiled, nil
+	}
+
+	schemaMutex.Lock()
+	defer schemaMutex.Unlock()
+	if compiled, ok := schemaCache[s]; ok {
+		return compiled, nil
+	}
+	dir := "schemas"
+	data, err := JSONSchemas.ReadFile(filepath.Join(dir, s+".json"))
+	if err != nil {
+		ex, exErr := os.Executable()
+		if exErr != nil {
+			panic(exErr)
+		}
+		slog.Error("error reading JSON Schema", "schema", s, "dir", filepath.Dir(ex), "error", err)
+	}
+	schemaLoader := gojsonschema.NewStringLoader(string(data))
+	if root, ok := schemaRoots[s]; ok {
+		compiled, err = compileRoot(schemaLoader, root)
+	} else {
+		compiled, err = gojsonschema.NewSchema(schemaLoader)
+	}
+	if err != nil {
+		return nil, err
+	}
+	schemaCache[s] = compiled
+	return compiled, nil
+}
+
+// compileRoot compiles a schema that validates against the part of the JSON
+// Schema at the JSON pointer root, referenced via the $id of the JSON Schema.
+func compileRoot(schemaLoader gojsonschema.JSONLoader, root string) (*gojsonschema.Schema, error) {
+	doc, err := schemaLoader.LoadJSON()
+	if err != nil {
+		return nil, err
+	}
+	id, _ := doc.(map[string]any)["$id"].(string)
+	sl := gojsonschema.NewSchemaLoader()
+	err = sl.AddSchema(id, schemaLoader)
+	if err != nil {
+		return nil, err
+	}
+	return sl.Compile(gojsonschema.NewGoLoader(map[string]any{"$ref": id + root}))
+}
@@ -12,6 +12,8 @@ import (
 	"os"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/xeipuuv/gojsonschema"
 	"sigs.k8s.io/yaml"
 )
 
@@ -27,7 +29,7 @@ func TestJSONSchemaErrors(t *testing.T) {
 		URL:  "https://elifesciences.org/articles/01567",
 	}
 
-	// missing required ID, defaults to empty string
+	// missing required ID, defaults to empty string, which is not a valid URI
 	n := commonmeta.Data{
 		Type: "JournalArticle",
 	}
@@ -40,8 +42,8 @@ func TestJSONSchemaErrors(t *testing.T) {
 
 	testCases := []testCase{
 		{meta: m, want: 0},
-		{meta: n, want: 0},
-		{meta: o, want: 0},
+		{meta: n, want: 2},
+		{meta: o, want: 2},
 	}
 	for _, tc := range testCases {
 		documentJSON, err := json.Marshal(tc.meta)
@@ -117,3 +119,114 @@ func TestJSONSchemaErrorsTestdataYAML(t *testing.T) {
 		}
 	}
 }
+
+func TestPreloadSchemas(t *testing.T) {
+	t.Parallel()
+	if err := schemautils.PreloadSchemas(); err != nil {
+		t.Errorf("PreloadSchemas: error %v", err)
+	}
+}
+
+// BenchmarkJSONSchemaErrors validates against the cached schema, compare with
+// BenchmarkJSONSchemaErrorsUncached, which compiles the schema for every document.
+func BenchmarkJSONSchemaErrors(b *testing.B) {
+	document, err := json.Marshal(commonmeta.Data{
+		ID:   "https://doi.org/10.7554/elife.01567",
+		Type: "JournalArticle",
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 1000; j++ {
+			schemautils.JSONSchemaErrors(document, "csl-data")
+		}
+	}
+}
+
+func BenchmarkJSONSchemaErrorsUncached(b *testing.B) {
+	document, err := json.Marshal(commonmeta.Data{
+		ID:   "https://doi.org/10.7554/elife.01567",
+		Type: "JournalArticle",
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	schema, err := schemautils.JSONSchemas.ReadFile("schemas/csl-data.json")
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 1000; j++ {
+			_, err := gojsonschema.Validate(gojsonschema.NewBytesLoader(schema), gojsonschema.NewBytesLoader(document))
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func TestSchemaErrors(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		name     string
+		document string
+		want     schemautils.SchemaErrors
+	}
+	testCases := []testCase{
+		{
+			name:     "misspelled type",
+			document: `{"id":"https://doi.org/10.5555/12345678","type":"JournalArticel"}`,
+			want: schemautils.SchemaErrors{
+				{Path: "/type", Value: "JournalArticel", Message: `"JournalArticel" is not an allowed value`, Suggestion: "JournalArticle"},
+			},
+		},
+		{
+			name:     "Crossref type",
+			document: `{"id":"https://doi.org/10.5555/12345678","type":"journal-article"}`,
+			want: schemautils.SchemaErrors{
+				{Path: "/type", Value: "journal-article", Message: `"journal-article" is not an allowed value`, Suggestion: "JournalArticle"},
+			},
+		},
+		{
+			name:     "missing id",
+			document: `{"type":"JournalArticle"}`,
+			want: schemautils.SchemaErrors{
+				{Path: "/id", Message: "missing required property"},
+			},
+		},
+		{
+			name:     "invalid id",
+			document: `{"id":"","type":"JournalArticle"}`,
+			want: schemautils.SchemaErrors{
+				{Path: "/id", Value: "", Message: `"" is not a valid uri`},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		result := schemautils.JSONSchemaErrors([]byte(tc.document))
+		got := schemautils.NewSchemaErrors(result.Errors())
+		if diff := cmp.Diff(tc.want, got); diff != "" {
+			t.Errorf("SchemaErrors (%s) mismatch (-want +got):\n%s", tc.name, diff)
+		}
+	}
+}
+
+func TestSchemaErrorsTable(t *testing.T) {
+	t.Parallel()
+	errs := schemautils.SchemaErrors{
+		{Path: "/id", Message: "missing required property"},
+		{Path: "/type", Value: "JournalArticel", Message: `"JournalArticel" is not an allowed value`, Suggestion: "JournalArticle"},
+	}
+	want := `PATH   VALUE             ERROR
+/id                      missing required property
+/type  "JournalArticel"  "JournalArticel" is not an allowed value, did you mean "JournalArticle"?
+`
+	if diff := cmp.Diff(want, errs.Table()); diff != "" {
+		t.Errorf("SchemaErrors table mismatch (-want +got):\n%s", diff)
+	}
+	wantError := `/id: missing required property; /type: "JournalArticel" is not an allowed value, did you mean "JournalArticle"?`
+	if got := errs.Error(); got != wantError {
+		t.Errorf("SchemaErrors error: want %s, got %s", wantError, got)
+	}
+}
@@ -0,0 +1,200 @@
+// Package spdxutils provides a set of functions to work with SPDX license identifiers
+package spdxutils
+
+import (
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// licenseURLs maps SPDX license IDs to the canonical license URL,
+// abbreviated list from https://spdx.org/licenses/
+var licenseURLs = map[string]string{
+	"CC-BY-1.0":       "https://creativecommons.org/licenses/by/1.0/legalcode",
+	"CC-BY-2.0":       "https://creativecommons.org/licenses/by/2.0/legalcode",
+	"CC-BY-2.5":       "https://creativecommons.org/licenses/by/2.5/legalcode",
+	"CC-BY-3.0":       "https://creativecommons.org/licenses/by/3.0/legalcode",
+	"CC-BY-4.0":       "https://creativecommons.org/licenses/by/4.0/legalcode",
+	"CC-BY-SA-1.0":    "https://creativecommons.org/licenses/by-sa/1.0/legalcode",
+	"CC-BY-SA-2.0":    "https://creativecommons.org/licenses/by-sa/2.0/legalcode",
+	"CC-BY-SA-2.5":    "https://creativecommons.org/licenses/by-sa/2.5/legalcode",
+	"CC-BY-SA-3.0":    "https://creativecommons.org/licenses/by-sa/3.0/legalcode",
+	"CC-BY-SA-4.0":    "https://creativecommons.org/licenses/by-sa/4.0/legalcode",
+	"CC-BY-ND-1.0":    "https://creativecommons.org/licenses/by-nd/1.0/legalcode",
+	"CC-BY-ND-2.0":    "https://creativecommons.org/licenses/by-nd/2.0/legalcode",
+	"CC-BY-ND-2.5":    "https://creativecommons.org/licenses/by-nd/2.5/legalcode",
+	"CC-BY-ND-3.0":    "https://creativecommons.org/licenses/by-nd/3.0/legalcode",
+	"CC-BY-ND-4.0":    "https://creativecommons.org/licenses/by-nd/4.0/legalcode",
+	"CC-BY-NC-1.0":    "https://creativecommons.org/licenses/by-nc/1.0/legalcode",
+	"CC-BY-NC-2.0":    "https://creativecommons.org/licenses/by-nc/2.0/legalcode",
+	"CC-BY-NC-2.5":    "https://creativecommons.org/licenses/by-nc/2.5/legalcode",
+	"CC-BY-NC-3.0":    "https://creativecommons.org/licenses/by-nc/3.0/legalcode",
+	"CC-BY-NC-4.0":    "https://creativecommons.org/licenses/by-nc/4.0/legalcode",
+	"CC-BY-NC-SA-1.0": "https://creativecommons.org/licenses/by-nc-sa/1.0/legalcode",
+	"CC-BY-NC-SA-2.0": "https://creativecommons.org/licenses/by-nc-sa/2.0/legalcode",
+	"CC-BY-NC-SA-2.5": "https://creativecommons.org/licenses/by-nc-sa/2.5/legalcode",
+	"CC-BY-NC-SA-3.0": "https://creativecommons.org/licenses/by-nc-sa/3.0/legalcode",
+	"CC-BY-NC-SA-4.0": "https://creativecommons.org/licenses/by-nc-sa/4.0/legalcode",
+	"CC-BY-NC-ND-1.0": "https://creativecommons.org/licenses/by-nc-nd/1.0/legalcode",
+	"CC-BY-NC-ND-2.0": "https://creativecommons.org/licenses/by-nc-nd/2.0/legalcode",
+	"CC-BY-NC-ND-2.5": "https://creativecommons.org/licenses/by-nc-nd/2.5/legalcode",
+	"CC-BY-NC-ND-3.0": "https://creativecommons.org/licenses/by-nc-nd/3.0/legalcode",
+	"CC-BY-NC-ND-4.0": "https://creativecommons.org/licenses/by-nc-nd/4.0/legalcode",
+	"CC0-1.0":         "https://creativecommons.org/publicdomain/zero/1.0/legalcode",
+	"Apache-2.0":      "https://opensource.org/licenses/Apache-2.0",
+	"BSD-2-Clause":    "https://opensource.org/licenses/BSD-2-Clause",
+	"BSD-3-Clause":    "https://opensource.org/licenses/BSD-3-Clause",
+	"GPL-2.0":         "https://opensource.org/licenses/GPL-2.0",
+	"GPL-3.0":         "https://opensource.org/licenses/GPL-3.0",
+	"LGPL-3.0":        "https://opensource.org/licenses/LGPL-3.0",
+	"MIT":             "https://opensource.org/licenses/MIT",
+	"MPL-2.0":         "https://opensource.org/licenses/MPL-2.0",
+}
+
+// licenseAliases maps other license URLs, without scheme and trailing
+// slash and in lowercase, to SPDX license IDs.
+var licenseAliases = map[string]string{
+	"creativecommons.org/licenses/by/3.0/us":       "CC-BY-3.0",
+	"creativecommons.org/licenses/by-nc-sa/3.0/us": "CC-BY-NC-SA-3.0",
+	"creativecommons.org/licenses/by-nd-nc/1.0":    "CC-BY-NC-ND-1.0",
+	"creativecommons.org/licenses/publicdomain":    "CC0-1.0",
+	"opensource.org/licenses/mit-license.php":      "MIT",
+	"opensource.org/licenses/apache2.0.php":        "Apache-2.0",
+	"www.apache.org/licenses/license-2.0":          "Apache-2.0",
+	"apache.org/licenses/license-2.0":              "Apache-2.0",
+	"www.gnu.org/licenses/gpl-3.0":                 "GPL-3.0",
+	"www.gnu.org/licenses/gpl-2.0":                 "GPL-2.0",
+	"www.gnu.org/licenses/lgpl-3.0":                "LGPL-3.0",
+	"www.mozilla.org/en-us/mpl/2.0":                "MPL-2.0",
+	"mozilla.org/mpl/2.0":                          "MPL-2.0",
+	"opensource.org/licenses/bsd-license.php":      "BSD-2-Clause",
+	"opensource.org/licenses/bsd-3-clause.php":     "BSD-3-Clause",
+	"opensource.org/licenses/gpl-license.php":      "GPL-2.0",
+	"opensource.org/licenses/gpl-3.0.html":         "GPL-3.0",
+	"opensource.org/licenses/lgpl-3.0.html":        "LGPL-3.0",
+	"opensource.org/licenses/mpl-2.0.php":          "MPL-2.0",
+}
+
+// suffixes of Creative Commons URLs pointing to the deed or a translation
+// of the legal code
+var ccSuffixRegex = regexp.MustCompile(`/(?:legalcode|deed)(?:\.[a-z_-]+)?$`)
+
+// Normalize returns the SPDX license ID and the canonical URL of a license,
+// given as URL, SPDX license ID, or a name like "CC BY 4.0". The ID is empty
+// for unknown licenses, unknown license URLs are returned normalized.
+func Normalize(license string) (string, string) {
+	license = strings.TrimSpace(license)
+	if license == "" {
+		return "", ""
+	}
+	if strings.HasPrefix(license, "http://") || strings.HasPrefix(license, "https://") {
+		url, _ := NormalizeURL(license)
+		return URLToID(url), url
+	}
+	id := ValidateID(license)
+	if id == "" {
+		// license names, e.g. "CC BY 4.0" or "MIT License"
+		name := strings.TrimSuffix(strings.TrimSpace(license), " License")
+		id = ValidateID(strings.Join(strings.Fields(name), "-"))
+	}
+	return id, IDToURL(id)
+}
+
+// NormalizeURL returns the canonical URL of a license, e.g. the legal code
+// of a Creative Commons license, and whether the license is known. Unknown
+// license URLs are returned using https and without trailing slash.
+func NormalizeURL(str string) (string, bool) {
+	key, u := urlKey(str)
+	if u == nil {
+		return "", false
+	}
+	id := lookup(key)
+	if id == "" {
+		return u.String(), false
+	}
+	return licenseURLs[id], true
+}
+
+// URLToID returns the SPDX license ID of a license URL, or an empty string
+// if the license is not known.
+func URLToID(str string) string {
+	key, u := urlKey(str)
+	if u == nil {
+		return ""
+	}
+	return lookup(key)
+}
+
+// IDToURL returns the canonical URL of a license given its SPDX license ID.
+// The comparison is case-insensitive.
+func IDToURL(id string) string {
+	return licenseURLs[ValidateID(id)]
+}
+
+// ValidateID returns the SPDX license ID in its canonical spelling, or an
+// empty string if the license is not known.
+func ValidateID(id string) string {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return ""
+	}
+	if _, ok := licenseURLs[id]; ok {
+		return id
+	}
+	for k := range licenseURLs {
+		if strings.EqualFold(k, id) {
+			return k
+		}
+	}
+	return ""
+}
+
+// lookup returns the SPDX license ID for a normalized URL key
+func lookup(key string) string {
+	if id, ok := licenseAliases[key]; ok {
+		return id
+	}
+	// SPDX license list, e.g. https://spdx.org/licenses/MIT.html
+	if strings.HasPrefix(key, "spdx.org/licenses/") {
+		id := strings.TrimSuffix(strings.TrimSuffix(path.Base(key), ".html"), ".json")
+		return ValidateID(id)
+	}
+	for id, v := range licenseURLs {
+		k, _ := urlKey(v)
+		if k == key {
+			return id
+		}
+	}
+	return ""
+}
+
+// urlKey parses a license URL and returns it using https and without
+// trailing slash, together with a key for lookups: host and path in
+// lowercase, without deed or translation of a Creative Commons license.
+func urlKey(str string) (string, *url.URL) {
+	str = strings.TrimSpace(str)
+	if str == "" {
+		return "", nil
+	}
+	u, err := url.Parse(str)
+	if err != nil || u.Host == "" {
+		return "", nil
+	}
+	if u.Path != "" && len(u.RawQuery) == 0 && u.Path[len(u.Path)-1] == '/' {
+		u.Path = u.Path[:len(u.Path)-1]
+	}
+	if u.Scheme == "http" {
+		u.Scheme = "https"
+	}
+	key := strings.ToLower(u.Host + u.Path)
+	if strings.HasPrefix(key, "creativecommons.org/") || strings.HasPrefix(key, "www.creativecommons.org/") {
+		key = strings.TrimPrefix(key, "www.")
+		key = ccSuffixRegex.ReplaceAllString(key, "")
+		key = strings.TrimSuffix(key, "/")
+	}
+	if strings.HasPrefix(key, "www.opensource.org/") {
+		key = strings.TrimPrefix(key, "www.")
+	}
+	return key, u
+}
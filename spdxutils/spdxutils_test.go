@@ -0,0 +1,68 @@
+package spdxutils_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/front-matter/commonmeta/spdxutils"
+)
+
+func TestNormalize(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input string
+		id    string
+		url   string
+	}
+	testCases := []testCase{
+		{input: "https://creativecommons.org/licenses/by/4.0/", id: "CC-BY-4.0", url: "https://creativecommons.org/licenses/by/4.0/legalcode"},
+		{input: "http://creativecommons.org/licenses/by/4.0", id: "CC-BY-4.0", url: "https://creativecommons.org/licenses/by/4.0/legalcode"},
+		{input: "https://creativecommons.org/licenses/by-nd/4.0/deed.de", id: "CC-BY-ND-4.0", url: "https://creativecommons.org/licenses/by-nd/4.0/legalcode"},
+		{input: "https://creativecommons.org/publicdomain/zero/1.0/", id: "CC0-1.0", url: "https://creativecommons.org/publicdomain/zero/1.0/legalcode"},
+		{input: "https://opensource.org/licenses/MIT", id: "MIT", url: "https://opensource.org/licenses/MIT"},
+		{input: "http://www.apache.org/licenses/LICENSE-2.0", id: "Apache-2.0", url: "https://opensource.org/licenses/Apache-2.0"},
+		{input: "https://spdx.org/licenses/BSD-3-Clause.html", id: "BSD-3-Clause", url: "https://opensource.org/licenses/BSD-3-Clause"},
+		{input: "cc-by-4.0", id: "CC-BY-4.0", url: "https://creativecommons.org/licenses/by/4.0/legalcode"},
+		{input: "CC BY-NC 4.0", id: "CC-BY-NC-4.0", url: "https://creativecommons.org/licenses/by-nc/4.0/legalcode"},
+		{input: "MIT License", id: "MIT", url: "https://opensource.org/licenses/MIT"},
+		{input: "http://www.elsevier.com/tdm/userlicense/1.0/", id: "", url: "https://www.elsevier.com/tdm/userlicense/1.0"},
+		{input: "All rights reserved", id: "", url: ""},
+		{input: "", id: "", url: ""},
+	}
+	for _, tc := range testCases {
+		id, url := spdxutils.Normalize(tc.input)
+		if tc.id != id || tc.url != url {
+			t.Errorf("Normalize(%v): want %v %v, got %v %v",
+				tc.input, tc.id, tc.url, id, url)
+		}
+	}
+}
+
+func TestURLToID(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input string
+		want  string
+	}
+	testCases := []testCase{
+		{input: "https://creativecommons.org/licenses/by/4.0/legalcode", want: "CC-BY-4.0"},
+		{input: "https://creativecommons.org/licenses/by/4.0", want: "CC-BY-4.0"},
+		{input: "https://creativecommons.org/licenses/by-sa/3.0/us/", want: ""},
+		{input: "https://creativecommons.org/licenses/by/3.0/us/", want: "CC-BY-3.0"},
+		{input: "https://example.org/license", want: ""},
+		{input: "CC-BY-4.0", want: ""},
+	}
+	for _, tc := range testCases {
+		got := spdxutils.URLToID(tc.input)
+		if tc.want != got {
+			t.Errorf("URLToID(%v): want %v, got %v", tc.input, tc.want, got)
+		}
+	}
+}
+
+func ExampleIDToURL() {
+	s := spdxutils.IDToURL("cc-by-4.0")
+	fmt.Println(s)
+	// Output:
+	// https://creativecommons.org/licenses/by/4.0/legalcode
+}
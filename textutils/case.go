@@ -0,0 +1,135 @@
+package textutils
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ProperNouns are words that are always capitalized when changing the case
+// of a title. Words that are also common words, e.g. May, are not included.
+// Acronyms and words with mixed case such as DNA, pH or mRNA are detected
+// and don't need to be listed.
+var ProperNouns = []string{
+	"I",
+	"Africa", "African", "America", "American", "Asia", "Asian", "Australia", "Australian",
+	"Europe", "European", "English", "French", "German", "Latin",
+	"January", "February", "April", "June", "July",
+	"September", "October", "November", "December",
+	"Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday", "Sunday",
+	"Arabidopsis", "Drosophila", "Escherichia", "Darwin", "Einstein", "Bayesian",
+	"Gaussian", "Markov", "Newton", "Newtonian", "Internet",
+}
+
+// minorWords are not capitalized in title case, unless they are the first or
+// last word, or follow a colon.
+var minorWords = []string{
+	"a", "an", "and", "as", "at", "but", "by", "for", "from", "in", "into",
+	"nor", "of", "on", "or", "per", "the", "to", "via", "vs", "with",
+}
+
+// SentenceCase converts a title to sentence case: the first word and the
+// first word after a colon are capitalized, all other words are lowercased.
+// Acronyms, words with mixed case, proper nouns and text in braces (as used
+// in BibTeX) are preserved.
+func SentenceCase(str string) string {
+	return changeCase(str, func(word string, first, last bool) string {
+		if first {
+			return capitalize(strings.ToLower(word))
+		}
+		return strings.ToLower(word)
+	})
+}
+
+// TitleCase converts a title to title case: all words are capitalized
+// except articles, conjunctions and short prepositions, unless they are the
+// first or last word or follow a colon. Acronyms, words with mixed case,
+// proper nouns and text in braces are preserved.
+func TitleCase(str string) string {
+	return changeCase(str, func(word string, first, last bool) string {
+		lower := strings.ToLower(word)
+		if !first && !last && slices.Contains(minorWords, strings.TrimFunc(lower, isPunct)) {
+			return lower
+		}
+		// capitalize each part of a hyphenated compound
+		parts := strings.Split(lower, "-")
+		for i := range parts {
+			parts[i] = capitalize(parts[i])
+		}
+		return strings.Join(parts, "-")
+	})
+}
+
+// changeCase applies fn to each word of str that is not preserved. first is
+// true for the first word and the first word after a colon, last for the
+// last word.
+func changeCase(str string, fn func(word string, first, last bool) string) string {
+	words := strings.Split(str, " ")
+	first := true
+	depth := 0 // nesting of braces
+	for i, word := range words {
+		if word == "" {
+			continue
+		}
+		braced := depth > 0 || strings.HasPrefix(word, "{")
+		depth += strings.Count(word, "{") - strings.Count(word, "}")
+		if !braced && !isPreserved(word) {
+			words[i] = fn(word, first, i == len(words)-1)
+		} else if !braced {
+			words[i] = properNoun(word)
+		}
+		first = strings.HasSuffix(word, ":") || strings.HasSuffix(word, "?") || strings.HasSuffix(word, "!")
+	}
+	return strings.Join(words, " ")
+}
+
+// isPreserved returns true for acronyms, words with mixed case, words
+// containing braces, and proper nouns.
+func isPreserved(word string) bool {
+	if strings.ContainsAny(word, "{}") {
+		return true
+	}
+	trimmed := strings.TrimFunc(word, isPunct)
+	for _, part := range strings.Split(trimmed, "-") {
+		// an uppercase letter after the first letter, e.g. DNA, pH or mRNA,
+		// checked for each part of a hyphenated compound such as Meta-Analysis
+		for i, r := range part {
+			if i > 0 && unicode.IsUpper(r) {
+				return true
+			}
+		}
+	}
+	return slices.ContainsFunc(ProperNouns, func(noun string) bool {
+		return strings.EqualFold(noun, trimmed)
+	})
+}
+
+// properNoun returns a proper noun in its canonical spelling, other words
+// unchanged.
+func properNoun(word string) string {
+	trimmed := strings.TrimFunc(word, isPunct)
+	i := slices.IndexFunc(ProperNouns, func(noun string) bool {
+		return strings.EqualFold(noun, trimmed)
+	})
+	if i == -1 || trimmed == "" {
+		return word
+	}
+	return strings.Replace(word, trimmed, ProperNouns[i], 1)
+}
+
+// capitalize uppercases the first letter of a word, skipping leading
+// punctuation such as quotes or parentheses.
+func capitalize(word string) string {
+	i := strings.IndexFunc(word, unicode.IsLetter)
+	if i == -1 {
+		return word
+	}
+	r, size := utf8.DecodeRuneInString(word[i:])
+	return word[:i] + string(unicode.ToUpper(r)) + word[i+size:]
+}
+
+// isPunct returns true for characters that are not letters or digits.
+func isPunct(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
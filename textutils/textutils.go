@@ -0,0 +1,111 @@
+// Package textutils provides a set of functions to work with text, e.g. to
+// convert HTML or JATS markup into plain text.
+package textutils
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Option configures how markup is stripped.
+type Option func(*options)
+
+type options struct {
+	formatting bool
+}
+
+// WithFormatting keeps basic inline formatting (b, i, em, strong, sub, sup)
+// as HTML tags, JATS formatting tags are converted to their HTML equivalent.
+func WithFormatting() Option {
+	return func(o *options) {
+		o.formatting = true
+	}
+}
+
+var (
+	// JATS inline formatting and their HTML equivalent
+	jatsFormattingRegexp = regexp.MustCompile(`<(/?)jats:(italic|bold|sub|sup)>`)
+	jatsFormatting       = map[string]string{"italic": "i", "bold": "b", "sub": "sub", "sup": "sup"}
+
+	// block elements separating paragraphs
+	blockRegexp = regexp.MustCompile(`(?i)</?(?:jats:)?(?:p|sec|title|list|list-item|div|h[1-6]|ul|ol|li|blockquote)(?:\s[^>]*)?/?>`)
+	breakRegexp = regexp.MustCompile(`(?i)<(?:jats:)?br\s*/?>`)
+	tagRegexp   = regexp.MustCompile(`</?([a-zA-Z][\w:.-]*)(?:\s[^>]*)?/?>`)
+
+	paragraphRegexp  = regexp.MustCompile(`\n\s*\n`)
+	whitespaceRegexp = regexp.MustCompile(`[ \t\r\n]+`)
+
+	// named and numeric character references, terminated by a semicolon
+	entityRegexp = regexp.MustCompile(`&(?:#[0-9]+|#[xX][0-9a-fA-F]+|[a-zA-Z][a-zA-Z0-9]*);`)
+)
+
+// formattingTags are the tags kept with the WithFormatting option.
+var formattingTags = []string{"b", "i", "em", "strong", "sub", "sup"}
+
+// StripMarkup removes HTML and JATS tags, e.g. from abstracts, and unescapes
+// HTML entities. Paragraphs are separated by a blank line, whitespace within
+// a paragraph is collapsed.
+func StripMarkup(str string, opts ...Option) string {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	str = jatsFormattingRegexp.ReplaceAllStringFunc(str, func(tag string) string {
+		m := jatsFormattingRegexp.FindStringSubmatch(tag)
+		return "<" + m[1] + jatsFormatting[m[2]] + ">"
+	})
+	str = blockRegexp.ReplaceAllString(str, "\n\n")
+	str = breakRegexp.ReplaceAllString(str, "\n\n")
+	str = tagRegexp.ReplaceAllStringFunc(str, func(tag string) string {
+		if o.formatting {
+			name := strings.ToLower(tagRegexp.FindStringSubmatch(tag)[1])
+			for _, v := range formattingTags {
+				if name == v {
+					// drop attributes
+					if strings.HasPrefix(tag, "</") {
+						return "</" + name + ">"
+					}
+					return "<" + name + ">"
+				}
+			}
+		}
+		return ""
+	})
+	str = decodeEntities(str, o.formatting)
+
+	var paragraphs []string
+	for _, p := range paragraphRegexp.Split(str, -1) {
+		// collapse whitespace, but keep non-breaking spaces
+		p = strings.TrimSpace(whitespaceRegexp.ReplaceAllString(p, " "))
+		if p != "" {
+			paragraphs = append(paragraphs, p)
+		}
+	}
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// DecodeEntities decodes named and numeric HTML entities such as &amp; or
+// &#x2013;, e.g. in titles. Only entities terminated by a semicolon are
+// decoded, and decoding is done once, so "AT&T" or "&amp;lt;" are not
+// decoded twice.
+func DecodeEntities(str string) string {
+	return decodeEntities(str, false)
+}
+
+// decodeEntities decodes HTML entities, keeping &lt;, &gt; and &amp; if the
+// string contains markup.
+func decodeEntities(str string, markup bool) string {
+	return entityRegexp.ReplaceAllStringFunc(str, func(entity string) string {
+		if markup && (entity == "&lt;" || entity == "&gt;" || entity == "&amp;") {
+			return entity
+		}
+		decoded := html.UnescapeString(entity)
+		// unknown entity, or only a prefix such as &not in &notanentity;
+		if strings.HasSuffix(decoded, ";") {
+			return entity
+		}
+		return decoded
+	})
+}
@@ -0,0 +1,130 @@
+package textutils_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/front-matter/commonmeta/textutils"
+)
+
+const abstract = `<jats:sec>
+  <jats:title>Background</jats:title>
+  <jats:p>Among various advantages, their <jats:italic>small size</jats:italic> makes
+    model organisms preferred subjects of investigation.</jats:p>
+</jats:sec>
+<jats:sec>
+  <jats:title>Results</jats:title>
+  <jats:p>CO<jats:sub>2</jats:sub> levels rose by 5&#x00A0;% &amp; more.</jats:p>
+</jats:sec>`
+
+func TestStripMarkup(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input string
+		opts  []textutils.Option
+		want  string
+	}
+	testCases := []testCase{
+		{
+			input: abstract,
+			want:  "Background\n\nAmong various advantages, their small size makes model organisms preferred subjects of investigation.\n\nResults\n\nCO2 levels rose by 5\u00a0% & more.",
+		},
+		{
+			input: abstract,
+			opts:  []textutils.Option{textutils.WithFormatting()},
+			want:  "Background\n\nAmong various advantages, their <i>small size</i> makes model organisms preferred subjects of investigation.\n\nResults\n\nCO<sub>2</sub> levels rose by 5\u00a0% &amp; more.",
+		},
+		{
+			input: `<p>First <a href="https://example.org">paragraph</a>.<br/>Second line</p><p class="x"><strong>Bold</strong> text</p>`,
+			want:  "First paragraph.\n\nSecond line\n\nBold text",
+		},
+		{
+			input: `<p class="x"><strong style="color: red">Bold</strong> text</p>`,
+			opts:  []textutils.Option{textutils.WithFormatting()},
+			want:  "<strong>Bold</strong> text",
+		},
+		{input: "Plain text", want: "Plain text"},
+		{input: "", want: ""},
+	}
+	for _, tc := range testCases {
+		got := textutils.StripMarkup(tc.input, tc.opts...)
+		if tc.want != got {
+			t.Errorf("StripMarkup(%q): want %q, got %q", tc.input, tc.want, got)
+		}
+	}
+}
+
+func TestDecodeEntities(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input string
+		want  string
+	}
+	testCases := []testCase{
+		{input: "Fish &amp; Chips", want: "Fish & Chips"},
+		{input: "Pages 1&#x2013;10 &mdash; revisited", want: "Pages 1–10 — revisited"},
+		{input: "Caf&#233; &eacute;t&eacute;", want: "Café été"},
+		{input: "&lt;i&gt;Drosophila&lt;/i&gt;", want: "<i>Drosophila</i>"},
+		{input: "&amp;lt; is escaped once", want: "&lt; is escaped once"},
+		{input: "AT&T and R&D", want: "AT&T and R&D"},
+		{input: "Fish & Chips – clean", want: "Fish & Chips – clean"},
+		{input: "&notanentity; &copy", want: "&notanentity; &copy"},
+	}
+	for _, tc := range testCases {
+		got := textutils.DecodeEntities(tc.input)
+		if tc.want != got {
+			t.Errorf("DecodeEntities(%q): want %q, got %q", tc.input, tc.want, got)
+		}
+	}
+}
+
+func ExampleStripMarkup() {
+	s := textutils.StripMarkup("<jats:p>First paragraph.</jats:p><jats:p>Second <jats:italic>paragraph</jats:italic>.</jats:p>")
+	fmt.Println(s)
+	// Output:
+	// First paragraph.
+	//
+	// Second paragraph.
+}
+
+func TestSentenceCase(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input string
+		want  string
+	}
+	testCases := []testCase{
+		{input: "The Role of DNA Methylation in Plant Development", want: "The role of DNA methylation in plant development"},
+		{input: "Soil pH Controls Nitrogen Uptake: A Global Meta-Analysis", want: "Soil pH controls nitrogen uptake: A global meta-analysis"},
+		{input: "mRNA Vaccines in Europe and Africa", want: "mRNA vaccines in Europe and Africa"},
+		{input: "Gene Expression in {Arabidopsis Thaliana} Roots", want: "Gene expression in {Arabidopsis Thaliana} roots"},
+		{input: "What Is Open Science? A Review", want: "What is open science? A review"},
+		{input: "", want: ""},
+	}
+	for _, tc := range testCases {
+		got := textutils.SentenceCase(tc.input)
+		if tc.want != got {
+			t.Errorf("SentenceCase(%q): want %q, got %q", tc.input, tc.want, got)
+		}
+	}
+}
+
+func TestTitleCase(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input string
+		want  string
+	}
+	testCases := []testCase{
+		{input: "the role of DNA methylation in plant development", want: "The Role of DNA Methylation in Plant Development"},
+		{input: "soil pH controls nitrogen uptake: a global meta-analysis", want: "Soil pH Controls Nitrogen Uptake: A Global Meta-Analysis"},
+		{input: "what the data are for", want: "What the Data Are For"},
+		{input: "a study of {eLife} and \"open\" peer review", want: "A Study of {eLife} and \"Open\" Peer Review"},
+	}
+	for _, tc := range testCases {
+		got := textutils.TitleCase(tc.input)
+		if tc.want != got {
+			t.Errorf("TitleCase(%q): want %q, got %q", tc.input, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Cache is an on-disk cache, e.g. for API responses. Entries older than
+// TTL are ignored, a TTL of zero means entries never expire.
+type Cache struct {
+	Dir string
+	TTL time.Duration
+}
+
+// NewCache returns a cache storing entries in dir.
+func NewCache(dir string, ttl time.Duration) *Cache {
+	return &Cache{Dir: dir, TTL: ttl}
+}
+
+// Get returns the cached value for key, if it exists and has not expired.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	if c == nil || c.Dir == "" {
+		return nil, false
+	}
+	filename := c.filename(key)
+	info, err := os.Stat(filename)
+	if err != nil {
+		return nil, false
+	}
+	if c.TTL > 0 && time.Since(info.ModTime()) > c.TTL {
+		return nil, false
+	}
+	value, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// Set stores value for key, creating the cache directory if needed.
+func (c *Cache) Set(key string, value []byte) error {
+	if c == nil || c.Dir == "" {
+		return nil
+	}
+	err := os.MkdirAll(c.Dir, 0o755)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.filename(key), value, 0o644)
+}
+
+// filename returns the file for key, using a hash as keys can contain slashes.
+func (c *Cache) filename(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(c.Dir, hex.EncodeToString(hash[:])+".json")
+}
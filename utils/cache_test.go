@@ -0,0 +1,38 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/front-matter/commonmeta/utils"
+)
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	cache := utils.NewCache(t.TempDir(), time.Hour)
+	_, ok := cache.Get("crossref/10.5555/12345678")
+	if ok {
+		t.Errorf("Cache Get: want miss before Set")
+	}
+	err := cache.Set("crossref/10.5555/12345678", []byte(`{"DOI": "10.5555/12345678"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, ok := cache.Get("crossref/10.5555/12345678")
+	if !ok || string(got) != `{"DOI": "10.5555/12345678"}` {
+		t.Errorf("Cache Get: want hit, got %s (%v)", got, ok)
+	}
+	_, ok = cache.Get("datacite/10.5555/12345678")
+	if ok {
+		t.Errorf("Cache Get: want miss for different source")
+	}
+
+	// expired entries are ignored
+	expired := utils.NewCache(cache.Dir, time.Nanosecond)
+	time.Sleep(time.Millisecond)
+	_, ok = expired.Get("crossref/10.5555/12345678")
+	if ok {
+		t.Errorf("Cache Get: want miss for expired entry")
+	}
+}
@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Retry configures retries of HTTP requests with exponential backoff.
+// MaxDelay is the longest delay between attempts, and defaults to
+// DefaultMaxDelay if zero.
+type Retry struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// DefaultMaxDelay is the longest delay between attempts if Retry.MaxDelay is
+// not set.
+const DefaultMaxDelay = 60 * time.Second
+
+// DefaultRetry retries a request up to two times, after 0.5 and 1 seconds
+// plus jitter.
+var DefaultRetry = Retry{MaxAttempts: 3, BaseDelay: 500 * time.Millisecond}
+
+// DoWithRetry sends a request without body, retrying on network errors,
+// 429 and 5xx responses. The delay between attempts doubles with every
+// attempt, plus random jitter, or is taken from the Retry-After header.
+// The delay is capped at retry.MaxDelay, and if the Retry-After header asks
+// for a longer delay the response is returned without retrying. Retries stop
+// when the context of the request is done. Requests and retries are logged
+// at debug level.
+func DoWithRetry(client *http.Client, req *http.Request, retry Retry) (*http.Response, error) {
+	var resp *http.Response
+	var err error
+	for attempt := 0; ; attempt++ {
+		slog.Debug("request", "method", req.Method, "url", req.URL.String())
+		resp, err = client.Do(req)
+		if err == nil && resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+			return resp, nil
+		}
+		if attempt+1 >= retry.MaxAttempts || req.Context().Err() != nil {
+			return resp, err
+		}
+
+		maxDelay := retry.MaxDelay
+		if maxDelay <= 0 {
+			maxDelay = DefaultMaxDelay
+		}
+		delay := retry.BaseDelay << attempt
+		if retry.BaseDelay > 0 {
+			delay += time.Duration(rand.Int63n(int64(retry.BaseDelay)))
+		}
+		delay = min(delay, maxDelay)
+		if resp != nil {
+			if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+				if retryAfter > maxDelay {
+					slog.Debug("not retrying request", "url", req.URL.String(), "retry-after", retryAfter)
+					return resp, nil
+				}
+				delay = retryAfter
+			}
+			resp.Body.Close()
+		}
+		slog.Debug("retrying request", "url", req.URL.String(), "attempt", attempt+2, "delay", delay)
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// parseRetryAfter parses the Retry-After header, given in seconds or as HTTP date.
+func parseRetryAfter(str string) (time.Duration, bool) {
+	if str == "" {
+		return 0, false
+	}
+	seconds, err := strconv.Atoi(str)
+	if err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	t, err := http.ParseTime(str)
+	if err == nil {
+		return max(time.Until(t), 0), true
+	}
+	return 0, false
+}
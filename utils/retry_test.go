@@ -0,0 +1,54 @@
+package utils_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/front-matter/commonmeta/utils"
+)
+
+func TestDoWithRetryMaxDelay(t *testing.T) {
+	t.Parallel()
+
+	type testCase struct {
+		name       string
+		retryAfter string
+		requests   int32
+		status     int
+	}
+
+	testCases := []testCase{
+		{name: "within max delay", retryAfter: "0", requests: 2, status: http.StatusOK},
+		{name: "exceeds max delay", retryAfter: "3600", requests: 1, status: http.StatusTooManyRequests},
+	}
+	for _, tc := range testCases {
+		var requests int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&requests, 1) == 1 {
+				w.Header().Set("Retry-After", tc.retryAfter)
+				w.WriteHeader(http.StatusTooManyRequests)
+			}
+		}))
+
+		req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		retry := utils.Retry{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Second}
+		resp, err := utils.DoWithRetry(http.DefaultClient, req, retry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		server.Close()
+		if resp.StatusCode != tc.status {
+			t.Errorf("DoWithRetry (%v): want status %d, got %d", tc.name, tc.status, resp.StatusCode)
+		}
+		if requests != tc.requests {
+			t.Errorf("DoWithRetry (%v): want %d requests, got %d", tc.name, tc.requests, requests)
+		}
+	}
+}
@@ -2,18 +2,22 @@
 package utils
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"io"
 	"log"
+	"log/slog"
 	"net/http"
 	"net/url"
+	"path"
 	"regexp"
 	"strings"
 	"time"
 
 	"github.com/front-matter/commonmeta/crockford"
 	"github.com/front-matter/commonmeta/doiutils"
+	"github.com/front-matter/commonmeta/spdxutils"
 	"github.com/microcosm-cc/bluemonday"
 )
 
@@ -85,89 +89,22 @@ func NormalizeURL(str string, secure bool, lower bool) (string, error) {
 	return u.String(), nil
 }
 
-// NormalizeCCUrl returns the normalized Creative Commons License URL
+// NormalizeCCUrl returns the normalized license URL, e.g. the legal code of
+// a Creative Commons license. It wraps spdxutils.NormalizeURL.
 func NormalizeCCUrl(url string) (string, bool) {
-	NormalizedLicenses := map[string]string{
-		"https://creativecommons.org/licenses/by/1.0":          "https://creativecommons.org/licenses/by/1.0/legalcode",
-		"https://creativecommons.org/licenses/by/2.0":          "https://creativecommons.org/licenses/by/2.0/legalcode",
-		"https://creativecommons.org/licenses/by/2.5":          "https://creativecommons.org/licenses/by/2.5/legalcode",
-		"https://creativecommons.org/licenses/by/3.0":          "https://creativecommons.org/licenses/by/3.0/legalcode",
-		"https://creativecommons.org/licenses/by/3.0/us":       "https://creativecommons.org/licenses/by/3.0/legalcode",
-		"https://creativecommons.org/licenses/by/4.0":          "https://creativecommons.org/licenses/by/4.0/legalcode",
-		"https://creativecommons.org/licenses/by-nc/1.0":       "https://creativecommons.org/licenses/by-nc/1.0/legalcode",
-		"https://creativecommons.org/licenses/by-nc/2.0":       "https://creativecommons.org/licenses/by-nc/2.0/legalcode",
-		"https://creativecommons.org/licenses/by-nc/2.5":       "https://creativecommons.org/licenses/by-nc/2.5/legalcode",
-		"https://creativecommons.org/licenses/by-nc/3.0":       "https://creativecommons.org/licenses/by-nc/3.0/legalcode",
-		"https://creativecommons.org/licenses/by-nc/4.0":       "https://creativecommons.org/licenses/by-nc/4.0/legalcode",
-		"https://creativecommons.org/licenses/by-nd-nc/1.0":    "https://creativecommons.org/licenses/by-nd-nc/1.0/legalcode",
-		"https://creativecommons.org/licenses/by-nd-nc/2.0":    "https://creativecommons.org/licenses/by-nd-nc/2.0/legalcode",
-		"https://creativecommons.org/licenses/by-nd-nc/2.5":    "https://creativecommons.org/licenses/by-nd-nc/2.5/legalcode",
-		"https://creativecommons.org/licenses/by-nd-nc/3.0":    "https://creativecommons.org/licenses/by-nd-nc/3.0/legalcode",
-		"https://creativecommons.org/licenses/by-nd-nc/4.0":    "https://creativecommons.org/licenses/by-nd-nc/4.0/legalcode",
-		"https://creativecommons.org/licenses/by-nc-sa/1.0":    "https://creativecommons.org/licenses/by-nc-sa/1.0/legalcode",
-		"https://creativecommons.org/licenses/by-nc-sa/2.0":    "https://creativecommons.org/licenses/by-nc-sa/2.0/legalcode",
-		"https://creativecommons.org/licenses/by-nc-sa/2.5":    "https://creativecommons.org/licenses/by-nc-sa/2.5/legalcode",
-		"https://creativecommons.org/licenses/by-nc-sa/3.0":    "https://creativecommons.org/licenses/by-nc-sa/3.0/legalcode",
-		"https://creativecommons.org/licenses/by-nc-sa/3.0/us": "https://creativecommons.org/licenses/by-nc-sa/3.0/legalcode",
-		"https://creativecommons.org/licenses/by-nc-sa/4.0":    "https://creativecommons.org/licenses/by-nc-sa/4.0/legalcode",
-		"https://creativecommons.org/licenses/by-nd/1.0":       "https://creativecommons.org/licenses/by-nd/1.0/legalcode",
-		"https://creativecommons.org/licenses/by-nd/2.0":       "https://creativecommons.org/licenses/by-nd/2.0/legalcode",
-		"https://creativecommons.org/licenses/by-nd/2.5":       "https://creativecommons.org/licenses/by-nd/2.5/legalcode",
-		"https://creativecommons.org/licenses/by-nd/3.0":       "https://creativecommons.org/licenses/by-nd/3.0/legalcode",
-		"https://creativecommons.org/licenses/by-nd/4.0":       "https://creativecommons.org/licenses/by-nd/2.0/legalcode",
-		"https://creativecommons.org/licenses/by-sa/1.0":       "https://creativecommons.org/licenses/by-sa/1.0/legalcode",
-		"https://creativecommons.org/licenses/by-sa/2.0":       "https://creativecommons.org/licenses/by-sa/2.0/legalcode",
-		"https://creativecommons.org/licenses/by-sa/2.5":       "https://creativecommons.org/licenses/by-sa/2.5/legalcode",
-		"https://creativecommons.org/licenses/by-sa/3.0":       "https://creativecommons.org/licenses/by-sa/3.0/legalcode",
-		"https://creativecommons.org/licenses/by-sa/4.0":       "https://creativecommons.org/licenses/by-sa/4.0/legalcode",
-		"https://creativecommons.org/licenses/by-nc-nd/1.0":    "https://creativecommons.org/licenses/by-nc-nd/1.0/legalcode",
-		"https://creativecommons.org/licenses/by-nc-nd/2.0":    "https://creativecommons.org/licenses/by-nc-nd/2.0/legalcode",
-		"https://creativecommons.org/licenses/by-nc-nd/2.5":    "https://creativecommons.org/licenses/by-nc-nd/2.5/legalcode",
-		"https://creativecommons.org/licenses/by-nc-nd/3.0":    "https://creativecommons.org/licenses/by-nc-nd/3.0/legalcode",
-		"https://creativecommons.org/licenses/by-nc-nd/4.0":    "https://creativecommons.org/licenses/by-nc-nd/4.0/legalcode",
-		"https://creativecommons.org/licenses/publicdomain":    "https://creativecommons.org/licenses/publicdomain/",
-		"https://creativecommons.org/publicdomain/zero/1.0":    "https://creativecommons.org/publicdomain/zero/1.0/legalcode",
-	}
-
-	if url == "" {
-		return "", false
-	}
-	var err error
-	url, err = NormalizeURL(url, true, false)
-	if err != nil {
-		return "", false
-	}
-	normalizedURL, ok := NormalizedLicenses[url]
-	if !ok {
-		return url, false
-	}
-	return normalizedURL, true
+	return spdxutils.NormalizeURL(url)
 }
 
-// URLToSPDX provides the SPDX license ID given a Creative Commons URL
+// URLToSPDX provides the SPDX license ID given a license URL. It wraps
+// spdxutils.URLToID.
 func URLToSPDX(url string) string {
-	// appreviated list from https://spdx.org/licenses/
-	SPDXLicenses := map[string]string{
-		"https://creativecommons.org/licenses/by/3.0/legalcode":       "CC-BY-3.0",
-		"https://creativecommons.org/licenses/by/4.0/legalcode":       "CC-BY-4.0",
-		"https://creativecommons.org/licenses/by-nc/3.0/legalcode":    "CC-BY-NC-3.0",
-		"https://creativecommons.org/licenses/by-nc/4.0/legalcode":    "CC-BY-NC-4.0",
-		"https://creativecommons.org/licenses/by-nc-nd/3.0/legalcode": "CC-BY-NC-ND-3.0",
-		"https://creativecommons.org/licenses/by-nc-nd/4.0/legalcode": "CC-BY-NC-ND-4.0",
-		"https://creativecommons.org/licenses/by-nc-sa/3.0/legalcode": "CC-BY-NC-SA-3.0",
-		"https://creativecommons.org/licenses/by-nc-sa/4.0/legalcode": "CC-BY-NC-SA-4.0",
-		"https://creativecommons.org/licenses/by-nd/3.0/legalcode":    "CC-BY-ND-3.0",
-		"https://creativecommons.org/licenses/by-nd/4.0/legalcode":    "CC-BY-ND-4.0",
-		"https://creativecommons.org/licenses/by-sa/3.0/legalcode":    "CC-BY-SA-3.0",
-		"https://creativecommons.org/licenses/by-sa/4.0/legalcode":    "CC-BY-SA-4.0",
-		"https://creativecommons.org/publicdomain/zero/1.0/legalcode": "CC0-1.0",
-		"https://creativecommons.org/licenses/publicdomain/":          "CC0-1.0",
-		"https://opensource.org/licenses/MIT":                         "MIT",
-		"https://opensource.org/licenses/Apache-2.0":                  "Apache-2.0",
-		"https://opensource.org/licenses/GPL-3.0":                     "GPL-3.0",
-	}
-	id := SPDXLicenses[url]
-	return id
+	return spdxutils.URLToID(url)
+}
+
+// SPDXToURL provides the license URL given a SPDX license ID. The
+// comparison is case-insensitive. It wraps spdxutils.IDToURL.
+func SPDXToURL(id string) string {
+	return spdxutils.IDToURL(id)
 }
 
 type params struct {
@@ -310,6 +247,125 @@ func FindFromFormatByFilename(filename string) string {
 	return ""
 }
 
+// DetectFormat detects the commonmeta read format of a file from its
+// extension, and from its content if the extension is ambiguous or missing.
+// It returns an empty string if the format can't be detected.
+func DetectFormat(filename string, content []byte) string {
+	ext := strings.ToLower(path.Ext(filename))
+	switch ext {
+	case ".bib":
+		return "bibtex"
+	case ".ris":
+		return "ris"
+	case ".cff":
+		return "cff"
+	case ".yaml", ".yml":
+		if bytes.Contains(content, []byte("cff-version")) {
+			return "cff"
+		}
+		return ""
+	case ".xml":
+		return detectXMLFormat(content)
+	case ".json", ".jsonld":
+		return detectJSONFormat(content)
+	}
+
+	// no or unknown extension, use the content
+	str := strings.TrimSpace(string(content))
+	switch {
+	case strings.HasPrefix(str, "@"):
+		return "bibtex"
+	case strings.HasPrefix(str, "TY  -"):
+		return "ris"
+	case strings.HasPrefix(str, "cff-version"):
+		return "cff"
+	case strings.HasPrefix(str, "<"):
+		return detectXMLFormat(content)
+	case strings.HasPrefix(str, "{"), strings.HasPrefix(str, "["):
+		return detectJSONFormat(content)
+	}
+	return ""
+}
+
+// detectXMLFormat detects the format of XML content by namespace or root element.
+func detectXMLFormat(content []byte) string {
+	str := string(content)
+	switch {
+	case strings.Contains(str, "http://datacite.org/schema/kernel"):
+		return "datacitexml"
+	case strings.Contains(str, "http://www.crossref.org/"), strings.Contains(str, "<crossref_result"), strings.Contains(str, "<doi_records"):
+		return "crossrefxml"
+	case strings.Contains(str, "http://www.loc.gov/MARC21/slim"):
+		return "marc"
+	case strings.Contains(str, "http://www.openarchives.org/OAI/2.0/oai_dc/"):
+		return "dublincore"
+	case strings.Contains(str, "<article") && strings.Contains(str, "<front>"):
+		return "jats"
+	}
+	return ""
+}
+
+// detectJSONFormat detects the format of JSON content by distinguishing keys.
+func detectJSONFormat(content []byte) string {
+	var dct map[string]interface{}
+	if err := json.Unmarshal(content, &dct); err != nil {
+		// use the first item of a list
+		var list []map[string]interface{}
+		if err := json.Unmarshal(content, &list); err != nil || len(list) == 0 {
+			return ""
+		}
+		dct = list[0]
+	}
+	if data, ok := dct["data"].(map[string]interface{}); ok {
+		if _, ok := data["attributes"]; ok {
+			return "datacite"
+		}
+	}
+	if v, ok := dct["@context"].(string); ok {
+		if strings.Contains(v, "codemeta") {
+			return "codemeta"
+		}
+		if strings.Contains(v, "schema.org") {
+			return "schemaorg"
+		}
+	}
+	if v, ok := dct["schema_version"].(string); ok && strings.HasPrefix(v, "https://commonmeta.org") {
+		return "commonmeta"
+	}
+	if v, ok := dct["schemaVersion"].(string); ok && strings.HasPrefix(v, "http://datacite.org/schema/kernel") {
+		return "datacite"
+	}
+	if _, ok := dct["member"]; ok {
+		if _, ok := dct["DOI"]; ok {
+			return "crossref"
+		}
+	}
+	if _, ok := dct["maintitle"]; ok {
+		return "openaire"
+	}
+	if _, ok := dct["pids"]; ok {
+		if _, ok := dct["metadata"]; ok {
+			return "inveniordm"
+		}
+	}
+	if _, ok := dct["guid"]; ok {
+		return "jsonfeed"
+	}
+	if issued, ok := dct["issued"].(map[string]interface{}); ok {
+		if _, ok := issued["date-parts"]; ok {
+			return "csl"
+		}
+	}
+	if contributors, ok := dct["contributors"].([]interface{}); ok && len(contributors) > 0 {
+		if c, ok := contributors[0].(map[string]interface{}); ok {
+			if _, ok := c["contributorRoles"]; ok {
+				return "commonmeta"
+			}
+		}
+	}
+	return ""
+}
+
 // ISSNAsURL returns the ISSN expressed as URL
 func ISSNAsURL(issn string) string {
 	if issn == "" {
@@ -332,6 +388,73 @@ func ValidateISSN(issn string) (string, bool) {
 	return matched[1], true
 }
 
+// ISSNLMappings maps ISSNs to their linking ISSN (ISSN-L), which groups the
+// print and electronic ISSNs of a journal. ISSNs not found here are looked up
+// in the ISSN portal.
+var ISSNLMappings = map[string]string{
+	"0028-0836": "0028-0836", // Nature
+	"1476-4687": "0028-0836",
+	"0036-8075": "0036-8075", // Science
+	"1095-9203": "0036-8075",
+	"0092-8674": "0092-8674", // Cell
+	"1097-4172": "0092-8674",
+	"1544-9173": "1544-9173", // PLOS Biology
+	"1545-7885": "1544-9173",
+	"1932-6203": "1932-6203", // PLOS ONE
+	"2050-084X": "2050-084X", // eLife
+}
+
+// ISSNPortalURL is the base URL of the ISSN portal
+var ISSNPortalURL = "https://portal.issn.org/resource/ISSN/"
+
+// GetISSNL returns the linking ISSN (ISSN-L) for an ISSN, using the bundled
+// mappings or the linked data of the ISSN portal. Requests to the ISSN portal
+// time out after 10 seconds.
+func GetISSNL(issn string) (string, error) {
+	issnstr, ok := ValidateISSN(strings.TrimSpace(issn))
+	if !ok {
+		return "", fmt.Errorf("invalid ISSN: %s", issn)
+	}
+	issnstr = strings.ToUpper(issnstr)
+	if issnl, ok := ISSNLMappings[issnstr]; ok {
+		return issnl, nil
+	}
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+	resp, err := client.Get(ISSNPortalURL + issnstr + "?format=json")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("ISSN-L lookup for %s failed: %s", issnstr, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		Graph []struct {
+			ID string `json:"@id"`
+		} `json:"@graph"`
+	}
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return "", err
+	}
+	for _, v := range result.Graph {
+		_, issnl, found := strings.Cut(v.ID, "resource/ISSN-L/")
+		if !found {
+			continue
+		}
+		if issnl, ok := ValidateISSN(issnl); ok {
+			return strings.ToUpper(issnl), nil
+		}
+	}
+	return "", fmt.Errorf("no ISSN-L found for %s", issnstr)
+}
+
 // Sanitize removes all HTML tags except for a whitelist of allowed tags. Used for
 // title and description fields.
 func Sanitize(html string) string {
@@ -379,18 +502,39 @@ func NormalizeORCID(orcid string) string {
 	return "https://orcid.org/" + orcidStr
 }
 
-// ValidateORCID validates an ORCID
+// orcidRegexp matches an ORCID iD, given as ID or as URL, with or without
+// separators.
+var orcidRegexp = regexp.MustCompile(`(?i)^(?:(?:http|https)://(?:(?:www|sandbox)\.)?orcid\.org/)?(\d{4})[ -]?(\d{4})[ -]?(\d{4})[ -]?(\d{3}[0-9X])$`)
+
+// ValidateORCID validates an ORCID iD, given either as ID or as URL, and
+// returns it as 0000-0002-1825-0097. The last character is a checksum
+// (ISO/IEC 7064 MOD 11-2), X stands for a check digit of 10.
 func ValidateORCID(orcid string) (string, bool) {
-	r, err := regexp.Compile(`^(?:(?:http|https)://(?:(?:www|sandbox)?\.)?orcid\.org/)?(\d{4}[ -]\d{4}[ -]\d{4}[ -]\d{3}[0-9X]+)$`)
-	if err != nil {
-		log.Printf("Error compiling regex: %v", err)
+	matched := orcidRegexp.FindStringSubmatch(strings.TrimSpace(orcid))
+	if len(matched) == 0 {
 		return "", false
 	}
-	matched := r.FindStringSubmatch(orcid)
-	if len(matched) == 0 {
+	parts := matched[1:]
+	parts[3] = strings.ToUpper(parts[3])
+	digits := strings.Join(parts, "")
+	if ORCIDCheckDigit(digits[:15]) != digits[15:] {
 		return "", false
 	}
-	return matched[1], true
+	return strings.Join(parts, "-"), true
+}
+
+// ORCIDCheckDigit calculates the ORCID check digit for the first 15 digits
+// of an ORCID iD.
+func ORCIDCheckDigit(digits string) string {
+	total := 0
+	for _, c := range digits {
+		total = (total + int(c-'0')) * 2
+	}
+	result := (12 - total%11) % 11
+	if result == 10 {
+		return "X"
+	}
+	return string(rune('0' + result))
 }
 
 // NormalizeROR returns a normalized ROR URL
@@ -402,15 +546,19 @@ func NormalizeROR(ror string) string {
 	return "https://ror.org/" + rorStr
 }
 
-// ValidateROR validates a ROR
+// rorRegexp matches a ROR ID, given as ID or as ror.org URL.
+var rorRegexp = regexp.MustCompile(`^(?:(?:https?://)?ror\.org/)?(0[0-9a-z]{6}\d{2})$`)
+
+// ValidateROR validates a ROR ID, given either as ID or as URL. ROR IDs
+// start with 0, followed by 6 characters in Crockford base32 and a two-digit
+// checksum (ISO/IEC 7064 MOD 97-10).
 func ValidateROR(ror string) (string, bool) {
-	r, err := regexp.Compile(`^(?:(?:http|https)://ror\.org/)?([0-9a-z]{7}\d{2})$`)
-	if err != nil {
-		log.Printf("Error compiling regex: %v", err)
+	matched := rorRegexp.FindStringSubmatch(strings.ToLower(strings.TrimSpace(ror)))
+	if len(matched) == 0 {
 		return "", false
 	}
-	matched := r.FindStringSubmatch(ror)
-	if len(matched) == 0 {
+	_, err := crockford.Decode(matched[1], true)
+	if err != nil {
 		return "", false
 	}
 	return matched[1], true
@@ -437,7 +585,7 @@ func GetROR(ror string) (ROR, error) {
 	}
 	err = json.Unmarshal(body, &content)
 	if err != nil {
-		fmt.Println("error:", err)
+		slog.Error("error unmarshalling JSON", "error", err)
 	}
 	return content, err
 }
@@ -524,7 +672,7 @@ func DecodeDOI(doi string) int64 {
 	suffix := strings.Split(d, "/")[1]
 	number, err := crockford.Decode(suffix, true)
 	if err != nil {
-		fmt.Println(err)
+		slog.Error("error decoding DOI suffix", "doi", doi, "error", err)
 		return 0
 	}
 	return number
@@ -2,6 +2,9 @@ package utils_test
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/front-matter/commonmeta/utils"
@@ -86,6 +89,45 @@ func ExampleValidateISSN() {
 	// 2146-8427
 }
 
+func TestGetISSNL(t *testing.T) {
+	// not parallel, as the test changes the ISSN portal URL
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		issn := strings.TrimPrefix(r.URL.Path, "/resource/ISSN/")
+		if issn != "2049-3630" {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, `{"@graph":[{"@id":"resource/ISSN/2049-3630"},{"@id":"resource/ISSN-L/2049-3630"}]}`)
+	}))
+	defer ts.Close()
+	portalURL := utils.ISSNPortalURL
+	utils.ISSNPortalURL = ts.URL + "/resource/ISSN/"
+	defer func() { utils.ISSNPortalURL = portalURL }()
+
+	type testCase struct {
+		input string
+		want  string
+		err   bool
+	}
+	testCases := []testCase{
+		{input: "1476-4687", want: "0028-0836"},
+		{input: "https://portal.issn.org/resource/ISSN/1095-9203", want: "0036-8075"},
+		{input: "2050-084x", want: "2050-084X"},
+		{input: "2049-3630", want: "2049-3630"},
+		{input: "1234-5679", err: true},
+		{input: "n/a", err: true},
+	}
+	for _, tc := range testCases {
+		got, err := utils.GetISSNL(tc.input)
+		if (err != nil) != tc.err {
+			t.Errorf("GetISSNL(%v): unexpected error %v", tc.input, err)
+		}
+		if tc.want != got {
+			t.Errorf("GetISSNL(%v): want %v, got %v", tc.input, tc.want, got)
+		}
+	}
+}
+
 func TestNormalizeORCID(t *testing.T) {
 	t.Parallel()
 	type testCase struct {
@@ -116,7 +158,10 @@ func TestValidateORCID(t *testing.T) {
 		{input: "https://orcid.org/0000-0002-1825-0097", want: "0000-0002-1825-0097"},
 		{input: "0000-0002-1825-0097", want: "0000-0002-1825-0097"},
 		{input: "https://sandbox.orcid.org/0000-0002-1825-0097", want: "0000-0002-1825-0097"},
-		{input: "0000-0002-1825-009", want: ""}, // invalid ORCID
+		{input: "0000-0002-1825-009", want: ""},   // invalid ORCID
+		{input: "0000-0002-1825-0098", want: ""},  // invalid checksum
+		{input: "0000-0002-1825-00970", want: ""}, // extra check character
+		{input: "0000 0002 1825 0097", want: "0000-0002-1825-0097"},
 	}
 	for _, tc := range testCases {
 		got, ok := utils.ValidateORCID(tc.input)
@@ -174,6 +219,10 @@ func TestValidateROR(t *testing.T) {
 	}
 	testCases := []testCase{
 		{input: "https://ror.org/0342dzm54", want: "0342dzm54"},
+		{input: "ror.org/04wxnsj81", want: "04wxnsj81"},
+		{input: "https://ror.org/0342dzm55", want: ""},
+		{input: "https://ror.org/0342dzu54", want: ""},
+		{input: "https://0abcde123", want: ""},
 	}
 	for _, tc := range testCases {
 		got, ok := utils.ValidateROR(tc.input)
@@ -191,6 +240,13 @@ func ExampleValidateROR() {
 	// 0342dzm54
 }
 
+func ExampleSPDXToURL() {
+	s := utils.SPDXToURL("cc0-1.0")
+	fmt.Println(s)
+	// Output:
+	// https://creativecommons.org/publicdomain/zero/1.0/legalcode
+}
+
 func ExampleValidateUUID() {
 	s, _ := utils.ValidateUUID("2491b2d5-7daf-486b-b78b-e5aab48064c1")
 	fmt.Println(s)
@@ -217,6 +273,47 @@ func TestSanitize(t *testing.T) {
 	}
 }
 
+func TestDetectFormat(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		filename string
+		content  string
+		want     string
+	}
+	testCases := []testCase{
+		{filename: "record.bib", content: "@article{key, title={Title}}", want: "bibtex"},
+		{filename: "record.ris", content: "TY  - JOUR\nER  - ", want: "ris"},
+		{filename: "CITATION.cff", content: "cff-version: 1.2.0", want: "cff"},
+		{filename: "citation.yaml", content: "cff-version: 1.2.0", want: "cff"},
+		{filename: "record.xml", content: `<resource xmlns="http://datacite.org/schema/kernel-4"></resource>`, want: "datacitexml"},
+		{filename: "record.xml", content: `<article article-type="research-article"><front></front></article>`, want: "jats"},
+		{filename: "record.xml", content: `<crossref_result xmlns="http://www.crossref.org/qrschema/3.0"></crossref_result>`, want: "crossrefxml"},
+		{filename: "record.xml", content: `<record xmlns="http://www.loc.gov/MARC21/slim"></record>`, want: "marc"},
+		{filename: "record.xml", content: `<oai_dc:dc xmlns:oai_dc="http://www.openarchives.org/OAI/2.0/oai_dc/"></oai_dc:dc>`, want: "dublincore"},
+		{filename: "record.xml", content: `<record></record>`, want: ""},
+		{filename: "record.json", content: `{"data": {"id": "10.5438/zhyx-n122", "attributes": {}}}`, want: "datacite"},
+		{filename: "record.json", content: `{"doi": "10.5438/zhyx-n122", "schemaVersion": "http://datacite.org/schema/kernel-4"}`, want: "datacite"},
+		{filename: "record.json", content: `{"DOI": "10.7554/elife.01567", "member": "4374", "issued": {"date-parts": [[2014, 2, 11]]}}`, want: "crossref"},
+		{filename: "record.json", content: `[{"id": "10.7554/elife.01567", "issued": {"date-parts": [[2014, 2, 11]]}}]`, want: "csl"},
+		{filename: "codemeta.json", content: `{"@context": "https://w3id.org/codemeta/3.0", "@type": "SoftwareSourceCode"}`, want: "codemeta"},
+		{filename: "record.jsonld", content: `{"@context": "http://schema.org", "@type": "Dataset"}`, want: "schemaorg"},
+		{filename: "record.json", content: `{"id": "https://doi.org/10.5555/12345678", "type": "JournalArticle", "contributors": [{"type": "Person", "contributorRoles": ["Author"]}]}`, want: "commonmeta"},
+		{filename: "record.json", content: `{"id": "1234", "pids": {}, "metadata": {}}`, want: "inveniordm"},
+		{filename: "record.json", content: `{"maintitle": "Title", "pid": []}`, want: "openaire"},
+		{filename: "record.json", content: `{"guid": "https://example.org/post", "title": "Title"}`, want: "jsonfeed"},
+		{filename: "record.json", content: `{"id": "10.5555/12345678", "title": "Title"}`, want: ""},
+		{filename: "-", content: "@book{key, title={Title}}", want: "bibtex"},
+		{filename: "", content: `<resource xmlns="http://datacite.org/schema/kernel-4"></resource>`, want: "datacitexml"},
+	}
+	for _, tc := range testCases {
+		got := utils.DetectFormat(tc.filename, []byte(tc.content))
+		if tc.want != got {
+			t.Errorf("Detect Format(%v, %v): want %v, got %v",
+				tc.filename, tc.content, tc.want, got)
+		}
+	}
+}
+
 func TestValidateURL(t *testing.T) {
 	t.Parallel()
 	type testCase struct {
@@ -0,0 +1,31 @@
+// Package verutils provides a set of functions to work with version numbers
+package verutils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// semverRegexp matches a semantic version (https://semver.org), with
+// optional leading v, e.g. v1.2.0 or 1.0.0-rc.1+build.5.
+var semverRegexp = regexp.MustCompile(`^[vV]?((?:0|[1-9]\d*)\.(?:0|[1-9]\d*)\.(?:0|[1-9]\d*)(?:-(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*)?(?:\+[0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*)?)$`)
+
+// ValidateSemver validates a semantic version, given with or without leading
+// v, and returns it without v.
+func ValidateSemver(version string) (string, bool) {
+	matched := semverRegexp.FindStringSubmatch(strings.TrimSpace(version))
+	if len(matched) == 0 {
+		return "", false
+	}
+	return matched[1], true
+}
+
+// NormalizeVersion returns a semantic version without leading v, e.g. 1.2.0
+// for v1.2.0. Other versions, e.g. 2.1, v2 or 2024-01, are returned unchanged
+// except for surrounding whitespace.
+func NormalizeVersion(version string) string {
+	if semver, ok := ValidateSemver(version); ok {
+		return semver
+	}
+	return strings.TrimSpace(version)
+}
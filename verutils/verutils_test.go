@@ -0,0 +1,57 @@
+package verutils_test
+
+import (
+	"testing"
+
+	"github.com/front-matter/commonmeta/verutils"
+)
+
+func TestValidateSemver(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input string
+		want  string
+		ok    bool
+	}
+	testCases := []testCase{
+		{input: "1.2.0", want: "1.2.0", ok: true},
+		{input: "v1.2.0", want: "1.2.0", ok: true},
+		{input: "V0.14.0", want: "0.14.0", ok: true},
+		{input: "1.0.0-rc.1+build.5", want: "1.0.0-rc.1+build.5", ok: true},
+		{input: " v2.0.1 ", want: "2.0.1", ok: true},
+		{input: "1.2", want: "", ok: false},
+		{input: "01.2.0", want: "", ok: false},
+		{input: "v2", want: "", ok: false},
+		{input: "", want: "", ok: false},
+	}
+	for _, tc := range testCases {
+		got, ok := verutils.ValidateSemver(tc.input)
+		if tc.want != got || tc.ok != ok {
+			t.Errorf("Validate Semver(%v): want %v %v, got %v %v", tc.input, tc.want, tc.ok, got, ok)
+		}
+	}
+}
+
+func TestNormalizeVersion(t *testing.T) {
+	t.Parallel()
+	type testCase struct {
+		input string
+		want  string
+	}
+	testCases := []testCase{
+		{input: "v1.2.0", want: "1.2.0"},
+		{input: "1.2.0", want: "1.2.0"},
+		{input: "v1.0.0-beta", want: "1.0.0-beta"},
+		{input: "2.1", want: "2.1"},
+		{input: "v2", want: "v2"},
+		{input: "2024-01", want: "2024-01"},
+		{input: "Release 3", want: "Release 3"},
+		{input: "", want: ""},
+	}
+	for _, tc := range testCases {
+		got := verutils.NormalizeVersion(tc.input)
+		if tc.want != got {
+			t.Errorf("Normalize Version(%v): want %v, got %v", tc.input, tc.want, got)
+		}
+	}
+}